@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/history"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/store"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// openHistory opens the compliance history store, in the embedded store st
+// when there is one, and makes it queryable through the tools. It returns nil
+// when history is disabled or unavailable.
+func openHistory(cfg *config.Config, t *tools.Tools, st *store.Store) *history.Store {
+	if cfg.Minder.AuthToken == "" && !cfg.Offline() {
+		slog.Warn("compliance history disabled: MINDER_AUTH_TOKEN is required for background snapshots")
+		return nil
+	}
+
+	var hs *history.Store
+	var err error
+	if st != nil {
+		hs, err = history.OpenIn(st)
+	} else {
+		hs, err = history.Open(cfg.History.Path)
+	}
+	if err != nil {
+		slog.Error("compliance history disabled", "error", err)
+		return nil
+	}
+	t.SetHistory(hs)
+	return hs
+}
+
+// startHistory records compliance summaries into store in the background.
+func startHistory(lc *lifecycle.Manager, cfg *config.Config, t *tools.Tools, store *history.Store, logger *slog.Logger) {
+	s := history.NewScheduler(cfg.History.Interval, cfg.History.Retention, t.ComplianceSnapshot, store, logger)
+	lc.Go(func(ctx context.Context) { s.Run(middleware.ContextWithToken(ctx, cfg.Minder.AuthToken)) })
+	slog.Info("Compliance history started", "interval", cfg.History.Interval,
+		"path", cfg.History.Path, "retention", cfg.History.Retention)
+}
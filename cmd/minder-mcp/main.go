@@ -3,98 +3,280 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/pprof"
 	"os"
+	"runtime"
 	"strings"
 	"time"
 
-	"github.com/mark3labs/mcp-go/server"
-	"github.com/rs/cors"
-
 	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/doctor"
+	"github.com/stacklok/minder-mcp/internal/history"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
 	"github.com/stacklok/minder-mcp/internal/logging"
+	"github.com/stacklok/minder-mcp/internal/mcpserver"
 	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/minder"
 	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/store"
 	"github.com/stacklok/minder-mcp/internal/tools"
 )
 
+// Build information, set at build time with
+// -ldflags "-X main.version=... -X main.commit=... -X main.buildTime=...".
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildTime = "unknown"
+)
+
+// buildInfo returns the build information for this binary.
+func buildInfo() resources.BuildInfo {
+	return resources.BuildInfo{
+		Name:      "minder-mcp",
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// commandFlags are flags that select an action instead of configuring the server.
+type commandFlags struct {
+	version     bool
+	checkConfig bool
+}
+
+// registerCommandFlags binds the action flags onto fs.
+func registerCommandFlags(fs *flag.FlagSet) *commandFlags {
+	c := &commandFlags{}
+	fs.BoolVar(&c.version, "version", false, "Print version information and exit")
+	fs.BoolVar(&c.checkConfig, "check-config", false,
+		"Check configuration, realm discovery, connectivity and auth for each Minder server, then exit")
+	return c
+}
+
 func main() {
-	cfg := config.Load()
-	if err := cfg.Validate(); err != nil {
+	configFile := config.ResolveFile(config.OSEnvReader, ".")
+	flag.Usage = usage
+	cmd := registerCommandFlags(flag.CommandLine)
+	cfg, err := loadConfig(configFile, flag.CommandLine, os.Args[1:])
+	if err == nil && cmd.version {
+		info := buildInfo()
+		fmt.Printf("%s %s (commit %s, built %s, %s)\n", info.Name, info.Version, info.Commit, info.BuildTime, info.GoVersion)
+		return
+	}
+	if err == nil && cmd.checkConfig {
+		os.Exit(checkConfig(cfg))
+	}
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Warn if insecure mode is enabled
-	if cfg.Minder.Insecure {
-		fmt.Fprintln(os.Stderr, "WARNING: Running in insecure mode - TLS is disabled")
+	if insecure := cfg.Minder.InsecureServers(); len(insecure) > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: Running in insecure mode - TLS is disabled for servers %s\n",
+			strings.Join(insecure, ", "))
 	}
 
 	// Setup logging
-	logger := logging.Setup(cfg.LogLevel)
+	logLevel := new(slog.LevelVar)
+	logger, logCloser := logging.New(logging.Options{
+		Level:      cfg.LogLevel,
+		LevelVar:   logLevel,
+		Format:     cfg.Logging.Format,
+		File:       cfg.Logging.File,
+		MaxSizeMB:  cfg.Logging.MaxSizeMB,
+		MaxBackups: cfg.Logging.MaxBackups,
+		MaxAgeDays: cfg.Logging.MaxAgeDays,
+		RedactKeys: cfg.Logging.RedactKeys,
+	})
 	slog.SetDefault(logger)
 
-	// Create MCP server
-	mcpServer := server.NewMCPServer(
-		"minder-mcp",
-		"0.1.0",
-		server.WithToolCapabilities(true),
-		server.WithResourceCapabilities(true, false), // Enable resource listing
-	)
-
-	// Register tools
-	t := tools.New(cfg, logger)
-	defer t.Close() // Ensure cleanup of HTTP client resources
-	t.Register(mcpServer)
-
-	// Register resources (including compliance dashboard)
-	resources.New(logger).Register(mcpServer)
-
-	// Create HTTP context function that extracts auth token
-	authContextFunc := func(ctx context.Context, r *http.Request) context.Context {
-		var token, source string
-		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
-			token = strings.TrimPrefix(auth, "Bearer ")
-			source = "header"
+	// Release subsystems on shutdown; the log file is registered first so it
+	// is closed last and records the other hooks' failures.
+	lc := lifecycle.New(context.Background())
+	lc.OnClose("log file", logCloser.Close)
+
+	// Keep state across restarts; the store is closed after the subsystems using it
+	var st *store.Store
+	if cfg.Store.Path != "" {
+		st = openStore(lc, cfg, logger)
+	}
+
+	t, closeTools, err := tools.NewForMode(cfg, logger)
+	if err != nil {
+		slog.Error("Failed to set up tools", "error", err)
+		exit(lc, 1)
+	}
+	if err := t.LoadExtensions(context.Background()); err != nil {
+		slog.Error("Failed to set up tools", "error", err)
+		exit(lc, 1)
+	}
+	// Let Minder tell this server's traffic from the CLI's
+	build := buildInfo()
+	t.SetUserAgent(build.Name + "/" + build.Version)
+	if st != nil {
+		if err := t.PersistRealms(st); err != nil {
+			slog.Warn("ignoring persisted realms", "error", err)
 		}
-		if token == "" {
-			token = cfg.Minder.AuthToken
-			source = "config"
+	}
+	// Close pooled Minder connections and the token refresher
+	lc.OnClose("minder clients", func() error { closeTools(); return nil })
+
+	// Open the compliance history store so its query tool is registered
+	var historyStore *history.Store
+	if cfg.History.Interval > 0 {
+		if historyStore = openHistory(cfg, t, st); historyStore != nil {
+			lc.OnClose("compliance history", historyStore.Close)
 		}
-		//nolint:gosec // G706 - source is a string literal, not user input
-		slog.Debug("auth context", "has_token", token != "", "source", source)
-		return middleware.ContextWithToken(ctx, token)
+	}
+
+	// Create MCP server with the tools and resources (including compliance dashboard)
+	info := buildInfo()
+	mcp, err := mcpserver.New(info, t, logger)
+	if err != nil {
+		slog.Error("Failed to set up MCP server", "error", err)
+		exit(lc, 1)
+	}
+	mcpServer, res := mcp.MCPServer, mcp.Resources
+
+	// Start the compliance watcher so the dashboard re-renders and transitions are reported
+	if cfg.Watch.Interval > 0 {
+		startWatcher(lc, cfg, t, res, mcpServer, st, logger)
+	}
+
+	// Record compliance summaries for historical queries
+	if historyStore != nil {
+		startHistory(lc, cfg, t, historyStore, logger)
+	}
+
+	// Publish scheduled compliance reports as resources
+	if cfg.Report.Schedule != "" {
+		startReports(lc, cfg, t, mcpServer, logger)
+	}
+
+	// Connect to Minder before the first tool call needs it
+	if cfg.MCP.Warmup && !cfg.Offline() {
+		lc.Go(t.Warmup)
 	}
 
 	// Create streamable HTTP server with auth context
-	mcpHandler := server.NewStreamableHTTPServer(mcpServer,
-		server.WithEndpointPath(cfg.MCP.EndpointPath),
-		server.WithHeartbeatInterval(30*time.Second),
-		server.WithHTTPContextFunc(authContextFunc),
-	)
+	mcpHandler := mcp.HTTPHandler(cfg)
 
 	// Wrap with CORS middleware for MCP Apps support
-	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Mcp-Session-Id"}, // Required for MCP session management
-		AllowCredentials: true,
-	}).Handler(mcpHandler)
+	origins := middleware.NewOriginAllowlist(cfg.MCP.CORSAllowedOrigins)
+	corsHandler := middleware.CORS(origins, mcpHandler)
+
+	// Refuse credentialed browser requests from untrusted origins
+	trustedOrigins := middleware.NewOriginAllowlist(cfg.MCP.TrustedOrigins)
+	guard := &middleware.OriginGuard{Trusted: trustedOrigins, StoredCredentials: cfg.Minder.HasAuthToken()}
+
+	// Restrict the listener to the configured client networks
+	prefixes, _ := cfg.MCP.AllowedPrefixes() // validated with the rest of the configuration
+	clients := middleware.NewIPAllowlist(prefixes)
+
+	mux := newMux(cfg, t, corsHandler)
+
+	// Reload log level, CORS origins and the tool allowlist on SIGHUP
+	r := &reloader{
+		configFile:   configFile,
+		level:        logLevel,
+		origins:      origins,
+		trusted:      trustedOrigins,
+		clients:      clients,
+		tools:        t,
+		mcpServer:    mcpServer,
+		enabledTools: cfg.MCP.EnabledTools,
+	}
+	lc.Go(r.run)
+
+	if cfg.MCP.PprofPort > 0 {
+		go servePprof(cfg.MCP.PprofPort)
+	}
 
 	addr := fmt.Sprintf(":%d", cfg.MCP.Port)
-	slog.Info("Starting Minder MCP server", "addr", addr, "endpoint", cfg.MCP.EndpointPath)
+	slog.Info("Starting Minder MCP server", "version", info.Version, "commit", info.Commit,
+		"addr", addr, "endpoint", cfg.MCP.EndpointPath, "metrics", cfg.MCP.MetricsEnabled, "stats", cfg.MCP.StatsEnabled,
+		"read_only", cfg.MCP.ReadOnly, "minder_servers", cfg.Minder.ServerNames(), "mode", cfg.MCP.Mode,
+		"allowed_cidrs", cfg.MCP.AllowedCIDRs)
 
 	srv := &http.Server{
 		Addr:              addr,
-		Handler:           corsHandler,
+		Handler:           clients.Handler(guard.Handler(mux)),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
+	ln, err := listen(addr)
+	if err != nil {
 		slog.Error("Failed to start server", "error", err)
-		os.Exit(1)
+		exit(lc, 1)
 	}
+	if err := serve(srv, ln); err != nil {
+		slog.Error("Server stopped", "error", err)
+		exit(lc, 1)
+	}
+	exit(lc, 0)
+}
+
+// checkConfig prints a PASS/FAIL report of pre-flight checks and returns the process exit code.
+func checkConfig(cfg *config.Config) int {
+	logger := logging.Setup(cfg.LogLevel)
+	refresher := minder.NewTokenRefresher()
+	defer refresher.Close()
+
+	results := doctor.Run(context.Background(), doctor.Checks(cfg, refresher, logger))
+	if err := doctor.Write(os.Stdout, results); err != nil {
+		return 1
+	}
+	if !doctor.Passed(results) {
+		return 1
+	}
+	return 0
+}
+
+// servePprof serves the runtime profiling endpoints on a loopback-only listener,
+// separate from the MCP endpoint so they are never exposed alongside tools.
+func servePprof(port int) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	slog.Warn("pprof endpoint enabled", "addr", addr)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := srv.ListenAndServe(); err != nil {
+		slog.Error("pprof server stopped", "error", err)
+	}
+}
+
+// usage prints command-line help.
+func usage() {
+	out := flag.CommandLine.Output()
+	_, _ = fmt.Fprintf(out, "Usage: %s [flags]\n\n", os.Args[0])
+	_, _ = fmt.Fprintln(out, "Minder MCP server. Every flag can also be set with the environment variable")
+	_, _ = fmt.Fprintln(out, "shown in its description; flags take precedence over the environment.")
+	_, _ = fmt.Fprintln(out, "The Minder auth token is only read from MINDER_AUTH_TOKEN.")
+	_, _ = fmt.Fprintln(out, "Settings not in the environment are read from the KEY=VALUE file named by")
+	_, _ = fmt.Fprintln(out, "MCP_CONFIG_FILE, or from ./.env unless MINDER_MCP_ENV=production. Send SIGHUP to")
+	_, _ = fmt.Fprintln(out, "reload the log level, CORS origins and enabled tools without restarting.")
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "Flags:")
+	flag.PrintDefaults()
 }
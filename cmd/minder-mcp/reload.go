@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/logging"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// loadConfig reads configuration from the environment and the optional config
+// file and layers the command-line flags in args on top. The caller validates the result.
+func loadConfig(configFile string, fs *flag.FlagSet, args []string) (*config.Config, error) {
+	cfg, err := config.LoadWithFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// reloader applies the settings that can change on SIGHUP without restarting
+// the HTTP listener or dropping live MCP sessions: log level, CORS and trusted
+// origins, allowed client networks and the tool allowlist. Other settings still require a restart.
+type reloader struct {
+	configFile   string
+	level        *slog.LevelVar
+	origins      *middleware.OriginAllowlist
+	trusted      *middleware.OriginAllowlist
+	clients      *middleware.IPAllowlist
+	tools        *tools.Tools
+	mcpServer    *server.MCPServer
+	enabledTools []string
+}
+
+// run reloads configuration on every SIGHUP until ctx is cancelled.
+func (r *reloader) run(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			r.reload()
+		}
+	}
+}
+
+// reload re-reads configuration and applies it, keeping the current settings on error.
+func (r *reloader) reload() {
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	registerCommandFlags(fs) // accepted so the original arguments still parse
+	cfg, err := loadConfig(r.configFile, fs, os.Args[1:])
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		slog.Error("configuration reload failed, keeping current settings", "error", err)
+		return
+	}
+	r.apply(cfg)
+	slog.Info("configuration reloaded",
+		"log_level", cfg.LogLevel,
+		"cors_allowed_origins", cfg.MCP.CORSAllowedOrigins,
+		"trusted_origins", cfg.MCP.TrustedOrigins,
+		"allowed_cidrs", cfg.MCP.AllowedCIDRs,
+		"enabled_tools", cfg.MCP.EnabledTools,
+	)
+}
+
+// apply updates the reloadable settings from cfg.
+func (r *reloader) apply(cfg *config.Config) {
+	r.level.Set(logging.ParseLevel(cfg.LogLevel))
+	r.origins.Set(cfg.MCP.CORSAllowedOrigins)
+	r.trusted.Set(cfg.MCP.TrustedOrigins)
+	prefixes, _ := cfg.MCP.AllowedPrefixes() // checked by Validate before apply
+	r.clients.Set(prefixes)
+
+	if !slices.Equal(r.enabledTools, cfg.MCP.EnabledTools) {
+		r.enabledTools = cfg.MCP.EnabledTools
+		r.tools.SetEnabledTools(cfg.MCP.EnabledTools)
+		r.mcpServer.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
+	}
+}
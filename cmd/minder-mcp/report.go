@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/cron"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/report"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// startReports generates compliance reports on the configured schedule and
+// publishes them as resources and, with a report directory, as files.
+func startReports(lc *lifecycle.Manager, cfg *config.Config, t *tools.Tools, mcpServer *server.MCPServer, logger *slog.Logger) {
+	if cfg.Minder.AuthToken == "" && !cfg.Offline() {
+		slog.Warn("compliance reports disabled: MINDER_AUTH_TOKEN is required for background snapshots")
+		return
+	}
+
+	// Validate has already parsed the schedule
+	schedule, err := cron.Parse(cfg.Report.Schedule)
+	if err != nil {
+		slog.Error("compliance reports disabled", "error", err)
+		return
+	}
+	publish := []report.PublishFunc{report.NewArchive(mcpServer, cfg.Report.Keep).Publish}
+	if cfg.Report.Dir != "" {
+		publish = append(publish, report.WriteDir(cfg.Report.Dir))
+	}
+
+	fetch := t.ComplianceSnapshot
+	if cfg.Report.LabelFilter != "" {
+		fetch = t.LabeledComplianceSnapshot(cfg.Report.LabelFilter)
+	}
+	s := report.NewScheduler(schedule, fetch, logger, publish...)
+	lc.Go(func(ctx context.Context) { s.Run(middleware.ContextWithToken(ctx, cfg.Minder.AuthToken)) })
+	slog.Info("Compliance reports scheduled", "schedule", cfg.Report.Schedule,
+		"next", schedule.Next(time.Now()), "dir", cfg.Report.Dir, "keep", cfg.Report.Keep, "label_filter", cfg.Report.LabelFilter)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
+	"github.com/stacklok/minder-mcp/internal/metrics"
+	"github.com/stacklok/minder-mcp/internal/systemd"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// shutdownTimeout bounds how long in-flight requests may run after SIGTERM.
+// Streaming MCP connections that are still open then are closed.
+const shutdownTimeout = 10 * time.Second
+
+// listen returns the socket passed by systemd socket activation or, when the
+// server was not socket activated, a new listener on addr.
+func listen(addr string) (net.Listener, error) {
+	lns, err := systemd.Listeners()
+	if err != nil {
+		return nil, err
+	}
+	if len(lns) == 0 {
+		return net.Listen("tcp", addr)
+	}
+	for _, extra := range lns[1:] {
+		slog.Warn("ignoring extra socket passed by systemd", "addr", extra.Addr())
+		_ = extra.Close()
+	}
+	slog.Info("using socket passed by systemd", "addr", lns[0].Addr())
+	return lns[0], nil
+}
+
+// newMux routes the MCP endpoint to mcpHandler next to the health checks and,
+// when enabled, the unauthenticated /metrics and /stats endpoints.
+func newMux(cfg *config.Config, t *tools.Tools, mcpHandler http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/", mcpHandler)
+	mux.Handle("/healthz", t.HealthHandler())
+	mux.Handle("/readyz", t.ReadinessHandler())
+	if cfg.MCP.MetricsEnabled {
+		mux.Handle("/metrics", metrics.Handler())
+	}
+	if cfg.MCP.StatsEnabled {
+		mux.Handle("/stats", t.Stats().Handler())
+	}
+	return mux
+}
+
+// serve runs srv on ln until SIGTERM or SIGINT, then stops accepting
+// connections and waits up to shutdownTimeout for requests to finish.
+// systemd is told when the server is ready and when it is stopping, and is
+// sent watchdog keep-alives if the unit sets WatchdogSec.
+func serve(srv *http.Server, ln net.Listener) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	if err := systemd.Notify("READY=1\nSTATUS=Serving MCP on " + ln.Addr().String()); err != nil {
+		slog.Warn("failed to notify systemd of readiness", "error", err)
+	}
+	go func() {
+		if err := systemd.RunWatchdog(ctx); err != nil {
+			slog.Warn("systemd watchdog keep-alives stopped", "error", err)
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down Minder MCP server", "timeout", shutdownTimeout)
+	_ = systemd.Notify("STOPPING=1")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("requests still running at shutdown were cut off", "error", err)
+		_ = srv.Close()
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// exit runs the shutdown hooks registered with lc, giving them up to
+// shutdownTimeout, and exits with code. Exiting through it instead of
+// os.Exit lets caches, stores and log files flush on every path out of main.
+func exit(lc *lifecycle.Manager, code int) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	err := lc.Shutdown(ctx)
+	cancel()
+	if err != nil && code == 0 {
+		code = 1
+	}
+	os.Exit(code)
+}
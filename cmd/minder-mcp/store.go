@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
+	"github.com/stacklok/minder-mcp/internal/store"
+)
+
+// openStore opens the embedded state store and maintains it in the
+// background. It returns nil when the store cannot be opened, leaving state
+// in memory.
+func openStore(lc *lifecycle.Manager, cfg *config.Config, logger *slog.Logger) *store.Store {
+	st, err := store.Open(cfg.Store.Path, int64(cfg.Store.MaxSizeMB)<<20, logger)
+	if err != nil {
+		slog.Error("embedded store disabled", "error", err)
+		return nil
+	}
+	lc.OnClose("embedded store", st.Close)
+	lc.Go(func(ctx context.Context) { st.Run(ctx, cfg.Store.MaintenanceInterval) })
+	slog.Info("Embedded store opened", "path", cfg.Store.Path, "max_size_mb", cfg.Store.MaxSizeMB,
+		"maintenance_interval", cfg.Store.MaintenanceInterval)
+	return st
+}
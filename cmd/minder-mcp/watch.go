@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/alert"
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/lifecycle"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/store"
+	"github.com/stacklok/minder-mcp/internal/tools"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+	"github.com/stacklok/minder-mcp/internal/webhook"
+)
+
+const (
+	// methodNotificationMessage is the MCP logging notification method.
+	methodNotificationMessage = "notifications/message"
+	// watcherLoggerName identifies compliance transitions in MCP logging notifications.
+	watcherLoggerName = "minder-mcp/watcher"
+
+	// watcherBucket and baselineKey locate the persisted watcher baseline.
+	watcherBucket = "watcher"
+	baselineKey   = "baseline"
+	// baselineMaxAge is how long a persisted baseline is compared against;
+	// after a longer outage the first poll establishes a new one.
+	baselineMaxAge = 7 * 24 * time.Hour
+)
+
+// startWatcher polls compliance status in the background, re-rendering the
+// dashboard on any change and reporting transitions to the configured targets.
+// With the embedded store st, the last snapshot is kept there so changes made
+// while the server was down are reported after a restart.
+func startWatcher(
+	lc *lifecycle.Manager, cfg *config.Config, t *tools.Tools, res *resources.Resources, mcpServer *server.MCPServer,
+	st *store.Store, logger *slog.Logger,
+) {
+	if cfg.Minder.AuthToken == "" && !cfg.Offline() {
+		slog.Warn("compliance watcher disabled: MINDER_AUTH_TOKEN is required for background polling")
+		return
+	}
+
+	w := watcher.New(cfg.Watch.Interval, t.ComplianceSnapshot, logger)
+	if st != nil {
+		persistBaseline(w, st, logger)
+	}
+	w.OnChange(func(_ context.Context, _, _ watcher.Snapshot) {
+		res.NotifyDashboardUpdated(mcpServer)
+	})
+	if cfg.Watch.Notifies(config.WatchNotifyLog) {
+		w.OnChange(watcher.LogTransitions(logger))
+	}
+	if cfg.Watch.Notifies(config.WatchNotifyMCP) {
+		w.OnChange(notifyTransitions(mcpServer))
+	}
+	if cfg.Watch.Notifies(config.WatchNotifyWebhook) {
+		w.OnChange(webhook.NewSender(cfg.Watch.WebhookURLs, cfg.Watch.WebhookSecret, logger).Notify())
+	}
+	if len(cfg.Watch.AlertSinks) > 0 {
+		w.OnChange(alert.NewRelay(alertRoutes(cfg.Watch.AlertSinks, logger), logger).Notify())
+	}
+
+	lc.Go(func(ctx context.Context) { w.Run(middleware.ContextWithToken(ctx, cfg.Minder.AuthToken)) })
+	slog.Info("Compliance watcher started", "interval", cfg.Watch.Interval,
+		"projects", cfg.Watch.Projects, "notify", cfg.Watch.Notify, "webhooks", len(cfg.Watch.WebhookURLs),
+		"alert_sinks", len(cfg.Watch.AlertSinks))
+}
+
+// persistBaseline restores the watcher's baseline from st and saves every
+// newly observed snapshot there.
+func persistBaseline(w *watcher.Watcher, st *store.Store, logger *slog.Logger) {
+	bucket, err := st.Bucket(watcherBucket, store.Limits{MaxEntries: 1, TTL: baselineMaxAge})
+	if err != nil {
+		logger.Warn("compliance baseline not persisted", "error", err)
+		return
+	}
+	var baseline watcher.Snapshot
+	if ok, err := bucket.Get(baselineKey, &baseline); err != nil {
+		logger.Warn("ignoring persisted compliance baseline", "error", err)
+	} else if ok {
+		w.Restore(baseline)
+	}
+	w.OnSnapshot(func(ctx context.Context, s watcher.Snapshot) {
+		if err := bucket.Put(baselineKey, s); err != nil {
+			logger.WarnContext(ctx, "failed to persist compliance baseline", "error", err)
+		}
+	})
+}
+
+// alertRoutes builds a route to each configured alert sink.
+func alertRoutes(sinks []config.AlertSink, logger *slog.Logger) []alert.Route {
+	routes := make([]alert.Route, 0, len(sinks))
+	for _, sink := range sinks {
+		route := alert.Route{
+			Projects:    sink.Projects,
+			MinSeverity: alert.Severity(sink.MinSeverity),
+			Recoveries:  sink.Recoveries,
+		}
+		switch sink.Type {
+		case config.AlertSinkWebhook:
+			route.Sink = alert.NewWebhookSink(sink.Name, sink.URL, sink.Secret, logger)
+		case config.AlertSinkSlack:
+			route.Sink = alert.NewSlackSink(sink.Name, sink.URL, logger)
+		default:
+			route.Sink = alert.NewLogSink(sink.Name, logger)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// notifyTransitions returns a ChangeFunc that sends each transition to every
+// connected client as an MCP logging notification.
+func notifyTransitions(mcpServer *server.MCPServer) watcher.ChangeFunc {
+	return func(_ context.Context, prev, curr watcher.Snapshot) {
+		for _, transition := range watcher.Diff(prev, curr) {
+			level := mcp.LoggingLevelInfo
+			if transition.Regression() {
+				level = mcp.LoggingLevelWarning
+			}
+			mcpServer.SendNotificationToAllClients(methodNotificationMessage, map[string]any{
+				"level":  level,
+				"logger": watcherLoggerName,
+				"data":   transition,
+			})
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package alert turns compliance transitions observed by the watcher into
+// alerts and routes them to sinks by project and severity.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// Severity is the severity of the rule type behind an alert, as reported by Minder.
+type Severity string
+
+// Severities Minder assigns to rule types.
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// severities lists the known severities, lowest first.
+var severities = []Severity{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// rank orders severities for thresholds. Rule types without a known severity
+// rank as medium, so they are neither dropped by a low threshold nor escalated.
+func (s Severity) rank() int {
+	if i := slices.Index(severities, s); i >= 0 {
+		return i
+	}
+	return slices.Index(severities, SeverityMedium)
+}
+
+// AtLeast reports whether s ranks at or above threshold.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() >= threshold.rank()
+}
+
+// Known reports whether s is one of the severities Minder assigns.
+func (s Severity) Known() bool {
+	return slices.Contains(severities, s)
+}
+
+// Alert is one profile or rule evaluation that started or stopped failing.
+type Alert struct {
+	Kind       watcher.TransitionKind `json:"kind"`
+	Regression bool                   `json:"regression"`
+	// Severity is empty when Minder reports none for the rule type. For a
+	// profile it is the highest severity among its failing rules.
+	Severity  Severity `json:"severity,omitempty"`
+	ProjectID string   `json:"project_id"`
+	Profile   string   `json:"profile"`
+	Rule      string   `json:"rule,omitempty"`
+	EntityID  string   `json:"entity_id,omitempty"`
+	// Entity is the display name of the evaluated entity, e.g. "owner/repo".
+	Entity string `json:"entity,omitempty"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// Summary describes the alert in one line of text.
+func (a Alert) Summary() string {
+	subject := "profile " + a.Profile
+	if a.Rule != "" {
+		entity := a.Entity
+		if entity == "" {
+			entity = a.EntityID
+		}
+		subject = fmt.Sprintf("rule %s on %s (profile %s)", a.Rule, entity, a.Profile)
+	}
+	verb := "stopped failing"
+	if a.Regression {
+		verb = "started failing"
+	}
+	from := a.From
+	if from == "" {
+		from = "new"
+	}
+	severity := a.Severity
+	if severity == "" {
+		severity = "unknown"
+	}
+	return fmt.Sprintf("[%s] %s %s in project %s: %s -> %s", severity, subject, verb, a.ProjectID, from, a.To)
+}
+
+// Alerts returns an alert for each transition between prev and curr, in the
+// order of watcher.Diff.
+func Alerts(prev, curr watcher.Snapshot) []Alert {
+	transitions := watcher.Diff(prev, curr)
+	alerts := make([]Alert, 0, len(transitions))
+	for _, t := range transitions {
+		parts := strings.SplitN(t.Key, "/", 4)
+		a := Alert{Kind: t.Kind, Regression: t.Regression(), From: t.From, To: t.To, ProjectID: parts[0]}
+		if len(parts) > 1 {
+			a.Profile = parts[1]
+		}
+		if len(parts) == 4 {
+			a.Rule, a.EntityID = parts[2], parts[3]
+			a.Entity = curr.Entities[a.EntityID]
+			a.Severity = ruleSeverity(t.Key, prev, curr)
+		} else {
+			// A profile regresses with its failing rules in curr and recovers
+			// from those failing in prev.
+			failing := curr
+			if !a.Regression {
+				failing = prev
+			}
+			a.Severity = profileSeverity(t.Key, failing)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts
+}
+
+// ruleSeverity returns the severity of the rule evaluation at key.
+func ruleSeverity(key string, prev, curr watcher.Snapshot) Severity {
+	if s, ok := curr.Severities[key]; ok {
+		return Severity(s)
+	}
+	return Severity(prev.Severities[key])
+}
+
+// profileSeverity returns the highest severity among the failing rules of
+// the profile at key in s, or "" if none has a severity.
+func profileSeverity(key string, s watcher.Snapshot) Severity {
+	var highest Severity
+	for ruleKey, status := range s.Rules {
+		severity, ok := s.Severities[ruleKey]
+		if !ok || !watcher.IsFailing(status) || !strings.HasPrefix(ruleKey, key+"/") {
+			continue
+		}
+		if highest == "" || Severity(severity).rank() > highest.rank() {
+			highest = Severity(severity)
+		}
+	}
+	return highest
+}
+
+// Sink delivers alerts to one destination.
+type Sink interface {
+	// Name identifies the sink in logs.
+	Name() string
+	// Send delivers alerts, all from the same poll.
+	Send(ctx context.Context, alerts []Alert) error
+}
+
+// Route sends the alerts matching its filters to a sink.
+type Route struct {
+	Sink Sink
+	// Projects limits the route to alerts from these project IDs. Empty matches every project.
+	Projects []string
+	// MinSeverity drops alerts of a lower severity. Empty matches every severity.
+	MinSeverity Severity
+	// Recoveries also routes alerts for profiles and rules that stopped failing.
+	Recoveries bool
+}
+
+// Matches reports whether a is routed to the route's sink.
+func (r Route) Matches(a Alert) bool {
+	if !a.Regression && !r.Recoveries {
+		return false
+	}
+	if len(r.Projects) > 0 && !slices.Contains(r.Projects, a.ProjectID) {
+		return false
+	}
+	return r.MinSeverity == "" || a.Severity.AtLeast(r.MinSeverity)
+}
+
+// Relay routes the alerts of each compliance change to sinks.
+type Relay struct {
+	routes []Route
+	logger *slog.Logger
+}
+
+// NewRelay returns a Relay sending alerts along routes.
+func NewRelay(routes []Route, logger *slog.Logger) *Relay {
+	return &Relay{routes: routes, logger: logger}
+}
+
+// Notify returns a ChangeFunc that sends each route the alerts it matches, in
+// one batch per poll. A failing sink is logged and does not stop the others.
+func (r *Relay) Notify() watcher.ChangeFunc {
+	return func(ctx context.Context, prev, curr watcher.Snapshot) {
+		alerts := Alerts(prev, curr)
+		for _, route := range r.routes {
+			var matched []Alert
+			for _, a := range alerts {
+				if route.Matches(a) {
+					matched = append(matched, a)
+				}
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			if err := route.Sink.Send(ctx, matched); err != nil {
+				r.logger.WarnContext(ctx, "alert delivery failed",
+					"sink", route.Sink.Name(), "alerts", len(matched), "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// recordingSink captures each batch it is sent and fails with err.
+type recordingSink struct {
+	name    string
+	batches [][]Alert
+	err     error
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Send(_ context.Context, alerts []Alert) error {
+	s.batches = append(s.batches, alerts)
+	return s.err
+}
+
+// transitionSnapshots returns two snapshots of project p1 in which the
+// branch protection rule (high) regressed the baseline profile, and a
+// project p2 in which a rule without a severity recovered.
+func transitionSnapshots() (watcher.Snapshot, watcher.Snapshot) {
+	prev := watcher.NewSnapshot()
+	prev.Profiles["p1/baseline"] = "success"
+	prev.Rules["p1/baseline/branch_protection/e1"] = "success"
+	prev.Rules["p1/baseline/secret_scanning/e1"] = "success"
+	prev.Profiles["p2/extra"] = "failure"
+	prev.Rules["p2/extra/dependabot/e2"] = "failure"
+
+	curr := watcher.NewSnapshot()
+	curr.Profiles["p1/baseline"] = "failure"
+	curr.Rules["p1/baseline/branch_protection/e1"] = "failure"
+	curr.Rules["p1/baseline/secret_scanning/e1"] = "success"
+	curr.Profiles["p2/extra"] = "success"
+	curr.Rules["p2/extra/dependabot/e2"] = "success"
+	curr.Entities["e1"] = "stacklok/minder"
+	curr.Severities["p1/baseline/branch_protection/e1"] = "high"
+	curr.Severities["p1/baseline/secret_scanning/e1"] = "critical"
+	return prev, curr
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	got := Alerts(transitionSnapshots())
+	want := []Alert{
+		{Kind: watcher.ProfileRegressed, Regression: true, Severity: SeverityHigh,
+			ProjectID: "p1", Profile: "baseline", From: "success", To: "failure"},
+		{Kind: watcher.ProfileRecovered, ProjectID: "p2", Profile: "extra", From: "failure", To: "success"},
+		{Kind: watcher.RuleFailing, Regression: true, Severity: SeverityHigh, ProjectID: "p1", Profile: "baseline",
+			Rule: "branch_protection", EntityID: "e1", Entity: "stacklok/minder", From: "success", To: "failure"},
+		{Kind: watcher.RuleRecovered, ProjectID: "p2", Profile: "extra",
+			Rule: "dependabot", EntityID: "e2", From: "failure", To: "success"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Alerts() returned %d alerts, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("alert[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAlert_Summary(t *testing.T) {
+	t.Parallel()
+
+	a := Alert{Kind: watcher.RuleFailing, Regression: true, Severity: SeverityHigh, ProjectID: "p1",
+		Profile: "baseline", Rule: "branch_protection", EntityID: "e1", Entity: "stacklok/minder", To: "failure"}
+	want := "[high] rule branch_protection on stacklok/minder (profile baseline) started failing in project p1: new -> failure"
+	if got := a.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestRoute_Matches(t *testing.T) {
+	t.Parallel()
+
+	high := Alert{Regression: true, Severity: SeverityHigh, ProjectID: "p1"}
+	unknown := Alert{Regression: true, ProjectID: "p1"}
+	recovery := Alert{Severity: SeverityCritical, ProjectID: "p1"}
+	tests := []struct {
+		name  string
+		route Route
+		alert Alert
+		want  bool
+	}{
+		{name: "no filters", route: Route{}, alert: high, want: true},
+		{name: "project routed", route: Route{Projects: []string{"p1"}}, alert: high, want: true},
+		{name: "other project", route: Route{Projects: []string{"p2"}}, alert: high, want: false},
+		{name: "at threshold", route: Route{MinSeverity: SeverityHigh}, alert: high, want: true},
+		{name: "below threshold", route: Route{MinSeverity: SeverityCritical}, alert: high, want: false},
+		{name: "unknown severity ranks medium", route: Route{MinSeverity: SeverityMedium}, alert: unknown, want: true},
+		{name: "unknown severity below high", route: Route{MinSeverity: SeverityHigh}, alert: unknown, want: false},
+		{name: "recovery routed", route: Route{Recoveries: true}, alert: recovery, want: true},
+		{name: "recovery dropped", route: Route{}, alert: recovery, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.route.Matches(tt.alert); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelay_Notify(t *testing.T) {
+	t.Parallel()
+
+	oncall := &recordingSink{name: "oncall", err: errors.New("receiver down")}
+	audit := &recordingSink{name: "audit"}
+	quiet := &recordingSink{name: "quiet"}
+	relay := NewRelay([]Route{
+		{Sink: oncall, MinSeverity: SeverityHigh},
+		{Sink: audit, Recoveries: true},
+		{Sink: quiet, Projects: []string{"p3"}, Recoveries: true},
+	}, discardLogger())
+
+	prev, curr := transitionSnapshots()
+	relay.Notify()(context.Background(), prev, curr)
+
+	if len(oncall.batches) != 1 || len(oncall.batches[0]) != 2 {
+		t.Errorf("oncall received %v, want one batch with the two high regressions", oncall.batches)
+	}
+	if len(audit.batches) != 1 || len(audit.batches[0]) != 4 {
+		t.Errorf("audit received %v, want one batch with all four alerts despite oncall failing", audit.batches)
+	}
+	if len(quiet.batches) != 0 {
+		t.Errorf("quiet received %v, want nothing for an unrouted project", quiet.batches)
+	}
+}
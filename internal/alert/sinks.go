@@ -0,0 +1,135 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/webhook"
+)
+
+// EventAlert is the webhook event type of alert batches.
+const EventAlert webhook.EventType = "compliance.alert"
+
+// slackMaxAlerts caps the alerts listed in one Slack message, keeping it
+// under Slack's block limit.
+const slackMaxAlerts = 20
+
+// logSink writes each alert as a log event.
+type logSink struct {
+	name   string
+	logger *slog.Logger
+}
+
+// NewLogSink returns a Sink logging alerts as warnings for regressions and
+// info events for recoveries.
+func NewLogSink(name string, logger *slog.Logger) Sink {
+	return &logSink{name: name, logger: logger}
+}
+
+func (s *logSink) Name() string { return s.name }
+
+func (s *logSink) Send(ctx context.Context, alerts []Alert) error {
+	for _, a := range alerts {
+		level := slog.LevelInfo
+		if a.Regression {
+			level = slog.LevelWarn
+		}
+		s.logger.Log(ctx, level, "compliance alert",
+			"sink", s.name,
+			"kind", a.Kind,
+			"severity", a.Severity,
+			"project_id", a.ProjectID,
+			"profile", a.Profile,
+			"rule", a.Rule,
+			"entity", a.Entity,
+			"from", a.From,
+			"to", a.To,
+		)
+	}
+	return nil
+}
+
+// webhookPayload is the JSON body posted by webhook sinks.
+type webhookPayload struct {
+	Type   webhook.EventType `json:"type"`
+	Time   time.Time         `json:"time"`
+	Alerts []Alert           `json:"alerts"`
+}
+
+// webhookSink posts alert batches as JSON.
+type webhookSink struct {
+	name   string
+	sender *webhook.Sender
+	now    func() time.Time
+}
+
+// NewWebhookSink returns a Sink posting each batch of alerts to url as a
+// compliance.alert event, signed with secret when it is non-empty.
+func NewWebhookSink(name, url, secret string, logger *slog.Logger) Sink {
+	return &webhookSink{name: name, sender: webhook.NewSender([]string{url}, secret, logger), now: time.Now}
+}
+
+func (s *webhookSink) Name() string { return s.name }
+
+func (s *webhookSink) Send(ctx context.Context, alerts []Alert) error {
+	return s.sender.SendPayload(ctx, EventAlert, webhookPayload{Type: EventAlert, Time: s.now().UTC(), Alerts: alerts})
+}
+
+// slackSink posts alert batches to a Slack incoming webhook.
+type slackSink struct {
+	name   string
+	sender *webhook.Sender
+}
+
+// NewSlackSink returns a Sink posting each batch of alerts to a Slack
+// incoming webhook URL as one Block Kit message.
+func NewSlackSink(name, url string, logger *slog.Logger) Sink {
+	return &slackSink{name: name, sender: webhook.NewSender([]string{url}, "", logger)}
+}
+
+func (s *slackSink) Name() string { return s.name }
+
+func (s *slackSink) Send(ctx context.Context, alerts []Alert) error {
+	return s.sender.SendPayload(ctx, EventAlert, slackMessage(alerts))
+}
+
+// slackMessage renders alerts as a Slack message with a text fallback for notifications.
+func slackMessage(alerts []Alert) map[string]any {
+	regressions := 0
+	for _, a := range alerts {
+		if a.Regression {
+			regressions++
+		}
+	}
+	title := fmt.Sprintf("Minder compliance: %d started failing, %d recovered", regressions, len(alerts)-regressions)
+
+	blocks := []map[string]any{{
+		"type": "header",
+		"text": map[string]any{"type": "plain_text", "text": title},
+	}}
+	for _, a := range alerts[:min(len(alerts), slackMaxAlerts)] {
+		icon := ":white_check_mark:"
+		if a.Regression {
+			icon = ":rotating_light:"
+		}
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": icon + " " + slackEscape(a.Summary())},
+		})
+	}
+	if extra := len(alerts) - slackMaxAlerts; extra > 0 {
+		blocks = append(blocks, map[string]any{
+			"type":     "context",
+			"elements": []map[string]any{{"type": "mrkdwn", "text": fmt.Sprintf("and %d more", extra)}},
+		})
+	}
+	return map[string]any{"text": title, "blocks": blocks}
+}
+
+// slackEscape escapes the characters Slack treats as markup in mrkdwn text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
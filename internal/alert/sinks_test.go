@@ -0,0 +1,126 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+	"github.com/stacklok/minder-mcp/internal/webhook"
+)
+
+// captureRequest starts a server that records the body and headers of the
+// last request and answers 200 OK.
+func captureRequest(t *testing.T) (*httptest.Server, *http.Header, *[]byte) {
+	t.Helper()
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &header, &body
+}
+
+var testAlerts = []Alert{
+	{Kind: watcher.RuleFailing, Regression: true, Severity: SeverityHigh, ProjectID: "p1", Profile: "baseline",
+		Rule: "branch_protection", EntityID: "e1", Entity: "<stacklok/minder>", From: "success", To: "failure"},
+	{Kind: watcher.ProfileRecovered, ProjectID: "p2", Profile: "extra", From: "failure", To: "success"},
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Parallel()
+
+	srv, header, body := captureRequest(t)
+	sink := NewWebhookSink("oncall", srv.URL, "s3cret", discardLogger())
+	if err := sink.Send(context.Background(), testAlerts); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got := header.Get(webhook.EventHeader); got != string(EventAlert) {
+		t.Errorf("event header = %q, want %q", got, EventAlert)
+	}
+	if got, want := header.Get(webhook.SignatureHeader), webhook.Sign([]byte("s3cret"), *body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(*body, &payload); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if payload.Type != EventAlert || len(payload.Alerts) != 2 || payload.Alerts[0] != testAlerts[0] {
+		t.Errorf("payload = %+v, want both alerts as %s", payload, EventAlert)
+	}
+}
+
+func TestSlackSink(t *testing.T) {
+	t.Parallel()
+
+	srv, _, body := captureRequest(t)
+	if err := NewSlackSink("team", srv.URL, discardLogger()).Send(context.Background(), testAlerts); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var msg struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(*body, &msg); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if msg.Text != "Minder compliance: 1 started failing, 1 recovered" {
+		t.Errorf("text = %q", msg.Text)
+	}
+	if len(msg.Blocks) != 3 || msg.Blocks[0].Type != "header" {
+		t.Fatalf("blocks = %+v, want a header and a section per alert", msg.Blocks)
+	}
+	if got := msg.Blocks[1].Text.Text; !strings.Contains(got, "&lt;stacklok/minder&gt;") || !strings.HasPrefix(got, ":rotating_light:") {
+		t.Errorf("regression section = %q, want an escaped entity and the regression icon", got)
+	}
+}
+
+func TestSlackMessage_Truncated(t *testing.T) {
+	t.Parallel()
+
+	alerts := make([]Alert, slackMaxAlerts+5)
+	for i := range alerts {
+		alerts[i] = Alert{Regression: true, ProjectID: "p1", Profile: fmt.Sprintf("profile-%d", i)}
+	}
+	blocks, _ := slackMessage(alerts)["blocks"].([]map[string]any)
+	if len(blocks) != slackMaxAlerts+2 {
+		t.Fatalf("message has %d blocks, want a header, %d sections and a footer", len(blocks), slackMaxAlerts)
+	}
+	footer, _ := json.Marshal(blocks[len(blocks)-1])
+	if !bytes.Contains(footer, []byte("and 5 more")) {
+		t.Errorf("footer = %s, want the number of alerts left out", footer)
+	}
+}
+
+func TestLogSink(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	sink := NewLogSink("audit", slog.New(slog.NewTextHandler(&buf, nil)))
+	if err := sink.Send(context.Background(), testAlerts); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "severity=high") {
+		t.Errorf("expected a warning with the severity for the regression, got %q", out)
+	}
+	if !strings.Contains(out, "level=INFO") || !strings.Contains(out, "kind=profile_recovered") {
+		t.Errorf("expected an info event for the recovery, got %q", out)
+	}
+}
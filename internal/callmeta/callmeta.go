@@ -0,0 +1,94 @@
+// Package callmeta collects what a single tool call did, such as the Minder
+// server it used and the caches it hit, for reporting in the result's _meta.
+package callmeta
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Cache statuses reported per cache.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// Info collects facts about a single tool call.
+// Info is safe for concurrent use by multiple goroutines.
+type Info struct {
+	mu       sync.Mutex
+	server   string
+	projects int
+	caches   map[string]string
+}
+
+// infoKey is the unexported context key for the Info.
+type infoKey struct{}
+
+// NewContext returns a context carrying a new Info.
+func NewContext(ctx context.Context) (context.Context, *Info) {
+	info := &Info{caches: map[string]string{}}
+	return context.WithValue(ctx, infoKey{}, info), info
+}
+
+// FromContext returns the Info in ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Info {
+	info, _ := ctx.Value(infoKey{}).(*Info)
+	return info
+}
+
+// SetServer records the address of the Minder server the call used.
+// It is a no-op when ctx carries no Info.
+func SetServer(ctx context.Context, addr string) {
+	if info := FromContext(ctx); info != nil {
+		info.mu.Lock()
+		defer info.mu.Unlock()
+		info.server = addr
+	}
+}
+
+// AddProjects adds n to the number of projects the call scanned.
+// It is a no-op when ctx carries no Info.
+func AddProjects(ctx context.Context, n int) {
+	if info := FromContext(ctx); info != nil {
+		info.mu.Lock()
+		defer info.mu.Unlock()
+		info.projects += n
+	}
+}
+
+// ObserveCache records whether a lookup in the named cache was a hit. A later
+// lookup in the same cache replaces the earlier status.
+// It is a no-op when ctx carries no Info.
+func ObserveCache(ctx context.Context, cache string, hit bool) {
+	if info := FromContext(ctx); info != nil {
+		status := CacheMiss
+		if hit {
+			status = CacheHit
+		}
+		info.mu.Lock()
+		defer info.mu.Unlock()
+		info.caches[cache] = status
+	}
+}
+
+// Fields returns the collected facts and elapsed as _meta fields. Facts that
+// were not recorded, such as the server of calls that never reached Minder,
+// are left out.
+func (i *Info) Fields(elapsed time.Duration) map[string]any {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	fields := map[string]any{"elapsed_ms": elapsed.Milliseconds()}
+	if i.server != "" {
+		fields["server"] = i.server
+	}
+	if i.projects > 0 {
+		fields["projects_scanned"] = i.projects
+	}
+	if len(i.caches) > 0 {
+		fields["cache"] = maps.Clone(i.caches)
+	}
+	return fields
+}
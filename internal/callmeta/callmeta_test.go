@@ -0,0 +1,57 @@
+package callmeta
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	ctx, info := NewContext(context.Background())
+	SetServer(ctx, "api.example.com:443")
+	AddProjects(ctx, 1)
+	AddProjects(ctx, 2)
+	ObserveCache(ctx, "access_token", false)
+	ObserveCache(ctx, "access_token", true)
+	ObserveCache(ctx, "realm_url", false)
+
+	fields := info.Fields(1500 * time.Millisecond)
+	if fields["elapsed_ms"] != int64(1500) {
+		t.Errorf("elapsed_ms = %v, want 1500", fields["elapsed_ms"])
+	}
+	if fields["server"] != "api.example.com:443" {
+		t.Errorf("server = %v, want api.example.com:443", fields["server"])
+	}
+	if fields["projects_scanned"] != 3 {
+		t.Errorf("projects_scanned = %v, want 3", fields["projects_scanned"])
+	}
+	caches, _ := fields["cache"].(map[string]string)
+	if caches["access_token"] != CacheHit || caches["realm_url"] != CacheMiss {
+		t.Errorf("cache = %v, want access_token hit and realm_url miss", fields["cache"])
+	}
+}
+
+func TestFields_NothingRecorded(t *testing.T) {
+	t.Parallel()
+
+	_, info := NewContext(context.Background())
+	fields := info.Fields(0)
+	if len(fields) != 1 {
+		t.Errorf("fields = %v, want only elapsed_ms", fields)
+	}
+}
+
+func TestNoInfo(t *testing.T) {
+	t.Parallel()
+
+	// Must not panic without an Info in the context
+	ctx := context.Background()
+	SetServer(ctx, "api.example.com:443")
+	AddProjects(ctx, 1)
+	ObserveCache(ctx, "access_token", true)
+	if FromContext(ctx) != nil {
+		t.Error("expected nil info")
+	}
+}
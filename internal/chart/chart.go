@@ -0,0 +1,202 @@
+// Package chart renders small compliance charts as SVG or PNG images, for
+// MCP clients that display images better than tables of numbers.
+//
+// SVG output is labelled. PNG output is drawn with the standard library only
+// and has no text, so callers should describe the data alongside it.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Chart image dimensions and margins, in pixels.
+const (
+	width        = 800
+	lineHeight   = 400
+	marginLeft   = 60
+	marginRight  = 30
+	marginTop    = 50
+	marginBottom = 50
+	// barLabelWidth is the room left of the bars for their labels.
+	barLabelWidth = 280
+	barHeight     = 24
+	barGap        = 8
+	// maxLabelLen truncates bar labels that would not fit barLabelWidth.
+	maxLabelLen = 40
+)
+
+// Point is one value of a time series.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Bar is one labelled value of a bar chart.
+type Bar struct {
+	Label string
+	Value float64
+}
+
+// Line describes a line chart of a percentage, such as the compliance score,
+// over time. The y axis runs from 0 to 100.
+type Line struct {
+	Title  string
+	Points []Point
+}
+
+// Bars describes a horizontal bar chart, such as failures per rule. Bars are
+// drawn in the given order.
+type Bars struct {
+	Title string
+	Bars  []Bar
+}
+
+// xy is a position in image coordinates.
+type xy struct{ x, y float64 }
+
+// plotArea returns the rectangle the line chart's data is drawn in.
+func plotArea() (left, top, right, bottom float64) {
+	return marginLeft, marginTop, width - marginRight, lineHeight - marginBottom
+}
+
+// positions maps the line chart's points into the plot area, spacing them by
+// time. A single point is centered.
+func (l Line) positions() []xy {
+	left, top, right, bottom := plotArea()
+	if len(l.Points) == 0 {
+		return nil
+	}
+	first, last := l.Points[0].Time, l.Points[len(l.Points)-1].Time
+	span := last.Sub(first).Seconds()
+
+	out := make([]xy, len(l.Points))
+	for i, p := range l.Points {
+		x := (left + right) / 2
+		if span > 0 {
+			x = left + (right-left)*p.Time.Sub(first).Seconds()/span
+		}
+		v := math.Max(0, math.Min(100, p.Value))
+		out[i] = xy{x: x, y: bottom - (bottom-top)*v/100}
+	}
+	return out
+}
+
+// barsHeight returns the image height fitting n bars.
+func barsHeight(n int) int {
+	return marginTop + n*(barHeight+barGap) + marginBottom
+}
+
+// barRects returns each bar's left, top and right edge, scaled so the largest
+// value spans the plot width.
+func (b Bars) barRects() [][3]float64 {
+	maxValue := 0.0
+	for _, bar := range b.Bars {
+		maxValue = math.Max(maxValue, bar.Value)
+	}
+	left, right := float64(barLabelWidth), float64(width-marginRight-60)
+	out := make([][3]float64, len(b.Bars))
+	for i, bar := range b.Bars {
+		top := float64(marginTop + i*(barHeight+barGap))
+		end := left
+		if maxValue > 0 {
+			end = left + (right-left)*math.Max(0, bar.Value)/maxValue
+		}
+		out[i] = [3]float64{left, top, end}
+	}
+	return out
+}
+
+// SVG renders the line chart as an SVG document.
+func (l Line) SVG() []byte {
+	var b strings.Builder
+	svgOpen(&b, lineHeight, l.Title)
+	left, top, right, bottom := plotArea()
+	for v := 0; v <= 100; v += 25 {
+		y := bottom - (bottom-top)*float64(v)/100
+		fmt.Fprintf(&b, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="#e5e7eb"/>`+"\n", left, y, right, y)
+		fmt.Fprintf(&b, `<text x="%g" y="%g" text-anchor="end" font-size="12" fill="#6b7280">%d%%</text>`+"\n",
+			left-8, y+4, v)
+	}
+
+	pos := l.positions()
+	if len(pos) > 0 {
+		coords := make([]string, len(pos))
+		for i, p := range pos {
+			coords[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#2563eb" stroke-width="2"/>`+"\n",
+			strings.Join(coords, " "))
+		for _, p := range pos {
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="#2563eb"/>`+"\n", p.x, p.y)
+		}
+		// Label the first and last dates, and the middle one for longer series
+		labels := []int{0, len(pos) - 1}
+		if len(pos) > 2 {
+			labels = append(labels, len(pos)/2)
+		}
+		for _, i := range uniq(labels) {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%g" text-anchor="middle" font-size="12" fill="#6b7280">%s</text>`+"\n",
+				pos[i].x, bottom+20, l.Points[i].Time.UTC().Format("2006-01-02"))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+// SVG renders the bar chart as an SVG document.
+func (b Bars) SVG() []byte {
+	var s strings.Builder
+	svgOpen(&s, barsHeight(len(b.Bars)), b.Title)
+	for i, r := range b.barRects() {
+		bar := b.Bars[i]
+		fmt.Fprintf(&s, `<text x="%g" y="%g" text-anchor="end" font-size="12" fill="#374151">%s</text>`+"\n",
+			r[0]-8, r[1]+barHeight/2+4, escape(truncate(bar.Label)))
+		fmt.Fprintf(&s, `<rect x="%g" y="%g" width="%.1f" height="%d" fill="#dc2626"/>`+"\n",
+			r[0], r[1], r[2]-r[0], barHeight)
+		fmt.Fprintf(&s, `<text x="%.1f" y="%g" font-size="12" fill="#374151">%g</text>`+"\n",
+			r[2]+6, r[1]+barHeight/2+4, bar.Value)
+	}
+	s.WriteString("</svg>\n")
+	return []byte(s.String())
+}
+
+// svgOpen writes the SVG header, background and title.
+func svgOpen(b *strings.Builder, height int, title string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`font-family="sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+	fmt.Fprintf(b, `<text x="%d" y="30" font-size="16" font-weight="bold" fill="#111827">%s</text>`+"\n",
+		marginLeft, escape(title))
+}
+
+// escape makes text safe inside SVG elements.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+// truncate shortens labels longer than maxLabelLen runes.
+func truncate(s string) string {
+	r := []rune(s)
+	if len(r) <= maxLabelLen {
+		return s
+	}
+	return string(r[:maxLabelLen-1]) + "…"
+}
+
+// uniq returns the distinct values of a small slice, keeping their order.
+func uniq(values []int) []int {
+	var out []int
+	for _, v := range values {
+		seen := false
+		for _, o := range out {
+			seen = seen || o == v
+		}
+		if !seen {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	line := Line{Title: "Score <all>", Points: []Point{
+		{Time: base, Value: 50},
+		{Time: base.Add(24 * time.Hour), Value: 70},
+		{Time: base.Add(48 * time.Hour), Value: 90},
+	}}
+
+	svg := string(line.SVG())
+	for _, want := range []string{"Score &lt;all&gt;", "<polyline", "2026-03-02", "2026-03-03", "2026-03-04", "100%"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+
+	data, err := line.PNG()
+	if err != nil {
+		t.Fatalf("PNG() returned error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG() output does not decode: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != width || b.Dy() != lineHeight {
+		t.Errorf("PNG size = %dx%d, want %dx%d", b.Dx(), b.Dy(), width, lineHeight)
+	}
+}
+
+func TestLine_Positions(t *testing.T) {
+	t.Parallel()
+
+	left, top, right, bottom := plotArea()
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		points []Point
+		want   []xy
+	}{
+		{
+			name:   "single point centered",
+			points: []Point{{Time: base, Value: 50}},
+			want:   []xy{{x: (left + right) / 2, y: (top + bottom) / 2}},
+		},
+		{
+			name:   "spaced by time and clamped",
+			points: []Point{{Time: base, Value: -10}, {Time: base.Add(time.Hour), Value: 150}},
+			want:   []xy{{x: left, y: bottom}, {x: right, y: top}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Line{Points: tt.points}.positions()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d positions, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("position %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBars(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("r", maxLabelLen+10)
+	bars := Bars{Title: "Failures", Bars: []Bar{{Label: "baseline/branch_protection", Value: 4}, {Label: long, Value: 2}}}
+
+	rects := bars.barRects()
+	if full, half := rects[0][2]-rects[0][0], rects[1][2]-rects[1][0]; full != 2*half {
+		t.Errorf("bar widths = %v and %v, want the first twice the second", full, half)
+	}
+
+	svg := string(bars.SVG())
+	if !strings.Contains(svg, "baseline/branch_protection") {
+		t.Error("SVG does not contain the first label")
+	}
+	if strings.Contains(svg, long) || !strings.Contains(svg, "…") {
+		t.Error("SVG does not truncate the long label")
+	}
+
+	data, err := bars.PNG()
+	if err != nil {
+		t.Fatalf("PNG() returned error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG() output does not decode: %v", err)
+	}
+	if got := img.Bounds().Dy(); got != barsHeight(2) {
+		t.Errorf("PNG height = %d, want %d", got, barsHeight(2))
+	}
+}
@@ -0,0 +1,84 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	gridColor  = color.RGBA{0xe5, 0xe7, 0xeb, 0xff}
+	lineColor  = color.RGBA{0x25, 0x63, 0xeb, 0xff}
+	barColor   = color.RGBA{0xdc, 0x26, 0x26, 0xff}
+)
+
+// PNG renders the line chart as a PNG image. Grid lines mark 0, 25, 50, 75 and
+// 100 percent; the image has no text.
+func (l Line) PNG() ([]byte, error) {
+	img := newCanvas(lineHeight)
+	left, top, right, bottom := plotArea()
+	for v := 0; v <= 100; v += 25 {
+		y := bottom - (bottom-top)*float64(v)/100
+		fillRect(img, left, y, right, y+1, gridColor)
+	}
+
+	pos := l.positions()
+	for i := 1; i < len(pos); i++ {
+		drawLine(img, pos[i-1], pos[i], lineColor)
+	}
+	for _, p := range pos {
+		fillRect(img, p.x-3, p.y-3, p.x+3, p.y+3, lineColor)
+	}
+	return encode(img)
+}
+
+// PNG renders the bar chart as a PNG image without labels.
+func (b Bars) PNG() ([]byte, error) {
+	img := newCanvas(barsHeight(len(b.Bars)))
+	for _, r := range b.barRects() {
+		fillRect(img, r[0], r[1], r[2], r[1]+barHeight, barColor)
+	}
+	return encode(img)
+}
+
+// newCanvas returns a blank chart image of the given height.
+func newCanvas(height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	return img
+}
+
+// fillRect fills the rectangle between two corners.
+func fillRect(img *image.RGBA, x0, y0, x1, y1 float64, c color.Color) {
+	r := image.Rect(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)))
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawLine draws a two pixel wide line between two points.
+func drawLine(img *image.RGBA, from, to xy, c color.RGBA) {
+	steps := int(math.Max(math.Abs(to.x-from.x), math.Abs(to.y-from.y)))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		x := int(math.Round(from.x + (to.x-from.x)*t))
+		y := int(math.Round(from.y + (to.y-from.y)*t))
+		img.SetRGBA(x, y, c)
+		img.SetRGBA(x+1, y, c)
+		img.SetRGBA(x, y+1, c)
+	}
+}
+
+// encode returns the image as PNG bytes.
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
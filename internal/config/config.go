@@ -2,9 +2,20 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/cron"
 )
 
 // EnvReader is a function type for reading environment variables.
@@ -18,22 +29,469 @@ func OSEnvReader(key string) string {
 // Config holds all configuration for the MCP server.
 type Config struct {
 	LogLevel string
+	Logging  LoggingConfig
 	Minder   MinderConfig
 	MCP      MCPConfig
+	Watch    WatchConfig
+	History  HistoryConfig
+	Report   ReportConfig
+	Store    StoreConfig
+}
+
+// LoggingConfig holds log output configuration.
+type LoggingConfig struct {
+	Format     string
+	File       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	// RedactKeys names log attributes and tool arguments masked in logs in
+	// addition to the built-in token, password and secret names.
+	RedactKeys []string
 }
 
+// DefaultServerName names the Minder server configured by MINDER_SERVER_HOST.
+const DefaultServerName = "default"
+
 // MinderConfig holds Minder-specific configuration.
 type MinderConfig struct {
 	AuthToken string
 	Host      string
 	Port      int
 	Insecure  bool
+	// InsecureConfirmed is the second opt-in, MINDER_INSECURE_CONFIRM=true,
+	// without which no server may be configured insecure, so a stray
+	// MINDER_INSECURE cannot silently downgrade a deployment to plaintext gRPC.
+	InsecureConfirmed bool
+	// insecureConfirm is MINDER_INSECURE_CONFIRM as set, so Validate can
+	// reject a value that is not a boolean instead of reading it as false.
+	insecureConfirm string
+	// CertPins restrict the certificates the server may present to those
+	// whose SubjectPublicKeyInfo ("sha256/<base64>") or DER certificate
+	// ("cert-sha256/<base64>") has a listed SHA-256 hash, on top of the
+	// system trust store. Empty trusts the system store alone.
+	CertPins []string
+	// AuthProfiles are service-account tokens a tool call may select with its
+	// auth_profile argument instead of the token it would otherwise use.
+	AuthProfiles []AuthProfile
+	// Servers are additional named Minder backends that sessions can select.
+	Servers []NamedServer
+	// AllowedHosts are host[:port] entries, besides the configured servers,
+	// that a request may route to with the X-Minder-Host header.
+	AllowedHosts []string
+	// RateLimitRetries is how many times a Minder call rejected with
+	// ResourceExhausted is retried. Zero returns the error at once.
+	RateLimitRetries int
+	// RateLimitMaxWait is the longest wait before such a retry.
+	RateLimitMaxWait time.Duration
+	// RealmCachePath is a file discovered identity provider realms are kept in
+	// across restarts. Empty discovers them again after every restart.
+	RealmCachePath string
+}
+
+// NamedServer is an additional Minder backend, configured with
+// MINDER_SERVER_<NAME>_HOST, _PORT, _INSECURE, _CERT_PINS, _AUTH_TOKEN and
+// _HEADER_TOKENS.
+type NamedServer struct {
+	Name      string
+	AuthToken string
+	Host      string
+	Port      int
+	Insecure  bool
+	CertPins  []string
+	// HeaderTokens sends callers' Authorization header tokens to this server
+	// when a session selects it. Without it they only go to the default
+	// server, so a token for one instance is not sent to another.
+	HeaderTokens bool
+}
+
+// AuthProfile is a service-account token tool calls can select by name,
+// configured with MINDER_AUTH_PROFILE_<NAME>_TOKEN, _SERVER and
+// _ALLOWED_CIDRS, so calls run with a least-privilege token by default and
+// escalate explicitly.
+type AuthProfile struct {
+	Name  string
+	Token string
+	// Server names the server the token belongs to; it is never sent to another.
+	Server string
+	// AllowedCIDRs are the client networks that may select the profile.
+	AllowedCIDRs []string
+}
+
+// AllowedPrefixes parses AllowedCIDRs like MCPConfig.AllowedPrefixes.
+func (p AuthProfile) AllowedPrefixes() ([]netip.Prefix, error) {
+	return parsePrefixes(authProfileEnvKey(p.Name, "ALLOWED_CIDRS"), p.AllowedCIDRs)
+}
+
+// AuthProfile returns the named auth profile.
+func (c *MinderConfig) AuthProfile(name string) (AuthProfile, bool) {
+	for _, p := range c.AuthProfiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return AuthProfile{}, false
+}
+
+// AuthProfileNames returns the names of the auth profiles.
+func (c *MinderConfig) AuthProfileNames() []string {
+	names := make([]string, 0, len(c.AuthProfiles))
+	for _, p := range c.AuthProfiles {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// Server returns the named server. DefaultServerName and "" return the
+// server configured by MINDER_SERVER_HOST.
+func (c *MinderConfig) Server(name string) (NamedServer, bool) {
+	if name == "" || name == DefaultServerName {
+		return NamedServer{
+			Name:      DefaultServerName,
+			AuthToken: c.AuthToken,
+			Host:      c.Host,
+			Port:      c.Port,
+			Insecure:  c.Insecure,
+			CertPins:  c.CertPins,
+		}, true
+	}
+	for _, srv := range c.Servers {
+		if srv.Name == name {
+			return srv, true
+		}
+	}
+	return NamedServer{}, false
+}
+
+// HasAuthToken reports whether any configured Minder server or auth profile
+// has a token, which requests without one of their own may act with.
+func (c *MinderConfig) HasAuthToken() bool {
+	if c.AuthToken != "" || len(c.AuthProfiles) > 0 {
+		return true
+	}
+	for _, srv := range c.Servers {
+		if srv.AuthToken != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerForHost returns the server for a request routed to host[:port]; the
+// port defaults to 443. Configured servers match first and keep their
+// settings and tokens. Other hosts must be in AllowedHosts and get TLS and no
+// configured token, so they are only reached with the request's own token.
+func (c *MinderConfig) ServerForHost(hostport string) (NamedServer, bool) {
+	host, port, err := splitHostPort(hostport)
+	if err != nil {
+		return NamedServer{}, false
+	}
+	for _, name := range c.ServerNames() {
+		if srv, _ := c.Server(name); strings.EqualFold(srv.Host, host) && srv.Port == port {
+			return srv, true
+		}
+	}
+	for _, entry := range c.AllowedHosts {
+		if allowedHost, allowedPort, err := splitHostPort(entry); err == nil && allowedHost == host && allowedPort == port {
+			return NamedServer{Name: net.JoinHostPort(host, strconv.Itoa(port)), Host: host, Port: port}, true
+		}
+	}
+	return NamedServer{}, false
+}
+
+// splitHostPort parses host[:port] into a lowercase host and a port defaulting to 443.
+func splitHostPort(hostport string) (string, int, error) {
+	if !strings.Contains(hostport, ":") {
+		if hostport == "" {
+			return "", 0, errors.New("empty host")
+		}
+		return strings.ToLower(hostport), 443, nil
+	}
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 || host == "" {
+		return "", 0, fmt.Errorf("invalid host and port %q", hostport)
+	}
+	return strings.ToLower(host), port, nil
+}
+
+// InsecureServers returns the names of the configured servers reached without TLS.
+func (c *MinderConfig) InsecureServers() []string {
+	var names []string
+	for _, name := range c.ServerNames() {
+		if srv, _ := c.Server(name); srv.Insecure {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ServerNames returns DefaultServerName followed by the names of the additional servers.
+func (c *MinderConfig) ServerNames() []string {
+	names := []string{DefaultServerName}
+	for _, srv := range c.Servers {
+		names = append(names, srv.Name)
+	}
+	return names
 }
 
 // MCPConfig holds MCP server configuration.
 type MCPConfig struct {
-	Port         int
-	EndpointPath string
+	Port           int
+	EndpointPath   string
+	MetricsEnabled bool
+	// StatsEnabled serves tool usage stats at /stats on the MCP port. Like
+	// /metrics, it is not authenticated.
+	StatsEnabled bool
+	// SlowCallThreshold is the tool call duration above which a latency breakdown is logged.
+	// Zero disables slow-call logging.
+	SlowCallThreshold time.Duration
+	// PprofPort is the loopback port serving /debug/pprof. Zero disables profiling.
+	PprofPort int
+	// CORSAllowedOrigins lists origins allowed to make cross-origin requests. "*" allows all.
+	CORSAllowedOrigins []string
+	// TrustedOrigins lists browser origins, besides loopback ones, allowed to
+	// send requests that act with credentials. "*" trusts all.
+	TrustedOrigins []string
+	// AllowedCIDRs restricts the clients that may connect to the MCP listener
+	// to these networks, given as CIDRs or single addresses. Empty allows all.
+	AllowedCIDRs []string
+	// EnabledTools restricts the tools offered to clients. Empty enables every tool.
+	EnabledTools []string
+	// ReadOnly registers only tools annotated as read-only and rejects any write tool call.
+	ReadOnly bool
+	// Warmup refreshes the configured token, connects to each Minder server
+	// and lists its projects at startup, so the first tool call is not slowed
+	// by realm discovery, token refresh or the TLS handshake.
+	Warmup bool
+	// ToolPrefix replaces DefaultToolPrefix at the start of every tool name, so
+	// several Minder servers can be told apart in one client. Empty keeps the default.
+	ToolPrefix string
+	// ToolOverridesPath is a YAML or JSON file replacing the titles and
+	// descriptions of tools, e.g. to translate them or add local guidance.
+	// Empty keeps the built-in text.
+	ToolOverridesPath string
+	// Plugins lists executables providing extra tools, see package plugin.
+	Plugins []string
+	// PluginTimeout bounds each run of a plugin.
+	PluginTimeout time.Duration
+	// PluginEnv names the environment variables passed to plugins besides
+	// PATH, HOME, TMPDIR and the locale.
+	PluginEnv []string
+	// HealthCheckTimeout bounds the health check tools make before calling
+	// Minder, so a wedged server fails calls quickly. Zero leaves it unbounded.
+	HealthCheckTimeout time.Duration
+	// RawCallMethods lists the Minder RPCs, as "minder.v1.Service/Method", that
+	// minder_raw_call may invoke. Empty leaves the tool unregistered.
+	RawCallMethods []string
+	// DefaultPageSize is the page size requested from Minder when a tool call omits one.
+	// Zero leaves the choice to Minder.
+	DefaultPageSize int
+	// MaxResults caps the number of items any list tool returns. Zero means no cap.
+	MaxResults int
+	// MaxResultBytes is the largest tool result returned inline. Larger results
+	// are kept as a temporary resource and replaced by a summary and a link to
+	// it. Zero returns every result inline.
+	MaxResultBytes int
+	// CompactJSON marshals tool results without indentation, which roughly
+	// halves the tokens large results cost a client.
+	CompactJSON bool
+	// MaxConcurrentCalls caps the tool calls running at once; further calls
+	// wait for a running one to finish. Zero means no cap.
+	MaxConcurrentCalls int
+	// MaxConcurrentCallsPerTool caps the running calls of each tool. Zero means no cap.
+	MaxConcurrentCallsPerTool int
+	// RateLimitPerMinute caps the tool calls each caller may make a minute,
+	// after an initial burst of RateLimitBurst calls. Zero means no cap. A
+	// caller is its header token once Minder has accepted it, and its client
+	// address until then.
+	RateLimitPerMinute int
+	RateLimitBurst     int
+	// ResultResourceTTL is how long an oversized result can be read as a resource.
+	ResultResourceTTL time.Duration
+	// Mode selects the backend tools talk to: ModeLive, ModeDemo, ModeRecord or ModeReplay.
+	Mode string
+	// RecordingDir holds the Minder responses written in ModeRecord and served in ModeReplay.
+	RecordingDir string
+}
+
+// DefaultToolPrefix starts the name of every tool unless MCP_TOOL_PREFIX overrides it.
+const DefaultToolPrefix = "minder_"
+
+const (
+	// ModeLive sends tool calls to the configured Minder servers.
+	ModeLive = "live"
+	// ModeDemo serves canned data from an in-process fake Minder, without a backend or token.
+	ModeDemo = "demo"
+	// ModeRecord talks to Minder like ModeLive and saves every response to RecordingDir.
+	ModeRecord = "record"
+	// ModeReplay serves the responses saved in RecordingDir, without a backend or token.
+	ModeReplay = "replay"
+)
+
+// Demo reports whether the server runs in demo mode.
+func (c *Config) Demo() bool {
+	return c.MCP.Mode == ModeDemo
+}
+
+// Offline reports whether tools are served without contacting a Minder server,
+// so no server host or auth token is needed.
+func (c *Config) Offline() bool {
+	return c.MCP.Mode == ModeDemo || c.MCP.Mode == ModeReplay
+}
+
+// WatchConfig holds configuration for the background compliance watcher.
+type WatchConfig struct {
+	// Interval is how often profile statuses are polled. Zero disables the watcher.
+	Interval time.Duration
+	// Projects limits polling to these project IDs. Empty polls every accessible project.
+	Projects []string
+	// Notify lists where compliance transitions are reported: WatchNotifyLog,
+	// WatchNotifyMCP and/or WatchNotifyWebhook.
+	Notify []string
+	// WebhookURLs receive a JSON event for each poll with transitions when
+	// Notify includes WatchNotifyWebhook.
+	WebhookURLs []string
+	// WebhookSecret signs webhook payloads with HMAC-SHA256. Empty sends them unsigned.
+	WebhookSecret string
+	// AlertSinks receive compliance alerts routed by project and severity.
+	AlertSinks []AlertSink
+}
+
+// AlertSink is a named destination for compliance alerts, configured with
+// MCP_ALERT_<NAME>_TYPE, _URL, _SECRET, _PROJECTS, _MIN_SEVERITY and _RECOVERIES.
+type AlertSink struct {
+	Name string
+	// Type is AlertSinkWebhook, AlertSinkSlack or AlertSinkLog.
+	Type string
+	// URL receives the alerts of webhook and Slack sinks.
+	URL string
+	// Secret signs webhook sink payloads with HMAC-SHA256. Empty sends them unsigned.
+	Secret string
+	// Projects limits the sink to alerts from these project IDs. Empty routes every project.
+	Projects []string
+	// MinSeverity drops alerts for rules of a lower severity: info, low,
+	// medium, high or critical. Empty routes every severity.
+	MinSeverity string
+	// Recoveries also routes alerts for profiles and rules that stopped failing.
+	Recoveries bool
+}
+
+const (
+	// AlertSinkWebhook posts alerts as JSON, optionally signed.
+	AlertSinkWebhook = "webhook"
+	// AlertSinkSlack posts alerts to a Slack incoming webhook.
+	AlertSinkSlack = "slack"
+	// AlertSinkLog writes alerts as log events.
+	AlertSinkLog = "log"
+)
+
+// alertSeverities are the values MinSeverity accepts, lowest first.
+var alertSeverities = []string{"info", "low", "medium", "high", "critical"}
+
+const (
+	// WatchNotifyLog reports compliance transitions as log events.
+	WatchNotifyLog = "log"
+	// WatchNotifyMCP reports compliance transitions as MCP logging notifications to connected clients.
+	WatchNotifyMCP = "mcp"
+	// WatchNotifyWebhook posts compliance transitions to the configured webhook URLs.
+	WatchNotifyWebhook = "webhook"
+)
+
+// Notifies reports whether transitions should be sent to the given target.
+func (w *WatchConfig) Notifies(target string) bool {
+	return slices.Contains(w.Notify, target)
+}
+
+// validate checks the notification targets and webhook URLs.
+func (w *WatchConfig) validate() error {
+	for _, target := range w.Notify {
+		if target != WatchNotifyLog && target != WatchNotifyMCP && target != WatchNotifyWebhook {
+			return fmt.Errorf("MCP_WATCH_NOTIFY entries must be log, mcp or webhook, got %q", target)
+		}
+	}
+	if w.Notifies(WatchNotifyWebhook) && len(w.WebhookURLs) == 0 {
+		return errors.New("MCP_WATCH_WEBHOOK_URLS is required when MCP_WATCH_NOTIFY includes webhook")
+	}
+	for _, raw := range w.WebhookURLs {
+		if !validWebhookURL(raw) {
+			return fmt.Errorf("MCP_WATCH_WEBHOOK_URLS entries must be http or https URLs, got %q", raw)
+		}
+	}
+	if len(w.AlertSinks) > 0 && w.Interval <= 0 {
+		return errors.New("MCP_ALERT_SINKS requires MCP_WATCH_INTERVAL, since alerts come from the compliance watcher")
+	}
+	seen := map[string]bool{}
+	for _, sink := range w.AlertSinks {
+		if !validServerName(sink.Name) {
+			return fmt.Errorf("MCP_ALERT_SINKS: invalid sink name %q: use lowercase letters, digits, '-' and '_'", sink.Name)
+		}
+		if seen[sink.Name] {
+			return fmt.Errorf("MCP_ALERT_SINKS: duplicate sink name %q", sink.Name)
+		}
+		seen[sink.Name] = true
+		switch sink.Type {
+		case AlertSinkLog:
+		case AlertSinkWebhook, AlertSinkSlack:
+			if !validWebhookURL(sink.URL) {
+				return fmt.Errorf("%s must be an http or https URL for a %s sink, got %q",
+					alertEnvKey(sink.Name, "URL"), sink.Type, sink.URL)
+			}
+		default:
+			return fmt.Errorf("%s must be webhook, slack or log, got %q", alertEnvKey(sink.Name, "TYPE"), sink.Type)
+		}
+		if sink.MinSeverity != "" && !slices.Contains(alertSeverities, sink.MinSeverity) {
+			return fmt.Errorf("%s must be one of %s, got %q",
+				alertEnvKey(sink.Name, "MIN_SEVERITY"), strings.Join(alertSeverities, ", "), sink.MinSeverity)
+		}
+	}
+	return nil
+}
+
+// validWebhookURL reports whether raw is an absolute http or https URL.
+func validWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// HistoryConfig holds configuration for scheduled compliance snapshots.
+type HistoryConfig struct {
+	// Interval is how often a compliance summary is recorded. Zero disables history.
+	Interval time.Duration
+	// Path is the bbolt database file summaries are stored in.
+	Path string
+	// Retention is how long summaries are kept. Zero keeps them forever.
+	Retention time.Duration
+}
+
+// ReportConfig holds configuration for scheduled compliance reports.
+type ReportConfig struct {
+	// Schedule is a cron schedule (e.g. "0 9 * * 1"), evaluated in UTC, at
+	// which a report is generated. Empty disables reports.
+	Schedule string
+	// Dir is a directory each report is also written to. Empty only
+	// publishes reports as resources.
+	Dir string
+	// Keep is the number of most recent reports offered as resources.
+	Keep int
+	// LabelFilter limits reports to profiles matching it, e.g. "team:payments".
+	// Empty covers the profiles the watcher covers.
+	LabelFilter string
+}
+
+// StoreConfig holds configuration for the embedded state store.
+type StoreConfig struct {
+	// Path is the bbolt file state is kept in across restarts. Empty keeps
+	// state in memory, apart from the history and realm cache files.
+	Path string
+	// MaxSizeMB caps the data in the store; the oldest entries are evicted beyond it.
+	MaxSizeMB int
+	// MaintenanceInterval is how often expired entries are removed and the
+	// file is compacted.
+	MaintenanceInterval time.Duration
 }
 
 // Load reads configuration from environment variables using the default OS reader.
@@ -45,27 +503,376 @@ func Load() *Config {
 func LoadWithReader(getEnv EnvReader) *Config {
 	return &Config{
 		LogLevel: getEnvDefault(getEnv, "LOG_LEVEL", "info"),
+		Logging: LoggingConfig{
+			Format:     getEnvDefault(getEnv, "LOG_FORMAT", "json"),
+			File:       getEnvDefault(getEnv, "LOG_FILE", ""),
+			MaxSizeMB:  getEnvInt(getEnv, "LOG_MAX_SIZE_MB", 100),
+			MaxBackups: getEnvInt(getEnv, "LOG_MAX_BACKUPS", 3),
+			MaxAgeDays: getEnvInt(getEnv, "LOG_MAX_AGE_DAYS", 28),
+			RedactKeys: getEnvList(getEnv, "LOG_REDACT_KEYS", nil),
+		},
 		Minder: MinderConfig{
-			AuthToken: getEnvDefault(getEnv, "MINDER_AUTH_TOKEN", ""),
-			Host:      getEnvDefault(getEnv, "MINDER_SERVER_HOST", ""),
-			Port:      getEnvInt(getEnv, "MINDER_SERVER_PORT", 443),
-			Insecure:  getEnvBool(getEnv, "MINDER_INSECURE", false),
+			AuthToken:         getEnvDefault(getEnv, "MINDER_AUTH_TOKEN", ""),
+			Host:              getEnvDefault(getEnv, "MINDER_SERVER_HOST", ""),
+			Port:              getEnvInt(getEnv, "MINDER_SERVER_PORT", 443),
+			Insecure:          getEnvBool(getEnv, "MINDER_INSECURE", false),
+			CertPins:          getEnvList(getEnv, "MINDER_CERT_PINS", nil),
+			InsecureConfirmed: getEnvBool(getEnv, "MINDER_INSECURE_CONFIRM", false),
+			insecureConfirm:   getEnv("MINDER_INSECURE_CONFIRM"),
+			Servers:           loadServers(getEnv),
+			AuthProfiles:      loadAuthProfiles(getEnv),
+			AllowedHosts:      getEnvList(getEnv, "MINDER_ALLOWED_HOSTS", nil),
+			RateLimitRetries:  getEnvInt(getEnv, "MINDER_RATE_LIMIT_RETRIES", 0),
+			RateLimitMaxWait:  getEnvDuration(getEnv, "MINDER_RATE_LIMIT_MAX_WAIT", 30*time.Second),
+			RealmCachePath:    getEnvDefault(getEnv, "MINDER_REALM_CACHE_PATH", ""),
 		},
 		MCP: MCPConfig{
-			Port:         getEnvInt(getEnv, "MCP_PORT", 8080),
-			EndpointPath: getEnvDefault(getEnv, "MCP_ENDPOINT_PATH", "/mcp"),
+			Port:                      getEnvInt(getEnv, "MCP_PORT", 8080),
+			EndpointPath:              getEnvDefault(getEnv, "MCP_ENDPOINT_PATH", "/mcp"),
+			MetricsEnabled:            getEnvBool(getEnv, "MCP_METRICS_ENABLED", false),
+			StatsEnabled:              getEnvBool(getEnv, "MCP_STATS_ENABLED", false),
+			SlowCallThreshold:         getEnvDuration(getEnv, "MCP_SLOW_CALL_THRESHOLD", 5*time.Second),
+			PprofPort:                 getEnvInt(getEnv, "MCP_PPROF_PORT", 0),
+			CORSAllowedOrigins:        getEnvList(getEnv, "MCP_CORS_ALLOWED_ORIGINS", []string{"*"}),
+			TrustedOrigins:            getEnvList(getEnv, "MCP_TRUSTED_ORIGINS", nil),
+			AllowedCIDRs:              getEnvList(getEnv, "MCP_ALLOWED_CIDRS", nil),
+			EnabledTools:              getEnvList(getEnv, "MCP_ENABLED_TOOLS", nil),
+			ReadOnly:                  getEnvBool(getEnv, "MINDER_MCP_READ_ONLY", false),
+			Warmup:                    getEnvBool(getEnv, "MCP_WARMUP", false),
+			ToolPrefix:                getEnvDefault(getEnv, "MCP_TOOL_PREFIX", DefaultToolPrefix),
+			ToolOverridesPath:         getEnvDefault(getEnv, "MCP_TOOL_OVERRIDES_PATH", ""),
+			RawCallMethods:            getEnvList(getEnv, "MCP_RAW_CALL_METHODS", nil),
+			Plugins:                   getEnvList(getEnv, "MCP_PLUGINS", nil),
+			PluginTimeout:             getEnvDuration(getEnv, "MCP_PLUGIN_TIMEOUT", 30*time.Second),
+			PluginEnv:                 getEnvList(getEnv, "MCP_PLUGIN_ENV", nil),
+			HealthCheckTimeout:        getEnvDuration(getEnv, "MCP_HEALTH_CHECK_TIMEOUT", 3*time.Second),
+			DefaultPageSize:           getEnvInt(getEnv, "MCP_DEFAULT_PAGE_SIZE", 0),
+			MaxResults:                getEnvInt(getEnv, "MCP_MAX_RESULTS", 0),
+			MaxResultBytes:            getEnvInt(getEnv, "MCP_MAX_RESULT_BYTES", 0),
+			CompactJSON:               getEnvBool(getEnv, "MCP_COMPACT_JSON", false),
+			MaxConcurrentCalls:        getEnvInt(getEnv, "MCP_MAX_CONCURRENT_CALLS", 0),
+			MaxConcurrentCallsPerTool: getEnvInt(getEnv, "MCP_MAX_CONCURRENT_CALLS_PER_TOOL", 0),
+			RateLimitPerMinute:        getEnvInt(getEnv, "MCP_RATE_LIMIT_PER_MINUTE", 0),
+			RateLimitBurst:            getEnvInt(getEnv, "MCP_RATE_LIMIT_BURST", 10),
+			ResultResourceTTL:         getEnvDuration(getEnv, "MCP_RESULT_RESOURCE_TTL", 15*time.Minute),
+			Mode:                      getEnvDefault(getEnv, "MINDER_MCP_MODE", ModeLive),
+			RecordingDir:              getEnvDefault(getEnv, "MINDER_MCP_RECORDING_DIR", ""),
+		},
+		Watch: WatchConfig{
+			Interval:      getEnvDuration(getEnv, "MCP_WATCH_INTERVAL", 0),
+			Projects:      getEnvList(getEnv, "MCP_WATCH_PROJECTS", nil),
+			Notify:        getEnvList(getEnv, "MCP_WATCH_NOTIFY", []string{WatchNotifyLog, WatchNotifyMCP}),
+			WebhookURLs:   getEnvList(getEnv, "MCP_WATCH_WEBHOOK_URLS", nil),
+			WebhookSecret: getEnvDefault(getEnv, "MCP_WATCH_WEBHOOK_SECRET", ""),
+			AlertSinks:    loadAlertSinks(getEnv),
+		},
+		History: HistoryConfig{
+			Interval:  getEnvDuration(getEnv, "MCP_HISTORY_INTERVAL", 0),
+			Path:      getEnvDefault(getEnv, "MCP_HISTORY_PATH", "minder-mcp-history.db"),
+			Retention: getEnvDuration(getEnv, "MCP_HISTORY_RETENTION", 90*24*time.Hour),
+		},
+		Report: ReportConfig{
+			Schedule:    getEnvDefault(getEnv, "MCP_REPORT_SCHEDULE", ""),
+			Dir:         getEnvDefault(getEnv, "MCP_REPORT_DIR", ""),
+			Keep:        getEnvInt(getEnv, "MCP_REPORT_KEEP", 12),
+			LabelFilter: getEnvDefault(getEnv, "MCP_REPORT_LABEL_FILTER", ""),
+		},
+		Store: StoreConfig{
+			Path:                getEnvDefault(getEnv, "MCP_STORE_PATH", ""),
+			MaxSizeMB:           getEnvInt(getEnv, "MCP_STORE_MAX_SIZE_MB", 256),
+			MaintenanceInterval: getEnvDuration(getEnv, "MCP_STORE_MAINTENANCE_INTERVAL", time.Hour),
 		},
 	}
 }
 
 // Validate checks that required configuration values are set.
 func (c *Config) Validate() error {
-	if c.Minder.Host == "" {
+	if err := c.validateMode(); err != nil {
+		return err
+	}
+	if c.Minder.Host == "" && !c.Offline() {
 		return errors.New("MINDER_SERVER_HOST is required")
 	}
+	if c.MCP.PprofPort != 0 && c.MCP.PprofPort == c.MCP.Port {
+		return errors.New("MCP_PPROF_PORT must differ from MCP_PORT")
+	}
+	if c.Logging.Format != "" && c.Logging.Format != "json" && c.Logging.Format != "text" {
+		return fmt.Errorf("LOG_FORMAT must be json or text, got %q", c.Logging.Format)
+	}
+	if c.MCP.DefaultPageSize < 0 || c.MCP.DefaultPageSize > 100 {
+		return fmt.Errorf("MCP_DEFAULT_PAGE_SIZE must be between 0 and 100, got %d", c.MCP.DefaultPageSize)
+	}
+	if c.MCP.MaxResults < 0 {
+		return fmt.Errorf("MCP_MAX_RESULTS must not be negative, got %d", c.MCP.MaxResults)
+	}
+	if c.MCP.MaxResultBytes < 0 {
+		return fmt.Errorf("MCP_MAX_RESULT_BYTES must not be negative, got %d", c.MCP.MaxResultBytes)
+	}
+	if c.MCP.MaxConcurrentCalls < 0 || c.MCP.MaxConcurrentCallsPerTool < 0 {
+		return errors.New("MCP_MAX_CONCURRENT_CALLS and MCP_MAX_CONCURRENT_CALLS_PER_TOOL must not be negative")
+	}
+	if c.MCP.RateLimitPerMinute < 0 {
+		return fmt.Errorf("MCP_RATE_LIMIT_PER_MINUTE must not be negative, got %d", c.MCP.RateLimitPerMinute)
+	}
+	if c.MCP.RateLimitPerMinute > 0 && c.MCP.RateLimitBurst < 1 {
+		return fmt.Errorf("MCP_RATE_LIMIT_BURST must be at least 1 when MCP_RATE_LIMIT_PER_MINUTE is set, got %d", c.MCP.RateLimitBurst)
+	}
+	if c.MCP.MaxResultBytes > 0 && c.MCP.ResultResourceTTL <= 0 {
+		return fmt.Errorf("MCP_RESULT_RESOURCE_TTL must be positive when MCP_MAX_RESULT_BYTES is set, got %v", c.MCP.ResultResourceTTL)
+	}
+	if c.Minder.RateLimitRetries < 0 {
+		return fmt.Errorf("MINDER_RATE_LIMIT_RETRIES must not be negative, got %d", c.Minder.RateLimitRetries)
+	}
+	if c.MCP.ToolPrefix != "" && !validServerName(c.MCP.ToolPrefix) {
+		return fmt.Errorf("MCP_TOOL_PREFIX must use lowercase letters, digits, '-' and '_', got %q", c.MCP.ToolPrefix)
+	}
+	if _, err := c.MCP.AllowedPrefixes(); err != nil {
+		return err
+	}
+	for _, method := range c.MCP.RawCallMethods {
+		service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		if !ok || service == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("MCP_RAW_CALL_METHODS entries must be Service/Method (e.g., minder.v1.UserService/GetUser), got %q", method)
+		}
+	}
+	if len(c.MCP.Plugins) > 0 && c.MCP.PluginTimeout <= 0 {
+		return fmt.Errorf("MCP_PLUGIN_TIMEOUT must be positive when MCP_PLUGINS is set, got %v", c.MCP.PluginTimeout)
+	}
+	if c.MCP.HealthCheckTimeout < 0 {
+		return fmt.Errorf("MCP_HEALTH_CHECK_TIMEOUT must not be negative, got %v", c.MCP.HealthCheckTimeout)
+	}
+	if err := c.Watch.validate(); err != nil {
+		return err
+	}
+	if c.History.Interval > 0 && c.History.Path == "" && c.Store.Path == "" {
+		return errors.New("MCP_HISTORY_PATH or MCP_STORE_PATH is required when MCP_HISTORY_INTERVAL is set")
+	}
+	if c.History.Retention < 0 {
+		return fmt.Errorf("MCP_HISTORY_RETENTION must not be negative, got %v", c.History.Retention)
+	}
+	if c.Store.Path != "" && c.Store.MaxSizeMB < 1 {
+		return fmt.Errorf("MCP_STORE_MAX_SIZE_MB must be at least 1, got %d", c.Store.MaxSizeMB)
+	}
+	if c.Store.Path != "" && c.Store.MaintenanceInterval <= 0 {
+		return fmt.Errorf("MCP_STORE_MAINTENANCE_INTERVAL must be positive, got %v", c.Store.MaintenanceInterval)
+	}
+	if c.Report.Schedule != "" {
+		if _, err := cron.Parse(c.Report.Schedule); err != nil {
+			return fmt.Errorf("MCP_REPORT_SCHEDULE is invalid: %w", err)
+		}
+		if c.Report.Keep < 1 {
+			return fmt.Errorf("MCP_REPORT_KEEP must be at least 1, got %d", c.Report.Keep)
+		}
+	}
+	return c.validateServers()
+}
+
+// AllowedPrefixes parses AllowedCIDRs. A single address stands for a prefix
+// containing only that address.
+func (c *MCPConfig) AllowedPrefixes() ([]netip.Prefix, error) {
+	return parsePrefixes("MCP_ALLOWED_CIDRS", c.AllowedCIDRs)
+}
+
+// parsePrefixes parses the CIDRs and addresses set by the variable key.
+func parsePrefixes(key string, entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s entries must be CIDRs or IP addresses, got %q", key, entry)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
+
+// validateMode checks the backend mode and the recording directory it needs.
+func (c *Config) validateMode() error {
+	switch c.MCP.Mode {
+	case "", ModeLive, ModeDemo:
+		return nil
+	case ModeRecord, ModeReplay:
+		if c.MCP.RecordingDir == "" {
+			return fmt.Errorf("MINDER_MCP_RECORDING_DIR is required when MINDER_MCP_MODE is %s", c.MCP.Mode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("MINDER_MCP_MODE must be live, demo, record or replay, got %q", c.MCP.Mode)
+	}
+}
+
+// validateServers checks the additional named servers.
+func (c *Config) validateServers() error {
+	seen := map[string]bool{DefaultServerName: true}
+	for _, srv := range c.Minder.Servers {
+		if !validServerName(srv.Name) {
+			return fmt.Errorf("MINDER_SERVERS: invalid server name %q: use lowercase letters, digits, '-' and '_'", srv.Name)
+		}
+		if seen[srv.Name] {
+			return fmt.Errorf("MINDER_SERVERS: duplicate server name %q", srv.Name)
+		}
+		seen[srv.Name] = true
+		if srv.Host == "" {
+			return fmt.Errorf("%s is required", serverEnvKey(srv.Name, "HOST"))
+		}
+		if err := validateCertPins(serverEnvKey(srv.Name, "CERT_PINS"), srv.CertPins, srv.Insecure); err != nil {
+			return err
+		}
+	}
+	if err := validateCertPins("MINDER_CERT_PINS", c.Minder.CertPins, c.Minder.Insecure); err != nil {
+		return err
+	}
+	if raw := c.Minder.insecureConfirm; raw != "" {
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return fmt.Errorf("MINDER_INSECURE_CONFIRM must be true or false "+
+				"(also accepted: 1, 0, t, f, T, F, TRUE, FALSE, True, False), got %q", raw)
+		}
+	}
+	if insecure := c.Minder.InsecureServers(); len(insecure) > 0 && !c.Minder.InsecureConfirmed {
+		return fmt.Errorf("servers %s are configured insecure: plaintext gRPC sends tokens unencrypted, "+
+			"so it must also be confirmed with MINDER_INSECURE_CONFIRM=true", strings.Join(insecure, ", "))
+	}
+	for _, entry := range c.Minder.AllowedHosts {
+		if _, _, err := splitHostPort(entry); err != nil {
+			return fmt.Errorf("MINDER_ALLOWED_HOSTS: invalid entry %q: use host or host:port", entry)
+		}
+	}
+	return c.validateAuthProfiles()
+}
+
+// validateAuthProfiles checks the auth profiles.
+func (c *Config) validateAuthProfiles() error {
+	seen := map[string]bool{}
+	for _, p := range c.Minder.AuthProfiles {
+		if !validServerName(p.Name) {
+			return fmt.Errorf("MINDER_AUTH_PROFILES: invalid profile name %q: use lowercase letters, digits, '-' and '_'", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("MINDER_AUTH_PROFILES: duplicate profile name %q", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Token == "" {
+			return fmt.Errorf("%s is required", authProfileEnvKey(p.Name, "TOKEN"))
+		}
+		if _, ok := c.Minder.Server(p.Server); !ok {
+			return fmt.Errorf("%s: unknown server %q", authProfileEnvKey(p.Name, "SERVER"), p.Server)
+		}
+		// Escalating must be granted to callers explicitly
+		if len(p.AllowedCIDRs) == 0 {
+			return fmt.Errorf("%s is required", authProfileEnvKey(p.Name, "ALLOWED_CIDRS"))
+		}
+		if _, err := p.AllowedPrefixes(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCertPins checks the certificate pins of a server, set by the
+// variable key: each must be a base64 SHA-256 hash with its prefix, and pins
+// need TLS.
+func validateCertPins(key string, pins []string, insecure bool) error {
+	if len(pins) > 0 && insecure {
+		return fmt.Errorf("%s cannot be used with an insecure connection", key)
+	}
+	for _, pin := range pins {
+		encoded, ok := strings.CutPrefix(pin, "cert-sha256/")
+		if !ok {
+			encoded, ok = strings.CutPrefix(pin, "sha256/")
+		}
+		if hash, err := base64.StdEncoding.DecodeString(encoded); !ok || err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("%s: invalid pin %q: use sha256/<base64> or cert-sha256/<base64>", key, pin)
+		}
+	}
 	return nil
 }
 
+// loadServers reads the named servers listed in MINDER_SERVERS.
+func loadServers(getEnv EnvReader) []NamedServer {
+	var servers []NamedServer
+	for _, name := range getEnvList(getEnv, "MINDER_SERVERS", nil) {
+		name = strings.ToLower(name)
+		servers = append(servers, NamedServer{
+			Name:         name,
+			AuthToken:    getEnvDefault(getEnv, serverEnvKey(name, "AUTH_TOKEN"), ""),
+			Host:         getEnvDefault(getEnv, serverEnvKey(name, "HOST"), ""),
+			Port:         getEnvInt(getEnv, serverEnvKey(name, "PORT"), 443),
+			Insecure:     getEnvBool(getEnv, serverEnvKey(name, "INSECURE"), false),
+			CertPins:     getEnvList(getEnv, serverEnvKey(name, "CERT_PINS"), nil),
+			HeaderTokens: getEnvBool(getEnv, serverEnvKey(name, "HEADER_TOKENS"), false),
+		})
+	}
+	return servers
+}
+
+// loadAuthProfiles reads the auth profiles listed in MINDER_AUTH_PROFILES.
+func loadAuthProfiles(getEnv EnvReader) []AuthProfile {
+	var profiles []AuthProfile
+	for _, name := range getEnvList(getEnv, "MINDER_AUTH_PROFILES", nil) {
+		name = strings.ToLower(name)
+		profiles = append(profiles, AuthProfile{
+			Name:         name,
+			Token:        getEnvDefault(getEnv, authProfileEnvKey(name, "TOKEN"), ""),
+			Server:       strings.ToLower(getEnvDefault(getEnv, authProfileEnvKey(name, "SERVER"), DefaultServerName)),
+			AllowedCIDRs: getEnvList(getEnv, authProfileEnvKey(name, "ALLOWED_CIDRS"), nil),
+		})
+	}
+	return profiles
+}
+
+// authProfileEnvKey returns the environment variable holding an auth
+// profile's setting, e.g. MINDER_AUTH_PROFILE_ADMIN_TOKEN.
+func authProfileEnvKey(name, setting string) string {
+	return "MINDER_AUTH_PROFILE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_" + setting
+}
+
+// loadAlertSinks reads the alert sinks listed in MCP_ALERT_SINKS.
+func loadAlertSinks(getEnv EnvReader) []AlertSink {
+	var sinks []AlertSink
+	for _, name := range getEnvList(getEnv, "MCP_ALERT_SINKS", nil) {
+		name = strings.ToLower(name)
+		sinks = append(sinks, AlertSink{
+			Name:        name,
+			Type:        strings.ToLower(getEnvDefault(getEnv, alertEnvKey(name, "TYPE"), "")),
+			URL:         getEnvDefault(getEnv, alertEnvKey(name, "URL"), ""),
+			Secret:      getEnvDefault(getEnv, alertEnvKey(name, "SECRET"), ""),
+			Projects:    getEnvList(getEnv, alertEnvKey(name, "PROJECTS"), nil),
+			MinSeverity: strings.ToLower(getEnvDefault(getEnv, alertEnvKey(name, "MIN_SEVERITY"), "")),
+			Recoveries:  getEnvBool(getEnv, alertEnvKey(name, "RECOVERIES"), true),
+		})
+	}
+	return sinks
+}
+
+// alertEnvKey returns the environment variable holding an alert sink's
+// setting, e.g. MCP_ALERT_ONCALL_URL.
+func alertEnvKey(name, setting string) string {
+	return "MCP_ALERT_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_" + setting
+}
+
+// serverEnvKey returns the environment variable holding a named server's setting,
+// e.g. MINDER_SERVER_STAGING_HOST.
+func serverEnvKey(name, setting string) string {
+	return "MINDER_SERVER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_" + setting
+}
+
+// validServerName reports whether name is usable as a server name.
+func validServerName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 func getEnvDefault(getEnv EnvReader, key, defaultValue string) string {
 	if value := getEnv(key); value != "" {
 		return value
@@ -90,3 +897,32 @@ func getEnvBool(getEnv EnvReader, key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(getEnv EnvReader, key string, defaultValue time.Duration) time.Duration {
+	if value := getEnv(key); value != "" {
+		if durVal, err := time.ParseDuration(value); err == nil {
+			return durVal
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList parses a comma-separated list, dropping empty entries.
+func getEnvList(getEnv EnvReader, key string, defaultValue []string) []string {
+	value := getEnv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return splitList(value)
+}
+
+// splitList splits a comma-separated string, trimming spaces and dropping empty entries.
+func splitList(value string) []string {
+	var out []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
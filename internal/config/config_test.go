@@ -1,7 +1,10 @@
 package config
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // mockEnvReader creates an EnvReader from a map of key-value pairs.
@@ -19,6 +22,15 @@ func TestLoadWithReader_Defaults(t *testing.T) {
 	if cfg.LogLevel != "info" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "info")
 	}
+	if cfg.Logging.Format != "json" {
+		t.Errorf("Logging.Format = %q, want %q", cfg.Logging.Format, "json")
+	}
+	if cfg.Logging.File != "" {
+		t.Errorf("Logging.File = %q, want empty", cfg.Logging.File)
+	}
+	if cfg.Logging.MaxSizeMB != 100 {
+		t.Errorf("Logging.MaxSizeMB = %d, want %d", cfg.Logging.MaxSizeMB, 100)
+	}
 	if cfg.Minder.AuthToken != "" {
 		t.Errorf("AuthToken = %q, want empty", cfg.Minder.AuthToken)
 	}
@@ -37,19 +49,118 @@ func TestLoadWithReader_Defaults(t *testing.T) {
 	if cfg.MCP.EndpointPath != "/mcp" {
 		t.Errorf("EndpointPath = %q, want %q", cfg.MCP.EndpointPath, "/mcp")
 	}
+	if cfg.MCP.MetricsEnabled {
+		t.Errorf("MetricsEnabled = %v, want false", cfg.MCP.MetricsEnabled)
+	}
+	if cfg.MCP.StatsEnabled {
+		t.Errorf("StatsEnabled = %v, want false", cfg.MCP.StatsEnabled)
+	}
+	if cfg.MCP.PprofPort != 0 {
+		t.Errorf("PprofPort = %d, want 0", cfg.MCP.PprofPort)
+	}
+	if cfg.MCP.SlowCallThreshold != 5*time.Second {
+		t.Errorf("SlowCallThreshold = %v, want %v", cfg.MCP.SlowCallThreshold, 5*time.Second)
+	}
+	if cfg.MCP.HealthCheckTimeout != 3*time.Second {
+		t.Errorf("HealthCheckTimeout = %v, want %v", cfg.MCP.HealthCheckTimeout, 3*time.Second)
+	}
+	if len(cfg.MCP.CORSAllowedOrigins) != 1 || cfg.MCP.CORSAllowedOrigins[0] != "*" {
+		t.Errorf("CORSAllowedOrigins = %v, want [*]", cfg.MCP.CORSAllowedOrigins)
+	}
+	if len(cfg.MCP.EnabledTools) != 0 {
+		t.Errorf("EnabledTools = %v, want empty", cfg.MCP.EnabledTools)
+	}
+	if cfg.MCP.ReadOnly {
+		t.Errorf("ReadOnly = %v, want false", cfg.MCP.ReadOnly)
+	}
+	if cfg.MCP.ToolPrefix != DefaultToolPrefix {
+		t.Errorf("ToolPrefix = %q, want %q", cfg.MCP.ToolPrefix, DefaultToolPrefix)
+	}
+	if cfg.MCP.MaxResultBytes != 0 || cfg.MCP.ResultResourceTTL != 15*time.Minute {
+		t.Errorf("MaxResultBytes, ResultResourceTTL = %d, %v, want 0, 15m", cfg.MCP.MaxResultBytes, cfg.MCP.ResultResourceTTL)
+	}
+	if cfg.Minder.RateLimitRetries != 0 || cfg.Minder.RateLimitMaxWait != 30*time.Second {
+		t.Errorf("RateLimitRetries, RateLimitMaxWait = %d, %v, want 0, 30s",
+			cfg.Minder.RateLimitRetries, cfg.Minder.RateLimitMaxWait)
+	}
+	if cfg.MCP.Mode != ModeLive || cfg.Demo() {
+		t.Errorf("MCP.Mode = %q, want %q", cfg.MCP.Mode, ModeLive)
+	}
+	if cfg.Watch.Interval != 0 {
+		t.Errorf("Watch.Interval = %v, want 0", cfg.Watch.Interval)
+	}
+	if !cfg.Watch.Notifies(WatchNotifyLog) || !cfg.Watch.Notifies(WatchNotifyMCP) {
+		t.Errorf("Watch.Notify = %v, want log and mcp", cfg.Watch.Notify)
+	}
+	if cfg.History.Interval != 0 {
+		t.Errorf("History.Interval = %v, want 0", cfg.History.Interval)
+	}
+	if cfg.History.Path != "minder-mcp-history.db" {
+		t.Errorf("History.Path = %q, want %q", cfg.History.Path, "minder-mcp-history.db")
+	}
+	if cfg.History.Retention != 90*24*time.Hour {
+		t.Errorf("History.Retention = %v, want %v", cfg.History.Retention, 90*24*time.Hour)
+	}
+	if cfg.Report.Schedule != "" || cfg.Report.Dir != "" || cfg.Report.Keep != 12 {
+		t.Errorf("Report = %+v, want no schedule, no directory and 12 kept", cfg.Report)
+	}
+	if cfg.Store.Path != "" || cfg.Store.MaxSizeMB != 256 || cfg.Store.MaintenanceInterval != time.Hour {
+		t.Errorf("Store = %+v, want no path, 256 MB and hourly maintenance", cfg.Store)
+	}
 }
 
 func TestLoadWithReader_CustomValues(t *testing.T) {
 	t.Parallel()
 
 	env := map[string]string{
-		"LOG_LEVEL":          "debug",
-		"MINDER_AUTH_TOKEN":  "test-token",
-		"MINDER_SERVER_HOST": "localhost",
-		"MINDER_SERVER_PORT": "9090",
-		"MINDER_INSECURE":    "true",
-		"MCP_PORT":           "3000",
-		"MCP_ENDPOINT_PATH":  "/api/mcp",
+		"LOG_LEVEL":                     "debug",
+		"LOG_FORMAT":                    "text",
+		"LOG_FILE":                      "/var/log/minder-mcp.log",
+		"MINDER_AUTH_TOKEN":             "test-token",
+		"MINDER_SERVER_HOST":            "localhost",
+		"MINDER_SERVER_PORT":            "9090",
+		"MINDER_INSECURE":               "true",
+		"MCP_PORT":                      "3000",
+		"MCP_ENDPOINT_PATH":             "/api/mcp",
+		"MCP_METRICS_ENABLED":           "true",
+		"MCP_STATS_ENABLED":             "true",
+		"MCP_WATCH_INTERVAL":            "30s",
+		"MCP_WATCH_PROJECTS":            "proj-1,proj-2",
+		"MCP_WATCH_NOTIFY":              "log",
+		"MCP_WATCH_WEBHOOK_URLS":        "https://hooks.example.com/a,https://hooks.example.com/b",
+		"MCP_WATCH_WEBHOOK_SECRET":      "s3cret",
+		"MCP_ALERT_SINKS":               "OnCall,audit",
+		"MCP_ALERT_ONCALL_TYPE":         "Slack",
+		"MCP_ALERT_ONCALL_URL":          "https://hooks.slack.com/services/T0/B0/x",
+		"MCP_ALERT_ONCALL_PROJECTS":     "proj-1",
+		"MCP_ALERT_ONCALL_MIN_SEVERITY": "HIGH",
+		"MCP_ALERT_ONCALL_RECOVERIES":   "false",
+		"MCP_ALERT_AUDIT_TYPE":          "log",
+		"MCP_HISTORY_INTERVAL":          "1h",
+		"MCP_HISTORY_PATH":              "/var/lib/minder-mcp/history.db",
+		"MCP_REPORT_SCHEDULE":           "0 9 * * 1",
+		"MCP_REPORT_DIR":                "/var/lib/minder-mcp/reports",
+		"MCP_REPORT_KEEP":               "4",
+		"MCP_REPORT_LABEL_FILTER":       "team:payments",
+		"MCP_STORE_PATH":                "/var/lib/minder-mcp/state.db",
+		"MCP_STORE_MAX_SIZE_MB":         "64",
+		"MCP_ENABLED_TOOLS":             "minder_list_projects, ,minder_get_profile",
+		"MINDER_MCP_READ_ONLY":          "true",
+		"MCP_WARMUP":                    "true",
+		"MCP_COMPACT_JSON":              "true",
+		"MCP_MAX_CONCURRENT_CALLS":      "16",
+		"MCP_RATE_LIMIT_PER_MINUTE":     "120",
+		"MCP_TOOL_PREFIX":               "prod_minder_",
+		"MCP_TOOL_OVERRIDES_PATH":       "/etc/minder-mcp/tools.yaml",
+		"MCP_PLUGINS":                   "/opt/plugins/tickets, /opt/plugins/oncall",
+		"MCP_PLUGIN_TIMEOUT":            "5s",
+		"MCP_PLUGIN_ENV":                "TICKETS_API_KEY",
+		"MCP_HEALTH_CHECK_TIMEOUT":      "750ms",
+		"MINDER_RATE_LIMIT_RETRIES":     "2",
+		"MINDER_RATE_LIMIT_MAX_WAIT":    "10s",
+		"LOG_REDACT_KEYS":               "ssn, pin",
+		"MCP_TRUSTED_ORIGINS":           "https://app.example.com",
+		"MCP_ALLOWED_CIDRS":             "10.0.0.0/8, 192.168.1.5",
 	}
 
 	cfg := LoadWithReader(mockEnvReader(env))
@@ -57,6 +168,12 @@ func TestLoadWithReader_CustomValues(t *testing.T) {
 	if cfg.LogLevel != "debug" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
 	}
+	if cfg.Logging.Format != "text" {
+		t.Errorf("Logging.Format = %q, want %q", cfg.Logging.Format, "text")
+	}
+	if cfg.Logging.File != "/var/log/minder-mcp.log" {
+		t.Errorf("Logging.File = %q, want %q", cfg.Logging.File, "/var/log/minder-mcp.log")
+	}
 	if cfg.Minder.AuthToken != "test-token" {
 		t.Errorf("AuthToken = %q, want %q", cfg.Minder.AuthToken, "test-token")
 	}
@@ -75,6 +192,93 @@ func TestLoadWithReader_CustomValues(t *testing.T) {
 	if cfg.MCP.EndpointPath != "/api/mcp" {
 		t.Errorf("EndpointPath = %q, want %q", cfg.MCP.EndpointPath, "/api/mcp")
 	}
+	if !cfg.MCP.MetricsEnabled {
+		t.Errorf("MetricsEnabled = %v, want true", cfg.MCP.MetricsEnabled)
+	}
+	if !cfg.MCP.StatsEnabled {
+		t.Errorf("StatsEnabled = %v, want true", cfg.MCP.StatsEnabled)
+	}
+	if len(cfg.MCP.AllowedCIDRs) != 2 || cfg.MCP.AllowedCIDRs[1] != "192.168.1.5" {
+		t.Errorf("AllowedCIDRs = %v, want [10.0.0.0/8 192.168.1.5]", cfg.MCP.AllowedCIDRs)
+	}
+	if len(cfg.MCP.TrustedOrigins) != 1 || cfg.MCP.TrustedOrigins[0] != "https://app.example.com" {
+		t.Errorf("TrustedOrigins = %v, want [https://app.example.com]", cfg.MCP.TrustedOrigins)
+	}
+	if len(cfg.Logging.RedactKeys) != 2 || cfg.Logging.RedactKeys[1] != "pin" {
+		t.Errorf("Logging.RedactKeys = %v, want [ssn pin]", cfg.Logging.RedactKeys)
+	}
+	if len(cfg.MCP.EnabledTools) != 2 || cfg.MCP.EnabledTools[1] != "minder_get_profile" {
+		t.Errorf("EnabledTools = %v, want [minder_list_projects minder_get_profile]", cfg.MCP.EnabledTools)
+	}
+	if !cfg.MCP.ReadOnly {
+		t.Errorf("ReadOnly = %v, want true", cfg.MCP.ReadOnly)
+	}
+	if !cfg.MCP.Warmup {
+		t.Errorf("Warmup = %v, want true", cfg.MCP.Warmup)
+	}
+	if !cfg.MCP.CompactJSON {
+		t.Errorf("CompactJSON = %v, want true", cfg.MCP.CompactJSON)
+	}
+	if cfg.MCP.MaxConcurrentCalls != 16 || cfg.MCP.MaxConcurrentCallsPerTool != 0 {
+		t.Errorf("MaxConcurrentCalls, MaxConcurrentCallsPerTool = %d, %d, want 16, 0",
+			cfg.MCP.MaxConcurrentCalls, cfg.MCP.MaxConcurrentCallsPerTool)
+	}
+	if cfg.MCP.RateLimitPerMinute != 120 || cfg.MCP.RateLimitBurst != 10 {
+		t.Errorf("RateLimitPerMinute, RateLimitBurst = %d, %d, want 120, 10", cfg.MCP.RateLimitPerMinute, cfg.MCP.RateLimitBurst)
+	}
+	if cfg.MCP.ToolPrefix != "prod_minder_" {
+		t.Errorf("ToolPrefix = %q, want %q", cfg.MCP.ToolPrefix, "prod_minder_")
+	}
+	if cfg.MCP.ToolOverridesPath != "/etc/minder-mcp/tools.yaml" {
+		t.Errorf("ToolOverridesPath = %q, want %q", cfg.MCP.ToolOverridesPath, "/etc/minder-mcp/tools.yaml")
+	}
+	if len(cfg.MCP.Plugins) != 2 || cfg.MCP.Plugins[1] != "/opt/plugins/oncall" || cfg.MCP.PluginTimeout != 5*time.Second {
+		t.Errorf("Plugins, PluginTimeout = %v, %v, want two plugins and 5s", cfg.MCP.Plugins, cfg.MCP.PluginTimeout)
+	}
+	if len(cfg.MCP.PluginEnv) != 1 || cfg.MCP.PluginEnv[0] != "TICKETS_API_KEY" {
+		t.Errorf("PluginEnv = %v, want [TICKETS_API_KEY]", cfg.MCP.PluginEnv)
+	}
+	if cfg.MCP.HealthCheckTimeout != 750*time.Millisecond {
+		t.Errorf("HealthCheckTimeout = %v, want 750ms", cfg.MCP.HealthCheckTimeout)
+	}
+	if cfg.Minder.RateLimitRetries != 2 || cfg.Minder.RateLimitMaxWait != 10*time.Second {
+		t.Errorf("RateLimitRetries, RateLimitMaxWait = %d, %v, want 2, 10s",
+			cfg.Minder.RateLimitRetries, cfg.Minder.RateLimitMaxWait)
+	}
+	if cfg.Watch.Interval != 30*time.Second {
+		t.Errorf("Watch.Interval = %v, want %v", cfg.Watch.Interval, 30*time.Second)
+	}
+	if len(cfg.Watch.Projects) != 2 || cfg.Watch.Projects[0] != "proj-1" {
+		t.Errorf("Watch.Projects = %v, want [proj-1 proj-2]", cfg.Watch.Projects)
+	}
+	if !cfg.Watch.Notifies(WatchNotifyLog) || cfg.Watch.Notifies(WatchNotifyMCP) {
+		t.Errorf("Watch.Notify = %v, want only log", cfg.Watch.Notify)
+	}
+	if len(cfg.Watch.WebhookURLs) != 2 || cfg.Watch.WebhookSecret != "s3cret" {
+		t.Errorf("Watch webhooks = %v (secret %q), want two URLs and the secret", cfg.Watch.WebhookURLs, cfg.Watch.WebhookSecret)
+	}
+	wantSinks := []AlertSink{
+		{Name: "oncall", Type: AlertSinkSlack, URL: "https://hooks.slack.com/services/T0/B0/x",
+			Projects: []string{"proj-1"}, MinSeverity: "high"},
+		{Name: "audit", Type: AlertSinkLog, Recoveries: true},
+	}
+	if !reflect.DeepEqual(cfg.Watch.AlertSinks, wantSinks) {
+		t.Errorf("Watch.AlertSinks = %+v, want %+v", cfg.Watch.AlertSinks, wantSinks)
+	}
+	if cfg.History.Interval != time.Hour {
+		t.Errorf("History.Interval = %v, want %v", cfg.History.Interval, time.Hour)
+	}
+	if cfg.History.Path != "/var/lib/minder-mcp/history.db" {
+		t.Errorf("History.Path = %q, want %q", cfg.History.Path, "/var/lib/minder-mcp/history.db")
+	}
+	wantReport := ReportConfig{Schedule: "0 9 * * 1", Dir: "/var/lib/minder-mcp/reports", Keep: 4, LabelFilter: "team:payments"}
+	if cfg.Report != wantReport {
+		t.Errorf("Report = %+v, want %+v", cfg.Report, wantReport)
+	}
+	wantStore := StoreConfig{Path: "/var/lib/minder-mcp/state.db", MaxSizeMB: 64, MaintenanceInterval: time.Hour}
+	if cfg.Store != wantStore {
+		t.Errorf("Store = %+v, want %+v", cfg.Store, wantStore)
+	}
 }
 
 func TestGetEnvDefault(t *testing.T) {
@@ -258,6 +462,242 @@ func TestGetEnvBool(t *testing.T) {
 	}
 }
 
+func TestGetEnvDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		key          string
+		defaultValue time.Duration
+		want         time.Duration
+	}{
+		{
+			name:         "parses valid duration",
+			env:          map[string]string{"INTERVAL": "90s"},
+			key:          "INTERVAL",
+			defaultValue: time.Minute,
+			want:         90 * time.Second,
+		},
+		{
+			name:         "returns default for missing key",
+			env:          map[string]string{},
+			key:          "INTERVAL",
+			defaultValue: time.Minute,
+			want:         time.Minute,
+		},
+		{
+			name:         "returns default for invalid duration",
+			env:          map[string]string{"INTERVAL": "soon"},
+			key:          "INTERVAL",
+			defaultValue: time.Minute,
+			want:         time.Minute,
+		},
+		{
+			name:         "returns default for bare integer",
+			env:          map[string]string{"INTERVAL": "30"},
+			key:          "INTERVAL",
+			defaultValue: time.Minute,
+			want:         time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := getEnvDuration(mockEnvReader(tt.env), tt.key, tt.defaultValue)
+			if got != tt.want {
+				t.Errorf("getEnvDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadWithReader_Servers(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"MINDER_SERVER_HOST":                           "api.example.com",
+		"MINDER_AUTH_TOKEN":                            "prod-token",
+		"MINDER_SERVERS":                               "Staging, dev-local",
+		"MINDER_SERVER_STAGING_HOST":                   "staging.example.com",
+		"MINDER_SERVER_STAGING_AUTH_TOKEN":             "staging-token",
+		"MINDER_SERVER_DEV_LOCAL_HOST":                 "localhost",
+		"MINDER_SERVER_DEV_LOCAL_PORT":                 "8090",
+		"MINDER_SERVER_DEV_LOCAL_INSECURE":             "true",
+		"MINDER_INSECURE_CONFIRM":                      "true",
+		"MINDER_AUTH_PROFILES":                         "Admin, staging-ro",
+		"MINDER_AUTH_PROFILE_ADMIN_TOKEN":              "admin-token",
+		"MINDER_AUTH_PROFILE_ADMIN_ALLOWED_CIDRS":      "10.0.0.0/24, 192.168.1.5",
+		"MINDER_AUTH_PROFILE_STAGING_RO_ALLOWED_CIDRS": "10.0.0.0/8",
+		"MINDER_AUTH_PROFILE_STAGING_RO_TOKEN":         "staging-ro-token",
+		"MINDER_AUTH_PROFILE_STAGING_RO_SERVER":        "Staging",
+		"MINDER_CERT_PINS":                             "sha256/AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=",
+		"MINDER_SERVER_STAGING_CERT_PINS":              "cert-sha256/AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=",
+		"MINDER_SERVER_STAGING_HEADER_TOKENS":          "true",
+	}
+
+	cfg := LoadWithReader(mockEnvReader(env))
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+
+	names := cfg.Minder.ServerNames()
+	if len(names) != 3 || names[0] != DefaultServerName || names[1] != "staging" || names[2] != "dev-local" {
+		t.Errorf("ServerNames() = %v, want [default staging dev-local]", names)
+	}
+
+	staging, ok := cfg.Minder.Server("staging")
+	if !ok {
+		t.Fatal("Server(staging) not found")
+	}
+	if staging.Host != "staging.example.com" || staging.Port != 443 || staging.AuthToken != "staging-token" ||
+		!staging.HeaderTokens {
+		t.Errorf("Server(staging) = %+v", staging)
+	}
+	if len(staging.CertPins) != 1 || staging.CertPins[0] != "cert-sha256/AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=" {
+		t.Errorf("Server(staging).CertPins = %v", staging.CertPins)
+	}
+
+	dev, _ := cfg.Minder.Server("dev-local")
+	if dev.Host != "localhost" || dev.Port != 8090 || !dev.Insecure {
+		t.Errorf("Server(dev-local) = %+v", dev)
+	}
+
+	def, ok := cfg.Minder.Server("")
+	if !ok || def.Name != DefaultServerName || def.Host != "api.example.com" || def.AuthToken != "prod-token" {
+		t.Errorf("Server(\"\") = %+v, want the default server", def)
+	}
+	if len(def.CertPins) != 1 || def.CertPins[0] != "sha256/AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=" {
+		t.Errorf("Server(\"\").CertPins = %v", def.CertPins)
+	}
+
+	if _, ok := cfg.Minder.Server("missing"); ok {
+		t.Error("Server(missing) should not be found")
+	}
+
+	admin, ok := cfg.Minder.AuthProfile("admin")
+	if !ok || admin.Token != "admin-token" || admin.Server != DefaultServerName || len(admin.AllowedCIDRs) != 2 {
+		t.Errorf("AuthProfile(admin) = %+v, want the admin token for the default server", admin)
+	}
+	stagingRO, _ := cfg.Minder.AuthProfile("staging-ro")
+	if stagingRO.Token != "staging-ro-token" || stagingRO.Server != "staging" {
+		t.Errorf("AuthProfile(staging-ro) = %+v", stagingRO)
+	}
+}
+
+func TestLoadWithReader_InsecureConfirmNotBoolean(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"on", "yes"} {
+		cfg := LoadWithReader(mockEnvReader(map[string]string{
+			"MINDER_SERVER_HOST":      "localhost",
+			"MINDER_INSECURE":         "true",
+			"MINDER_INSECURE_CONFIRM": value,
+		}))
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "MINDER_INSECURE_CONFIRM") || !strings.Contains(err.Error(), "true or false") {
+			t.Errorf("Validate() with MINDER_INSECURE_CONFIRM=%s = %v, want an error naming the variable and accepted values",
+				value, err)
+		}
+	}
+}
+
+func TestMinderConfig_ServerForHost(t *testing.T) {
+	t.Parallel()
+
+	cfg := MinderConfig{
+		Host:         "api.example.com",
+		Port:         443,
+		AuthToken:    "prod-token",
+		Servers:      []NamedServer{{Name: "dev", Host: "localhost", Port: 8090, Insecure: true}},
+		AllowedHosts: []string{"customer-a.example.com", "customer-b.example.com:8443"},
+	}
+
+	tests := []struct {
+		host      string
+		wantOK    bool
+		wantName  string
+		wantPort  int
+		wantToken string
+	}{
+		{host: "api.example.com", wantOK: true, wantName: DefaultServerName, wantPort: 443, wantToken: "prod-token"},
+		{host: "API.example.com:443", wantOK: true, wantName: DefaultServerName, wantPort: 443, wantToken: "prod-token"},
+		{host: "localhost:8090", wantOK: true, wantName: "dev", wantPort: 8090},
+		{host: "customer-a.example.com", wantOK: true, wantName: "customer-a.example.com:443", wantPort: 443},
+		{host: "customer-b.example.com:8443", wantOK: true, wantName: "customer-b.example.com:8443", wantPort: 8443},
+		{host: "customer-b.example.com"},
+		{host: "localhost"},
+		{host: "evil.example.com"},
+		{host: "customer-a.example.com:notaport"},
+		{host: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			t.Parallel()
+
+			srv, ok := cfg.ServerForHost(tt.host)
+			if ok != tt.wantOK {
+				t.Fatalf("ServerForHost(%q) ok = %v, want %v", tt.host, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if srv.Name != tt.wantName || srv.Port != tt.wantPort || srv.AuthToken != tt.wantToken {
+				t.Errorf("ServerForHost(%q) = %+v", tt.host, srv)
+			}
+		})
+	}
+}
+
+func TestMinderConfig_HasAuthToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  MinderConfig
+		want bool
+	}{
+		{name: "no tokens", cfg: MinderConfig{Servers: []NamedServer{{Name: "dev"}}}},
+		{name: "default server token", cfg: MinderConfig{AuthToken: "t"}, want: true},
+		{name: "named server token", cfg: MinderConfig{Servers: []NamedServer{{Name: "dev", AuthToken: "t"}}}, want: true},
+		{name: "auth profile token", cfg: MinderConfig{AuthProfiles: []AuthProfile{{Name: "admin", Token: "t"}}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.HasAuthToken(); got != tt.want {
+				t.Errorf("HasAuthToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMCPConfig_AllowedPrefixes(t *testing.T) {
+	t.Parallel()
+
+	cfg := MCPConfig{AllowedCIDRs: []string{"10.1.2.3/8", "192.168.1.5", "::ffff:172.16.0.1", "fd00::/8"}}
+	prefixes, err := cfg.AllowedPrefixes()
+	if err != nil {
+		t.Fatalf("AllowedPrefixes() error = %v", err)
+	}
+	want := []string{"10.0.0.0/8", "192.168.1.5/32", "172.16.0.1/32", "fd00::/8"}
+	if len(prefixes) != len(want) {
+		t.Fatalf("AllowedPrefixes() = %v, want %v", prefixes, want)
+	}
+	for i, p := range prefixes {
+		if p.String() != want[i] {
+			t.Errorf("prefix %d = %s, want %s", i, p, want[i])
+		}
+	}
+
+	if _, err := (&MCPConfig{AllowedCIDRs: []string{"office"}}).AllowedPrefixes(); err == nil {
+		t.Error("AllowedPrefixes() accepted an invalid entry")
+	}
+}
+
 func TestConfig_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -275,6 +715,383 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid allowed host",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", AllowedHosts: []string{"customer.example.com:0"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "demo mode without host",
+			cfg:     &Config{MCP: MCPConfig{Mode: ModeDemo}},
+			wantErr: false,
+		},
+		{
+			name:    "replay mode without host",
+			cfg:     &Config{MCP: MCPConfig{Mode: ModeReplay, RecordingDir: "testdata"}},
+			wantErr: false,
+		},
+		{
+			name: "record mode without recording dir",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{Mode: ModeRecord},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown mode",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{Mode: "mock"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pprof port clashes with MCP port",
+			cfg: &Config{
+				Minder: MinderConfig{
+					Host: "api.example.com",
+				},
+				MCP: MCPConfig{Port: 8080, PprofPort: 8080},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid log format",
+			cfg: &Config{
+				Logging: LoggingConfig{Format: "xml"},
+				Minder: MinderConfig{
+					Host: "api.example.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "result byte budget without resource TTL",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{MaxResultBytes: 65536},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative rate limit retries",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", RateLimitRetries: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid tool prefix",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{ToolPrefix: "Minder."},
+			},
+			wantErr: true,
+		},
+		{
+			name: "plugins without timeout",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{Plugins: []string{"/opt/plugins/tickets"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed certificate pin",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", CertPins: []string{"sha256/not-base64"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate pin without TLS",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", Servers: []NamedServer{
+					{Name: "dev", Host: "localhost", Insecure: true, CertPins: []string{"sha256/AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "insecure server without confirmation",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", Servers: []NamedServer{
+					{Name: "dev", Host: "localhost", Insecure: true},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "insecure confirmation that is not a boolean",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", insecureConfirm: "yes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth profile without token",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", AuthProfiles: []AuthProfile{
+					{Name: "admin", Server: DefaultServerName},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth profile for unknown server",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", AuthProfiles: []AuthProfile{
+					{Name: "admin", Token: "t", Server: "staging", AllowedCIDRs: []string{"10.0.0.0/8"}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth profile without allowed callers",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", AuthProfiles: []AuthProfile{
+					{Name: "admin", Token: "t", Server: DefaultServerName},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth profile with invalid allowed callers",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com", AuthProfiles: []AuthProfile{
+					{Name: "admin", Token: "t", Server: DefaultServerName, AllowedCIDRs: []string{"office"}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative health check timeout",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{HealthCheckTimeout: -time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "raw call method without service",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{RawCallMethods: []string{"minder.v1.UserService/GetUser", "GetUser"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default page size above Minder maximum",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{DefaultPageSize: 500},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative concurrency limit",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{MaxConcurrentCallsPerTool: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid allowed CIDR",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.0/33"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rate limit without burst",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{RateLimitPerMinute: 60},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max results",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				MCP:    MCPConfig{MaxResults: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown watch notify target",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch:  WatchConfig{Notify: []string{"email"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook notify without URLs",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch:  WatchConfig{Notify: []string{WatchNotifyWebhook}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook URL without scheme",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch: WatchConfig{
+					Notify:      []string{WatchNotifyWebhook},
+					WebhookURLs: []string{"hooks.example.com/minder"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "alert sinks without watcher",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch:  WatchConfig{AlertSinks: []AlertSink{{Name: "audit", Type: AlertSinkLog}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "alert sink with unknown type",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch:  WatchConfig{Interval: time.Minute, AlertSinks: []AlertSink{{Name: "pager", Type: "email"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "slack alert sink without URL",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch:  WatchConfig{Interval: time.Minute, AlertSinks: []AlertSink{{Name: "team", Type: AlertSinkSlack}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "alert sink with unknown severity",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch: WatchConfig{Interval: time.Minute, AlertSinks: []AlertSink{
+					{Name: "audit", Type: AlertSinkLog, MinSeverity: "severe"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate alert sinks",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch: WatchConfig{Interval: time.Minute, AlertSinks: []AlertSink{
+					{Name: "audit", Type: AlertSinkLog}, {Name: "audit", Type: AlertSinkLog},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid alert sinks",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Watch: WatchConfig{Interval: time.Minute, AlertSinks: []AlertSink{
+					{Name: "audit", Type: AlertSinkLog},
+					{Name: "oncall", Type: AlertSinkWebhook, URL: "https://hooks.example.com/alerts", MinSeverity: "high"},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid report schedule",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Report: ReportConfig{Schedule: "@weekly", Keep: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid report schedule",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Report: ReportConfig{Schedule: "every monday", Keep: 12},
+			},
+			wantErr: true,
+		},
+		{
+			name: "report schedule keeping no reports",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Report: ReportConfig{Schedule: "0 9 * * 1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "history kept in the store",
+			cfg: &Config{
+				Minder:  MinderConfig{Host: "api.example.com"},
+				History: HistoryConfig{Interval: time.Hour},
+				Store:   StoreConfig{Path: "state.db", MaxSizeMB: 1, MaintenanceInterval: time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "store without size cap",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Store:  StoreConfig{Path: "state.db", MaintenanceInterval: time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "store without maintenance interval",
+			cfg: &Config{
+				Minder: MinderConfig{Host: "api.example.com"},
+				Store:  StoreConfig{Path: "state.db", MaxSizeMB: 256},
+			},
+			wantErr: true,
+		},
+		{
+			name: "history enabled without path",
+			cfg: &Config{
+				Minder:  MinderConfig{Host: "api.example.com"},
+				History: HistoryConfig{Interval: time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "named server without host",
+			cfg: &Config{
+				Minder: MinderConfig{
+					Host:    "api.example.com",
+					Servers: []NamedServer{{Name: "staging"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "named server reuses the default name",
+			cfg: &Config{
+				Minder: MinderConfig{
+					Host:    "api.example.com",
+					Servers: []NamedServer{{Name: DefaultServerName, Host: "other.example.com"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "named server with invalid name",
+			cfg: &Config{
+				Minder: MinderConfig{
+					Host:    "api.example.com",
+					Servers: []NamedServer{{Name: "stag ing", Host: "staging.example.com"}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid config without host",
 			cfg: &Config{
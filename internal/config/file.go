@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DotEnvFile is the file read for local development when MCP_CONFIG_FILE is not set.
+const DotEnvFile = ".env"
+
+// ResolveFile returns the path of the KEY=VALUE file to read settings from:
+// MCP_CONFIG_FILE when set, otherwise DotEnvFile in dir when it exists and
+// MINDER_MCP_ENV is not "production". It returns "" when there is no file to read.
+func ResolveFile(getEnv EnvReader, dir string) string {
+	if path := getEnv("MCP_CONFIG_FILE"); path != "" {
+		return path
+	}
+	if strings.EqualFold(getEnv("MINDER_MCP_ENV"), "production") {
+		return ""
+	}
+	path := filepath.Join(dir, DotEnvFile)
+	if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+		return path
+	}
+	return ""
+}
+
+// ReadEnvFile parses a file of KEY=VALUE lines. Blank lines and lines starting
+// with '#' are ignored, and matching single or double quotes around values are removed.
+func ReadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path) //nolint:gosec // path is operator-supplied configuration
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// LayeredEnvReader returns an EnvReader that consults each reader in order and
+// returns the first non-empty value.
+func LayeredEnvReader(readers ...EnvReader) EnvReader {
+	return func(key string) string {
+		for _, r := range readers {
+			if value := r(key); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+}
+
+// LoadWithFile reads configuration from environment variables, falling back to
+// values from the KEY=VALUE file at path for variables that are not set.
+// An empty path reads the environment only.
+func LoadWithFile(path string) (*Config, error) {
+	if path == "" {
+		return Load(), nil
+	}
+	values, err := ReadEnvFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	fileReader := func(key string) string { return values[key] }
+	return LoadWithReader(LayeredEnvReader(OSEnvReader, fileReader)), nil
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "minder-mcp.env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	return path
+}
+
+func TestReadEnvFile(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, `# comment
+LOG_LEVEL=debug
+
+export MINDER_SERVER_HOST = api.example.com
+MCP_ENDPOINT_PATH="/api/mcp"
+MCP_CORS_ALLOWED_ORIGINS='https://a.example.com'
+`)
+
+	values, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatalf("ReadEnvFile() returned error: %v", err)
+	}
+
+	want := map[string]string{
+		"LOG_LEVEL":                "debug",
+		"MINDER_SERVER_HOST":       "api.example.com",
+		"MCP_ENDPOINT_PATH":        "/api/mcp",
+		"MCP_CORS_ALLOWED_ORIGINS": "https://a.example.com",
+	}
+	if len(values) != len(want) {
+		t.Errorf("got %d values, want %d: %v", len(values), len(want), values)
+	}
+	for k, v := range want {
+		if values[k] != v {
+			t.Errorf("%s = %q, want %q", k, values[k], v)
+		}
+	}
+}
+
+func TestReadEnvFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ReadEnvFile(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := ReadEnvFile(writeFile(t, "NOT_A_PAIR\n")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestLayeredEnvReader(t *testing.T) {
+	t.Parallel()
+
+	reader := LayeredEnvReader(
+		mockEnvReader(map[string]string{"A": "first", "B": ""}),
+		mockEnvReader(map[string]string{"A": "second", "B": "fallback", "C": "only"}),
+	)
+
+	tests := map[string]string{"A": "first", "B": "fallback", "C": "only", "D": ""}
+	for key, want := range tests {
+		if got := reader(key); got != want {
+			t.Errorf("reader(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	t.Parallel()
+
+	withDotEnv := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withDotEnv, DotEnvFile), []byte("LOG_LEVEL=debug\n"), 0o600); err != nil {
+		t.Fatalf("failed to write .env: %v", err)
+	}
+	withoutDotEnv := t.TempDir()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		dir  string
+		want string
+	}{
+		{
+			name: "explicit config file wins",
+			env:  map[string]string{"MCP_CONFIG_FILE": "/etc/minder-mcp.env"},
+			dir:  withDotEnv,
+			want: "/etc/minder-mcp.env",
+		},
+		{
+			name: "dotenv used when present",
+			env:  map[string]string{},
+			dir:  withDotEnv,
+			want: filepath.Join(withDotEnv, DotEnvFile),
+		},
+		{
+			name: "dotenv ignored in production",
+			env:  map[string]string{"MINDER_MCP_ENV": "Production"},
+			dir:  withDotEnv,
+			want: "",
+		},
+		{
+			name: "no file when dotenv is missing",
+			env:  map[string]string{},
+			dir:  withoutDotEnv,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ResolveFile(mockEnvReader(tt.env), tt.dir); got != tt.want {
+				t.Errorf("ResolveFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"flag"
+	"strings"
+)
+
+// RegisterFlags binds command-line flags for every setting onto c.
+// Flag defaults are the values already loaded into c, so parsing flags after
+// Load layers them over environment variables: flag > env > built-in default.
+// The auth token and webhook secret are deliberately not exposed as flags to
+// keep them out of process listings; use MINDER_AUTH_TOKEN and
+// MCP_WATCH_WEBHOOK_SECRET instead.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "Log level: debug, info, warn, error (env LOG_LEVEL)")
+	fs.StringVar(&c.Logging.Format, "log-format", c.Logging.Format, "Log format: json or text (env LOG_FORMAT)")
+	fs.StringVar(&c.Logging.File, "log-file", c.Logging.File, "Write logs to this file instead of stderr (env LOG_FILE)")
+	fs.IntVar(&c.Logging.MaxSizeMB, "log-max-size-mb", c.Logging.MaxSizeMB,
+		"Rotate the log file at this size in MB (env LOG_MAX_SIZE_MB)")
+	fs.IntVar(&c.Logging.MaxBackups, "log-max-backups", c.Logging.MaxBackups,
+		"Rotated log files to keep, 0 keeps all (env LOG_MAX_BACKUPS)")
+	fs.IntVar(&c.Logging.MaxAgeDays, "log-max-age-days", c.Logging.MaxAgeDays,
+		"Days to keep rotated log files, 0 keeps all (env LOG_MAX_AGE_DAYS)")
+	fs.Var((*listValue)(&c.Logging.RedactKeys), "log-redact-keys",
+		"Comma-separated extra argument names to mask in logs (env LOG_REDACT_KEYS)")
+
+	fs.StringVar(&c.Minder.Host, "minder-host", c.Minder.Host, "Minder gRPC host (env MINDER_SERVER_HOST)")
+	fs.IntVar(&c.Minder.Port, "minder-port", c.Minder.Port, "Minder gRPC port (env MINDER_SERVER_PORT)")
+	fs.BoolVar(&c.Minder.Insecure, "minder-insecure", c.Minder.Insecure,
+		"Disable TLS to the Minder server (env MINDER_INSECURE); startup fails unless MINDER_INSECURE_CONFIRM=true is also set")
+	fs.Var((*listValue)(&c.Minder.CertPins), "minder-cert-pins",
+		"Comma-separated SHA-256 pins, sha256/<base64> of a public key or cert-sha256/<base64> of a certificate, "+
+			"one of which the Minder server's certificate chain must match (env MINDER_CERT_PINS)")
+	fs.IntVar(&c.Minder.RateLimitRetries, "minder-rate-limit-retries", c.Minder.RateLimitRetries,
+		"Retries of Minder calls rejected as rate limited, 0 disables (env MINDER_RATE_LIMIT_RETRIES)")
+	fs.DurationVar(&c.Minder.RateLimitMaxWait, "minder-rate-limit-max-wait", c.Minder.RateLimitMaxWait,
+		"Longest wait before retrying a rate-limited Minder call (env MINDER_RATE_LIMIT_MAX_WAIT)")
+	fs.StringVar(&c.Minder.RealmCachePath, "realm-cache-path", c.Minder.RealmCachePath,
+		"File discovered identity provider realms are kept in across restarts (env MINDER_REALM_CACHE_PATH)")
+
+	fs.IntVar(&c.MCP.Port, "port", c.MCP.Port, "MCP HTTP server port (env MCP_PORT)")
+	fs.StringVar(&c.MCP.EndpointPath, "endpoint-path", c.MCP.EndpointPath, "MCP endpoint path (env MCP_ENDPOINT_PATH)")
+	fs.BoolVar(&c.MCP.MetricsEnabled, "metrics", c.MCP.MetricsEnabled,
+		"Serve /metrics on the MCP port (env MCP_METRICS_ENABLED)")
+	fs.BoolVar(&c.MCP.StatsEnabled, "stats", c.MCP.StatsEnabled,
+		"Serve tool usage stats at /stats on the MCP port, without authentication (env MCP_STATS_ENABLED)")
+	fs.DurationVar(&c.MCP.SlowCallThreshold, "slow-call-threshold", c.MCP.SlowCallThreshold,
+		"Log a latency breakdown for slower tool calls, 0 disables (env MCP_SLOW_CALL_THRESHOLD)")
+	fs.IntVar(&c.MCP.PprofPort, "pprof-port", c.MCP.PprofPort,
+		"Serve /debug/pprof on 127.0.0.1 at this port, 0 disables (env MCP_PPROF_PORT)")
+	fs.Var((*listValue)(&c.MCP.CORSAllowedOrigins), "cors-allowed-origins",
+		"Comma-separated CORS origins, * allows all (env MCP_CORS_ALLOWED_ORIGINS)")
+	fs.Var((*listValue)(&c.MCP.TrustedOrigins), "trusted-origins",
+		"Comma-separated origins, besides loopback, trusted with credentialed requests, * trusts all (env MCP_TRUSTED_ORIGINS)")
+	fs.Var((*listValue)(&c.MCP.AllowedCIDRs), "allowed-cidrs",
+		"Comma-separated client networks allowed to connect, empty allows all (env MCP_ALLOWED_CIDRS)")
+	fs.Var((*listValue)(&c.MCP.EnabledTools), "enabled-tools",
+		"Comma-separated tools to offer, empty enables all (env MCP_ENABLED_TOOLS)")
+	fs.BoolVar(&c.MCP.ReadOnly, "read-only", c.MCP.ReadOnly,
+		"Expose only read-only tools and reject writes (env MINDER_MCP_READ_ONLY)")
+	fs.BoolVar(&c.MCP.Warmup, "warmup", c.MCP.Warmup,
+		"Connect to each Minder server and refresh the configured token at startup (env MCP_WARMUP)")
+	fs.StringVar(&c.MCP.ToolPrefix, "tool-prefix", c.MCP.ToolPrefix,
+		"Prefix of every tool name, replacing minder_ (env MCP_TOOL_PREFIX)")
+	fs.StringVar(&c.MCP.ToolOverridesPath, "tool-overrides", c.MCP.ToolOverridesPath,
+		"YAML or JSON file overriding tool titles and descriptions (env MCP_TOOL_OVERRIDES_PATH)")
+	fs.Var((*listValue)(&c.MCP.RawCallMethods), "raw-call-methods",
+		"Comma-separated Minder RPCs minder_raw_call may invoke, empty disables the tool (env MCP_RAW_CALL_METHODS)")
+	fs.Var((*listValue)(&c.MCP.Plugins), "plugins",
+		"Comma-separated plugin executables providing extra tools (env MCP_PLUGINS)")
+	fs.DurationVar(&c.MCP.PluginTimeout, "plugin-timeout", c.MCP.PluginTimeout,
+		"Time each plugin run may take before it is killed (env MCP_PLUGIN_TIMEOUT)")
+	fs.Var((*listValue)(&c.MCP.PluginEnv), "plugin-env",
+		"Comma-separated environment variables passed to plugins besides PATH, HOME, TMPDIR and the locale (env MCP_PLUGIN_ENV)")
+	fs.DurationVar(&c.MCP.HealthCheckTimeout, "health-check-timeout", c.MCP.HealthCheckTimeout,
+		"Time the health check before each tool call may take, 0 means no limit (env MCP_HEALTH_CHECK_TIMEOUT)")
+	fs.IntVar(&c.MCP.DefaultPageSize, "default-page-size", c.MCP.DefaultPageSize,
+		"Page size requested when a tool call omits one, 0 lets Minder choose (env MCP_DEFAULT_PAGE_SIZE)")
+	fs.IntVar(&c.MCP.MaxResults, "max-results", c.MCP.MaxResults,
+		"Maximum items returned by any list tool, 0 means no cap (env MCP_MAX_RESULTS)")
+	fs.IntVar(&c.MCP.MaxResultBytes, "max-result-bytes", c.MCP.MaxResultBytes,
+		"Larger tool results are returned as a resource link and summary, 0 disables (env MCP_MAX_RESULT_BYTES)")
+	fs.BoolVar(&c.MCP.CompactJSON, "compact-json", c.MCP.CompactJSON,
+		"Return tool results as JSON without indentation (env MCP_COMPACT_JSON)")
+	fs.IntVar(&c.MCP.MaxConcurrentCalls, "max-concurrent-calls", c.MCP.MaxConcurrentCalls,
+		"Tool calls running at once, further calls wait, 0 means no cap (env MCP_MAX_CONCURRENT_CALLS)")
+	fs.IntVar(&c.MCP.MaxConcurrentCallsPerTool, "max-concurrent-calls-per-tool", c.MCP.MaxConcurrentCallsPerTool,
+		"Calls of each tool running at once, 0 means no cap (env MCP_MAX_CONCURRENT_CALLS_PER_TOOL)")
+	fs.IntVar(&c.MCP.RateLimitPerMinute, "rate-limit-per-minute", c.MCP.RateLimitPerMinute,
+		"Tool calls each caller may make a minute, 0 means no cap (env MCP_RATE_LIMIT_PER_MINUTE)")
+	fs.IntVar(&c.MCP.RateLimitBurst, "rate-limit-burst", c.MCP.RateLimitBurst,
+		"Tool calls each token may make at once before the per-minute rate applies (env MCP_RATE_LIMIT_BURST)")
+	fs.DurationVar(&c.MCP.ResultResourceTTL, "result-resource-ttl", c.MCP.ResultResourceTTL,
+		"How long oversized results stay readable as resources (env MCP_RESULT_RESOURCE_TTL)")
+	fs.StringVar(&c.MCP.Mode, "mode", c.MCP.Mode,
+		"live talks to Minder, demo serves canned data, record saves Minder responses, "+
+			"replay serves saved responses (env MINDER_MCP_MODE)")
+	fs.StringVar(&c.MCP.RecordingDir, "recording-dir", c.MCP.RecordingDir,
+		"Directory of recorded Minder responses for record and replay modes (env MINDER_MCP_RECORDING_DIR)")
+
+	fs.DurationVar(&c.Watch.Interval, "watch-interval", c.Watch.Interval,
+		"Compliance watcher poll interval, 0 disables (env MCP_WATCH_INTERVAL)")
+	fs.Var((*listValue)(&c.Watch.Projects), "watch-projects",
+		"Comma-separated project IDs to watch, empty watches all (env MCP_WATCH_PROJECTS)")
+	fs.Var((*listValue)(&c.Watch.Notify), "watch-notify",
+		"Comma-separated transition targets: log, mcp, webhook (env MCP_WATCH_NOTIFY)")
+	fs.Var((*listValue)(&c.Watch.WebhookURLs), "watch-webhook-urls",
+		"Comma-separated URLs that receive compliance transition events (env MCP_WATCH_WEBHOOK_URLS)")
+	fs.DurationVar(&c.History.Interval, "history-interval", c.History.Interval,
+		"Compliance snapshot interval, 0 disables history (env MCP_HISTORY_INTERVAL)")
+	fs.StringVar(&c.History.Path, "history-path", c.History.Path,
+		"Compliance history database file (env MCP_HISTORY_PATH)")
+	fs.DurationVar(&c.History.Retention, "history-retention", c.History.Retention,
+		"How long compliance snapshots are kept, 0 keeps forever (env MCP_HISTORY_RETENTION)")
+	fs.StringVar(&c.Report.Schedule, "report-schedule", c.Report.Schedule,
+		"Cron schedule, in UTC, of compliance reports, empty disables (env MCP_REPORT_SCHEDULE)")
+	fs.StringVar(&c.Report.Dir, "report-dir", c.Report.Dir,
+		"Directory compliance reports are also written to (env MCP_REPORT_DIR)")
+	fs.IntVar(&c.Report.Keep, "report-keep", c.Report.Keep,
+		"Number of recent compliance reports offered as resources (env MCP_REPORT_KEEP)")
+	fs.StringVar(&c.Report.LabelFilter, "report-label-filter", c.Report.LabelFilter,
+		"Profile label filter of compliance reports, e.g. team:payments (env MCP_REPORT_LABEL_FILTER)")
+	fs.StringVar(&c.Store.Path, "store-path", c.Store.Path,
+		"Embedded store file for state kept across restarts, empty disables (env MCP_STORE_PATH)")
+	fs.IntVar(&c.Store.MaxSizeMB, "store-max-size-mb", c.Store.MaxSizeMB,
+		"Data size cap of the embedded store (env MCP_STORE_MAX_SIZE_MB)")
+}
+
+// listValue is a flag.Value for comma-separated lists.
+type listValue []string
+
+// String returns the list joined with commas.
+func (l *listValue) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+// Set replaces the list with the comma-separated value.
+func (l *listValue) Set(value string) error {
+	*l = splitList(value)
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRegisterFlags_Precedence(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"MINDER_SERVER_HOST": "env.example.com",
+		"MCP_PORT":           "9000",
+		"LOG_LEVEL":          "warn",
+	}
+	cfg := LoadWithReader(mockEnvReader(env))
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.RegisterFlags(fs)
+
+	err := fs.Parse([]string{
+		"--minder-host", "flag.example.com",
+		"--minder-insecure",
+		"--watch-interval", "2m",
+		"--cors-allowed-origins", "https://a.example.com,https://b.example.com",
+		"--watch-notify", "mcp",
+	})
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	// Flags override env
+	if cfg.Minder.Host != "flag.example.com" {
+		t.Errorf("Host = %q, want %q", cfg.Minder.Host, "flag.example.com")
+	}
+	if !cfg.Minder.Insecure {
+		t.Errorf("Insecure = %v, want true", cfg.Minder.Insecure)
+	}
+	if cfg.Watch.Interval != 2*time.Minute {
+		t.Errorf("Watch.Interval = %v, want %v", cfg.Watch.Interval, 2*time.Minute)
+	}
+	if cfg.Watch.Notifies(WatchNotifyLog) || !cfg.Watch.Notifies(WatchNotifyMCP) {
+		t.Errorf("Watch.Notify = %v, want only mcp", cfg.Watch.Notify)
+	}
+
+	if len(cfg.MCP.CORSAllowedOrigins) != 2 || cfg.MCP.CORSAllowedOrigins[0] != "https://a.example.com" {
+		t.Errorf("CORSAllowedOrigins = %v, want two origins", cfg.MCP.CORSAllowedOrigins)
+	}
+
+	// Env values survive when no flag is given
+	if cfg.MCP.Port != 9000 {
+		t.Errorf("MCP.Port = %d, want %d", cfg.MCP.Port, 9000)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "warn")
+	}
+
+	// Built-in defaults survive when neither is given
+	if cfg.MCP.EndpointPath != "/mcp" {
+		t.Errorf("EndpointPath = %q, want %q", cfg.MCP.EndpointPath, "/mcp")
+	}
+}
+
+func TestRegisterFlags_NoAuthTokenFlag(t *testing.T) {
+	t.Parallel()
+
+	cfg := LoadWithReader(mockEnvReader(map[string]string{}))
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "auth-token" || f.Name == "minder-auth-token" {
+			t.Errorf("auth token must not be exposed as flag %q", f.Name)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+// Package cron parses cron-style schedules and computes their next run time.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search for the next run, so a schedule that names
+// an impossible date such as February 30 does not loop forever.
+const maxSearchYears = 5
+
+// shortcuts are the named schedules accepted in place of five fields.
+var shortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 1",
+	"@monthly": "0 0 1 * *",
+}
+
+// field describes the valid range of one schedule field.
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron schedule, evaluated in UTC.
+type Schedule struct {
+	spec    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	anyDay  bool // day of month is "*"
+	anyWeek bool // day of week is "*"
+}
+
+// Parse parses a five-field schedule, "minute hour day-of-month month
+// day-of-week", or one of @hourly, @daily, @weekly (Monday) and @monthly. Each
+// field is "*", a number, a range "a-b", a step "*/n" or "a-b/n", or a
+// comma-separated list of these. Day of week 0 and 7 are both Sunday. As in
+// cron, when both day fields are restricted a day matching either runs.
+func Parse(spec string) (*Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if s, ok := shortcuts[expr]; ok {
+		expr = s
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (minute hour day-of-month month day-of-week) "+
+			"or be @hourly, @daily, @weekly or @monthly", spec)
+	}
+
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+		bits[i] = b
+	}
+	// Sunday may be written as 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &Schedule{
+		spec:    strings.TrimSpace(spec),
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		anyDay:  parts[2] == "*",
+		anyWeek: parts[4] == "*",
+	}, nil
+}
+
+// parseField returns the values a field matches as a bit set.
+func parseField(s string, f field) (uint64, error) {
+	var bits uint64
+	for item := range strings.SplitSeq(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepStr, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = parseValue(first, f); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = parseValue(last, f); err != nil {
+					return 0, err
+				}
+				if hi < lo {
+					return 0, fmt.Errorf("invalid range %q in %s field", rng, f.name)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses a single number within the field's range.
+func parseValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s must be from %d to %d, got %q", f.name, f.min, f.max, s)
+	}
+	return v, nil
+}
+
+// String returns the schedule as it was given to Parse.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t, truncated to the minute, at which the
+// schedule runs, or the zero time when it never runs.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the schedule runs on t's day.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return dow
+	case s.anyWeek:
+		return dom
+	default:
+		return dom || dow
+	}
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"too few fields", "0 9 * *"},
+		{"too many fields", "0 9 * * 1 2026"},
+		{"unknown shortcut", "@yearly"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"month out of range", "0 0 1 13 *"},
+		{"day of week out of range", "0 0 * * 8"},
+		{"not a number", "0 nine * * *"},
+		{"reversed range", "0 17-9 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"bad step", "*/x * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Parse(tt.spec); err == nil {
+				t.Errorf("Parse(%q) succeeded, want error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	from := time.Date(2026, 3, 4, 10, 30, 15, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, 3, 4, 10, 31, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"weekly runs on Monday", "@weekly", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"later today", "45 10 * * *", time.Date(2026, 3, 4, 10, 45, 0, 0, time.UTC)},
+		{"same minute is not next", "30 10 * * *", time.Date(2026, 3, 5, 10, 30, 0, 0, time.UTC)},
+		{"list", "0 8,12,16 * * *", time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{"step", "*/20 * * * *", time.Date(2026, 3, 4, 10, 40, 0, 0, time.UTC)},
+		{"range with step", "0 9-17/4 * * *", time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)},
+		{"weekdays", "0 9 * * 1-5", time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 9 * * 7", time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)},
+		{"next year", "0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"either day field matches", "0 0 15 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.spec, err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextUsesUTC(t *testing.T) {
+	t.Parallel()
+
+	s, err := Parse("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2026, 3, 4, 8, 0, 0, 0, time.FixedZone("CET", 3600))
+	want := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	if got := s.Next(from); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if s.String() != "0 9 * * *" {
+		t.Errorf("String() = %q", s.String())
+	}
+}
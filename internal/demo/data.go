@@ -0,0 +1,306 @@
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stacklok/minder-mcp/pkg/mindertest"
+)
+
+// Identifiers of the demo resources, stable across restarts so they can be
+// quoted in docs and reused between tool calls.
+const (
+	// ProjectID is the ID of the single demo project.
+	ProjectID = "6c4bd1b1-3b3c-4a43-9f0e-1d2a6f0d7e01"
+	// ProviderName is the name of the demo GitHub App provider.
+	ProviderName = "github-app-acme-corp"
+	// Owner is the GitHub organization owning the demo repositories.
+	Owner = "acme-corp"
+)
+
+// entity is a repository, artifact or pull request that rules are evaluated against.
+type entity struct {
+	id    string
+	kind  minderv1.Entity
+	name  string
+	owner string
+	repo  string
+}
+
+// info returns the entity info Minder attaches to rule evaluation statuses.
+func (e entity) info() map[string]string {
+	info := map[string]string{"entity_id": e.id, "entity_type": mindertest.EntityTypeName(e.kind)}
+	if e.kind == minderv1.Entity_ENTITY_REPOSITORIES {
+		info["repo_owner"], info["repo_name"] = e.owner, e.repo
+	} else {
+		info["name"] = e.name
+	}
+	return info
+}
+
+// newFixtures builds the demo project with evaluations relative to now, so
+// timestamps always look recent.
+func newFixtures(now time.Time) mindertest.Fixtures {
+	ago := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(now.Add(-d)) }
+	projectID := ProjectID
+	provider := ProviderName
+	projectCtx := func() *minderv1.Context {
+		return &minderv1.Context{Project: &projectID, Provider: &provider}
+	}
+
+	d := mindertest.Fixtures{
+		Projects: []*minderv1.Project{{
+			ProjectId:   ProjectID,
+			Name:        "acme-corp",
+			DisplayName: "Acme Corp",
+			Description: "Demo project with canned data; no Minder backend is contacted",
+			CreatedAt:   ago(90 * 24 * time.Hour),
+			UpdatedAt:   ago(24 * time.Hour),
+		}},
+		Providers: []*minderv1.Provider{{
+			Id:      "0b0c6f7e-5d1a-4c8e-b7a2-6a1f4e9d2c10",
+			Name:    ProviderName,
+			Class:   "github-app",
+			Project: ProjectID,
+			Version: "v1",
+			Implements: []minderv1.ProviderType{
+				minderv1.ProviderType_PROVIDER_TYPE_GITHUB, minderv1.ProviderType_PROVIDER_TYPE_GIT,
+				minderv1.ProviderType_PROVIDER_TYPE_REST, minderv1.ProviderType_PROVIDER_TYPE_REPO_LISTER,
+			},
+			AuthFlows:        []minderv1.AuthorizationFlow{minderv1.AuthorizationFlow_AUTHORIZATION_FLOW_GITHUB_APP_FLOW},
+			CredentialsState: "set",
+		}},
+		Statuses: map[string][]*minderv1.RuleEvaluationStatus{},
+	}
+
+	repo := func(id, name string, hookID int64, private bool) entity {
+		r := &minderv1.Repository{
+			Id:            &id,
+			Context:       projectCtx(),
+			Owner:         Owner,
+			Name:          name,
+			RepoId:        700000 + hookID,
+			CloneUrl:      fmt.Sprintf("https://github.com/%s/%s.git", Owner, name),
+			DeployUrl:     fmt.Sprintf("https://api.github.com/repos/%s/%s/keys", Owner, name),
+			DefaultBranch: "main",
+			License:       "apache-2.0",
+			IsPrivate:     private,
+			CreatedAt:     ago(60 * 24 * time.Hour),
+			UpdatedAt:     ago(2 * time.Hour),
+		}
+		if hookID != 0 {
+			r.HookId = hookID
+			r.HookName = "minder-webhook"
+			r.HookType = "Repository"
+			r.HookUrl = fmt.Sprintf("https://api.github.com/repos/%s/%s/hooks/%d", Owner, name, hookID)
+			r.HookUuid = fmt.Sprintf("d9a1c0de-0000-4000-8000-%012d", hookID)
+		}
+		d.Repositories = append(d.Repositories, r)
+		return entity{id: id, kind: minderv1.Entity_ENTITY_REPOSITORIES, name: Owner + "/" + name, owner: Owner, repo: name}
+	}
+	apiServer := repo("2f1e6c3a-7b5d-4e0f-9a8c-1b2d3e4f5a61", "api-server", 41001, false)
+	webFrontend := repo("2f1e6c3a-7b5d-4e0f-9a8c-1b2d3e4f5a62", "web-frontend", 41002, false)
+	infra := repo("2f1e6c3a-7b5d-4e0f-9a8c-1b2d3e4f5a63", "infrastructure", 0, true)
+
+	imageID := "8a7b6c5d-4e3f-4a1b-9c2d-3e4f5a6b7c81"
+	d.Artifacts = append(d.Artifacts, &minderv1.Artifact{
+		ArtifactPk: imageID,
+		Owner:      Owner,
+		Name:       "api-server",
+		Type:       "container",
+		Visibility: "public",
+		Repository: Owner + "/api-server",
+		Context:    projectCtx(),
+		CreatedAt:  ago(30 * 24 * time.Hour),
+		Versions: []*minderv1.ArtifactVersion{
+			{VersionId: 3, Tags: []string{"latest", "v1.4.0"}, Sha: "sha256:4f1c9e2b7d3a", CreatedAt: ago(6 * time.Hour)},
+			{VersionId: 2, Tags: []string{"v1.3.2"}, Sha: "sha256:9b2e7a1c5d4f", CreatedAt: ago(8 * 24 * time.Hour)},
+		},
+	})
+	image := entity{id: imageID, kind: minderv1.Entity_ENTITY_ARTIFACTS, name: Owner + "/api-server"}
+	pr := func(id string, number int) entity {
+		return entity{id: id, kind: minderv1.Entity_ENTITY_PULL_REQUESTS, name: fmt.Sprintf("%s/api-server/%d", Owner, number)}
+	}
+	pr128 := pr("5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e91", 128)
+	pr127 := pr("5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e92", 127)
+
+	ruleType := func(id, name, display, inEntity, description, guidance string, severity minderv1.Severity_Value) {
+		d.RuleTypes = append(d.RuleTypes, &minderv1.RuleType{
+			Id:                  &id,
+			Name:                name,
+			DisplayName:         display,
+			Type:                "rule-type",
+			Version:             "v1",
+			Context:             projectCtx(),
+			Description:         description,
+			Guidance:            guidance,
+			ShortFailureMessage: display + " check failed",
+			Severity:            &minderv1.Severity{Value: severity},
+			ReleasePhase:        minderv1.RuleTypeReleasePhase_RULE_TYPE_RELEASE_PHASE_GA,
+			Def:                 &minderv1.RuleType_Definition{InEntity: inEntity},
+		})
+	}
+	ruleType("a1000000-0000-4000-8000-000000000001", "branch_protection_enabled", "Branch protection enabled",
+		"repository", "Verifies that the default branch has branch protection rules.",
+		"Enable branch protection for the default branch under Settings > Branches.", minderv1.Severity_VALUE_HIGH)
+	ruleType("a1000000-0000-4000-8000-000000000002", "secret_scanning", "Secret scanning enabled",
+		"repository", "Verifies that GitHub secret scanning is enabled.",
+		"Enable secret scanning under Settings > Code security and analysis.", minderv1.Severity_VALUE_HIGH)
+	ruleType("a1000000-0000-4000-8000-000000000003", "dependabot_configured", "Dependabot configured",
+		"repository", "Verifies that Dependabot version updates are configured for the repository's ecosystems.",
+		"Add a .github/dependabot.yml covering each package ecosystem.", minderv1.Severity_VALUE_MEDIUM)
+	ruleType("a1000000-0000-4000-8000-000000000004", "artifact_signature", "Artifact signature verified",
+		"artifact", "Verifies that container images are signed with Sigstore and have build provenance.",
+		"Sign images in the release workflow with cosign keyless signing.", minderv1.Severity_VALUE_CRITICAL)
+	ruleType("a1000000-0000-4000-8000-000000000005", "pr_vulnerability_check", "PR vulnerability check",
+		"pull_request", "Checks dependencies added in pull requests against the OSV vulnerability database.",
+		"Upgrade the dependency to a patched version before merging.", minderv1.Severity_VALUE_HIGH)
+
+	rule := func(ruleType, name string) *minderv1.Profile_Rule {
+		return &minderv1.Profile_Rule{Type: ruleType, Name: name, Def: &structpb.Struct{}}
+	}
+	baselineID := "b2000000-0000-4000-8000-000000000001"
+	supplyChainID := "b2000000-0000-4000-8000-000000000002"
+	on, off := "on", "off"
+	d.Profiles = []*minderv1.Profile{
+		{
+			Id:          &baselineID,
+			Context:     projectCtx(),
+			Name:        "baseline-security",
+			DisplayName: "Baseline security",
+			Labels:      []string{"security"},
+			Type:        "profile",
+			Version:     "v1",
+			Alert:       &on,
+			Remediate:   &off,
+			Repository: []*minderv1.Profile_Rule{
+				rule("branch_protection_enabled", "branch_protection_enabled"),
+				rule("secret_scanning", "secret_scanning"),
+				rule("dependabot_configured", "dependabot_configured"),
+			},
+		},
+		{
+			Id:          &supplyChainID,
+			Context:     projectCtx(),
+			Name:        "supply-chain",
+			DisplayName: "Supply chain",
+			Labels:      []string{"supply-chain"},
+			Type:        "profile",
+			Version:     "v1",
+			Alert:       &on,
+			Remediate:   &off,
+			Artifact:    []*minderv1.Profile_Rule{rule("artifact_signature", "artifact_signature")},
+			PullRequest: []*minderv1.Profile_Rule{rule("pr_vulnerability_check", "pr_vulnerability_check")},
+		},
+	}
+
+	d.DataSources = []*minderv1.DataSource{{
+		Id:      "c3000000-0000-4000-8000-000000000001",
+		Name:    "osv",
+		Type:    "data-source",
+		Version: "v1",
+		Context: &minderv1.ContextV2{ProjectId: ProjectID},
+		Driver: &minderv1.DataSource_Rest{Rest: &minderv1.RestDataSource{
+			Def: map[string]*minderv1.RestDataSource_Def{
+				"query": {Method: "POST", Endpoint: "https://api.osv.dev/v1/query", Parse: "json"},
+			},
+		}},
+	}}
+
+	// Each evaluation is recorded in history; the newest per profile, rule and
+	// entity is the current status.
+	seq := 0
+	evaluate := func(profile *minderv1.Profile, ruleType string, e entity, status, details string, age time.Duration) {
+		seq++
+		id := fmt.Sprintf("e4000000-0000-4000-8000-%012d", seq)
+		rt := findRuleType(d.RuleTypes, ruleType)
+		alert := &minderv1.EvaluationHistoryAlert{Status: "off"}
+		if status == "failure" {
+			alert.Status = "on"
+			alert.Details = "Alert raised for " + e.name
+		}
+		d.History = append(d.History, &minderv1.EvaluationHistory{
+			Id:          id,
+			Entity:      &minderv1.EvaluationHistoryEntity{Id: e.id, Type: e.kind, Name: e.name},
+			Rule:        &minderv1.EvaluationHistoryRule{Name: ruleType, RuleType: ruleType, Profile: profile.Name, Severity: rt.Severity},
+			Status:      &minderv1.EvaluationHistoryStatus{Status: status, Details: details},
+			Alert:       alert,
+			Remediation: &minderv1.EvaluationHistoryRemediation{Status: "skipped"},
+			EvaluatedAt: ago(age),
+		})
+
+		current := d.Statuses[profile.GetId()]
+		for _, s := range current {
+			if s.RuleTypeName == ruleType && s.EntityInfo["entity_id"] == e.id {
+				return
+			}
+		}
+		d.Statuses[profile.GetId()] = append(current, &minderv1.RuleEvaluationStatus{
+			ProfileId:           profile.GetId(),
+			RuleId:              rt.GetId(),
+			RuleName:            ruleType,
+			RuleTypeName:        ruleType,
+			RuleDescriptionName: ruleType,
+			RuleDisplayName:     rt.DisplayName,
+			Entity:              mindertest.EntityTypeName(e.kind),
+			EntityInfo:          e.info(),
+			Status:              status,
+			Details:             details,
+			Guidance:            guidanceFor(rt, status),
+			LastUpdated:         ago(age),
+			RemediationStatus:   "skipped",
+			Alert:               &minderv1.EvalResultAlert{Status: alert.Status, Details: alert.Details},
+			Severity:            rt.Severity,
+			RuleEvaluationId:    id,
+			ReleasePhase:        rt.ReleasePhase,
+		})
+	}
+
+	// Newest first: the first evaluation of each rule and entity is its current status.
+	baseline, supplyChain := d.Profiles[0], d.Profiles[1]
+	evaluate(supplyChain, "pr_vulnerability_check", pr128, "failure",
+		"golang.org/x/net v0.17.0 is affected by GO-2024-2687 (HTTP/2 CONTINUATION flood); fixed in v0.23.0", 20*time.Minute)
+	evaluate(baseline, "branch_protection_enabled", webFrontend, "failure",
+		"main: required pull request reviews are not enabled", 45*time.Minute)
+	evaluate(baseline, "dependabot_configured", apiServer, "failure",
+		"no dependabot configuration found for ecosystem gomod", time.Hour)
+	evaluate(baseline, "branch_protection_enabled", apiServer, "success", "", time.Hour)
+	evaluate(baseline, "secret_scanning", apiServer, "success", "", time.Hour)
+	evaluate(baseline, "secret_scanning", webFrontend, "success", "", 2*time.Hour)
+	evaluate(baseline, "dependabot_configured", webFrontend, "success", "", 2*time.Hour)
+	evaluate(baseline, "branch_protection_enabled", infra, "success", "", 3*time.Hour)
+	evaluate(baseline, "secret_scanning", infra, "error",
+		"GET https://api.github.com/repos/acme-corp/infrastructure: 403 secret scanning is not available for this repository",
+		3*time.Hour)
+	evaluate(baseline, "dependabot_configured", infra, "success", "", 3*time.Hour)
+	evaluate(supplyChain, "artifact_signature", image, "success", "", 6*time.Hour)
+	evaluate(supplyChain, "pr_vulnerability_check", pr127, "success", "", 26*time.Hour)
+	// Earlier evaluations, so history shows web-frontend regressing and api-server not yet fixed
+	evaluate(baseline, "branch_protection_enabled", webFrontend, "success", "", 3*24*time.Hour)
+	evaluate(baseline, "dependabot_configured", apiServer, "failure",
+		"no dependabot configuration found for ecosystem gomod", 3*24*time.Hour)
+
+	return d
+}
+
+// guidanceFor returns the rule type's guidance for failing evaluations, as Minder does.
+func guidanceFor(rt *minderv1.RuleType, status string) string {
+	if status == "failure" {
+		return rt.Guidance
+	}
+	return ""
+}
+
+// findRuleType returns the rule type with the given name, or nil.
+func findRuleType(ruleTypes []*minderv1.RuleType, name string) *minderv1.RuleType {
+	for _, rt := range ruleTypes {
+		if rt.Name == name {
+			return rt
+		}
+	}
+	return nil
+}
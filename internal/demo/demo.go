@@ -0,0 +1,29 @@
+// Package demo serves canned Minder data from an in-process gRPC server, so
+// the MCP server can be evaluated without a Minder backend or credentials.
+package demo
+
+import (
+	"context"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/minder"
+	"github.com/stacklok/minder-mcp/pkg/mindertest"
+)
+
+// Start starts a fake Minder serving the demo project, with evaluations
+// timestamped relative to now. Only the read RPCs the tools use are
+// implemented; others return Unimplemented.
+func Start(now time.Time) *mindertest.Server {
+	return mindertest.Start(newFixtures(now))
+}
+
+// NewClient returns a Minder client connected to srv, matching the client
+// factory signature the tools accept. Closing the client closes its
+// connection.
+func NewClient(ctx context.Context, srv *mindertest.Server) (*minder.Client, error) {
+	conn, err := srv.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return minder.NewClientFromConn(conn), nil
+}
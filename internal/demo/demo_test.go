@@ -0,0 +1,146 @@
+package demo_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/demo"
+	"github.com/stacklok/minder-mcp/internal/tools"
+	"github.com/stacklok/minder-mcp/pkg/mindertest"
+)
+
+// startDemo starts a demo server that is stopped when the test ends.
+func startDemo(t *testing.T) *mindertest.Server {
+	t.Helper()
+	srv := demo.Start(time.Now())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestServer_ProfileStatus(t *testing.T) {
+	t.Parallel()
+
+	client, err := demo.NewClient(context.Background(), startDemo(t))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	projectID := demo.ProjectID
+	resp, err := client.Profiles().GetProfileStatusByName(context.Background(), &minderv1.GetProfileStatusByNameRequest{
+		Name:    "baseline-security",
+		Context: &minderv1.Context{Project: &projectID},
+	})
+	if err != nil {
+		t.Fatalf("GetProfileStatusByName() error = %v", err)
+	}
+	if got := resp.GetProfileStatus().GetProfileStatus(); got != "failure" {
+		t.Errorf("profile status = %q, want failure", got)
+	}
+	if len(resp.RuleEvaluationStatus) != 9 {
+		t.Errorf("got %d rule evaluations, want 3 rules on 3 repositories", len(resp.RuleEvaluationStatus))
+	}
+
+	other := "other-project"
+	_, err = client.Profiles().ListProfiles(context.Background(), &minderv1.ListProfilesRequest{
+		Context: &minderv1.Context{Project: &other},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ListProfiles() in another project error = %v, want NotFound", err)
+	}
+}
+
+func TestServer_EvaluationHistoryFilters(t *testing.T) {
+	t.Parallel()
+
+	client, err := demo.NewClient(context.Background(), startDemo(t))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	projectID := demo.ProjectID
+	resp, err := client.EvalResults().ListEvaluationHistory(context.Background(), &minderv1.ListEvaluationHistoryRequest{
+		Context:    &minderv1.Context{Project: &projectID},
+		EntityName: []string{demo.Owner + "/web-frontend"},
+		Status:     []string{"failure"},
+	})
+	if err != nil {
+		t.Fatalf("ListEvaluationHistory() error = %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].GetRule().GetRuleType() != "branch_protection_enabled" {
+		t.Errorf("got %v, want the web-frontend branch protection failure", resp.Data)
+	}
+}
+
+// TestDemoMode_Tools checks that the tools return data, not errors, in demo mode.
+func TestDemoMode_Tools(t *testing.T) {
+	t.Parallel()
+
+	srv := startDemo(t)
+	cfg := &config.Config{MCP: config.MCPConfig{Mode: config.ModeDemo}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tl := tools.NewWithClientFactory(cfg, logger, func(ctx context.Context) (tools.MinderClient, error) {
+		return demo.NewClient(ctx, srv)
+	})
+	mcpServer := server.NewMCPServer("test", "0.0.0")
+	tl.Register(mcpServer)
+
+	tests := []struct {
+		tool string
+		args map[string]any
+	}{
+		{tool: "minder_list_projects"},
+		{tool: "minder_resolve", args: map[string]any{"kind": "repository", "name": demo.Owner + "/api-server"}},
+		{tool: "minder_list_repositories"},
+		{tool: "minder_get_repository", args: map[string]any{"owner": demo.Owner, "name": "api-server"}},
+		{tool: "minder_list_profiles"},
+		{tool: "minder_get_profile_status", args: map[string]any{"name": "supply-chain"}},
+		{tool: "minder_get_entity_status", args: map[string]any{"owner": demo.Owner, "name": "api-server"}},
+		{tool: "minder_get_profile_actions"},
+		{tool: "minder_list_rule_types"},
+		{tool: "minder_list_rule_type_profiles", args: map[string]any{"rule_type": "artifact_signature"}},
+		{tool: "minder_get_rule_type_stats"},
+		{tool: "minder_list_data_sources"},
+		{tool: "minder_list_providers"},
+		{tool: "minder_list_artifacts"},
+		{tool: "minder_get_artifact_provenance", args: map[string]any{"name": "api-server"}},
+		{tool: "minder_list_evaluation_history"},
+		{tool: "minder_get_pull_request_evaluations", args: map[string]any{"owner": demo.Owner, "name": "api-server"}},
+		{tool: "minder_get_repository_webhook", args: map[string]any{"owner": demo.Owner, "name": "infrastructure"}},
+		{tool: "minder_get_slack_summary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			t.Parallel()
+			tool := mcpServer.GetTool(tt.tool)
+			if tool == nil {
+				t.Fatalf("tool %s is not registered", tt.tool)
+			}
+			result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: tt.tool, Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("%s returned Go error: %v", tt.tool, err)
+			}
+			text, _ := mcp.AsTextContent(result.Content[0])
+			if result.IsError {
+				t.Fatalf("%s returned error result: %s", tt.tool, text.Text)
+			}
+			if strings.TrimSpace(text.Text) == "" {
+				t.Errorf("%s returned no content", tt.tool)
+			}
+		})
+	}
+}
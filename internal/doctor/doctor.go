@@ -0,0 +1,190 @@
+// Package doctor runs pre-flight checks against the server configuration and
+// the configured Minder backends and reports the outcome of each.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPass means the check succeeded.
+	StatusPass Status = "PASS"
+	// StatusFail means the check failed.
+	StatusFail Status = "FAIL"
+	// StatusSkip means the check was not run.
+	StatusSkip Status = "SKIP"
+)
+
+// checkTimeout bounds each check so an unreachable server cannot hang the report.
+const checkTimeout = 15 * time.Second
+
+// Check is a single diagnostic.
+type Check struct {
+	Name string
+	// Critical checks skip every later check when they fail.
+	Critical bool
+	// Run performs the check and returns a short detail for the report.
+	// Returning an error wrapping ErrSkipped reports the check as skipped.
+	Run func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of running a Check.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ErrSkipped marks a check that could not run, e.g. because an input is not configured.
+var ErrSkipped = errors.New("skipped")
+
+// Skip returns an error that reports a check as skipped with the given reason.
+func Skip(reason string) error {
+	return fmt.Errorf("%w: %s", ErrSkipped, reason)
+}
+
+// Run executes the checks in order.
+func Run(ctx context.Context, checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	failedCritical := ""
+	for _, c := range checks {
+		if failedCritical != "" {
+			results = append(results, Result{Name: c.Name, Status: StatusSkip, Detail: failedCritical + " failed"})
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		detail, err := c.Run(checkCtx)
+		cancel()
+
+		switch {
+		case errors.Is(err, ErrSkipped):
+			results = append(results, Result{Name: c.Name, Status: StatusSkip, Detail: err.Error()})
+		case err != nil:
+			results = append(results, Result{Name: c.Name, Status: StatusFail, Detail: err.Error()})
+			if c.Critical {
+				failedCritical = c.Name
+			}
+		default:
+			results = append(results, Result{Name: c.Name, Status: StatusPass, Detail: detail})
+		}
+	}
+	return results
+}
+
+// Passed reports whether no check failed.
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Write prints one line per result.
+func Write(w io.Writer, results []Result) error {
+	for _, r := range results {
+		line := fmt.Sprintf("[%s] %s", r.Status, r.Name)
+		if r.Detail != "" {
+			line += ": " + r.Detail
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checks returns the checks for cfg: configuration validity, then realm URL
+// discovery, gRPC connectivity and authentication for each Minder server.
+func Checks(cfg *config.Config, refresher *minder.TokenRefresher, logger *slog.Logger) []Check {
+	checks := []Check{{
+		Name:     "configuration",
+		Critical: true,
+		Run: func(context.Context) (string, error) {
+			if err := cfg.Validate(); err != nil {
+				return "", err
+			}
+			return "valid", nil
+		},
+	}}
+
+	for _, name := range cfg.Minder.ServerNames() {
+		srv, _ := cfg.Minder.Server(name)
+		checks = append(checks, serverChecks(srv, refresher, logger)...)
+	}
+	return checks
+}
+
+// serverChecks returns the connectivity checks for one Minder server.
+func serverChecks(srv config.NamedServer, refresher *minder.TokenRefresher, logger *slog.Logger) []Check {
+	serverCfg := minder.ServerConfig{Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins}
+	prefix := fmt.Sprintf("%s (%s:%d): ", srv.Name, srv.Host, srv.Port)
+
+	return []Check{
+		{
+			Name: prefix + "realm URL",
+			Run: func(ctx context.Context) (string, error) {
+				return refresher.RealmURL(ctx, serverCfg)
+			},
+		},
+		{
+			Name: prefix + "gRPC connectivity",
+			Run: func(ctx context.Context) (string, error) {
+				client, err := minder.NewClient(minder.ClientConfig{
+					Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins, Logger: logger,
+				})
+				if err != nil {
+					return "", err
+				}
+				defer func() { _ = client.Close() }()
+
+				resp, err := client.Health().CheckHealth(ctx, &minderv1.CheckHealthRequest{})
+				if err != nil {
+					return "", err
+				}
+				return "health status " + resp.GetStatus(), nil
+			},
+		},
+		{
+			Name: prefix + "authentication",
+			Run: func(ctx context.Context) (string, error) {
+				if srv.AuthToken == "" {
+					return "", Skip("no auth token configured; clients must send an Authorization header")
+				}
+				token, err := refresher.GetValidAccessToken(ctx, srv.AuthToken, serverCfg)
+				if err != nil {
+					return "", err
+				}
+				client, err := minder.NewClient(minder.ClientConfig{
+					Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins,
+					Token: token, Logger: logger,
+				})
+				if err != nil {
+					return "", err
+				}
+				defer func() { _ = client.Close() }()
+
+				user, err := client.Users().GetUser(ctx, &minderv1.GetUserRequest{})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("authenticated, %d project(s) accessible", len(user.GetProjects())), nil
+			},
+		},
+	}
+}
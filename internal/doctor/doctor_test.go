@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+func staticCheck(name string, critical bool, detail string, err error) Check {
+	return Check{
+		Name:     name,
+		Critical: critical,
+		Run: func(context.Context) (string, error) {
+			return detail, err
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	results := Run(context.Background(), []Check{
+		staticCheck("ok", false, "fine", nil),
+		staticCheck("optional", false, "", Skip("not configured")),
+		staticCheck("broken", false, "", errors.New("boom")),
+		staticCheck("critical", true, "", errors.New("down")),
+		staticCheck("after", false, "never", nil),
+	})
+
+	want := []Result{
+		{Name: "ok", Status: StatusPass, Detail: "fine"},
+		{Name: "optional", Status: StatusSkip, Detail: "skipped: not configured"},
+		{Name: "broken", Status: StatusFail, Detail: "boom"},
+		{Name: "critical", Status: StatusFail, Detail: "down"},
+		{Name: "after", Status: StatusSkip, Detail: "critical failed"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result[%d] = %+v, want %+v", i, results[i], want[i])
+		}
+	}
+	if Passed(results) {
+		t.Error("Passed() = true, want false")
+	}
+}
+
+func TestPassed_SkipsDoNotFail(t *testing.T) {
+	t.Parallel()
+
+	results := []Result{
+		{Name: "a", Status: StatusPass},
+		{Name: "b", Status: StatusSkip},
+	}
+	if !Passed(results) {
+		t.Error("Passed() = false, want true")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := Write(&buf, []Result{
+		{Name: "configuration", Status: StatusPass, Detail: "valid"},
+		{Name: "authentication", Status: StatusFail},
+	})
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	want := "[PASS] configuration: valid\n[FAIL] authentication\n"
+	if buf.String() != want {
+		t.Errorf("Write() output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestChecks_InvalidConfigSkipsServerChecks(t *testing.T) {
+	t.Parallel()
+
+	refresher := minder.NewTokenRefresher()
+	defer refresher.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg := &config.Config{Minder: config.MinderConfig{Port: 443}}
+	results := Run(context.Background(), Checks(cfg, refresher, logger))
+
+	if results[0].Status != StatusFail || !strings.Contains(results[0].Detail, "MINDER_SERVER_HOST") {
+		t.Errorf("configuration result = %+v, want FAIL mentioning MINDER_SERVER_HOST", results[0])
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want configuration plus three server checks", len(results))
+	}
+	for _, r := range results[1:] {
+		if r.Status != StatusSkip {
+			t.Errorf("%s status = %s, want %s", r.Name, r.Status, StatusSkip)
+		}
+	}
+}
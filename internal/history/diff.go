@@ -0,0 +1,60 @@
+package history
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Delta describes how compliance changed between two summaries.
+type Delta struct {
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	ScoreFrom         float64   `json:"score_from"`
+	ScoreTo           float64   `json:"score_to"`
+	ScoreDelta        float64   `json:"score_delta"`
+	FailingRulesDelta int       `json:"failing_rules_delta"`
+	// NewlyFailing maps an entity name to the "<profile>/<rule>" entries
+	// failing at To that were not failing at From.
+	NewlyFailing map[string][]string `json:"newly_failing"`
+	// Recovered maps an entity name to the entries failing at From that no longer fail at To.
+	Recovered map[string][]string `json:"recovered"`
+	// NewlyCompliant lists entities that had failing rules at From and none at To, sorted.
+	NewlyCompliant []string `json:"newly_compliant"`
+}
+
+// Compare reports the changes from the earlier summary from to the later summary to.
+func Compare(from, to Summary) Delta {
+	d := Delta{
+		From:              from.Time,
+		To:                to.Time,
+		ScoreFrom:         from.Score,
+		ScoreTo:           to.Score,
+		ScoreDelta:        to.Score - from.Score,
+		FailingRulesDelta: to.FailingRules - from.FailingRules,
+		NewlyFailing:      subtractRules(to.FailingByEntity, from.FailingByEntity),
+		Recovered:         subtractRules(from.FailingByEntity, to.FailingByEntity),
+		NewlyCompliant:    []string{},
+	}
+	for entity := range from.FailingByEntity {
+		if len(to.FailingByEntity[entity]) == 0 {
+			d.NewlyCompliant = append(d.NewlyCompliant, entity)
+		}
+	}
+	slices.Sort(d.NewlyCompliant)
+	return d
+}
+
+// subtractRules returns, per entity, the rules in a that are not in b.
+// Entities left without rules are omitted.
+func subtractRules(a, b map[string][]string) map[string][]string {
+	out := make(map[string][]string)
+	for _, entity := range slices.Sorted(maps.Keys(a)) {
+		for _, rule := range a[entity] {
+			if !slices.Contains(b[entity], rule) {
+				out[entity] = append(out[entity], rule)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	from := Summary{
+		Time:         base,
+		Score:        50,
+		FailingRules: 3,
+		FailingByEntity: map[string][]string{
+			"stacklok/minder":     {"baseline/branch_protection", "baseline/dependabot"},
+			"stacklok/minder-mcp": {"baseline/secret_scanning"},
+		},
+	}
+	to := Summary{
+		Time:         base.Add(7 * 24 * time.Hour),
+		Score:        75,
+		FailingRules: 2,
+		FailingByEntity: map[string][]string{
+			"stacklok/minder":   {"baseline/dependabot", "baseline/codeql"},
+			"stacklok/toolhive": {"baseline/branch_protection"},
+		},
+	}
+
+	d := Compare(from, to)
+
+	if d.ScoreDelta != 25 || d.FailingRulesDelta != -1 {
+		t.Errorf("score delta = %v, failing rules delta = %d, want 25 and -1", d.ScoreDelta, d.FailingRulesDelta)
+	}
+	if got := d.NewlyFailing["stacklok/minder"]; !slices.Equal(got, []string{"baseline/codeql"}) {
+		t.Errorf("NewlyFailing[stacklok/minder] = %v, want [baseline/codeql]", got)
+	}
+	if got := d.NewlyFailing["stacklok/toolhive"]; !slices.Equal(got, []string{"baseline/branch_protection"}) {
+		t.Errorf("NewlyFailing[stacklok/toolhive] = %v, want [baseline/branch_protection]", got)
+	}
+	if got := d.Recovered["stacklok/minder"]; !slices.Equal(got, []string{"baseline/branch_protection"}) {
+		t.Errorf("Recovered[stacklok/minder] = %v, want [baseline/branch_protection]", got)
+	}
+	if !slices.Equal(d.NewlyCompliant, []string{"stacklok/minder-mcp"}) {
+		t.Errorf("NewlyCompliant = %v, want [stacklok/minder-mcp]", d.NewlyCompliant)
+	}
+}
+
+func TestCompare_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	sum := Summary{Score: 80, FailingByEntity: map[string][]string{"repo": {"p/r"}}}
+	d := Compare(sum, sum)
+	if d.ScoreDelta != 0 || len(d.NewlyFailing) != 0 || len(d.Recovered) != 0 || len(d.NewlyCompliant) != 0 {
+		t.Errorf("Compare(same, same) = %+v, want no changes", d)
+	}
+}
@@ -0,0 +1,77 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// Scheduler records a compliance summary at a fixed interval.
+type Scheduler struct {
+	interval  time.Duration
+	retention time.Duration
+	fetch     watcher.FetchFunc
+	store     *Store
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewScheduler returns a Scheduler that stores a summary of each fetched
+// snapshot every interval. Summaries older than retention are pruned;
+// zero retention keeps them forever.
+func NewScheduler(
+	interval, retention time.Duration, fetch watcher.FetchFunc, store *Store, logger *slog.Logger,
+) *Scheduler {
+	return &Scheduler{
+		interval:  interval,
+		retention: retention,
+		fetch:     fetch,
+		store:     store,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Run records a summary immediately and then every interval until ctx is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.record(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.record(ctx)
+		}
+	}
+}
+
+// record fetches a snapshot, stores its summary and prunes expired summaries.
+func (s *Scheduler) record(ctx context.Context) {
+	snapshot, err := s.fetch(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "compliance snapshot failed", "error", err)
+		return
+	}
+
+	now := s.now()
+	sum := Summarize(now, snapshot)
+	if err := s.store.Put(sum); err != nil {
+		s.logger.ErrorContext(ctx, "failed to store compliance summary", "error", err)
+		return
+	}
+	s.logger.DebugContext(ctx, "compliance summary recorded", "score", sum.Score, "failing_rules", sum.FailingRules)
+
+	if s.retention > 0 {
+		removed, err := s.store.Prune(now.Add(-s.retention))
+		if err != nil {
+			s.logger.WarnContext(ctx, "failed to prune compliance history", "error", err)
+		} else if removed > 0 {
+			s.logger.DebugContext(ctx, "compliance history pruned", "removed", removed)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+func TestSchedulerRecord(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := store.Put(Summary{Time: base.Add(-48 * time.Hour)}); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+
+	snapshot := watcher.NewSnapshot()
+	snapshot.Rules["proj/baseline/rule/repo-1"] = "failure"
+	fetchErr := errors.New("unavailable")
+	calls := 0
+	fetch := func(_ context.Context) (watcher.Snapshot, error) {
+		calls++
+		if calls == 2 {
+			return watcher.Snapshot{}, fetchErr
+		}
+		return snapshot, nil
+	}
+
+	s := NewScheduler(time.Hour, 24*time.Hour, fetch, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.now = func() time.Time { return base }
+
+	s.record(context.Background())
+	s.record(context.Background()) // fetch error: nothing recorded
+
+	summaries, err := store.List(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("got %d summaries, want 1 after pruning the expired one", len(summaries))
+	}
+	if summaries[0].FailingRules != 1 || summaries[0].Score != 0 {
+		t.Errorf("summary = %+v, want one failing rule and score 0", summaries[0])
+	}
+}
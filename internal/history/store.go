@@ -0,0 +1,157 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/stacklok/minder-mcp/internal/store"
+)
+
+// ErrNoSummary is returned when no summary matches a query.
+var ErrNoSummary = errors.New("no compliance summary recorded")
+
+// summariesBucket holds summaries keyed by their big-endian UnixNano timestamp,
+// so cursor order is time order.
+var summariesBucket = []byte("summaries")
+
+// database runs bbolt transactions; both *bolt.DB and *store.Store do.
+type database interface {
+	View(fn func(*bolt.Tx) error) error
+	Update(fn func(*bolt.Tx) error) error
+}
+
+// Store persists compliance summaries in a bbolt database.
+type Store struct {
+	db    database
+	close func() error
+}
+
+// Open opens, or creates, the history database at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open history store %s: %w", path, err)
+	}
+	s, err := newStore(db, db.Close)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("initialize history store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// OpenIn keeps the history in the shared embedded store st. Closing the
+// returned Store leaves st open.
+func OpenIn(st *store.Store) (*Store, error) {
+	s, err := newStore(st, func() error { return nil })
+	if err != nil {
+		return nil, fmt.Errorf("initialize history in %s: %w", st.Path(), err)
+	}
+	return s, nil
+}
+
+// newStore creates the summaries bucket in db if needed.
+func newStore(db database, closeFn func() error) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(summariesBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, close: closeFn}, nil
+}
+
+// Close closes the database file, unless the history is kept in a shared store.
+func (s *Store) Close() error {
+	return s.close()
+}
+
+// Put records a summary, replacing any summary with the same timestamp.
+func (s *Store) Put(sum Summary) error {
+	data, err := json.Marshal(sum)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(summariesBucket).Put(timeKey(sum.Time), data)
+	})
+}
+
+// List returns the summaries recorded from from up to and including to, oldest
+// first. A zero to means no upper bound.
+func (s *Store) List(from, to time.Time) ([]Summary, error) {
+	var summaries []Summary
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(summariesBucket).Cursor()
+		for k, v := c.Seek(timeKey(from)); k != nil; k, v = c.Next() {
+			if !to.IsZero() && keyTime(k).After(to) {
+				break
+			}
+			var sum Summary
+			if err := json.Unmarshal(v, &sum); err != nil {
+				return fmt.Errorf("decode summary at %s: %w", keyTime(k), err)
+			}
+			summaries = append(summaries, sum)
+		}
+		return nil
+	})
+	return summaries, err
+}
+
+// Latest returns the most recent summary recorded at or before at.
+func (s *Store) Latest(at time.Time) (Summary, error) {
+	var sum Summary
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(summariesBucket).Cursor()
+		k, v := c.Seek(timeKey(at))
+		switch {
+		case k == nil:
+			k, v = c.Last()
+		case keyTime(k).After(at):
+			k, v = c.Prev()
+		}
+		if k == nil {
+			return ErrNoSummary
+		}
+		return json.Unmarshal(v, &sum)
+	})
+	return sum, err
+}
+
+// Prune deletes summaries recorded before the given time and returns how many were removed.
+func (s *Store) Prune(before time.Time) (int, error) {
+	removed := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(summariesBucket)
+		// Collect keys first: deleting through a cursor while iterating skips entries
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil && keyTime(k).Before(before); k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		removed = len(keys)
+		return nil
+	})
+	return removed, err
+}
+
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(max(t.UnixNano(), 0))) //nolint:gosec // negative times are clamped to zero
+	return key
+}
+
+func keyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key))).UTC() //nolint:gosec // keys are written by timeKey
+}
@@ -0,0 +1,153 @@
+package history
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/store"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestStore_PutList(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		sum := Summary{Time: base.Add(time.Duration(i) * time.Hour), Score: float64(i * 10)}
+		if err := store.Put(sum); err != nil {
+			t.Fatalf("Put() returned error: %v", err)
+		}
+	}
+
+	all, err := store.List(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	if len(all) != 5 || all[0].Score != 0 || all[4].Score != 40 {
+		t.Errorf("List() = %+v, want five summaries oldest first", all)
+	}
+
+	window, err := store.List(base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	if len(window) != 3 || window[0].Score != 10 || window[2].Score != 30 {
+		t.Errorf("List(window) = %+v, want hours 1 to 3 inclusive", window)
+	}
+}
+
+func TestStore_Latest(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := store.Latest(base); !errors.Is(err, ErrNoSummary) {
+		t.Errorf("Latest() on empty store error = %v, want ErrNoSummary", err)
+	}
+
+	for i := range 3 {
+		if err := store.Put(Summary{Time: base.Add(time.Duration(i) * 24 * time.Hour), Score: float64(i)}); err != nil {
+			t.Fatalf("Put() returned error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		wantScore float64
+		wantErr   bool
+	}{
+		{name: "exact match", at: base.Add(24 * time.Hour), wantScore: 1},
+		{name: "between summaries", at: base.Add(36 * time.Hour), wantScore: 1},
+		{name: "after last", at: base.Add(30 * 24 * time.Hour), wantScore: 2},
+		{name: "before first", at: base.Add(-time.Hour), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := store.Latest(tt.at)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNoSummary) {
+					t.Errorf("Latest() error = %v, want ErrNoSummary", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Latest() returned error: %v", err)
+			}
+			if sum.Score != tt.wantScore {
+				t.Errorf("Latest() score = %v, want %v", sum.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		if err := store.Put(Summary{Time: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatalf("Put() returned error: %v", err)
+		}
+	}
+
+	removed, err := store.Prune(base.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("Prune() returned error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Prune() removed %d, want 2", removed)
+	}
+	remaining, _ := store.List(time.Time{}, time.Time{})
+	if len(remaining) != 2 {
+		t.Errorf("remaining summaries = %d, want 2", len(remaining))
+	}
+}
+
+func TestOpenIn(t *testing.T) {
+	t.Parallel()
+
+	shared, err := store.Open(filepath.Join(t.TempDir(), "state.db"), 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("store.Open() returned error: %v", err)
+	}
+	defer func() { _ = shared.Close() }()
+
+	s, err := OpenIn(shared)
+	if err != nil {
+		t.Fatalf("OpenIn() returned error: %v", err)
+	}
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.Put(Summary{Time: at, Score: 80}); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	// Closing the history leaves the shared store usable
+	s, err = OpenIn(shared)
+	if err != nil {
+		t.Fatalf("OpenIn() after Close returned error: %v", err)
+	}
+	sum, err := s.Latest(at)
+	if err != nil || sum.Score != 80 {
+		t.Errorf("Latest() = %+v, %v; want the summary written before Close", sum, err)
+	}
+}
@@ -0,0 +1,127 @@
+// Package history records compliance summaries over time in an embedded store,
+// so trends such as the score per week can be queried after Minder's own
+// evaluation history has moved on.
+package history
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// Summary is a point-in-time record of compliance across the watched projects.
+type Summary struct {
+	Time time.Time `json:"time"`
+	// Score is the percentage of evaluated rules that are not failing, from 0 to 100.
+	// Skipped and pending evaluations are not counted.
+	Score           float64 `json:"score"`
+	Profiles        int     `json:"profiles"`
+	FailingProfiles int     `json:"failing_profiles"`
+	Rules           int     `json:"rules"`
+	FailingRules    int     `json:"failing_rules"`
+	// FailingByEntity maps an entity name (e.g. "owner/repo") to its failing
+	// rules as sorted "<profile>/<rule>" entries.
+	FailingByEntity map[string][]string `json:"failing_by_entity,omitempty"`
+}
+
+// Summarize reduces a compliance snapshot to a Summary taken at the given time.
+func Summarize(at time.Time, s watcher.Snapshot) Summary {
+	sum := Summary{
+		Time:            at.UTC(),
+		Profiles:        len(s.Profiles),
+		FailingByEntity: make(map[string][]string),
+	}
+	for _, status := range s.Profiles {
+		if watcher.IsFailing(status) {
+			sum.FailingProfiles++
+		}
+	}
+
+	for key, status := range s.Rules {
+		if status == "" || status == "skipped" || status == "pending" {
+			continue
+		}
+		sum.Rules++
+		if !watcher.IsFailing(status) {
+			continue
+		}
+		sum.FailingRules++
+
+		// Rule keys are "<project_id>/<profile_name>/<rule_name>/<entity_id>"
+		parts := strings.SplitN(key, "/", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		entity := s.Entities[parts[3]]
+		if entity == "" {
+			entity = parts[3]
+		}
+		sum.FailingByEntity[entity] = append(sum.FailingByEntity[entity], parts[1]+"/"+parts[2])
+	}
+	for _, rules := range sum.FailingByEntity {
+		slices.Sort(rules)
+	}
+
+	sum.Score = 100
+	if sum.Rules > 0 {
+		sum.Score = 100 * float64(sum.Rules-sum.FailingRules) / float64(sum.Rules)
+	}
+	return sum
+}
+
+// Period groups summaries for trend queries.
+type Period string
+
+const (
+	// PeriodDay groups summaries by UTC calendar day.
+	PeriodDay Period = "day"
+	// PeriodWeek groups summaries by ISO week, starting Monday UTC.
+	PeriodWeek Period = "week"
+)
+
+// start returns the beginning of the period containing t.
+func (p Period) start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if p == PeriodWeek {
+		// Go weeks start on Sunday; shift so Monday is day zero
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// Bucket aggregates the summaries recorded within one period.
+type Bucket struct {
+	Start    time.Time `json:"start"`
+	Samples  int       `json:"samples"`
+	AvgScore float64   `json:"avg_score"`
+	MinScore float64   `json:"min_score"`
+	MaxScore float64   `json:"max_score"`
+	// Last is the final summary recorded in the period, without per-entity detail.
+	Last Summary `json:"last"`
+}
+
+// Aggregate groups time-ordered summaries into buckets of the given period.
+func Aggregate(summaries []Summary, period Period) []Bucket {
+	var buckets []Bucket
+	var total float64
+	for _, sum := range summaries {
+		start := period.start(sum.Time)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].Start.Equal(start) {
+			buckets = append(buckets, Bucket{Start: start, MinScore: sum.Score, MaxScore: sum.Score})
+			total = 0
+		}
+		b := &buckets[len(buckets)-1]
+		b.Samples++
+		total += sum.Score
+		b.AvgScore = total / float64(b.Samples)
+		b.MinScore = min(b.MinScore, sum.Score)
+		b.MaxScore = max(b.MaxScore, sum.Score)
+		b.Last = sum
+		b.Last.FailingByEntity = nil
+	}
+	return buckets
+}
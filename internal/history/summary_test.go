@@ -0,0 +1,87 @@
+package history
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	s := watcher.NewSnapshot()
+	s.Profiles["proj/baseline"] = "failure"
+	s.Profiles["proj/secrets"] = "success"
+	s.Rules["proj/baseline/branch_protection/repo-1"] = "failure"
+	s.Rules["proj/baseline/dependabot/repo-1"] = "error"
+	s.Rules["proj/baseline/branch_protection/repo-2"] = "success"
+	s.Rules["proj/secrets/secret_scanning/repo-2"] = "success"
+	s.Rules["proj/secrets/secret_scanning/repo-3"] = "skipped"
+	s.Entities["repo-1"] = "stacklok/minder"
+
+	at := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	sum := Summarize(at, s)
+
+	if !sum.Time.Equal(at) {
+		t.Errorf("Time = %v, want %v", sum.Time, at)
+	}
+	if sum.Profiles != 2 || sum.FailingProfiles != 1 {
+		t.Errorf("profiles = %d/%d failing, want 2/1", sum.Profiles, sum.FailingProfiles)
+	}
+	if sum.Rules != 4 || sum.FailingRules != 2 {
+		t.Errorf("rules = %d/%d failing, want 4/2", sum.Rules, sum.FailingRules)
+	}
+	if sum.Score != 50 {
+		t.Errorf("Score = %v, want 50", sum.Score)
+	}
+	want := []string{"baseline/branch_protection", "baseline/dependabot"}
+	if got := sum.FailingByEntity["stacklok/minder"]; !slices.Equal(got, want) {
+		t.Errorf("FailingByEntity[stacklok/minder] = %v, want %v", got, want)
+	}
+	if len(sum.FailingByEntity) != 1 {
+		t.Errorf("expected one failing entity, got %v", sum.FailingByEntity)
+	}
+}
+
+func TestSummarize_NoRules(t *testing.T) {
+	t.Parallel()
+
+	sum := Summarize(time.Now(), watcher.NewSnapshot())
+	if sum.Score != 100 {
+		t.Errorf("Score = %v, want 100 when nothing is evaluated", sum.Score)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	// 2026-03-02 is a Monday
+	summaries := []Summary{
+		{Time: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), Score: 60},
+		{Time: time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC), Score: 80},
+		{Time: time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC), Score: 100},
+		{Time: time.Date(2026, 3, 9, 1, 0, 0, 0, time.UTC), Score: 90,
+			FailingByEntity: map[string][]string{"repo": {"p/r"}}},
+	}
+
+	weeks := Aggregate(summaries, PeriodWeek)
+	if len(weeks) != 2 {
+		t.Fatalf("got %d weekly buckets, want 2", len(weeks))
+	}
+	if !weeks[0].Start.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("first week starts %v, want Monday 2026-03-02", weeks[0].Start)
+	}
+	if weeks[0].Samples != 3 || weeks[0].AvgScore != 80 || weeks[0].MinScore != 60 || weeks[0].MaxScore != 100 {
+		t.Errorf("first week = %+v, want 3 samples averaging 80 from 60 to 100", weeks[0])
+	}
+	if weeks[1].Last.FailingByEntity != nil {
+		t.Error("expected bucket summaries to omit per-entity detail")
+	}
+
+	days := Aggregate(summaries, PeriodDay)
+	if len(days) != 4 {
+		t.Errorf("got %d daily buckets, want 4", len(days))
+	}
+}
@@ -0,0 +1,107 @@
+// Package lifecycle coordinates the shutdown of the server's background
+// tasks and the resources they use.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Hook flushes or closes a subsystem at shutdown. ctx carries the deadline
+// for the whole shutdown.
+type Hook func(ctx context.Context) error
+
+// Manager owns the context background tasks run under and the hooks that
+// release subsystems when the server stops. Shutdown first cancels that
+// context and waits for the tasks to return, so no task is still writing to a
+// store when its hook closes it, then runs the hooks in the reverse order of
+// registration.
+type Manager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  sync.WaitGroup
+
+	mu    sync.Mutex
+	hooks []namedHook
+	done  bool
+}
+
+// namedHook is a Hook and the subsystem name it is logged under.
+type namedHook struct {
+	name string
+	fn   Hook
+}
+
+// New returns a Manager whose task context is derived from parent.
+func New(parent context.Context) *Manager {
+	ctx, cancel := context.WithCancel(parent)
+	return &Manager{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context background tasks should run under. It is
+// canceled when shutdown starts.
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Go runs task in a goroutine with the task context. Shutdown waits for it
+// to return before running hooks.
+func (m *Manager) Go(task func(ctx context.Context)) {
+	m.tasks.Add(1)
+	go func() {
+		defer m.tasks.Done()
+		task(m.ctx)
+	}()
+}
+
+// OnShutdown registers fn to run at shutdown under name. Hooks registered
+// later run earlier, so a subsystem is released before the ones it was built on.
+func (m *Manager) OnShutdown(name string, fn Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, namedHook{name: name, fn: fn})
+}
+
+// OnClose registers a Close method, such as io.Closer's, as a hook.
+func (m *Manager) OnClose(name string, closeFn func() error) {
+	m.OnShutdown(name, func(context.Context) error { return closeFn() })
+}
+
+// Shutdown stops background tasks and runs every hook once, even if an
+// earlier hook failed or ctx expired; hooks are expected to honor ctx. It
+// returns the hook errors joined. Calls after the first do nothing.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if m.done {
+		m.mu.Unlock()
+		return nil
+	}
+	m.done = true
+	hooks := m.hooks
+	m.mu.Unlock()
+
+	m.cancel()
+	waited := make(chan struct{})
+	go func() {
+		m.tasks.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-ctx.Done():
+		slog.Warn("background tasks still running at shutdown", "error", ctx.Err())
+	}
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if err := h.fn(ctx); err != nil {
+			slog.Warn("shutdown hook failed", "subsystem", h.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
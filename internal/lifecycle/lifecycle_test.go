@@ -0,0 +1,110 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShutdown_RunsHooksInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	m := New(context.Background())
+	var order []string
+	for _, name := range []string{"logs", "store", "cache"} {
+		m.OnShutdown(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if want := []string{"cache", "store", "logs"}; !slices.Equal(order, want) {
+		t.Errorf("hooks ran in order %v, want %v", order, want)
+	}
+}
+
+func TestShutdown_WaitsForTasksBeforeHooks(t *testing.T) {
+	t.Parallel()
+
+	m := New(context.Background())
+	stopped := false
+	m.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		stopped = true
+	})
+	m.OnShutdown("store", func(context.Context) error {
+		if !stopped {
+			t.Error("hook ran before the background task returned")
+		}
+		return nil
+	})
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if m.Context().Err() == nil {
+		t.Error("task context was not canceled")
+	}
+}
+
+func TestShutdown_StuckTaskDoesNotBlockHooks(t *testing.T) {
+	t.Parallel()
+
+	m := New(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	m.Go(func(context.Context) { <-release })
+	ran := false
+	m.OnShutdown("store", func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !ran {
+		t.Error("hook did not run after the shutdown deadline passed")
+	}
+}
+
+func TestShutdown_JoinsErrorsAndRunsEveryHook(t *testing.T) {
+	t.Parallel()
+
+	m := New(context.Background())
+	errStore := errors.New("flush failed")
+	ran := 0
+	m.OnClose("logs", func() error { ran++; return nil })
+	m.OnClose("store", func() error { ran++; return errStore })
+	m.OnClose("cache", func() error { ran++; return nil })
+
+	err := m.Shutdown(context.Background())
+	if !errors.Is(err, errStore) {
+		t.Errorf("Shutdown() error = %v, want %v", err, errStore)
+	}
+	if ran != 3 {
+		t.Errorf("%d hooks ran, want 3", ran)
+	}
+}
+
+func TestShutdown_Once(t *testing.T) {
+	t.Parallel()
+
+	m := New(context.Background())
+	calls := 0
+	m.OnClose("store", func() error { calls++; return nil })
+
+	_ = m.Shutdown(context.Background())
+	_ = m.Shutdown(context.Background())
+	if calls != 1 {
+		t.Errorf("hook ran %d times, want 1", calls)
+	}
+}
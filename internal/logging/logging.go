@@ -2,32 +2,131 @@
 package logging
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+const (
+	// FormatJSON emits one JSON object per log line.
+	FormatJSON = "json"
+	// FormatText emits logfmt-style key=value lines.
+	FormatText = "text"
 )
 
+// Options configures logger output.
+type Options struct {
+	// Level is one of debug, info, warn, error. Defaults to info.
+	Level string
+	// LevelVar, when set, is initialized from Level and controls the logger's
+	// level so it can be changed at runtime with LevelVar.Set.
+	LevelVar *slog.LevelVar
+	// Format is json or text. Defaults to json.
+	Format string
+	// File is the path of a log file. When empty, logs go to stderr.
+	File string
+	// MaxSizeMB is the size at which the log file is rotated.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files to keep. Zero keeps all.
+	MaxBackups int
+	// MaxAgeDays is the number of days to keep rotated files. Zero keeps all.
+	MaxAgeDays int
+	// RedactKeys names attributes and tool arguments to mask in addition to
+	// DefaultRedactKeys.
+	RedactKeys []string
+}
+
 // Setup creates and returns a configured slog.Logger based on the log level string.
 // Valid levels are: debug, info, warn, error.
 // If an invalid level is provided, it defaults to info.
 func Setup(level string) *slog.Logger {
-	var logLevel slog.Level
+	logger, _ := New(Options{Level: level})
+	return logger
+}
+
+// New creates a slog.Logger from the given options.
+// The returned io.Closer releases the log file, if one was opened, and must be
+// closed on shutdown. It is a no-op when logging to stderr.
+func New(opts Options) (*slog.Logger, io.Closer) {
+	var out io.Writer = os.Stderr
+	var closer io.Closer = nopCloser{}
+	if opts.File != "" {
+		rotator := &lumberjack.Logger{
+			Filename:   opts.File,
+			MaxSize:    opts.MaxSizeMB,
+			MaxBackups: opts.MaxBackups,
+			MaxAge:     opts.MaxAgeDays,
+		}
+		out = rotator
+		closer = rotator
+	}
+
+	var level slog.Leveler = ParseLevel(opts.Level)
+	if opts.LevelVar != nil {
+		opts.LevelVar.Set(ParseLevel(opts.Level))
+		level = opts.LevelVar
+	}
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: newRedactor(opts.RedactKeys).replaceAttr,
+	}
+
+	var handler slog.Handler
+	if strings.ToLower(opts.Format) == FormatText {
+		handler = slog.NewTextHandler(out, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(out, handlerOpts)
+	}
+
+	return slog.New(&requestIDHandler{Handler: handler}), closer
+}
+
+// requestIDHandler adds the request ID from the context to every log record.
+type requestIDHandler struct {
+	slog.Handler
+}
+
+// Handle adds a request_id attribute when the context carries one.
+func (h *requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := middleware.RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a requestIDHandler wrapping the handler with attrs.
+func (h *requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestIDHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a requestIDHandler wrapping the handler with a group.
+func (h *requestIDHandler) WithGroup(name string) slog.Handler {
+	return &requestIDHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// ParseLevel converts a level name to a slog.Level, defaulting to info.
+func ParseLevel(level string) slog.Level {
 	switch strings.ToLower(level) {
 	case "debug":
-		logLevel = slog.LevelDebug
+		return slog.LevelDebug
 	case "info":
-		logLevel = slog.LevelInfo
+		return slog.LevelInfo
 	case "warn":
-		logLevel = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		logLevel = slog.LevelError
+		return slog.LevelError
 	default:
-		logLevel = slog.LevelInfo
+		return slog.LevelInfo
 	}
+}
 
-	handler := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-		Level: logLevel,
-	})
+// nopCloser is an io.Closer that does nothing.
+type nopCloser struct{}
 
-	return slog.New(handler)
-}
+func (nopCloser) Close() error { return nil }
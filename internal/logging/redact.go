@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the value of a sensitive log attribute.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactKeys are attribute and argument names whose values are always
+// masked. Names are compared case-insensitively, ignoring '-' and '_'.
+var DefaultRedactKeys = []string{
+	"token", "access_token", "refresh_token", "id_token", "auth_token", "bearer",
+	"authorization", "cookie", "set_cookie",
+	"password", "passwd", "secret", "client_secret", "webhook_secret",
+	"api_key", "private_key", "credential", "credentials",
+}
+
+// jwtPattern matches a JSON Web Token appearing anywhere in a string.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// bearerPattern matches the credential of an HTTP Bearer authorization value.
+var bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)\S+`)
+
+// redactor masks sensitive values in log attributes.
+type redactor struct {
+	keys map[string]struct{}
+}
+
+// newRedactor returns a redactor for DefaultRedactKeys and extra.
+func newRedactor(extra []string) *redactor {
+	r := &redactor{keys: make(map[string]struct{}, len(DefaultRedactKeys)+len(extra))}
+	for _, k := range append(append([]string{}, DefaultRedactKeys...), extra...) {
+		if k = normalizeKey(k); k != "" {
+			r.keys[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// normalizeKey lowercases a name and drops '-' and '_' so that apiKey,
+// api-key and API_KEY compare equal.
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	return strings.NewReplacer("-", "", "_", "").Replace(key)
+}
+
+// sensitive reports whether values under key must be masked.
+func (r *redactor) sensitive(key string) bool {
+	_, ok := r.keys[normalizeKey(key)]
+	return ok
+}
+
+// replaceAttr is a slog.HandlerOptions.ReplaceAttr masking sensitive attributes.
+func (r *redactor) replaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+	if r.sensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s := a.Value.String(); s != redactString(s) {
+			return slog.String(a.Key, redactString(s))
+		}
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case map[string]any, []any:
+			return slog.Any(a.Key, r.redact(v))
+		}
+	}
+	return a
+}
+
+// redact returns a copy of v, a decoded JSON value such as tool arguments,
+// with sensitive fields and embedded tokens masked.
+func (r *redactor) redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if r.sensitive(k) {
+				out[k] = Redacted
+			} else {
+				out[k] = r.redact(val)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = r.redact(val)
+		}
+		return out
+	case string:
+		return redactString(v)
+	default:
+		return v
+	}
+}
+
+// redactString masks bearer credentials and JWTs embedded in s.
+func redactString(s string) string {
+	if !strings.Contains(s, "eyJ") && !strings.Contains(strings.ToLower(s), "bearer") {
+		return s
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+	return jwtPattern.ReplaceAllString(s, Redacted)
+}
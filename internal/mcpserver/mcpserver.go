@@ -0,0 +1,99 @@
+// Package mcpserver assembles the MCP server from the tools and resources,
+// shared by the minder-mcp binary and pkg/mindermcp.
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// heartbeatInterval keeps idle streamable HTTP connections open through proxies.
+const heartbeatInterval = 30 * time.Second
+
+// Option customizes the server built by New.
+type Option func(*settings)
+
+type settings struct {
+	middleware []server.ToolHandlerMiddleware
+	resources  bool
+}
+
+// WithToolMiddleware wraps every tool call in mw, such as an authorization
+// check, a quota or custom logging. The first middleware is outermost, and
+// all of them run before the tools' own logging, metrics and limits.
+func WithToolMiddleware(mw ...server.ToolHandlerMiddleware) Option {
+	return func(s *settings) { s.middleware = append(s.middleware, mw...) }
+}
+
+// WithoutResources leaves out the compliance dashboard and server info
+// resources. Resources registered by tools, such as stored results, remain.
+func WithoutResources() Option {
+	return func(s *settings) { s.resources = false }
+}
+
+// Server is the assembled MCP server.
+type Server struct {
+	*server.MCPServer
+	// Resources serves the compliance dashboard and server info. It is nil
+	// when they are left out.
+	Resources *resources.Resources
+}
+
+// New builds the MCP server reporting info, registers the tools of t and,
+// unless left out, the dashboard and server info resources. Configure t
+// fully, including its extensions and history, before calling New.
+func New(info resources.BuildInfo, t *tools.Tools, logger *slog.Logger, opts ...Option) (*Server, error) {
+	set := settings{resources: true}
+	for _, opt := range opts {
+		opt(&set)
+	}
+
+	// Drop per-session tool state when a session ends
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		t.ForgetSession(session.SessionID())
+	})
+	serverOpts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false), // Enable resource listing
+		server.WithLogging(),                         // Compliance transitions are sent as log notifications
+		server.WithToolFilter(t.FilterTools),
+		server.WithHooks(hooks),
+	}
+	if set.resources {
+		if err := resources.VerifyDashboard(); err != nil {
+			return nil, fmt.Errorf("compliance dashboard failed its integrity check: %w", err)
+		}
+	}
+	for _, mw := range set.middleware {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(mw))
+	}
+
+	s := &Server{MCPServer: server.NewMCPServer(info.Name, info.Version, serverOpts...)}
+	t.Register(s.MCPServer)
+	if set.resources {
+		s.Resources = resources.New(logger, info)
+		s.Resources.Register(s.MCPServer)
+	}
+	return s, nil
+}
+
+// HTTPHandler serves s over streamable HTTP at the configured endpoint path,
+// taking each call's Minder token, host and request ID from its request.
+func (s *Server) HTTPHandler(cfg *config.Config) http.Handler {
+	return server.NewStreamableHTTPServer(s.MCPServer,
+		server.WithEndpointPath(cfg.MCP.EndpointPath),
+		server.WithHeartbeatInterval(heartbeatInterval),
+		server.WithHTTPContextFunc(middleware.HTTPContext(cfg.Minder.AuthToken)),
+	)
+}
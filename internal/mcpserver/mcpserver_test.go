@@ -0,0 +1,102 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+func newDemoServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	cfg := config.LoadWithReader(func(string) string { return "" })
+	cfg.MCP.Mode = config.ModeDemo
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tl, closeTools, err := tools.NewForMode(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewForMode() error = %v", err)
+	}
+	t.Cleanup(closeTools)
+	s, err := New(resources.BuildInfo{Name: "minder-mcp", Version: "test"}, tl, logger, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+func handle(s *Server, message string) string {
+	out, _ := json.Marshal(s.HandleMessage(context.Background(), json.RawMessage(message)))
+	return string(out)
+}
+
+func TestNew_ToolMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	record := func(name string) server.ToolHandlerMiddleware {
+		return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				order = append(order, name+":"+req.Params.Name)
+				return next(ctx, req)
+			}
+		}
+	}
+	deny := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req.Params.Name == "minder_list_providers" {
+				return mcp.NewToolResultError("denied by policy"), nil
+			}
+			return next(ctx, req)
+		}
+	}
+	s := newDemoServer(t, WithToolMiddleware(record("outer"), record("inner")), WithToolMiddleware(deny))
+
+	got := handle(s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"minder_list_projects"}}`)
+	if strings.Contains(got, `"isError":true`) {
+		t.Errorf("minder_list_projects = %s, want a result", got)
+	}
+	got = handle(s, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"minder_list_providers"}}`)
+	if !strings.Contains(got, "denied by policy") {
+		t.Errorf("minder_list_providers = %s, want the middleware's denial", got)
+	}
+	want := []string{"outer:minder_list_projects", "inner:minder_list_projects",
+		"outer:minder_list_providers", "inner:minder_list_providers"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("middleware ran as %v, want %v", order, want)
+	}
+}
+
+func TestNew_Resources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantResources bool
+	}{
+		{name: "default", wantResources: true},
+		{name: "without resources", opts: []Option{WithoutResources()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newDemoServer(t, tt.opts...)
+			if (s.Resources != nil) != tt.wantResources {
+				t.Errorf("Resources = %v, want set %v", s.Resources, tt.wantResources)
+			}
+			got := handle(s, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+			if strings.Contains(got, resources.DashboardURI) != tt.wantResources {
+				t.Errorf("resources/list = %s, want the dashboard listed %v", got, tt.wantResources)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package metrics provides Prometheus instrumentation for the MCP server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "minder_mcp"
+
+// Tool invocation outcomes used as the "outcome" label.
+const (
+	// OutcomeSuccess indicates the tool returned a successful result.
+	OutcomeSuccess = "success"
+	// OutcomeToolError indicates the tool returned an error result to the client.
+	OutcomeToolError = "tool_error"
+	// OutcomeFailure indicates the tool handler returned a Go error.
+	OutcomeFailure = "failure"
+)
+
+// Cache lookup results used as the "result" label.
+const (
+	// CacheHit indicates the value was served from cache.
+	CacheHit = "hit"
+	// CacheMiss indicates the value had to be fetched.
+	CacheMiss = "miss"
+)
+
+var (
+	// ToolInvocations counts tool calls by tool name, outcome and, for error
+	// results, the error code.
+	ToolInvocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_invocations_total",
+		Help:      "Total number of MCP tool invocations.",
+	}, []string{"tool", "outcome", "error_code"})
+
+	// ToolDuration observes tool handler latency by tool name.
+	ToolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_duration_seconds",
+		Help:      "Latency of MCP tool handlers.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tool"})
+
+	// MinderRPCDuration observes Minder gRPC call latency by method and status code.
+	MinderRPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "minder_rpc_duration_seconds",
+		Help:      "Latency of gRPC calls to the Minder backend.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// TokenRefreshes counts OAuth token refresh attempts by result.
+	TokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_refreshes_total",
+		Help:      "Total number of access token refresh attempts.",
+	}, []string{"result"})
+
+	// CacheRequests counts cache lookups by cache name and hit/miss result.
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Total number of cache lookups.",
+	}, []string{"cache", "result"})
+)
+
+// registry holds the server's metrics, isolated from the global default registry.
+var registry = newRegistry()
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ToolInvocations,
+		ToolDuration,
+		MinderRPCDuration,
+		TokenRefreshes,
+		CacheRequests,
+	)
+	return r
+}
+
+// Handler returns an http.Handler that serves metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveTool records the outcome and latency of a tool invocation. The error
+// code of an error result, such as permission_denied, tells failures apart; it
+// is empty for other outcomes.
+func ObserveTool(tool, outcome, errorCode string, duration time.Duration) {
+	ToolInvocations.WithLabelValues(tool, outcome, errorCode).Inc()
+	ToolDuration.WithLabelValues(tool).Observe(duration.Seconds())
+}
+
+// ObserveCache records a cache lookup.
+func ObserveCache(cache string, hit bool) {
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	CacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// UnaryClientInterceptor returns a gRPC interceptor that records Minder RPC latency.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		MinderRPCDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
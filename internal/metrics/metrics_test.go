@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestObserveTool(t *testing.T) {
+	before := testutil.ToFloat64(ToolInvocations.WithLabelValues("test_tool", OutcomeToolError, "not_found"))
+	other := testutil.ToFloat64(ToolInvocations.WithLabelValues("test_tool", OutcomeToolError, "permission_denied"))
+
+	ObserveTool("test_tool", OutcomeToolError, "not_found", 50*time.Millisecond)
+
+	after := testutil.ToFloat64(ToolInvocations.WithLabelValues("test_tool", OutcomeToolError, "not_found"))
+	if after != before+1 {
+		t.Errorf("tool_invocations_total = %v, want %v", after, before+1)
+	}
+	if got := testutil.ToFloat64(ToolInvocations.WithLabelValues("test_tool", OutcomeToolError, "permission_denied")); got != other {
+		t.Errorf("tool_invocations_total for another error code = %v, want %v", got, other)
+	}
+}
+
+func TestObserveCache(t *testing.T) {
+	hits := testutil.ToFloat64(CacheRequests.WithLabelValues("test_cache", CacheHit))
+	misses := testutil.ToFloat64(CacheRequests.WithLabelValues("test_cache", CacheMiss))
+
+	ObserveCache("test_cache", true)
+	ObserveCache("test_cache", false)
+	ObserveCache("test_cache", false)
+
+	if got := testutil.ToFloat64(CacheRequests.WithLabelValues("test_cache", CacheHit)); got != hits+1 {
+		t.Errorf("cache hits = %v, want %v", got, hits+1)
+	}
+	if got := testutil.ToFloat64(CacheRequests.WithLabelValues("test_cache", CacheMiss)); got != misses+2 {
+		t.Errorf("cache misses = %v, want %v", got, misses+2)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+	wantErr := status.Error(codes.NotFound, "missing")
+
+	err := interceptor(context.Background(), "/minder.v1.Test/Method", nil, nil, nil,
+		func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			return wantErr
+		})
+	if err != wantErr {
+		t.Errorf("interceptor returned %v, want %v", err, wantErr)
+	}
+
+	count := testutil.CollectAndCount(MinderRPCDuration, namespace+"_minder_rpc_duration_seconds")
+	if count == 0 {
+		t.Error("expected minder_rpc_duration_seconds to have observations")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ObserveTool("handler_tool", OutcomeSuccess, "", time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"minder_mcp_tool_invocations_total",
+		"minder_mcp_tool_duration_seconds",
+		`tool="handler_tool"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
@@ -10,6 +10,9 @@ type contextKey struct{ name string }
 // authTokenKey is the unexported context key for the authentication token.
 var authTokenKey = &contextKey{"auth_token"}
 
+// headerTokenKey marks a token the caller sent in its Authorization header.
+var headerTokenKey = &contextKey{"header_token"}
+
 // ContextWithToken returns a new context with the authentication token set.
 func ContextWithToken(ctx context.Context, token string) context.Context {
 	return context.WithValue(ctx, authTokenKey, token)
@@ -22,3 +25,16 @@ func TokenFromContext(ctx context.Context) string {
 	}
 	return ""
 }
+
+// ContextWithHeaderToken returns a new context with the token the caller sent
+// in its Authorization header set.
+func ContextWithHeaderToken(ctx context.Context, token string) context.Context {
+	return ContextWithToken(context.WithValue(ctx, headerTokenKey, true), token)
+}
+
+// TokenFromHeader reports whether the token in the context is the caller's
+// own, sent in its Authorization header, rather than the configured token.
+func TokenFromHeader(ctx context.Context) bool {
+	fromHeader, _ := ctx.Value(headerTokenKey).(bool)
+	return fromHeader
+}
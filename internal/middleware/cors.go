@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/rs/cors"
+)
+
+// CORS lets browsers on origins call next, as MCP Apps hosts do, and read
+// the Mcp-Session-Id header that carries the MCP session.
+func CORS(origins *OriginAllowlist, next http.Handler) http.Handler {
+	return cors.New(cors.Options{
+		AllowOriginFunc:  origins.Allowed,
+		AllowedMethods:   []string{"GET", "POST", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"Mcp-Session-Id"},
+		AllowCredentials: true,
+	}).Handler(next)
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import "context"
+
+// MinderHostHeader is the HTTP header routing a request to another Minder
+// backend, as host or host:port. Hosts are validated against the configured
+// allowlist before use.
+const MinderHostHeader = "X-Minder-Host"
+
+// minderHostKey is the unexported context key for the requested Minder host.
+var minderHostKey = &contextKey{"minder_host"}
+
+// ContextWithMinderHost returns a new context with the requested Minder host set.
+func ContextWithMinderHost(ctx context.Context, host string) context.Context {
+	return context.WithValue(ctx, minderHostKey, host)
+}
+
+// MinderHostFromContext extracts the requested Minder host from the context,
+// or "" when the request did not name one.
+func MinderHostFromContext(ctx context.Context) string {
+	if host, ok := ctx.Value(minderHostKey).(string); ok {
+		return host
+	}
+	return ""
+}
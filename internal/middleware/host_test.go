@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMinderHostFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "host set",
+			ctx:  ContextWithMinderHost(context.Background(), "customer.example.com:8443"),
+			want: "customer.example.com:8443",
+		},
+		{
+			name: "no host",
+			ctx:  context.Background(),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := MinderHostFromContext(tt.ctx); got != tt.want {
+				t.Errorf("MinderHostFromContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// HTTPContext returns the function that copies an MCP HTTP request's bearer
+// token, Minder host, request ID and client address into the context of its
+// tool calls.
+// Requests without a bearer token use configToken, unless they are routed to
+// another Minder host: configured servers supply their own tokens.
+func HTTPContext(configToken string) func(context.Context, *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		var token, source string
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+			source = "header"
+		}
+		host := r.Header.Get(MinderHostHeader)
+		if host != "" {
+			ctx = ContextWithMinderHost(ctx, host)
+		} else if token == "" {
+			token = configToken
+			source = "config"
+		}
+		ctx = ContextWithRequestID(ctx, RequestIDOrNew(r.Header.Get(RequestIDHeader)))
+		if addr, ok := remoteAddr(r); ok {
+			ctx = ContextWithClientAddr(ctx, addr)
+		}
+		//nolint:gosec // G706 - source is a string literal, not user input
+		slog.DebugContext(ctx, "auth context", "has_token", token != "", "source", source)
+		if source == "header" {
+			return ContextWithHeaderToken(ctx, token)
+		}
+		return ContextWithToken(ctx, token)
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantToken  string
+		wantHost   string
+		wantHeader bool
+	}{
+		{
+			name:       "bearer token",
+			headers:    map[string]string{"Authorization": "Bearer user-token"},
+			wantToken:  "user-token",
+			wantHeader: true,
+		},
+		{
+			name:      "configured token",
+			wantToken: "config-token",
+		},
+		{
+			name:      "other host without token",
+			headers:   map[string]string{MinderHostHeader: "customer.example.com:8443"},
+			wantHost:  "customer.example.com:8443",
+			wantToken: "",
+		},
+		{
+			name: "other host with token",
+			headers: map[string]string{
+				MinderHostHeader: "customer.example.com:8443",
+				"Authorization":  "Bearer user-token",
+			},
+			wantHost:   "customer.example.com:8443",
+			wantToken:  "user-token",
+			wantHeader: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest("POST", "/mcp", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			ctx := HTTPContext("config-token")(context.Background(), r)
+			if got := TokenFromContext(ctx); got != tt.wantToken {
+				t.Errorf("TokenFromContext() = %q, want %q", got, tt.wantToken)
+			}
+			if got := TokenFromHeader(ctx); got != tt.wantHeader {
+				t.Errorf("TokenFromHeader() = %v, want %v", got, tt.wantHeader)
+			}
+			if got := MinderHostFromContext(ctx); got != tt.wantHost {
+				t.Errorf("MinderHostFromContext() = %q, want %q", got, tt.wantHost)
+			}
+			if addr, ok := ClientAddrFromContext(ctx); !ok || addr.String() != "192.0.2.1" {
+				t.Errorf("ClientAddrFromContext() = %v, %v, want the request's remote address", addr, ok)
+			}
+			if RequestIDFromContext(ctx) == "" {
+				t.Error("RequestIDFromContext() is empty, want a generated request ID")
+			}
+		})
+	}
+}
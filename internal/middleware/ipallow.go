@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"sync"
+)
+
+// IPAllowlist restricts the clients that may reach a handler to a set of
+// network prefixes that can be replaced at runtime. An empty list allows every
+// client.
+type IPAllowlist struct {
+	mu       sync.RWMutex
+	prefixes []netip.Prefix
+}
+
+// NewIPAllowlist creates an IPAllowlist containing prefixes.
+func NewIPAllowlist(prefixes []netip.Prefix) *IPAllowlist {
+	a := &IPAllowlist{}
+	a.Set(prefixes)
+	return a
+}
+
+// Set replaces the allowed prefixes.
+func (a *IPAllowlist) Set(prefixes []netip.Prefix) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.prefixes = prefixes
+}
+
+// Allowed reports whether a client at addr may connect. IPv4 addresses mapped
+// into IPv6 are matched as IPv4.
+func (a *IPAllowlist) Allowed(addr netip.Addr) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.prefixes) == 0 {
+		return true
+	}
+	addr = addr.Unmap()
+	for _, p := range a.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler wraps next, answering 403 Forbidden to clients outside the list.
+// The client is the peer of the TCP connection; forwarding headers such as
+// X-Forwarded-For are ignored because any client can set them.
+func (a *IPAllowlist) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if addr, ok := remoteAddr(r); !ok || !a.Allowed(addr) {
+			http.Error(w, "client address not allowed", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// remoteAddr parses the IP address of the client that sent r.
+func remoteAddr(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.WithZone(""), true
+}
+
+// clientAddrKey is the unexported context key for the client's IP address.
+var clientAddrKey = &contextKey{"client_addr"}
+
+// ContextWithClientAddr returns a new context with the IP address of the
+// client that sent the request set.
+func ContextWithClientAddr(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, clientAddrKey, addr)
+}
+
+// ClientAddrFromContext returns the IP address of the client that sent the
+// request, if known.
+func ClientAddrFromContext(ctx context.Context) (netip.Addr, bool) {
+	addr, ok := ctx.Value(clientAddrKey).(netip.Addr)
+	return addr, ok
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestIPAllowlist_Handler(t *testing.T) {
+	t.Parallel()
+
+	a := NewIPAllowlist([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("fd00::/8"),
+	})
+	handler := a.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		remoteAddr string
+		forwarded  string
+		want       int
+	}{
+		{remoteAddr: "10.1.2.3:4567", want: http.StatusOK},
+		{remoteAddr: "[::ffff:10.1.2.3]:4567", want: http.StatusOK},
+		{remoteAddr: "[fd00::1%eth0]:4567", want: http.StatusOK},
+		{remoteAddr: "192.168.1.5:4567", want: http.StatusForbidden},
+		{remoteAddr: "192.168.1.5:4567", forwarded: "10.1.2.3", want: http.StatusForbidden},
+		{remoteAddr: "not-an-address", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr+tt.forwarded, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPAllowlist_EmptyAllowsAll(t *testing.T) {
+	t.Parallel()
+
+	a := NewIPAllowlist(nil)
+	if !a.Allowed(netip.MustParseAddr("203.0.113.7")) {
+		t.Error("empty allowlist should allow every client")
+	}
+}
+
+func TestIPAllowlist_Set(t *testing.T) {
+	t.Parallel()
+
+	a := NewIPAllowlist(nil)
+	a.Set([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+
+	if a.Allowed(netip.MustParseAddr("203.0.113.7")) {
+		t.Error("client should be rejected after Set")
+	}
+	if !a.Allowed(netip.MustParseAddr("10.0.0.1")) {
+		t.Error("client in the new network should be allowed")
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// OriginGuard rejects browser requests from untrusted origins when they would
+// act with credentials, so a web page cannot use the visitor's browser to
+// reach a locally running server through DNS rebinding or a cross-site request.
+// Unlike CORS, which only hides responses from the page, the request is
+// refused before it reaches the handler.
+type OriginGuard struct {
+	// Trusted lists origins allowed to send credentialed requests. Loopback
+	// origins are always trusted; "*" trusts every origin.
+	Trusted *OriginAllowlist
+	// StoredCredentials is set when the server holds a Minder token of its
+	// own, which a request without an Authorization header would act with.
+	StoredCredentials bool
+}
+
+// Handler wraps next with origin validation. Requests without an Origin
+// header, such as those from non-browser MCP clients, and CORS preflight
+// requests are passed through.
+func (g *OriginGuard) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || r.Method == http.MethodOptions || !g.credentialed(r) || g.trusted(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+	})
+}
+
+// credentialed reports whether r carries credentials or would be served with
+// the server's own.
+func (g *OriginGuard) credentialed(r *http.Request) bool {
+	return g.StoredCredentials || r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != ""
+}
+
+// trusted reports whether origin is a loopback origin or in the trusted list.
+func (g *OriginGuard) trusted(origin string) bool {
+	return isLoopbackOrigin(origin) || (g.Trusted != nil && g.Trusted.Allowed(origin))
+}
+
+// isLoopbackOrigin reports whether origin is an http(s) origin on localhost or
+// a loopback address.
+func isLoopbackOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOriginGuard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stored bool
+		method string
+		origin string
+		header map[string]string
+		want   int
+	}{
+		{name: "no origin", stored: true, want: http.StatusOK},
+		{name: "untrusted origin without credentials", origin: "https://evil.example.com", want: http.StatusOK},
+		{
+			name:   "untrusted origin with authorization",
+			origin: "https://evil.example.com",
+			header: map[string]string{"Authorization": "Bearer t"},
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "untrusted origin with cookie",
+			origin: "https://evil.example.com",
+			header: map[string]string{"Cookie": "session=1"},
+			want:   http.StatusForbidden,
+		},
+		{name: "untrusted origin with stored token", stored: true, origin: "https://evil.example.com", want: http.StatusForbidden},
+		{name: "rebound origin", stored: true, origin: "http://rebind.example.com:8080", want: http.StatusForbidden},
+		{name: "null origin", stored: true, origin: "null", want: http.StatusForbidden},
+		{name: "trusted origin", stored: true, origin: "https://app.example.com", want: http.StatusOK},
+		{name: "localhost origin", stored: true, origin: "http://localhost:3000", want: http.StatusOK},
+		{name: "loopback origin", stored: true, origin: "http://127.0.0.1:8080", want: http.StatusOK},
+		{name: "ipv6 loopback origin", stored: true, origin: "http://[::1]:8080", want: http.StatusOK},
+		{name: "preflight", stored: true, method: http.MethodOptions, origin: "https://evil.example.com", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := &OriginGuard{
+				Trusted:           NewOriginAllowlist([]string{"https://app.example.com"}),
+				StoredCredentials: tt.stored,
+			}
+			handler := guard.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, "/mcp", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestOriginGuard_WildcardTrustsAll(t *testing.T) {
+	t.Parallel()
+
+	guard := &OriginGuard{Trusted: NewOriginAllowlist([]string{"*"}), StoredCredentials: true}
+	if !guard.trusted("https://anything.example.com") {
+		t.Error("wildcard should trust every origin")
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strings"
+	"sync"
+)
+
+// OriginAllowlist is a set of allowed CORS origins that can be replaced at runtime.
+// The wildcard "*" allows every origin.
+type OriginAllowlist struct {
+	mu      sync.RWMutex
+	any     bool
+	origins map[string]struct{}
+}
+
+// NewOriginAllowlist creates an OriginAllowlist containing origins.
+func NewOriginAllowlist(origins []string) *OriginAllowlist {
+	a := &OriginAllowlist{}
+	a.Set(origins)
+	return a
+}
+
+// Set replaces the allowed origins.
+func (a *OriginAllowlist) Set(origins []string) {
+	set := make(map[string]struct{}, len(origins))
+	wildcard := false
+	for _, o := range origins {
+		o = strings.TrimSpace(o)
+		if o == "*" {
+			wildcard = true
+		}
+		if o != "" {
+			set[strings.ToLower(o)] = struct{}{}
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.any = wildcard
+	a.origins = set
+}
+
+// Allowed reports whether origin may make cross-origin requests.
+// Origins are compared case-insensitively.
+func (a *OriginAllowlist) Allowed(origin string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.any {
+		return true
+	}
+	_, ok := a.origins[strings.ToLower(origin)]
+	return ok
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import "testing"
+
+func TestOriginAllowlist(t *testing.T) {
+	t.Parallel()
+
+	a := NewOriginAllowlist([]string{"https://app.example.com", " https://Other.example.com "})
+
+	tests := map[string]bool{
+		"https://app.example.com":   true,
+		"https://other.example.com": true,
+		"https://evil.example.com":  false,
+		"":                          false,
+	}
+	for origin, want := range tests {
+		if got := a.Allowed(origin); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
+
+func TestOriginAllowlist_Wildcard(t *testing.T) {
+	t.Parallel()
+
+	a := NewOriginAllowlist([]string{"*"})
+	if !a.Allowed("https://anything.example.com") {
+		t.Error("wildcard should allow every origin")
+	}
+}
+
+func TestOriginAllowlist_Set(t *testing.T) {
+	t.Parallel()
+
+	a := NewOriginAllowlist([]string{"*"})
+	a.Set([]string{"https://app.example.com"})
+
+	if a.Allowed("https://other.example.com") {
+		t.Error("origin should be rejected after replacing the wildcard")
+	}
+	if !a.Allowed("https://app.example.com") {
+		t.Error("new origin should be allowed after Set")
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the HTTP header used to accept and propagate request IDs.
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLen bounds client-supplied request IDs to keep log lines sane.
+const maxRequestIDLen = 128
+
+// requestIDKey is the unexported context key for the request ID.
+var requestIDKey = &contextKey{"request_id"}
+
+// ContextWithRequestID returns a new context with the request ID set.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext extracts the request ID from the context.
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// NewRequestID generates a new random request ID.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// RequestIDOrNew returns id if it is a safe client-supplied request ID,
+// otherwise a newly generated one. Only letters, digits, '-', '_' and '.'
+// are accepted so the value can be logged and forwarded verbatim.
+func RequestIDOrNew(id string) string {
+	if id == "" || len(id) > maxRequestIDLen {
+		return NewRequestID()
+	}
+	for _, r := range id {
+		isAlnum := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlnum && r != '-' && r != '_' && r != '.' {
+			return NewRequestID()
+		}
+	}
+	return id
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestContextWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	if got := RequestIDFromContext(ctx); got != "req-123" {
+		t.Errorf("RequestIDFromContext() = %q, want %q", got, "req-123")
+	}
+}
+
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	t.Parallel()
+
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("RequestIDFromContext() = %q, want empty", got)
+	}
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		id       string
+		wantSame bool
+	}{
+		{
+			name:     "keeps valid id",
+			id:       "abc-123_DEF.456",
+			wantSame: true,
+		},
+		{
+			name:     "generates for empty id",
+			id:       "",
+			wantSame: false,
+		},
+		{
+			name:     "generates for id with unsafe characters",
+			id:       "abc\ninjected=1",
+			wantSame: false,
+		},
+		{
+			name:     "generates for overly long id",
+			id:       strings.Repeat("a", maxRequestIDLen+1),
+			wantSame: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := RequestIDOrNew(tt.id)
+			if tt.wantSame && got != tt.id {
+				t.Errorf("RequestIDOrNew(%q) = %q, want unchanged", tt.id, got)
+			}
+			if !tt.wantSame && (got == tt.id || got == "") {
+				t.Errorf("RequestIDOrNew(%q) = %q, want a generated id", tt.id, got)
+			}
+		})
+	}
+}
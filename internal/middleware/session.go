@@ -0,0 +1,20 @@
+package middleware
+
+import "context"
+
+// sessionIDKey is the unexported context key for the MCP session ID.
+var sessionIDKey = &contextKey{"session_id"}
+
+// ContextWithSessionID returns a new context with the MCP session ID set.
+func ContextWithSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, id)
+}
+
+// SessionIDFromContext extracts the MCP session ID from the context, or ""
+// when the call is not part of a session.
+func SessionIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(sessionIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextWithSessionID(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithSessionID(context.Background(), "session-1")
+	if got := SessionIDFromContext(ctx); got != "session-1" {
+		t.Errorf("SessionIDFromContext() = %q, want %q", got, "session-1")
+	}
+	if got := SessionIDFromContext(context.Background()); got != "" {
+		t.Errorf("SessionIDFromContext() = %q, want empty", got)
+	}
+}
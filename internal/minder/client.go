@@ -1,18 +1,40 @@
 package minder
 
 import (
-	"crypto/tls"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
+	"time"
 
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stacklok/minder-mcp/internal/metrics"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/timing"
+)
+
+// gRPC metadata keys used to forward the IDs of a tool call to Minder, so its
+// logs can attribute traffic to this server and correlate it with ours. The
+// session ID unlocks credentials saved for the session, so only a truncated
+// hash of it is sent.
+const (
+	requestIDMetadataKey   = "x-request-id"
+	sessionHashMetadataKey = "x-mcp-session-hash"
 )
 
 // Client wraps a gRPC connection and provides access to Minder service clients.
 type Client struct {
-	conn *grpc.ClientConn
+	conn grpc.ClientConnInterface
+	// close releases the connection; a no-op for connections owned by a Pool.
+	close func() error
 }
 
 // ClientConfig holds configuration for creating a Minder client.
@@ -20,38 +42,152 @@ type ClientConfig struct {
 	Host     string
 	Port     int
 	Insecure bool
+	// CertPins restrict the certificates the server may present; see tlsConfig.
+	CertPins []string
 	Token    string
+	// Logger receives a debug line per Minder RPC. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Interceptors run after the built-in interceptors, closest to the RPC.
+	Interceptors []grpc.UnaryClientInterceptor
+	// RateLimitRetries is how many times an RPC failing with ResourceExhausted
+	// is retried. Zero disables retries.
+	RateLimitRetries int
+	// RateLimitMaxWait is the longest wait before a retry; an error asking
+	// for a longer wait is returned instead.
+	RateLimitMaxWait time.Duration
+	// UserAgent identifies this server to Minder, e.g. "minder-mcp/v1.2.0".
+	// Empty leaves gRPC's default.
+	UserAgent string
 }
 
 // NewClient creates a new Minder gRPC client.
 func NewClient(cfg ClientConfig) (*Client, error) {
+	conn, err := dial(cfg, grpc.WithPerRPCCredentials(NewJWTTokenCredentials(cfg.Token, cfg.Insecure)))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, close: conn.Close}, nil
+}
+
+// dial opens a connection to the server in cfg with the built-in
+// interceptors and transport security. cfg.Token is not used.
+func dial(cfg ClientConfig, extra ...grpc.DialOption) (*grpc.ClientConn, error) {
 	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
-	opts := []grpc.DialOption{
-		grpc.WithPerRPCCredentials(NewJWTTokenCredentials(cfg.Token, cfg.Insecure)),
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	// Retries wrap the later interceptors so each attempt is logged and measured
+	interceptors := []grpc.UnaryClientInterceptor{callMetadataInterceptor}
+	if cfg.RateLimitRetries > 0 {
+		interceptors = append(interceptors, rateLimitRetryInterceptor(cfg.RateLimitRetries, cfg.RateLimitMaxWait, logger))
+	}
+	interceptors = append(interceptors,
+		loggingInterceptor(logger),
+		timingInterceptor,
+		metrics.UnaryClientInterceptor(),
+	)
+	interceptors = append(interceptors, cfg.Interceptors...)
+	opts := append([]grpc.DialOption{grpc.WithChainUnaryInterceptor(interceptors...)}, extra...)
+	if cfg.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(cfg.UserAgent))
 	}
 
 	// Add transport credentials - only use insecure when explicitly configured
 	if cfg.Insecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-			MinVersion: tls.VersionTLS13,
-			ServerName: cfg.Host,
-		})))
+		tlsCfg, err := tlsConfig(cfg.Host, cfg.CertPins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Minder: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
 	}
 
 	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Minder: %w", err)
 	}
+	return conn, nil
+}
 
-	return &Client{conn: conn}, nil
+// NewClientFromConn wraps an existing gRPC connection, such as one to an
+// in-process server. Closing the client closes conn.
+func NewClientFromConn(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, close: conn.Close}
 }
 
-// Close closes the gRPC connection.
+// callMetadataInterceptor forwards the request ID and the hash of the MCP
+// session ID from the context as outgoing gRPC metadata.
+func callMetadataInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if id := middleware.RequestIDFromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, id)
+	}
+	if id := middleware.SessionIDFromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, sessionHashMetadataKey, sessionHash(id))
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// sessionHash returns the hex of the first 8 bytes of the SHA-256 of an MCP
+// session ID, which groups a session's calls without revealing the ID.
+func sessionHash(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loggingInterceptor logs every Minder RPC with its duration, status code and response size.
+func loggingInterceptor(logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		var size int
+		if msg, ok := reply.(proto.Message); ok && err == nil {
+			size = proto.Size(msg)
+		}
+		logger.DebugContext(ctx, "minder rpc",
+			"method", method,
+			"duration", time.Since(start),
+			"code", status.Code(err).String(),
+			"response_bytes", size,
+		)
+		return err
+	}
+}
+
+// timingInterceptor records each Minder RPC as a phase of the current tool call.
+func timingInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	defer timing.Record(ctx, "rpc "+method, time.Now())
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// Close closes the gRPC connection, unless it belongs to a Pool.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	return c.close()
 }
 
 // Health returns the HealthServiceClient.
@@ -84,6 +220,11 @@ func (c *Client) Providers() minderv1.ProvidersServiceClient {
 	return minderv1.NewProvidersServiceClient(c.conn)
 }
 
+// OAuth returns the OAuthServiceClient.
+func (c *Client) OAuth() minderv1.OAuthServiceClient {
+	return minderv1.NewOAuthServiceClient(c.conn)
+}
+
 // Projects returns the ProjectsServiceClient.
 func (c *Client) Projects() minderv1.ProjectsServiceClient {
 	return minderv1.NewProjectsServiceClient(c.conn)
@@ -113,3 +254,9 @@ func (c *Client) Permissions() minderv1.PermissionsServiceClient {
 func (c *Client) Invites() minderv1.InviteServiceClient {
 	return minderv1.NewInviteServiceClient(c.conn)
 }
+
+// Conn returns the underlying connection, for invoking RPCs that have no
+// typed accessor.
+func (c *Client) Conn() grpc.ClientConnInterface {
+	return c.conn
+}
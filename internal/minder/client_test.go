@@ -0,0 +1,130 @@
+package minder
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+func TestCallMetadataInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		sessionID     string
+		want          []string
+		wantSessionID []string
+	}{
+		{
+			name:      "forwards request ID",
+			requestID: "req-123",
+			want:      []string{"req-123"},
+		},
+		{
+			name:          "forwards session ID hash",
+			requestID:     "req-123",
+			sessionID:     "session-1",
+			want:          []string{"req-123"},
+			wantSessionID: []string{"84097828fc31a8c8"},
+		},
+		{
+			name:      "omits metadata without request ID",
+			requestID: "",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = middleware.ContextWithRequestID(ctx, tt.requestID)
+			}
+			if tt.sessionID != "" {
+				ctx = middleware.ContextWithSessionID(ctx, tt.sessionID)
+			}
+
+			var got, gotSessionID []string
+			err := callMetadataInterceptor(ctx, "/minder.v1.Test/Method", nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					got = md.Get(requestIDMetadataKey)
+					gotSessionID = md.Get(sessionHashMetadataKey)
+					return nil
+				})
+			if err != nil {
+				t.Fatalf("interceptor returned error: %v", err)
+			}
+
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("metadata %s = %v, want %v", requestIDMetadataKey, got, tt.want)
+			}
+			if len(gotSessionID) != len(tt.wantSessionID) || (len(gotSessionID) > 0 && gotSessionID[0] != tt.wantSessionID[0]) {
+				t.Errorf("metadata %s = %v, want %v", sessionHashMetadataKey, gotSessionID, tt.wantSessionID)
+			}
+		})
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+		wantSize bool
+	}{
+		{
+			name:     "logs successful call with response size",
+			wantCode: "OK",
+			wantSize: true,
+		},
+		{
+			name:     "logs failed call status code",
+			err:      status.Error(codes.NotFound, "missing"),
+			wantCode: "NotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			reply := &minderv1.CheckHealthResponse{Status: "OK"}
+
+			err := loggingInterceptor(logger)(context.Background(), "/minder.v1.HealthService/CheckHealth", nil, reply, nil,
+				func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					return tt.err
+				})
+			if err != tt.err {
+				t.Fatalf("interceptor returned %v, want %v", err, tt.err)
+			}
+
+			out := buf.String()
+			for _, want := range []string{`"msg":"minder rpc"`, `"method":"/minder.v1.HealthService/CheckHealth"`, `"code":"` + tt.wantCode + `"`} {
+				if !strings.Contains(out, want) {
+					t.Errorf("log output %q does not contain %q", out, want)
+				}
+			}
+			if hasSize := !strings.Contains(out, `"response_bytes":0`); hasSize != tt.wantSize {
+				t.Errorf("non-zero response size logged = %v, want %v: %s", hasSize, tt.wantSize, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package minder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefreshStatus is the outcome of the latest token refreshes against a server's realm.
+type RefreshStatus struct {
+	LastSuccess time.Time
+	LastFailure time.Time
+	// LastError describes the latest failure.
+	LastError string
+	// realmAnswered is set when the latest failure came from the identity
+	// provider itself, such as a revoked refresh token.
+	realmAnswered bool
+}
+
+// Failing reports whether the latest refresh failed.
+func (s RefreshStatus) Failing() bool {
+	return s.LastFailure.After(s.LastSuccess)
+}
+
+// RealmStatus describes what is known of a server's identity provider realm
+// without contacting it.
+type RealmStatus struct {
+	URL          string
+	DiscoveredAt time.Time
+	// Reachable is nil until a token has been refreshed against the realm.
+	Reachable *bool
+}
+
+// recordRefresh stores the outcome of a token refresh against cfg's realm.
+// The caller holds t.mu.
+func (t *TokenRefresher) recordRefresh(cfg ServerConfig, err error, now time.Time) {
+	key := realmKey(cfg)
+	s, ok := t.refreshes[key]
+	if !ok {
+		s = &RefreshStatus{}
+		t.refreshes[key] = s
+	}
+	if err == nil {
+		s.LastSuccess = now
+		return
+	}
+	s.LastFailure = now
+	s.LastError = err.Error()
+	s.realmAnswered = errors.Is(err, ErrReauthenticationRequired)
+}
+
+// RefreshStatus returns the outcome of the latest token refreshes for cfg,
+// and false if no token has been refreshed for it.
+func (t *TokenRefresher) RefreshStatus(cfg ServerConfig) (RefreshStatus, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	s, ok := t.refreshes[realmKey(cfg)]
+	if !ok {
+		return RefreshStatus{}, false
+	}
+	return *s, true
+}
+
+// RealmStatus returns the cached realm of cfg's server, and false if it has
+// not been discovered. The realm counts as reachable when the latest token
+// refresh succeeded or was refused by the identity provider.
+func (t *TokenRefresher) RealmStatus(cfg ServerConfig) (RealmStatus, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	entry, ok := t.realms[realmKey(cfg)]
+	if !ok {
+		return RealmStatus{}, false
+	}
+	rs := RealmStatus{URL: entry.RealmURL, DiscoveredAt: entry.DiscoveredAt}
+	if s, ok := t.refreshes[realmKey(cfg)]; ok {
+		reachable := !s.Failing() || s.realmAnswered
+		rs.Reachable = &reachable
+	}
+	return rs, true
+}
+
+// CallStatus is the outcome of the latest RPCs to a Minder server.
+type CallStatus struct {
+	// LastSuccess is when the server last answered, including with
+	// application errors such as NotFound.
+	LastSuccess time.Time
+	// LastFailure is when an RPC last failed because the server was
+	// unreachable or too slow. Rejected credentials are a problem of one
+	// caller's token, not of the server, and count as answers.
+	LastFailure time.Time
+	// LastError describes the latest failure.
+	LastError string
+}
+
+// Failing reports whether the latest RPC failed.
+func (s CallStatus) Failing() bool {
+	return s.LastFailure.After(s.LastSuccess)
+}
+
+// CallTracker records the outcome of Minder RPCs per server, so health checks
+// can report whether tools can actually be served without calling Minder.
+// CallTracker is safe for concurrent use.
+type CallTracker struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	servers map[string]*CallStatus
+}
+
+// NewCallTracker creates a CallTracker with no recorded calls.
+func NewCallTracker() *CallTracker {
+	return &CallTracker{now: time.Now, servers: make(map[string]*CallStatus)}
+}
+
+// Interceptor returns a client interceptor recording each RPC's outcome under
+// the address of the server it was sent to.
+func (c *CallTracker) Interceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		c.record(cc.Target(), err)
+		return err
+	}
+}
+
+// record stores the outcome of an RPC to address. Calls canceled by the
+// caller say nothing about the server and are ignored.
+func (c *CallTracker) record(address string, err error) {
+	code := status.Code(err)
+	if code == codes.Canceled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.servers[address]
+	if !ok {
+		s = &CallStatus{}
+		c.servers[address] = s
+	}
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		s.LastFailure = c.now()
+		s.LastError = err.Error()
+	default:
+		s.LastSuccess = c.now()
+	}
+}
+
+// Status returns the outcome of the latest RPCs to the server at address
+// (host:port), and false if none has been made.
+func (c *CallTracker) Status(address string) (CallStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.servers[address]
+	if !ok {
+		return CallStatus{}, false
+	}
+	return *s, true
+}
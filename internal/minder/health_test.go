@@ -0,0 +1,138 @@
+package minder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallTracker_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	conn, err := grpc.NewClient("minder.example.com:443", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	tests := []struct {
+		name        string
+		errs        []error
+		wantFailing bool
+		wantKnown   bool
+	}{
+		{name: "no calls"},
+		{name: "success", errs: []error{nil}, wantKnown: true},
+		{name: "application error", errs: []error{status.Error(codes.NotFound, "gone")}, wantKnown: true},
+		{name: "rejected token", errs: []error{status.Error(codes.Unauthenticated, "expired")}, wantKnown: true},
+		{name: "unavailable", errs: []error{status.Error(codes.Unavailable, "down")}, wantKnown: true, wantFailing: true},
+		{
+			name:      "recovered",
+			errs:      []error{status.Error(codes.DeadlineExceeded, "slow"), nil},
+			wantKnown: true,
+		},
+		{name: "canceled", errs: []error{status.Error(codes.Canceled, "client went away")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewCallTracker()
+			tick := time.Unix(0, 0)
+			c.now = func() time.Time {
+				tick = tick.Add(time.Second)
+				return tick
+			}
+			interceptor := c.Interceptor()
+			for _, callErr := range tt.errs {
+				invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					return callErr
+				}
+				if got := interceptor(context.Background(), "/minder.v1.ProjectsService/ListProjects", nil, nil, conn, invoker); !errors.Is(got, callErr) {
+					t.Errorf("interceptor returned %v, want %v", got, callErr)
+				}
+			}
+
+			s, ok := c.Status("minder.example.com:443")
+			if ok != tt.wantKnown {
+				t.Fatalf("Status() known = %v, want %v", ok, tt.wantKnown)
+			}
+			if s.Failing() != tt.wantFailing {
+				t.Errorf("Failing() = %v, want %v (status %+v)", s.Failing(), tt.wantFailing, s)
+			}
+			if tt.wantFailing && s.LastError == "" {
+				t.Error("LastError is empty for a failing server")
+			}
+		})
+	}
+}
+
+func TestTokenRefresher_RealmStatus(t *testing.T) {
+	t.Parallel()
+
+	cfg := ServerConfig{Host: "minder.example.com", Port: 443}
+	realm := "https://auth.example.com/realms/stacklok"
+	tests := []struct {
+		name          string
+		refreshErr    []error
+		wantReachable *bool
+	}{
+		{name: "never refreshed"},
+		{name: "refreshed", refreshErr: []error{nil}, wantReachable: ptrTo(true)},
+		{name: "refresh failed", refreshErr: []error{nil, fmt.Errorf("%w: connection refused", ErrRefreshFailed)},
+			wantReachable: ptrTo(false)},
+		{name: "token revoked", refreshErr: []error{ErrReauthenticationRequired}, wantReachable: ptrTo(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewTokenRefresher()
+			tr.realms[realmKey(cfg)] = &realmEntry{RealmURL: realm, DiscoveredAt: time.Unix(100, 0)}
+			now := time.Unix(200, 0)
+			for _, err := range tt.refreshErr {
+				now = now.Add(time.Second)
+				tr.recordRefresh(cfg, err, now)
+			}
+
+			rs, ok := tr.RealmStatus(cfg)
+			if !ok || rs.URL != realm {
+				t.Fatalf("RealmStatus() = %+v, %v, want realm %s", rs, ok, realm)
+			}
+			switch {
+			case tt.wantReachable == nil && rs.Reachable != nil:
+				t.Errorf("Reachable = %v, want unknown", *rs.Reachable)
+			case tt.wantReachable != nil && (rs.Reachable == nil || *rs.Reachable != *tt.wantReachable):
+				t.Errorf("Reachable = %v, want %v", rs.Reachable, *tt.wantReachable)
+			}
+
+			refresh, ok := tr.RefreshStatus(cfg)
+			if ok != (len(tt.refreshErr) > 0) {
+				t.Errorf("RefreshStatus() known = %v, want %v", ok, len(tt.refreshErr) > 0)
+			}
+			wantFailing := len(tt.refreshErr) > 0 && tt.refreshErr[len(tt.refreshErr)-1] != nil
+			if refresh.Failing() != wantFailing {
+				t.Errorf("Failing() = %v, want %v", refresh.Failing(), wantFailing)
+			}
+		})
+	}
+}
+
+func TestTokenRefresher_RealmStatus_NotDiscovered(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := NewTokenRefresher().RealmStatus(ServerConfig{Host: "minder.example.com", Port: 443}); ok {
+		t.Error("RealmStatus() reported a realm that was never discovered")
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
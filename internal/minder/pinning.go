@@ -0,0 +1,92 @@
+package minder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Certificate pins restrict the certificates a Minder server may present,
+// for deployments that do not want to rely on the system trust store alone.
+// A pin is the base64 SHA-256 of a certificate's SubjectPublicKeyInfo,
+// "sha256/<base64>", which survives renewals that keep the key, or of the
+// whole DER certificate, "cert-sha256/<base64>".
+const (
+	spkiPinPrefix = "sha256/"
+	certPinPrefix = "cert-sha256/"
+)
+
+// certPin is a parsed certificate pin.
+type certPin struct {
+	// wholeCert pins the DER certificate rather than its public key.
+	wholeCert bool
+	hash      []byte
+}
+
+// parseCertPins parses pins in either form.
+func parseCertPins(pins []string) ([]certPin, error) {
+	parsed := make([]certPin, 0, len(pins))
+	for _, pin := range pins {
+		encoded, wholeCert := strings.CutPrefix(pin, certPinPrefix)
+		if !wholeCert {
+			var ok bool
+			if encoded, ok = strings.CutPrefix(pin, spkiPinPrefix); !ok {
+				return nil, fmt.Errorf("certificate pin %q must start with %s or %s", pin, spkiPinPrefix, certPinPrefix)
+			}
+		}
+		hash, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("certificate pin %q must be a base64 SHA-256 hash", pin)
+		}
+		parsed = append(parsed, certPin{wholeCert: wholeCert, hash: hash})
+	}
+	return parsed, nil
+}
+
+// matches reports whether the DER certificate raw, with public key spki, is pinned.
+func (p certPin) matches(raw, spki []byte) bool {
+	data := spki
+	if p.wholeCert {
+		data = raw
+	}
+	sum := sha256.Sum256(data)
+	return bytes.Equal(sum[:], p.hash)
+}
+
+// errCertNotPinned is returned when a server's certificate chain matches none of the pins.
+var errCertNotPinned = errors.New("server certificate chain matches none of the configured certificate pins")
+
+// tlsConfig returns the TLS configuration for connecting to a Minder server
+// at host. Without pins the system trust store decides; with pins the
+// verified chain must also include a pinned certificate or public key, so a
+// pin may name the server's own certificate or the CA that issued it.
+func tlsConfig(host string, pins []string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		ServerName: host,
+	}
+	if len(pins) == 0 {
+		return cfg, nil
+	}
+	parsed, err := parseCertPins(pins)
+	if err != nil {
+		return nil, err
+	}
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				for _, pin := range parsed {
+					if pin.matches(cert.Raw, cert.RawSubjectPublicKeyInfo) {
+						return nil
+					}
+				}
+			}
+		}
+		return errCertNotPinned
+	}
+	return cfg, nil
+}
@@ -0,0 +1,101 @@
+package minder
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTLSConfig_CertPins(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	cert := srv.Certificate()
+	spkiSum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	certSum := sha256.Sum256(cert.Raw)
+	otherSum := sha256.Sum256([]byte("another key"))
+	spkiPin := "sha256/" + base64.StdEncoding.EncodeToString(spkiSum[:])
+	certPin := "cert-sha256/" + base64.StdEncoding.EncodeToString(certSum[:])
+	otherPin := "sha256/" + base64.StdEncoding.EncodeToString(otherSum[:])
+
+	tests := []struct {
+		name         string
+		pins         []string
+		wantParseErr bool
+		wantVerified bool
+	}{
+		{name: "no pins", pins: nil, wantVerified: true},
+		{name: "public key pin", pins: []string{otherPin, spkiPin}, wantVerified: true},
+		{name: "certificate pin", pins: []string{certPin}, wantVerified: true},
+		{name: "unmatched pin", pins: []string{otherPin}, wantVerified: false},
+		{name: "public key hash given as certificate pin", pins: []string{"cert-sha256/" + spkiPin[len("sha256/"):]}},
+		{name: "missing prefix", pins: []string{spkiPin[len("sha256/"):]}, wantParseErr: true},
+		{
+			name:         "not a SHA-256 hash",
+			pins:         []string{"sha256/" + base64.StdEncoding.EncodeToString([]byte("short"))},
+			wantParseErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := tlsConfig("minder.example.com", tt.pins)
+			if (err != nil) != tt.wantParseErr {
+				t.Fatalf("tlsConfig() error = %v, want error %v", err, tt.wantParseErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.MinVersion != tls.VersionTLS13 || cfg.ServerName != "minder.example.com" {
+				t.Errorf("tlsConfig() = MinVersion %x, ServerName %q", cfg.MinVersion, cfg.ServerName)
+			}
+			if cfg.VerifyConnection == nil {
+				if !tt.wantVerified {
+					t.Fatal("tlsConfig() does not check pins")
+				}
+				return
+			}
+			err = cfg.VerifyConnection(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}})
+			if tt.wantVerified && err != nil {
+				t.Errorf("VerifyConnection() error = %v, want pinned certificate accepted", err)
+			}
+			if !tt.wantVerified && !errors.Is(err, errCertNotPinned) {
+				t.Errorf("VerifyConnection() error = %v, want %v", err, errCertNotPinned)
+			}
+		})
+	}
+}
+
+func TestTLSConfig_CertPinsOverConnection(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // the rejected handshake is expected
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	otherSum := sha256.Sum256([]byte("another key"))
+
+	cfg, err := tlsConfig("example.com", []string{"sha256/" + base64.StdEncoding.EncodeToString(otherSum[:])})
+	if err != nil {
+		t.Fatalf("tlsConfig() error = %v", err)
+	}
+	cfg.RootCAs = roots
+	conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), cfg)
+	if err == nil {
+		_ = conn.Close()
+		t.Fatal("handshake with an unpinned certificate succeeded")
+	}
+	if !errors.Is(err, errCertNotPinned) {
+		t.Errorf("handshake error = %v, want %v", err, errCertNotPinned)
+	}
+}
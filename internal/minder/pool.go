@@ -0,0 +1,82 @@
+package minder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Pool shares one gRPC connection per Minder server between clients, so only
+// the first call to a server pays for the dial and TLS handshake. Each client
+// sends its own token with every RPC, so clients of different users can share
+// a connection. Pool is safe for concurrent use.
+type Pool struct {
+	mu     sync.Mutex
+	conns  map[string]*grpc.ClientConn
+	closed bool
+}
+
+// NewPool creates an empty connection pool.
+func NewPool() *Pool {
+	return &Pool{conns: make(map[string]*grpc.ClientConn)}
+}
+
+// Client returns a client for cfg over the pooled connection to its server,
+// dialing it on first use. The connection keeps the logger, interceptors and
+// retry settings of the first cfg for that server; only Token may differ
+// between calls. Closing the client leaves the connection open.
+func (p *Pool) Client(cfg ClientConfig) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, errors.New("minder connection pool is closed")
+	}
+
+	key := fmt.Sprintf("%s:%d/insecure=%t/pins=%s", cfg.Host, cfg.Port, cfg.Insecure, strings.Join(cfg.CertPins, ","))
+	conn, ok := p.conns[key]
+	if !ok {
+		var err error
+		if conn, err = dial(cfg); err != nil {
+			return nil, err
+		}
+		p.conns[key] = conn
+	}
+	return &Client{
+		conn:  tokenConn{conn: conn, creds: NewJWTTokenCredentials(cfg.Token, cfg.Insecure)},
+		close: func() error { return nil },
+	}, nil
+}
+
+// Close closes every pooled connection. Clients created earlier stop working.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	var errs []error
+	for key, conn := range p.conns {
+		errs = append(errs, conn.Close())
+		delete(p.conns, key)
+	}
+	return errors.Join(errs...)
+}
+
+// tokenConn adds a client's token to every RPC on a shared connection.
+type tokenConn struct {
+	conn  *grpc.ClientConn
+	creds credentials.PerRPCCredentials
+}
+
+func (c tokenConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.conn.Invoke(ctx, method, args, reply, append(opts, grpc.PerRPCCredentials(c.creds))...)
+}
+
+func (c tokenConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return c.conn.NewStream(ctx, desc, method, append(opts, grpc.PerRPCCredentials(c.creds))...)
+}
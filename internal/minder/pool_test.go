@@ -0,0 +1,109 @@
+package minder
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authRecorder answers health checks and records the authorization and
+// user-agent headers of each one.
+type authRecorder struct {
+	minderv1.UnimplementedHealthServiceServer
+	tokens     chan string
+	userAgents chan string
+}
+
+func (r *authRecorder) CheckHealth(ctx context.Context, _ *minderv1.CheckHealthRequest) (*minderv1.CheckHealthResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	r.tokens <- md.Get("authorization")[0]
+	r.userAgents <- md.Get("user-agent")[0]
+	return &minderv1.CheckHealthResponse{Status: "OK"}, nil
+}
+
+// startAuthRecorder serves an authRecorder on a local port.
+func startAuthRecorder(t *testing.T) (*authRecorder, int) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	rec := &authRecorder{tokens: make(chan string, 10), userAgents: make(chan string, 10)}
+	minderv1.RegisterHealthServiceServer(srv, rec)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return rec, lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestPoolSharesConnection(t *testing.T) {
+	t.Parallel()
+
+	rec, port := startAuthRecorder(t)
+	pool := NewPool()
+
+	alice, err := pool.Client(ClientConfig{Host: "127.0.0.1", Port: port, Insecure: true, Token: "alice"})
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	bob, err := pool.Client(ClientConfig{Host: "127.0.0.1", Port: port, Insecure: true, Token: "bob"})
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	if len(pool.conns) != 1 {
+		t.Errorf("pool has %d connections, want 1", len(pool.conns))
+	}
+
+	// Closing a pooled client must leave the connection usable by others.
+	if err := alice.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	for _, tc := range []struct {
+		client *Client
+		want   string
+	}{
+		{alice, "Bearer alice"},
+		{bob, "Bearer bob"},
+	} {
+		if _, err := tc.client.Health().CheckHealth(context.Background(), &minderv1.CheckHealthRequest{}); err != nil {
+			t.Fatalf("CheckHealth() error = %v", err)
+		}
+		if got := <-rec.tokens; got != tc.want {
+			t.Errorf("authorization = %q, want %q", got, tc.want)
+		}
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Errorf("pool.Close() error = %v", err)
+	}
+	if _, err := pool.Client(ClientConfig{Host: "127.0.0.1", Port: port, Insecure: true}); err == nil {
+		t.Error("Client() after Close() succeeded, want error")
+	}
+}
+
+func TestPoolUserAgent(t *testing.T) {
+	t.Parallel()
+
+	rec, port := startAuthRecorder(t)
+	pool := NewPool()
+	t.Cleanup(func() { _ = pool.Close() })
+
+	client, err := pool.Client(ClientConfig{
+		Host: "127.0.0.1", Port: port, Insecure: true, Token: "alice", UserAgent: "minder-mcp/v1.2.3",
+	})
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	if _, err := client.Health().CheckHealth(context.Background(), &minderv1.CheckHealthRequest{}); err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	// gRPC appends its own version to the configured user-agent
+	if got := <-rec.userAgents; !strings.HasPrefix(got, "minder-mcp/v1.2.3 ") {
+		t.Errorf("user-agent = %q, want it to start with minder-mcp/v1.2.3", got)
+	}
+}
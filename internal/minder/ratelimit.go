@@ -0,0 +1,99 @@
+package minder
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRetryDelay is the first wait before retrying a ResourceExhausted
+// error that does not say how long to wait. It doubles on each attempt.
+const defaultRetryDelay = time.Second
+
+// QuotaViolation describes a quota Minder reported as exceeded.
+type QuotaViolation struct {
+	Subject     string `json:"subject,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// RetryAfter returns how long Minder asked callers to wait before retrying
+// err, from its RetryInfo details. It returns zero when Minder gave no delay.
+func RetryAfter(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+// QuotaViolations returns the exceeded quotas listed in err's QuotaFailure details.
+func QuotaViolations(err error) []QuotaViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	var violations []QuotaViolation
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.QuotaFailure); ok {
+			for _, v := range failure.GetViolations() {
+				violations = append(violations, QuotaViolation{Subject: v.GetSubject(), Description: v.GetDescription()})
+			}
+		}
+	}
+	return violations
+}
+
+// rateLimitRetryInterceptor retries RPCs failing with ResourceExhausted up to
+// retries times, waiting as long as Minder asks or with exponential backoff
+// from defaultRetryDelay. It gives up early when a wait would exceed maxWait
+// or outlast the call's deadline.
+func rateLimitRetryInterceptor(retries int, maxWait time.Duration, logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		backoff := defaultRetryDelay
+		for attempt := 0; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.ResourceExhausted || attempt >= retries {
+				return err
+			}
+
+			wait := RetryAfter(err)
+			if wait <= 0 {
+				wait = backoff
+				backoff *= 2
+			}
+			if wait > maxWait {
+				return err
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return err
+			}
+
+			logger.DebugContext(ctx, "retrying rate-limited minder rpc",
+				"method", method, "attempt", attempt+1, "wait", wait)
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
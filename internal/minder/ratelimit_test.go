@@ -0,0 +1,135 @@
+package minder
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// rateLimited returns a ResourceExhausted error asking callers to wait.
+func rateLimited(t *testing.T, wait time.Duration) error {
+	t.Helper()
+	st, err := status.New(codes.ResourceExhausted, "too many requests").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "project:p1", Description: "100 requests per minute"},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("adding status details: %v", err)
+	}
+	return st.Err()
+}
+
+func TestRetryAfterAndQuotaViolations(t *testing.T) {
+	t.Parallel()
+
+	err := rateLimited(t, 5*time.Second)
+	if got := RetryAfter(err); got != 5*time.Second {
+		t.Errorf("RetryAfter() = %v, want 5s", got)
+	}
+	want := QuotaViolation{Subject: "project:p1", Description: "100 requests per minute"}
+	if got := QuotaViolations(err); len(got) != 1 || got[0] != want {
+		t.Errorf("QuotaViolations() = %v, want [%v]", got, want)
+	}
+
+	plain := status.Error(codes.ResourceExhausted, "too many requests")
+	if got := RetryAfter(plain); got != 0 {
+		t.Errorf("RetryAfter() without details = %v, want 0", got)
+	}
+	if got := QuotaViolations(plain); got != nil {
+		t.Errorf("QuotaViolations() without details = %v, want nil", got)
+	}
+}
+
+func TestRateLimitRetryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		retries   int
+		maxWait   time.Duration
+		wait      time.Duration
+		timeout   time.Duration
+		failures  int
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "succeeds after retrying",
+			retries:   3,
+			maxWait:   time.Second,
+			wait:      time.Millisecond,
+			failures:  2,
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "gives up after the retries",
+			retries:   2,
+			maxWait:   time.Second,
+			wait:      time.Millisecond,
+			failures:  5,
+			wantCalls: 3,
+			wantCode:  codes.ResourceExhausted,
+		},
+		{
+			name:      "does not wait longer than max wait",
+			retries:   3,
+			maxWait:   time.Millisecond,
+			wait:      time.Minute,
+			failures:  1,
+			wantCalls: 1,
+			wantCode:  codes.ResourceExhausted,
+		},
+		{
+			name:      "does not wait past the deadline",
+			retries:   3,
+			maxWait:   time.Hour,
+			wait:      time.Minute,
+			timeout:   time.Second,
+			failures:  1,
+			wantCalls: 1,
+			wantCode:  codes.ResourceExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			interceptor := rateLimitRetryInterceptor(tt.retries, tt.maxWait, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			err := interceptor(ctx, "/minder.v1.Test/Method", nil, nil, nil,
+				func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					calls++
+					if calls <= tt.failures {
+						return rateLimited(t, tt.wait)
+					}
+					return nil
+				})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package minder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/store"
+)
+
+const (
+	// realmCacheVersion is the format of the realm cache file. Files of another
+	// version are ignored.
+	realmCacheVersion = 1
+
+	// realmCacheMaxAge is how long a persisted realm is trusted before it is
+	// discovered again.
+	realmCacheMaxAge = 30 * 24 * time.Hour
+
+	// realmBucket is the embedded store bucket realms are persisted in.
+	realmBucket = "realms"
+	// realmStoreMaxEntries bounds the persisted realms, one per Minder server.
+	realmStoreMaxEntries = 256
+)
+
+// realmEntry is a discovered realm and the token endpoint derived from it.
+type realmEntry struct {
+	RealmURL      string `json:"realm_url"`
+	TokenEndpoint string `json:"token_endpoint"`
+	// DiscoveredAt is when the realm was read from the server.
+	DiscoveredAt time.Time `json:"discovered_at"`
+	// RefreshedAt is when a token was last refreshed against the endpoint.
+	// Zero until the endpoint has worked once.
+	RefreshedAt time.Time `json:"refreshed_at,omitzero"`
+
+	// loaded marks entries read from the cache file rather than discovered
+	// by this process; they are discovered again if a refresh fails.
+	loaded bool
+}
+
+// realmCacheFile is the on-disk form of the realm cache.
+type realmCacheFile struct {
+	Version int `json:"version"`
+	// Realms is keyed by the Minder server's host:port.
+	Realms map[string]*realmEntry `json:"realms"`
+}
+
+// EnableRealmCache persists discovered realm URLs and their token endpoints in
+// the file at path, so a restarted server can refresh tokens without first
+// asking each Minder server for its realm. Entries already in the file are
+// loaded; entries that fail validation or are older than 30 days are
+// dropped. A missing file is not an error. Write failures are logged to logger.
+func (t *TokenRefresher) EnableRealmCache(path string, logger *slog.Logger) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.realmCachePath = path
+	t.logger = logger
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read realm cache: %w", err)
+	}
+	var file realmCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse realm cache %s: %w", path, err)
+	}
+	if file.Version != realmCacheVersion {
+		return nil
+	}
+	for key, entry := range file.Realms {
+		if err := t.validRealmEntry(key, entry, time.Now()); err != nil {
+			logger.Debug("dropping cached realm", "server", key, "error", err)
+			continue
+		}
+		entry.loaded = true
+		t.realms[key] = entry
+	}
+	return nil
+}
+
+// validRealmEntry checks a persisted realm as if it had just been discovered
+// from the server at key, and that it is recent enough to trust.
+func (t *TokenRefresher) validRealmEntry(key string, entry *realmEntry, now time.Time) error {
+	if entry == nil {
+		return errors.New("empty entry")
+	}
+	i := strings.LastIndex(key, ":")
+	if i <= 0 {
+		return fmt.Errorf("invalid server %q", key)
+	}
+	if _, err := strconv.Atoi(key[i+1:]); err != nil {
+		return fmt.Errorf("invalid server %q", key)
+	}
+	if now.Sub(entry.DiscoveredAt) > realmCacheMaxAge || entry.DiscoveredAt.After(now) {
+		return fmt.Errorf("discovered at %s, outside the %s cache lifetime", entry.DiscoveredAt, realmCacheMaxAge)
+	}
+	// Whether the server may use a plaintext realm is checked again when the realm is used
+	if err := t.validateRealmURL(entry.RealmURL, key[:i], true); err != nil {
+		return err
+	}
+	endpoint, err := tokenEndpointFor(entry.RealmURL)
+	if err != nil || endpoint != entry.TokenEndpoint {
+		return fmt.Errorf("token endpoint %q does not belong to realm %q", entry.TokenEndpoint, entry.RealmURL)
+	}
+	return nil
+}
+
+// EnableRealmStore persists discovered realms in the embedded store st
+// instead of a cache file, with the same validation and 30-day lifetime as
+// EnableRealmCache. Write failures are logged to logger.
+func (t *TokenRefresher) EnableRealmStore(st *store.Store, logger *slog.Logger) error {
+	bucket, err := st.Bucket(realmBucket, store.Limits{MaxEntries: realmStoreMaxEntries, TTL: realmCacheMaxAge})
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.realmStore = bucket
+	t.logger = logger
+	return bucket.ForEach(func(key string, value json.RawMessage) error {
+		var entry realmEntry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			logger.Debug("dropping cached realm", "server", key, "error", err)
+			return nil
+		}
+		if err := t.validRealmEntry(key, &entry, time.Now()); err != nil {
+			logger.Debug("dropping cached realm", "server", key, "error", err)
+			return nil
+		}
+		entry.loaded = true
+		t.realms[key] = &entry
+		return nil
+	})
+}
+
+// saveRealmCache writes the realm cache file or store, if enabled. The caller holds t.mu.
+func (t *TokenRefresher) saveRealmCache() {
+	if t.realmStore != nil {
+		if err := t.saveRealmStore(); err != nil {
+			t.logger.Warn("failed to persist realms", "error", err)
+		}
+		return
+	}
+	if t.realmCachePath == "" {
+		return
+	}
+	if err := writeRealmCache(t.realmCachePath, realmCacheFile{Version: realmCacheVersion, Realms: t.realms}); err != nil {
+		t.logger.Warn("failed to write realm cache", "path", t.realmCachePath, "error", err)
+	}
+}
+
+// saveRealmStore replaces the realms in the store with t.realms. The caller holds t.mu.
+func (t *TokenRefresher) saveRealmStore() error {
+	var stale []string
+	err := t.realmStore.ForEach(func(key string, _ json.RawMessage) error {
+		if t.realms[key] == nil {
+			stale = append(stale, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range stale {
+		if err := t.realmStore.Delete(key); err != nil {
+			return err
+		}
+	}
+	for key, entry := range t.realms {
+		if err := t.realmStore.Put(key, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRealmCache atomically replaces the file at path with the cache,
+// readable only by the current user.
+func writeRealmCache(path string, file realmCacheFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
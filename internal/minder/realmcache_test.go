@@ -0,0 +1,206 @@
+package minder
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/stacklok/minder-mcp/internal/store"
+)
+
+const testRealmURL = "http://localhost:8081/realms/test"
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestRealmCache_SurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	minderv1.RegisterUserServiceServer(srv, &mockUserService{realmURL: testRealmURL})
+	go func() { _ = srv.Serve(lis) }()
+	tcpAddr, ok := lis.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	cfg := ServerConfig{Host: "localhost", Port: tcpAddr.Port, Insecure: true}
+
+	path := filepath.Join(t.TempDir(), "realms.json")
+	first := NewTokenRefresher()
+	defer first.Close()
+	require.NoError(t, first.EnableRealmCache(path, discardLogger()))
+	got, err := first.RealmURL(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, testRealmURL, got)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// With the server gone, only the cache can answer
+	srv.Stop()
+	second := NewTokenRefresher()
+	defer second.Close()
+	require.NoError(t, second.EnableRealmCache(path, discardLogger()))
+	got, err = second.RealmURL(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, testRealmURL, got)
+}
+
+func TestRealmStore_SurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	minderv1.RegisterUserServiceServer(srv, &mockUserService{realmURL: testRealmURL})
+	go func() { _ = srv.Serve(lis) }()
+	tcpAddr, ok := lis.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	cfg := ServerConfig{Host: "localhost", Port: tcpAddr.Port, Insecure: true}
+
+	path := filepath.Join(t.TempDir(), "state.db")
+	st, err := store.Open(path, 0, discardLogger())
+	require.NoError(t, err)
+	first := NewTokenRefresher()
+	defer first.Close()
+	require.NoError(t, first.EnableRealmStore(st, discardLogger()))
+	got, err := first.RealmURL(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, testRealmURL, got)
+	require.NoError(t, st.Close())
+
+	// With the server gone, only the store can answer
+	srv.Stop()
+	st, err = store.Open(path, 0, discardLogger())
+	require.NoError(t, err)
+	defer func() { _ = st.Close() }()
+	second := NewTokenRefresher()
+	defer second.Close()
+	require.NoError(t, second.EnableRealmStore(st, discardLogger()))
+	got, err = second.RealmURL(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, testRealmURL, got)
+}
+
+func TestEnableRealmCache_Validation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	endpoint := testRealmURL + "/protocol/openid-connect/token"
+	entry := func(realm, endpoint string, discovered time.Time) *realmEntry {
+		return &realmEntry{RealmURL: realm, TokenEndpoint: endpoint, DiscoveredAt: discovered}
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		entry   *realmEntry
+		wantHit bool
+	}{
+		{name: "valid", key: "localhost:8080", entry: entry(testRealmURL, endpoint, now.Add(-time.Hour)), wantHit: true},
+		{
+			name: "untrusted realm",
+			key:  "api.stacklok.com:443",
+			entry: entry("https://auth.example.com/realms/test",
+				"https://auth.example.com/realms/test/protocol/openid-connect/token", now),
+		},
+		{name: "mismatched endpoint", key: "localhost:8080", entry: entry(testRealmURL, "http://localhost:9999/token", now)},
+		{name: "expired", key: "localhost:8080", entry: entry(testRealmURL, endpoint, now.Add(-realmCacheMaxAge-time.Hour))},
+		{name: "discovered in the future", key: "localhost:8080", entry: entry(testRealmURL, endpoint, now.Add(time.Hour))},
+		{name: "key without port", key: "localhost", entry: entry(testRealmURL, endpoint, now)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "realms.json")
+			data, err := json.Marshal(realmCacheFile{Version: realmCacheVersion, Realms: map[string]*realmEntry{tt.key: tt.entry}})
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(path, data, 0o600))
+
+			refresher := NewTokenRefresher()
+			defer refresher.Close()
+			require.NoError(t, refresher.EnableRealmCache(path, discardLogger()))
+			_, ok := refresher.realms[tt.key]
+			assert.Equal(t, tt.wantHit, ok)
+		})
+	}
+}
+
+func TestEnableRealmCache_Files(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0o600))
+	otherVersion := filepath.Join(dir, "v2.json")
+	require.NoError(t, os.WriteFile(otherVersion, []byte(`{"version": 2, "realms": {"localhost:1": {}}}`), 0o600))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.json")},
+		{name: "corrupt file", path: corrupt, wantErr: true},
+		{name: "other version", path: otherVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refresher := NewTokenRefresher()
+			defer refresher.Close()
+			err := refresher.EnableRealmCache(tt.path, discardLogger())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, refresher.realms)
+		})
+	}
+}
+
+func TestForgetLoadedRealm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		loaded     bool
+		err        error
+		wantForget bool
+	}{
+		{name: "loaded realm, endpoint failure", loaded: true, err: ErrRefreshFailed, wantForget: true},
+		{name: "loaded realm, revoked token", loaded: true, err: ErrReauthenticationRequired},
+		{name: "discovered realm", err: ErrRefreshFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refresher := NewTokenRefresher()
+			defer refresher.Close()
+			cfg := ServerConfig{Host: "localhost", Port: 8080}
+			refresher.realms[realmKey(cfg)] = &realmEntry{RealmURL: testRealmURL, loaded: tt.loaded}
+
+			refresher.forgetLoadedRealm(cfg, tt.err)
+			_, ok := refresher.realms[realmKey(cfg)]
+			assert.Equal(t, tt.wantForget, !ok)
+		})
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -24,6 +26,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/callmeta"
+	"github.com/stacklok/minder-mcp/internal/metrics"
+	"github.com/stacklok/minder-mcp/internal/store"
 )
 
 const (
@@ -34,6 +40,10 @@ const (
 	// Using 60 seconds to account for network latency, clock skew, and multi-step operations.
 	tokenRefreshBuffer = 60 * time.Second
 
+	// tokenCacheName and realmCacheName label cache metrics.
+	tokenCacheName = "access_token"
+	realmCacheName = "realm_url"
+
 	// offlineTokenType is the Keycloak-specific token type claim for offline/refresh tokens.
 	offlineTokenType = "Offline"
 
@@ -42,6 +52,14 @@ const (
 
 	// maxWWWAuthHeaderLen is the maximum length of WWW-Authenticate header to parse.
 	maxWWWAuthHeaderLen = 2048
+
+	// maxCachedTokens bounds the access token cache, which holds one entry per
+	// refresh token and so grows with the number of users of a shared server.
+	maxCachedTokens = 1024
+
+	// maxTokenIdle is how long a cached access token may go unused before it
+	// is dropped, so the cache holds only the tokens of recent users.
+	maxTokenIdle = 30 * time.Minute
 )
 
 // Sentinel errors for programmatic error handling.
@@ -63,6 +81,10 @@ var (
 
 	// ErrInvalidRealmURL indicates the discovered realm URL is invalid or untrusted.
 	ErrInvalidRealmURL = errors.New("invalid or untrusted realm URL")
+
+	// ErrReauthenticationRequired indicates the refresh token is expired or
+	// revoked, so the user must sign in again for a new one.
+	ErrReauthenticationRequired = errors.New("re-authentication required")
 )
 
 // ServerConfig holds server connection configuration for token operations.
@@ -70,12 +92,17 @@ type ServerConfig struct {
 	Host     string
 	Port     int
 	Insecure bool
+	// CertPins restrict the certificates the server may present; see tlsConfig.
+	CertPins []string
 }
 
 // cachedToken holds a cached access token with its expiry time.
 type cachedToken struct {
 	accessToken string
 	expiresAt   time.Time
+	// lastUsed is when the token was last returned, in Unix nanoseconds. It
+	// is updated under the read lock, hence atomic.
+	lastUsed atomic.Int64
 }
 
 // TokenRefresher handles token validation and refresh.
@@ -85,9 +112,19 @@ type TokenRefresher struct {
 	clientID   string
 
 	// mu protects cached token state
-	mu        sync.RWMutex
-	cache     map[string]*cachedToken // keyed by refresh token hash
-	realmURLs map[string]string       // keyed by host:port, cached realm URLs
+	mu     sync.RWMutex
+	cache  map[string]*cachedToken // keyed by refresh token hash
+	realms map[string]*realmEntry  // keyed by host:port, cached realm URLs
+	// refreshes is the outcome of the latest token refreshes, keyed by host:port
+	refreshes map[string]*RefreshStatus
+
+	// realmCachePath persists realms across restarts when set; see EnableRealmCache.
+	realmCachePath string
+	// realmStore persists realms in the embedded store instead; see EnableRealmStore.
+	realmStore *store.Bucket
+	logger     *slog.Logger
+	// userAgent identifies this server to Minder during realm discovery; see SetUserAgent.
+	userAgent string
 }
 
 // NewTokenRefresher creates a new TokenRefresher.
@@ -112,7 +149,9 @@ func NewTokenRefresher() *TokenRefresher {
 		},
 		clientID:  DefaultClientID,
 		cache:     make(map[string]*cachedToken),
-		realmURLs: make(map[string]string),
+		realms:    make(map[string]*realmEntry),
+		refreshes: make(map[string]*RefreshStatus),
+		logger:    slog.Default(),
 	}
 }
 
@@ -168,6 +207,13 @@ func (t *TokenRefresher) GetValidAccessToken(
 	return token, nil
 }
 
+// observeCache records a cache lookup in the cache metrics and in the
+// metadata of the tool call in ctx.
+func observeCache(ctx context.Context, cache string, hit bool) {
+	metrics.ObserveCache(cache, hit)
+	callmeta.ObserveCache(ctx, cache, hit)
+}
+
 // getOrRefreshToken returns a cached access token or refreshes if needed.
 func (t *TokenRefresher) getOrRefreshToken(
 	ctx context.Context,
@@ -183,7 +229,9 @@ func (t *TokenRefresher) getOrRefreshToken(
 	if cached, ok := t.cache[cacheKey]; ok {
 		// Check if cached token is still valid (with buffer)
 		if time.Now().Add(tokenRefreshBuffer).Before(cached.expiresAt) {
+			cached.lastUsed.Store(time.Now().UnixNano())
 			t.mu.RUnlock()
+			observeCache(ctx, tokenCacheName, true)
 			return cached.accessToken, nil
 		}
 	}
@@ -196,23 +244,103 @@ func (t *TokenRefresher) getOrRefreshToken(
 	// Double-check cache after acquiring write lock (another goroutine may have refreshed)
 	if cached, ok := t.cache[cacheKey]; ok {
 		if time.Now().Add(tokenRefreshBuffer).Before(cached.expiresAt) {
+			cached.lastUsed.Store(time.Now().UnixNano())
+			observeCache(ctx, tokenCacheName, true)
 			return cached.accessToken, nil
 		}
 	}
+	observeCache(ctx, tokenCacheName, false)
 
 	// Perform the refresh
 	accessToken, expiresAt, err := t.refreshToken(ctx, refreshToken, cfg)
+	t.recordRefresh(cfg, err, time.Now())
 	if err != nil {
+		metrics.TokenRefreshes.WithLabelValues("failure").Inc()
 		return "", err
 	}
+	metrics.TokenRefreshes.WithLabelValues("success").Inc()
 
-	// Cache the new token
-	t.cache[cacheKey] = &cachedToken{
-		accessToken: accessToken,
-		expiresAt:   expiresAt,
+	t.cacheToken(cacheKey, accessToken, expiresAt, time.Now())
+	return accessToken, nil
+}
+
+// Forget drops the access token cached for refreshToken, so the next call
+// with it refreshes again. It reports whether a token was cached.
+func (t *TokenRefresher) Forget(refreshToken string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := hashToken(refreshToken)
+	_, ok := t.cache[key]
+	delete(t.cache, key)
+	return ok
+}
+
+// cacheToken stores an access token under key. Entries that expired or went
+// unused for maxTokenIdle are dropped first and, if the cache is still full,
+// the least recently used one. The caller holds t.mu.
+func (t *TokenRefresher) cacheToken(key, accessToken string, expiresAt, now time.Time) {
+	idleSince := now.Add(-maxTokenIdle).UnixNano()
+	var oldestKey string
+	var oldest int64
+	for k, cached := range t.cache {
+		used := cached.lastUsed.Load()
+		if !now.Before(cached.expiresAt) || used < idleSince {
+			delete(t.cache, k)
+			continue
+		}
+		if oldestKey == "" || used < oldest {
+			oldestKey, oldest = k, used
+		}
 	}
+	if _, ok := t.cache[key]; !ok && len(t.cache) >= maxCachedTokens {
+		delete(t.cache, oldestKey)
+	}
+	cached := &cachedToken{accessToken: accessToken, expiresAt: expiresAt}
+	cached.lastUsed.Store(now.UnixNano())
+	t.cache[key] = cached
+}
 
-	return accessToken, nil
+// RealmURL returns the validated identity provider realm URL advertised by the
+// server, discovering it if it is not already cached.
+func (t *TokenRefresher) RealmURL(ctx context.Context, cfg ServerConfig) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	realmURL, err := t.getRealmURL(ctx, cfg)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRealmDiscoveryFailed, err)
+	}
+	if err := t.validateRealmURL(realmURL, cfg.Host, cfg.Insecure); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRealmURL, err)
+	}
+	return realmURL, nil
+}
+
+// TokenInfo describes a token from its unverified claims.
+type TokenInfo struct {
+	// Offline is set for offline/refresh tokens, which are exchanged for access tokens.
+	Offline bool
+	// ExpiresAt is zero when the token has no expiry claim.
+	ExpiresAt time.Time
+}
+
+// ParseTokenInfo reads a token's type and expiry without verifying it.
+func ParseTokenInfo(token string) (TokenInfo, error) {
+	if token == "" {
+		return TokenInfo{}, ErrNoToken
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return TokenInfo{}, fmt.Errorf("%w: %v", ErrTokenMalformed, err)
+	}
+	var info TokenInfo
+	if typ, ok := claims["typ"].(string); ok && typ == offlineTokenType {
+		info.Offline = true
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		info.ExpiresAt = exp.Time
+	}
+	return info, nil
 }
 
 // refreshToken uses a refresh token to obtain a new access token.
@@ -228,12 +356,12 @@ func (t *TokenRefresher) refreshToken(
 	}
 
 	// Validate the realm URL for security (SSRF protection)
-	if err := t.validateRealmURL(realmURL, cfg.Host); err != nil {
+	if err := t.validateRealmURL(realmURL, cfg.Host, cfg.Insecure); err != nil {
 		return "", time.Time{}, fmt.Errorf("%w: %v", ErrInvalidRealmURL, err)
 	}
 
 	// Build token endpoint URL
-	tokenEndpoint, err := url.JoinPath(realmURL, "protocol/openid-connect/token")
+	tokenEndpoint, err := tokenEndpointFor(realmURL)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("%w: failed to build token endpoint: %v", ErrRefreshFailed, err)
 	}
@@ -258,12 +386,46 @@ func (t *TokenRefresher) refreshToken(
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
-		return "", time.Time{}, t.wrapOAuthError(err)
+		err = t.wrapOAuthError(err)
+		t.forgetLoadedRealm(cfg, err)
+		return "", time.Time{}, err
 	}
+	t.markRealmRefreshed(cfg)
 
 	return newToken.AccessToken, newToken.Expiry, nil
 }
 
+// tokenEndpointFor returns the OpenID Connect token endpoint of a realm.
+func tokenEndpointFor(realmURL string) (string, error) {
+	return url.JoinPath(realmURL, "protocol/openid-connect/token")
+}
+
+// markRealmRefreshed records that a token refresh succeeded against the
+// server's realm. The caller holds t.mu.
+func (t *TokenRefresher) markRealmRefreshed(cfg ServerConfig) {
+	if entry, ok := t.realms[realmKey(cfg)]; ok {
+		entry.RefreshedAt = time.Now().UTC()
+		t.saveRealmCache()
+	}
+}
+
+// forgetLoadedRealm drops a realm loaded from the cache file after a refresh
+// against it failed, so the next refresh discovers the realm again in case
+// the server moved it. A revoked refresh token says nothing about the realm.
+// The caller holds t.mu.
+func (t *TokenRefresher) forgetLoadedRealm(cfg ServerConfig, err error) {
+	key := realmKey(cfg)
+	if entry, ok := t.realms[key]; ok && entry.loaded && !errors.Is(err, ErrReauthenticationRequired) {
+		delete(t.realms, key)
+		t.saveRealmCache()
+	}
+}
+
+// realmKey returns the realm cache key of a server.
+func realmKey(cfg ServerConfig) string {
+	return fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+}
+
 // wrapOAuthError wraps OAuth errors with more specific error types.
 func (*TokenRefresher) wrapOAuthError(err error) error {
 	if err == nil {
@@ -275,8 +437,8 @@ func (*TokenRefresher) wrapOAuthError(err error) error {
 	// Check for specific OAuth error codes
 	if strings.Contains(errStr, "invalid_grant") {
 		return fmt.Errorf(
-			"%w: refresh token is expired or revoked; re-authentication required",
-			ErrRefreshFailed,
+			"%w: refresh token is expired or revoked; %w",
+			ErrRefreshFailed, ErrReauthenticationRequired,
 		)
 	}
 	if strings.Contains(errStr, "invalid_client") {
@@ -298,7 +460,10 @@ func hashToken(token string) string {
 
 // validateRealmURL validates that the discovered realm URL is trusted.
 // This prevents SSRF attacks where a malicious server could redirect token requests.
-func (*TokenRefresher) validateRealmURL(realmURL string, expectedHost string) error {
+// A plain http realm is only accepted on localhost, and only when allowHTTP
+// is set because the Minder server itself was explicitly configured insecure,
+// so a server reached over TLS can never send tokens to a plaintext realm.
+func (*TokenRefresher) validateRealmURL(realmURL string, expectedHost string, allowHTTP bool) error {
 	parsedRealm, err := url.Parse(realmURL)
 	if err != nil {
 		return fmt.Errorf("invalid URL format: %w", err)
@@ -310,6 +475,9 @@ func (*TokenRefresher) validateRealmURL(realmURL string, expectedHost string) er
 		if !isLocalhostHost(host) {
 			return fmt.Errorf("http scheme only allowed for localhost, got host: %s", host)
 		}
+		if !allowHTTP {
+			return errors.New("http scheme only allowed for servers configured as insecure")
+		}
 	} else if parsedRealm.Scheme != "https" {
 		return fmt.Errorf("invalid scheme: %s (https required)", parsedRealm.Scheme)
 	}
@@ -414,12 +582,14 @@ func getBaseDomain(host string) string {
 
 // getRealmURL returns a cached realm URL or discovers it from the server.
 func (t *TokenRefresher) getRealmURL(ctx context.Context, cfg ServerConfig) (string, error) {
-	cacheKey := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	cacheKey := realmKey(cfg)
 
 	// Check cache first (already holding write lock from caller)
-	if realmURL, ok := t.realmURLs[cacheKey]; ok {
-		return realmURL, nil
+	if entry, ok := t.realms[cacheKey]; ok {
+		observeCache(ctx, realmCacheName, true)
+		return entry.RealmURL, nil
 	}
+	observeCache(ctx, realmCacheName, false)
 
 	// Discover the realm URL
 	realmURL, err := t.discoverRealmURL(ctx, cfg)
@@ -427,14 +597,32 @@ func (t *TokenRefresher) getRealmURL(ctx context.Context, cfg ServerConfig) (str
 		return "", err
 	}
 
-	// Cache it
-	t.realmURLs[cacheKey] = realmURL
+	// Cache it. Only realms that pass validation are persisted, since the
+	// others are never used and would be dropped on load.
+	tokenEndpoint, err := tokenEndpointFor(realmURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to build token endpoint: %w", err)
+	}
+	t.realms[cacheKey] = &realmEntry{
+		RealmURL:      realmURL,
+		TokenEndpoint: tokenEndpoint,
+		DiscoveredAt:  time.Now().UTC(),
+	}
+	if t.validateRealmURL(realmURL, cfg.Host, cfg.Insecure) == nil {
+		t.saveRealmCache()
+	}
 
 	return realmURL, nil
 }
 
+// SetUserAgent sets the user-agent sent to Minder servers when discovering
+// their realm. Call it before the refresher is used.
+func (t *TokenRefresher) SetUserAgent(userAgent string) {
+	t.userAgent = userAgent
+}
+
 // discoverRealmURL discovers the Keycloak realm URL from the server's www-authenticate gRPC metadata.
-func (*TokenRefresher) discoverRealmURL(ctx context.Context, cfg ServerConfig) (string, error) {
+func (t *TokenRefresher) discoverRealmURL(ctx context.Context, cfg ServerConfig) (string, error) {
 	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
 	// Set up dial options (TLS or insecure)
@@ -442,10 +630,14 @@ func (*TokenRefresher) discoverRealmURL(ctx context.Context, cfg ServerConfig) (
 	if cfg.Insecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-			MinVersion: tls.VersionTLS13,
-			ServerName: cfg.Host,
-		})))
+		tlsCfg, err := tlsConfig(cfg.Host, cfg.CertPins)
+		if err != nil {
+			return "", fmt.Errorf("failed to connect: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	if t.userAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(t.userAgent))
 	}
 
 	// Create unauthenticated connection
@@ -50,8 +50,8 @@ func TestNewTokenRefresher(t *testing.T) {
 	if refresher.cache == nil {
 		t.Error("cache is nil")
 	}
-	if refresher.realmURLs == nil {
-		t.Error("realmURLs is nil")
+	if refresher.realms == nil {
+		t.Error("realms is nil")
 	}
 }
 
@@ -63,6 +63,79 @@ func TestTokenRefresher_Close(t *testing.T) {
 	refresher.Close()
 }
 
+func TestForget(t *testing.T) {
+	t.Parallel()
+
+	refresher := NewTokenRefresher()
+	refresher.cacheToken(hashToken("offline-token"), "access", time.Now().Add(time.Hour), time.Now())
+
+	if !refresher.Forget("offline-token") {
+		t.Error("Forget() = false, want true for a cached token")
+	}
+	if _, ok := refresher.cache[hashToken("offline-token")]; ok {
+		t.Error("token is still cached after Forget()")
+	}
+	if refresher.Forget("offline-token") {
+		t.Error("Forget() = true, want false for a token no longer cached")
+	}
+}
+
+func TestCacheToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		expired     int
+		idle        int
+		wantEvicted string
+	}{
+		{name: "evicts least recently used when full", wantEvicted: "key-1"},
+		{name: "drops expired entries before evicting", expired: 2},
+		{name: "drops idle entries before evicting", idle: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			refresher := NewTokenRefresher()
+			for i := range maxCachedTokens {
+				expiresAt := now.Add(time.Hour)
+				if i < tt.expired {
+					expiresAt = now.Add(-time.Minute)
+				}
+				refresher.cacheToken(fmt.Sprintf("key-%d", i), "token", expiresAt, now.Add(time.Duration(i)*time.Millisecond))
+			}
+			for i := range tt.idle {
+				refresher.cache[fmt.Sprintf("key-%d", maxCachedTokens-1-i)].lastUsed.Store(now.Add(-maxTokenIdle).UnixNano())
+			}
+			// key-0 was used after key-1, so key-1 is the least recently used.
+			// Expired entries were already dropped by the later inserts.
+			if cached, ok := refresher.cache["key-0"]; ok {
+				cached.lastUsed.Store(now.Add(time.Second).UnixNano())
+			}
+
+			refresher.cacheToken("new", "token", now.Add(time.Hour), now.Add(time.Minute))
+
+			if _, ok := refresher.cache["new"]; !ok {
+				t.Error("new token was not cached")
+			}
+			if want := maxCachedTokens - tt.expired - tt.idle + 1; tt.expired+tt.idle > 0 && len(refresher.cache) != want {
+				t.Errorf("cache has %d entries, want %d", len(refresher.cache), want)
+			}
+			if tt.wantEvicted != "" {
+				if len(refresher.cache) != maxCachedTokens {
+					t.Errorf("cache has %d entries, want %d", len(refresher.cache), maxCachedTokens)
+				}
+				if _, ok := refresher.cache[tt.wantEvicted]; ok {
+					t.Errorf("%s is still cached, want it evicted", tt.wantEvicted)
+				}
+			}
+		})
+	}
+}
+
 func TestGetValidAccessToken_EmptyToken(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +210,52 @@ func TestGetValidAccessToken_ExpiredAccessToken(t *testing.T) {
 	}
 }
 
+func TestParseTokenInfo(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    TokenInfo
+		wantErr error
+	}{
+		{
+			name:  "access token",
+			token: createTestJWT(t, map[string]interface{}{"typ": "Bearer", "exp": exp.Unix()}),
+			want:  TokenInfo{ExpiresAt: exp},
+		},
+		{
+			name:  "offline token without expiry",
+			token: createTestJWT(t, map[string]interface{}{"typ": "Offline"}),
+			want:  TokenInfo{Offline: true},
+		},
+		{name: "empty", token: "", wantErr: ErrNoToken},
+		{name: "malformed", token: "not-a-jwt", wantErr: ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTokenInfo(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseTokenInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTokenInfo() returned error: %v", err)
+			}
+			if got.Offline != tt.want.Offline || !got.ExpiresAt.Equal(tt.want.ExpiresAt) {
+				t.Errorf("ParseTokenInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExtractRealmFromWWWAuthenticate(t *testing.T) {
 	t.Parallel()
 
@@ -266,6 +385,65 @@ func TestDiscoverRealmURL_NoHeader(t *testing.T) {
 	require.Contains(t, err.Error(), "www-authenticate")
 }
 
+func TestRealmURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		realmURL string
+		wantErr  error
+	}{
+		{
+			name:     "trusted realm",
+			realmURL: "http://localhost:8081/realms/test",
+		},
+		{
+			name:     "untrusted realm",
+			realmURL: "https://auth.example.com/realms/test",
+			wantErr:  ErrInvalidRealmURL,
+		},
+		{
+			name:     "no header",
+			realmURL: "",
+			wantErr:  ErrRealmDiscoveryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lis, err := net.Listen("tcp", "localhost:0")
+			require.NoError(t, err)
+
+			srv := grpc.NewServer()
+			minderv1.RegisterUserServiceServer(srv, &mockUserService{realmURL: tt.realmURL})
+			go func() {
+				_ = srv.Serve(lis)
+			}()
+			defer srv.Stop()
+
+			tcpAddr, ok := lis.Addr().(*net.TCPAddr)
+			require.True(t, ok)
+
+			refresher := NewTokenRefresher()
+			defer refresher.Close()
+
+			got, err := refresher.RealmURL(context.Background(), ServerConfig{
+				Host:     "localhost",
+				Port:     tcpAddr.Port,
+				Insecure: true,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.realmURL, got)
+		})
+	}
+}
+
 func TestValidateRealmURL(t *testing.T) {
 	t.Parallel()
 
@@ -276,6 +454,7 @@ func TestValidateRealmURL(t *testing.T) {
 		name         string
 		realmURL     string
 		expectedHost string
+		insecure     bool
 		wantError    bool
 	}{
 		{
@@ -288,18 +467,27 @@ func TestValidateRealmURL(t *testing.T) {
 			name:         "valid http URL for localhost",
 			realmURL:     "http://localhost:8080/realms/test",
 			expectedHost: "localhost",
+			insecure:     true,
 			wantError:    false,
 		},
+		{
+			name:         "http URL for localhost rejected for a server reached over TLS",
+			realmURL:     "http://localhost:8080/realms/test",
+			expectedHost: "localhost",
+			wantError:    true,
+		},
 		{
 			name:         "valid http URL for 127.0.0.1",
 			realmURL:     "http://127.0.0.1:8080/realms/test",
 			expectedHost: "127.0.0.1",
+			insecure:     true,
 			wantError:    false,
 		},
 		{
 			name:         "http not allowed for non-localhost",
 			realmURL:     "http://auth.example.com/realms/test",
 			expectedHost: "api.example.com",
+			insecure:     true,
 			wantError:    true,
 		},
 		{
@@ -349,7 +537,7 @@ func TestValidateRealmURL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			err := refresher.validateRealmURL(tt.realmURL, tt.expectedHost)
+			err := refresher.validateRealmURL(tt.realmURL, tt.expectedHost, tt.insecure)
 			if tt.wantError && err == nil {
 				t.Error("expected error but got none")
 			}
@@ -370,6 +558,7 @@ func TestWrapOAuthError(t *testing.T) {
 		name        string
 		err         error
 		wantContain string
+		wantReauth  bool
 	}{
 		{
 			name:        "nil error",
@@ -380,6 +569,7 @@ func TestWrapOAuthError(t *testing.T) {
 			name:        "invalid_grant error",
 			err:         fmt.Errorf("oauth2: cannot fetch token: invalid_grant"),
 			wantContain: "expired or revoked",
+			wantReauth:  true,
 		},
 		{
 			name:        "invalid_client error",
@@ -414,6 +604,9 @@ func TestWrapOAuthError(t *testing.T) {
 			if !strings.Contains(wrapped.Error(), tt.wantContain) {
 				t.Errorf("error should contain %q, got: %v", tt.wantContain, wrapped)
 			}
+			if errors.Is(wrapped, ErrReauthenticationRequired) != tt.wantReauth {
+				t.Errorf("errors.Is(ErrReauthenticationRequired) = %v, want %v", !tt.wantReauth, tt.wantReauth)
+			}
 		})
 	}
 }
@@ -0,0 +1,284 @@
+// Package paramschema validates rule definitions and parameters against the
+// JSON Schemas rule types declare, so a profile can be checked field by field
+// before Minder sees it.
+//
+// It supports the keywords rule type schemas use: type, enum, const,
+// properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, allOf, anyOf and oneOf. Other keywords are ignored, so a
+// value is never rejected for a keyword it was not checked against.
+package paramschema
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a value that does not match its schema.
+type Error struct {
+	// Path locates the value, e.g. "branches[0].name". It is empty for the
+	// value validated itself.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks value, decoded from JSON, against schema and returns every
+// mismatch, ordered by path. A nil schema accepts any value.
+func Validate(schema map[string]any, value any) []Error {
+	var errs []Error
+	validate(schema, value, "", &errs)
+	slices.SortStableFunc(errs, func(a, b Error) int { return cmp.Compare(a.Path, b.Path) })
+	return errs
+}
+
+func validate(schema map[string]any, value any, path string, errs *[]Error) {
+	if schema == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, Error{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if allowed, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(allowed, equals(value)) {
+		fail("must be one of %s", formatValues(allowed))
+	}
+	if want, ok := schema["const"]; ok && !reflect.DeepEqual(want, value) {
+		fail("must be %s", formatValue(want))
+	}
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !slices.ContainsFunc(types, isType(value)) {
+		fail("must be %s, got %s", strings.Join(types, " or "), typeOf(value))
+		return
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		validateObject(schema, v, path, errs)
+	case []any:
+		validateArray(schema, v, path, errs)
+	case string:
+		validateString(schema, v, fail)
+	case float64:
+		validateNumber(schema, v, fail)
+	}
+	validateCombinations(schema, value, path, errs)
+}
+
+func validateObject(schema map[string]any, obj map[string]any, path string, errs *[]Error) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, found := obj[name]; !found {
+					*errs = append(*errs, Error{Path: childPath(path, name), Message: "is required"})
+				}
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedKeys(obj) {
+		if prop, ok := properties[name].(map[string]any); ok {
+			validate(prop, obj[name], childPath(path, name), errs)
+			continue
+		}
+		if _, ok := properties[name]; ok {
+			continue
+		}
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				*errs = append(*errs, Error{Path: childPath(path, name), Message: "is not allowed"})
+			}
+		case map[string]any:
+			validate(extra, obj[name], childPath(path, name), errs)
+		}
+	}
+}
+
+func validateArray(schema map[string]any, arr []any, path string, errs *[]Error) {
+	if n, ok := schemaNumber(schema["minItems"]); ok && float64(len(arr)) < n {
+		*errs = append(*errs, Error{Path: path, Message: fmt.Sprintf("must have at least %s items", formatNumber(n))})
+	}
+	if n, ok := schemaNumber(schema["maxItems"]); ok && float64(len(arr)) > n {
+		*errs = append(*errs, Error{Path: path, Message: fmt.Sprintf("must have at most %s items", formatNumber(n))})
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range arr {
+			validate(items, item, path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
+
+func validateString(schema map[string]any, s string, fail func(string, ...any)) {
+	length := float64(utf8.RuneCountInString(s))
+	if n, ok := schemaNumber(schema["minLength"]); ok && length < n {
+		fail("must be at least %s characters long", formatNumber(n))
+	}
+	if n, ok := schemaNumber(schema["maxLength"]); ok && length > n {
+		fail("must be at most %s characters long", formatNumber(n))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		// A pattern Go cannot compile is skipped rather than failing every value
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(s) {
+			fail("must match pattern %s", pattern)
+		}
+	}
+}
+
+func validateNumber(schema map[string]any, n float64, fail func(string, ...any)) {
+	if limit, ok := schemaNumber(schema["minimum"]); ok && n < limit {
+		fail("must be at least %s", formatNumber(limit))
+	}
+	if limit, ok := schemaNumber(schema["maximum"]); ok && n > limit {
+		fail("must be at most %s", formatNumber(limit))
+	}
+	if limit, ok := schemaNumber(schema["exclusiveMinimum"]); ok && n <= limit {
+		fail("must be greater than %s", formatNumber(limit))
+	}
+	if limit, ok := schemaNumber(schema["exclusiveMaximum"]); ok && n >= limit {
+		fail("must be less than %s", formatNumber(limit))
+	}
+}
+
+// validateCombinations applies allOf, anyOf and oneOf. The errors of anyOf
+// and oneOf branches are not reported, since it is unclear which branch the
+// value was meant to match.
+func validateCombinations(schema map[string]any, value any, path string, errs *[]Error) {
+	for _, sub := range subschemas(schema["allOf"]) {
+		validate(sub, value, path, errs)
+	}
+	if branches := subschemas(schema["anyOf"]); len(branches) > 0 && matching(branches, value) == 0 {
+		*errs = append(*errs, Error{Path: path, Message: "does not match any of the allowed forms"})
+	}
+	if branches := subschemas(schema["oneOf"]); len(branches) > 0 && matching(branches, value) != 1 {
+		*errs = append(*errs, Error{Path: path, Message: "must match exactly one of the allowed forms"})
+	}
+}
+
+// matching returns the number of schemas value matches.
+func matching(schemas []map[string]any, value any) int {
+	n := 0
+	for _, s := range schemas {
+		if len(Validate(s, value)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func subschemas(v any) []map[string]any {
+	list, _ := v.([]any)
+	var schemas []map[string]any
+	for _, item := range list {
+		if s, ok := item.(map[string]any); ok {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas
+}
+
+// schemaTypes returns the types a type keyword allows, given as a name or a list of names.
+func schemaTypes(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// isType returns whether value is of a JSON Schema type.
+func isType(value any) func(string) bool {
+	return func(typ string) bool {
+		actual := typeOf(value)
+		switch typ {
+		case "number":
+			return actual == "number" || actual == "integer"
+		default:
+			return actual == typ
+		}
+	}
+}
+
+// typeOf returns the JSON Schema type of a value decoded from JSON.
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func schemaNumber(v any) (float64, bool) {
+	n, ok := v.(float64)
+	return n, ok
+}
+
+func equals(value any) func(any) bool {
+	return func(other any) bool { return reflect.DeepEqual(value, other) }
+}
+
+func childPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatValue(v)
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,104 @@
+package paramschema
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// branchSchema is shaped like the rule schema of a branch protection rule type.
+const branchSchema = `{
+	"type": "object",
+	"required": ["branch"],
+	"additionalProperties": false,
+	"properties": {
+		"branch": {"type": "string", "minLength": 1},
+		"approvals": {"type": "integer", "minimum": 0, "maximum": 6},
+		"mode": {"type": "string", "enum": ["strict", "relaxed"]},
+		"checks": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^[a-z-]+$"}},
+		"reviewers": {"anyOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name:  "valid",
+			value: `{"branch": "main", "approvals": 2, "mode": "strict", "checks": ["lint"], "reviewers": ["a", "b"]}`,
+		},
+		{
+			name:  "missing required property",
+			value: `{"approvals": 1}`,
+			want:  []string{"branch: is required"},
+		},
+		{
+			name:  "wrong types",
+			value: `{"branch": 5, "approvals": 1.5}`,
+			want:  []string{"approvals: must be integer, got number", "branch: must be string, got integer"},
+		},
+		{
+			name:  "bounds and enum",
+			value: `{"branch": "", "approvals": 7, "mode": "lax"}`,
+			want: []string{
+				"approvals: must be at most 6",
+				"branch: must be at least 1 characters long",
+				`mode: must be one of "strict", "relaxed"`,
+			},
+		},
+		{
+			name:  "array items",
+			value: `{"branch": "main", "checks": ["lint", "Unit Tests", "build"]}`,
+			want:  []string{"checks: must have at most 2 items", "checks[1]: must match pattern ^[a-z-]+$"},
+		},
+		{
+			name:  "additional property",
+			value: `{"branch": "main", "unknown": true}`,
+			want:  []string{"unknown: is not allowed"},
+		},
+		{
+			name:  "no anyOf branch matches",
+			value: `{"branch": "main", "reviewers": 3}`,
+			want:  []string{"reviewers: does not match any of the allowed forms"},
+		},
+		{
+			name:  "not an object",
+			value: `["main"]`,
+			want:  []string{"must be object, got array"},
+		},
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(branchSchema), &schema); err != nil {
+		t.Fatalf("invalid test schema: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("invalid test value: %v", err)
+			}
+			var got []string
+			for _, err := range Validate(schema, value) {
+				got = append(got, err.Error())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_NilSchema(t *testing.T) {
+	t.Parallel()
+
+	if errs := Validate(nil, map[string]any{"anything": true}); len(errs) != 0 {
+		t.Errorf("Validate(nil) = %v, want no errors", errs)
+	}
+}
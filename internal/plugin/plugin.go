@@ -0,0 +1,198 @@
+// Package plugin runs external executables that add tools to the server.
+//
+// A plugin is any executable speaking a small JSON protocol. Run with the
+// argument "describe", it prints {"tools": [...]} with MCP tool definitions.
+// Run with "call", it reads a Request from stdin and prints an MCP
+// CallToolResult, or plain text that is returned as a text result. Lines it
+// writes to stderr are logged, and a non-zero exit fails the call.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Request is the input of one tool call, written to the plugin's stdin.
+type Request struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	SessionID string         `json:"session_id,omitempty"`
+	// Minder lets the plugin call Minder as the user making the request. It
+	// is nil when the request carries no Minder credentials.
+	Minder *Minder `json:"minder,omitempty"`
+}
+
+// Minder is the Minder server a call is made against and the access token
+// of the user making it.
+type Minder struct {
+	Server   string `json:"server"`
+	Insecure bool   `json:"insecure,omitempty"`
+	Token    string `json:"token"`
+}
+
+// Plugin is an executable providing tools.
+type Plugin struct {
+	// Name is the executable's base name, used in logs and errors.
+	Name    string
+	path    string
+	timeout time.Duration
+	// env names the server's environment variables passed to the plugin
+	// besides the basic ones in baseEnv.
+	env    []string
+	logger *slog.Logger
+	tools  []mcp.Tool
+}
+
+// Load runs the executable at path with "describe" and returns the plugin
+// providing the tools it lists. Every run of the plugin, including this
+// one, is killed after timeout and is passed the environment variables
+// named in env besides the basic ones.
+func Load(ctx context.Context, path string, timeout time.Duration, env []string, logger *slog.Logger) (*Plugin, error) {
+	p := &Plugin{Name: filepath.Base(path), path: path, timeout: timeout, env: env, logger: logger}
+	out, err := p.run(ctx, nil, "describe")
+	if err != nil {
+		return nil, err
+	}
+	var desc struct {
+		Tools []mcp.Tool `json:"tools"`
+	}
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("plugin %s: parsing describe output: %w", p.Name, err)
+	}
+	for _, tool := range desc.Tools {
+		if tool.Name == "" {
+			return nil, fmt.Errorf("plugin %s: a tool has no name", p.Name)
+		}
+		if tool.InputSchema.Type == "" {
+			tool.InputSchema.Type = "object"
+		}
+		p.tools = append(p.tools, tool)
+	}
+	if len(p.tools) == 0 {
+		return nil, fmt.Errorf("plugin %s: describe listed no tools", p.Name)
+	}
+	return p, nil
+}
+
+// Tools returns the definitions of the tools the plugin provides.
+func (p *Plugin) Tools() []mcp.Tool {
+	return p.tools
+}
+
+// Call runs the plugin with "call" to handle req. A plugin that fails or
+// times out returns an error; a result the plugin marks as an error does not.
+func (p *Plugin) Call(ctx context.Context, req Request) (*mcp.CallToolResult, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.run(ctx, input, "call")
+	if err != nil {
+		return nil, err
+	}
+	out = bytes.TrimSpace(out)
+	if !bytes.HasPrefix(out, []byte("{")) {
+		return mcp.NewToolResultText(string(out)), nil
+	}
+	var result mcp.CallToolResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("plugin %s: parsing result of %s: %w", p.Name, req.Tool, err)
+	}
+	return &result, nil
+}
+
+// run runs the plugin with arg and input on stdin, returning its stdout. The
+// plugin only gets the environment pluginEnv allows, since the server's holds
+// tokens and secrets and calls pass the caller's own token.
+func (p *Plugin) run(ctx context.Context, input []byte, arg string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.path, arg) //nolint:gosec // plugins are configured by the operator
+	cmd.Env = pluginEnv(p.env)
+	cmd.Stdin = bytes.NewReader(input)
+	// Stop waiting for output held open by the plugin's children once it is killed
+	cmd.WaitDelay = time.Second
+	var stdout bytes.Buffer
+	stderr := &stderrLogger{ctx: ctx, plugin: p}
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	stderr.flush()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, fmt.Errorf("plugin %s: %s timed out after %s", p.Name, arg, p.timeout)
+	case err != nil && stderr.last != "":
+		return nil, fmt.Errorf("plugin %s: %s failed: %s", p.Name, arg, stderr.last)
+	case err != nil:
+		return nil, fmt.Errorf("plugin %s: %s failed: %w", p.Name, arg, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// stderrLogger logs each line a plugin writes to stderr and keeps the last
+// non-empty one to explain a failure.
+type stderrLogger struct {
+	ctx    context.Context
+	plugin *Plugin
+	buf    []byte
+	last   string
+}
+
+// Write logs the complete lines in b, keeping a trailing partial line for the next write.
+func (l *stderrLogger) Write(b []byte) (int, error) {
+	l.buf = append(l.buf, b...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		l.log(string(l.buf[:i]))
+		l.buf = l.buf[i+1:]
+	}
+}
+
+// flush logs a final line not ended by a newline.
+func (l *stderrLogger) flush() {
+	l.log(string(l.buf))
+	l.buf = nil
+}
+
+func (l *stderrLogger) log(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	l.last = line
+	l.plugin.logger.InfoContext(l.ctx, "plugin output", "plugin", l.plugin.Name, "line", line)
+}
+
+// baseEnv are the environment variables every plugin gets. Locale variables
+// starting with LC_ are passed too.
+var baseEnv = []string{"PATH", "HOME", "TMPDIR", "LANG", "LANGUAGE"}
+
+// pluginEnv returns the part of the server's environment a plugin gets: the
+// variables in baseEnv, the locale and those named in extra.
+func pluginEnv(extra []string) []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if slices.Contains(baseEnv, name) || strings.HasPrefix(name, "LC_") || slices.Contains(extra, name) {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
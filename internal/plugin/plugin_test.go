@@ -0,0 +1,167 @@
+package plugin
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// writePlugin writes a shell script plugin running script and returns its path.
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tickets")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700); err != nil { //nolint:gosec // test plugin must be executable
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+	return path
+}
+
+const ticketsPlugin = `case "$1" in
+describe)
+  echo '{"tools": [{"name": "file_ticket", "description": "File a ticket",
+    "inputSchema": {"properties": {"title": {"type": "string"}}}, "annotations": {"readOnlyHint": false}}]}'
+  ;;
+call)
+  input=$(cat)
+  echo "filing ticket" >&2
+  case "$input" in
+  *'"title":"fail"'*) echo "ticket system unavailable" >&2; exit 1 ;;
+  *'"title":"text"'*) echo "TICKET-1 filed" ;;
+  *'"title":"slow"'*) sleep 5 ;;
+  *) printf '{"content": [{"type": "text", "text": %s}]}' "$(printf '%s' "$input" | sed 's/"/\\"/g; s/^/"/; s/$/"/')" ;;
+  esac
+  ;;
+esac
+`
+
+func loadTestPlugin(t *testing.T, script string, timeout time.Duration) *Plugin {
+	t.Helper()
+	p, err := Load(context.Background(), writePlugin(t, script), timeout, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	p := loadTestPlugin(t, ticketsPlugin, 5*time.Second)
+	if p.Name != "tickets" {
+		t.Errorf("Name = %q, want tickets", p.Name)
+	}
+	tools := p.Tools()
+	if len(tools) != 1 || tools[0].Name != "file_ticket" || tools[0].InputSchema.Type != "object" {
+		t.Fatalf("Tools() = %+v, want file_ticket with an object schema", tools)
+	}
+	if ro := tools[0].Annotations.ReadOnlyHint; ro == nil || *ro {
+		t.Errorf("ReadOnlyHint = %v, want false", ro)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{name: "failing describe", script: "echo broken >&2; exit 1\n"},
+		{name: "invalid output", script: "echo not json\n"},
+		{name: "no tools", script: `echo '{"tools": []}'` + "\n"},
+		{name: "unnamed tool", script: `echo '{"tools": [{"description": "x"}]}'` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Load(context.Background(), writePlugin(t, tt.script), 5*time.Second, nil, logger); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	if _, err := Load(context.Background(), filepath.Join(t.TempDir(), "missing"), time.Second, nil, logger); err == nil {
+		t.Error("expected an error for a missing executable")
+	}
+}
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	p := loadTestPlugin(t, ticketsPlugin, time.Second)
+	tests := []struct {
+		name     string
+		title    string
+		wantText []string
+		wantErr  string
+	}{
+		{
+			name:     "json result",
+			title:    "outage",
+			wantText: []string{`"tool":"file_ticket"`, `"request_id":"req-1"`, `"token":"access-token"`},
+		},
+		{name: "text result", title: "text", wantText: []string{"TICKET-1 filed"}},
+		{name: "failure", title: "fail", wantErr: "ticket system unavailable"},
+		{name: "timeout", title: "slow", wantErr: "timed out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := p.Call(context.Background(), Request{
+				Tool:      "file_ticket",
+				Arguments: map[string]any{"title": tt.title},
+				RequestID: "req-1",
+				Minder:    &Minder{Server: "api.example.com:443", Token: "access-token"},
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Call() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Call() error = %v", err)
+			}
+			if len(result.Content) != 1 {
+				t.Fatalf("Content = %+v, want one text item", result.Content)
+			}
+			text, _ := mcp.AsTextContent(result.Content[0])
+			for _, want := range tt.wantText {
+				if text == nil || !strings.Contains(text.Text, want) {
+					t.Errorf("result %v does not contain %q", result.Content[0], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPluginEnv(t *testing.T) {
+	t.Setenv("MINDER_AUTH_TOKEN", "secret")
+	t.Setenv("MINDER_SERVER_STAGING_AUTH_TOKEN", "secret")
+	t.Setenv("MCP_WATCH_WEBHOOK_SECRET", "secret")
+	t.Setenv("TICKETS_API_KEY", "key")
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("LC_ALL", "C.UTF-8")
+
+	env := pluginEnv([]string{"TICKETS_API_KEY"})
+	if strings.Contains(strings.Join(env, "\n"), "secret") {
+		t.Errorf("plugin environment %v contains the server's secrets", env)
+	}
+	for _, want := range []string{"PATH=/usr/bin", "LC_ALL=C.UTF-8", "TICKETS_API_KEY=key"} {
+		if !slices.Contains(env, want) {
+			t.Errorf("plugin environment %v is missing %s", env, want)
+		}
+	}
+	if slices.Contains(pluginEnv(nil), "TICKETS_API_KEY=key") {
+		t.Error("plugin environment contains a variable that was not passed")
+	}
+}
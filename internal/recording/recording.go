@@ -0,0 +1,221 @@
+// Package recording saves Minder gRPC responses to disk and serves them back,
+// for offline development and deterministic end-to-end tests.
+//
+// Each response is stored as one JSON file under
+// <dir>/<service>/<method>/<key>.json, where key is derived from the
+// deterministically encoded request, so replaying the same request returns
+// the recorded response or error.
+package recording
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// keyLength is the number of hex characters of the request hash used in file names.
+const keyLength = 16
+
+// Entry is one recorded RPC.
+type Entry struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// Error is a recorded gRPC error status.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Path returns the file a request to method is recorded in under dir.
+func Path(dir, method string, req proto.Message) (string, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("encoding %s request: %w", method, err)
+	}
+	sum := sha256.Sum256(raw)
+	name := hex.EncodeToString(sum[:])[:keyLength] + ".json"
+	return filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(method, "/")), name), nil
+}
+
+// Recorder saves the responses of the RPCs passing through its interceptor.
+type Recorder struct {
+	dir    string
+	logger *slog.Logger
+}
+
+// NewRecorder returns a recorder writing to dir, which is created if needed,
+// and logging recording failures to logger.
+func NewRecorder(dir string, logger *slog.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating recording directory: %w", err)
+	}
+	return &Recorder{dir: dir, logger: logger}, nil
+}
+
+// Interceptor returns a client interceptor that records each RPC after it
+// completes. Recording failures are logged as errors, so gaps in a recording
+// are noticed while it is made, and the call's own result is returned.
+func (r *Recorder) Interceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		callErr := invoker(ctx, method, req, reply, cc, opts...)
+		if err := r.record(method, req, reply, callErr); err != nil {
+			r.logger.ErrorContext(ctx, "recording failed", "method", method, "error", err)
+		}
+		return callErr
+	}
+}
+
+// record writes one RPC to its file, replacing any earlier recording.
+func (r *Recorder) record(method string, req, reply any, callErr error) error {
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return fmt.Errorf("request %T is not a protobuf message", req)
+	}
+	path, err := Path(r.dir, method, reqMsg)
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{Method: method}
+	if entry.Request, err = protojson.Marshal(reqMsg); err != nil {
+		return err
+	}
+	if callErr != nil {
+		st := status.Convert(callErr)
+		entry.Error = &Error{Code: st.Code().String(), Message: st.Message()}
+	} else if replyMsg, ok := reply.(proto.Message); ok {
+		if entry.Response, err = protojson.Marshal(replyMsg); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	// Write then rename so concurrent replays never read a partial file; each
+	// write has its own temporary file as identical RPCs may run concurrently
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Replayer serves recorded responses in place of a Minder server.
+type Replayer struct {
+	dir string
+}
+
+// NewReplayer returns a replayer reading recordings from dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening recording directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("recording path %s is not a directory", dir)
+	}
+	return &Replayer{dir: dir}, nil
+}
+
+// NewClient returns a Minder client whose RPCs are answered from the
+// recordings. No connection is ever made.
+func (r *Replayer) NewClient(_ context.Context) (*minder.Client, error) {
+	conn, err := grpc.NewClient("passthrough:///replay",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(r.intercept),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return minder.NewClientFromConn(conn), nil
+}
+
+// intercept answers an RPC from its recording. A request that was never
+// recorded fails with NotFound.
+func (r *Replayer) intercept(
+	_ context.Context, method string, req, reply any, _ *grpc.ClientConn, _ grpc.UnaryInvoker, _ ...grpc.CallOption,
+) error {
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "request %T is not a protobuf message", req)
+	}
+	path, err := Path(r.dir, method, reqMsg)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path is built from the recording dir and a hash
+	if errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.NotFound, "no recorded response for %s with this request (%s); "+
+			"record it with MINDER_MCP_MODE=record", method, filepath.Base(path))
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "reading recording: %v", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return status.Errorf(codes.Internal, "decoding recording %s: %v", path, err)
+	}
+	if entry.Error != nil {
+		return status.Error(parseCode(entry.Error.Code), entry.Error.Message)
+	}
+	replyMsg, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "reply %T is not a protobuf message", reply)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(entry.Response, replyMsg); err != nil {
+		return status.Errorf(codes.Internal, "decoding recorded %s response: %v", method, err)
+	}
+	return nil
+}
+
+// parseCode parses a code name as written by codes.Code.String, e.g. "NotFound".
+func parseCode(name string) codes.Code {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == name {
+			return c
+		}
+	}
+	return codes.Unknown
+}
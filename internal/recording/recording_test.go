@@ -0,0 +1,169 @@
+package recording
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const getRepositoryMethod = "/minder.v1.RepositoryService/GetRepositoryByName"
+
+// fakeInvoker answers an RPC with resp, or err when set.
+func fakeInvoker(resp proto.Message, err error) grpc.UnaryInvoker {
+	return func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		if err != nil {
+			return err
+		}
+		proto.Merge(reply.(proto.Message), resp)
+		return nil
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	interceptor := rec.Interceptor()
+	ctx := context.Background()
+
+	found := &minderv1.GetRepositoryByNameRequest{Name: "acme/api"}
+	foundResp := &minderv1.GetRepositoryByNameResponse{
+		Repository: &minderv1.Repository{Owner: "acme", Name: "api", HookId: 42},
+	}
+	require.NoError(t, interceptor(ctx, getRepositoryMethod, found,
+		&minderv1.GetRepositoryByNameResponse{}, nil, fakeInvoker(foundResp, nil)))
+
+	missing := &minderv1.GetRepositoryByNameRequest{Name: "acme/gone"}
+	err = interceptor(ctx, getRepositoryMethod, missing,
+		&minderv1.GetRepositoryByNameResponse{}, nil, fakeInvoker(nil, status.Error(codes.NotFound, "repository not found")))
+	assert.Equal(t, codes.NotFound, status.Code(err), "recording must pass the original error through")
+
+	replayer, err := NewReplayer(dir)
+	require.NoError(t, err)
+	client, err := replayer.NewClient(ctx)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	tests := []struct {
+		name     string
+		req      *minderv1.GetRepositoryByNameRequest
+		wantCode codes.Code
+		wantMsg  string
+		wantRepo string
+	}{
+		{
+			name:     "recorded response",
+			req:      &minderv1.GetRepositoryByNameRequest{Name: "acme/api"},
+			wantCode: codes.OK,
+			wantRepo: "api",
+		},
+		{
+			name:     "recorded error",
+			req:      &minderv1.GetRepositoryByNameRequest{Name: "acme/gone"},
+			wantCode: codes.NotFound,
+			wantMsg:  "repository not found",
+		},
+		{
+			name:     "not recorded",
+			req:      &minderv1.GetRepositoryByNameRequest{Name: "acme/other"},
+			wantCode: codes.NotFound,
+			wantMsg:  "MINDER_MCP_MODE=record",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := client.Repositories().GetRepositoryByName(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.Contains(t, status.Convert(err).Message(), tt.wantMsg)
+				return
+			}
+			assert.Equal(t, tt.wantRepo, resp.GetRepository().GetName())
+			assert.Equal(t, int64(42), resp.GetRepository().GetHookId())
+		})
+	}
+}
+
+func TestRecorder_ConcurrentIdenticalCalls(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	interceptor := rec.Interceptor()
+	req := &minderv1.GetRepositoryByNameRequest{Name: "acme/api"}
+	resp := &minderv1.GetRepositoryByNameResponse{Repository: &minderv1.Repository{Owner: "acme", Name: "api"}}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			assert.NoError(t, interceptor(context.Background(), getRepositoryMethod, req,
+				&minderv1.GetRepositoryByNameResponse{}, nil, fakeInvoker(resp, nil)))
+		})
+	}
+	wg.Wait()
+
+	path, err := Path(dir, getRepositoryMethod, req)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must not be left behind")
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+}
+
+func TestRecorder_FailureKeepsCallResult(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	// A file in place of the service directory makes every recording fail
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "minder.v1.RepositoryService"), nil, 0o600))
+
+	resp := &minderv1.GetRepositoryByNameResponse{Repository: &minderv1.Repository{Name: "api"}}
+	reply := &minderv1.GetRepositoryByNameResponse{}
+	err = rec.Interceptor()(context.Background(), getRepositoryMethod, &minderv1.GetRepositoryByNameRequest{Name: "acme/api"},
+		reply, nil, fakeInvoker(resp, nil))
+	require.NoError(t, err, "a failed recording must not fail the call")
+	assert.Equal(t, "api", reply.GetRepository().GetName())
+}
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	a, err := Path("rec", getRepositoryMethod, &minderv1.GetRepositoryByNameRequest{Name: "acme/api"})
+	require.NoError(t, err)
+	b, err := Path("rec", getRepositoryMethod, &minderv1.GetRepositoryByNameRequest{Name: "acme/api"})
+	require.NoError(t, err)
+	c, err := Path("rec", getRepositoryMethod, &minderv1.GetRepositoryByNameRequest{Name: "acme/web"})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b, "equal requests share a recording")
+	assert.NotEqual(t, a, c, "different requests have separate recordings")
+	assert.Contains(t, a, "minder.v1.RepositoryService")
+}
+
+func TestNewReplayerMissingDir(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReplayer(t.TempDir() + "/missing")
+	assert.Error(t, err)
+}
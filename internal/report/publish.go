@@ -0,0 +1,111 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// uriPrefix is the base of report resource URIs; each report is published
+	// as uriPrefix followed by its ID.
+	uriPrefix = "minder://reports/"
+	// LatestURI always holds the most recent report.
+	LatestURI = uriPrefix + "latest"
+
+	markdownMIMEType = "text/markdown"
+	htmlMIMEType     = "text/html"
+)
+
+// URI returns the resource URI of the report with the given ID.
+func URI(id string) string {
+	return uriPrefix + id
+}
+
+// Archive publishes reports as MCP resources, keeping the most recent ones.
+type Archive struct {
+	server *server.MCPServer
+	keep   int
+
+	mu  sync.Mutex
+	ids []string
+}
+
+// NewArchive returns an Archive that registers reports with s and keeps the
+// keep most recent, removing older reports from the resource list.
+func NewArchive(s *server.MCPServer, keep int) *Archive {
+	return &Archive{server: s, keep: max(keep, 1)}
+}
+
+// Publish registers r as a dated resource and as LatestURI. Both hold the
+// report as Markdown and as HTML.
+func (a *Archive) Publish(_ context.Context, r Report) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.server.AddResource(
+		mcp.NewResource(URI(r.ID), "Compliance Report "+r.ID,
+			mcp.WithResourceDescription(fmt.Sprintf("Compliance report generated %s", r.Time.UTC().Format("2006-01-02 15:04 UTC"))),
+			mcp.WithMIMEType(markdownMIMEType),
+		),
+		contentsHandler(URI(r.ID), r),
+	)
+	a.server.AddResource(
+		mcp.NewResource(LatestURI, "Latest Compliance Report",
+			mcp.WithResourceDescription("The most recent scheduled compliance report, currently "+r.ID),
+			mcp.WithMIMEType(markdownMIMEType),
+		),
+		contentsHandler(LatestURI, r),
+	)
+
+	a.ids = append(a.ids, r.ID)
+	if excess := len(a.ids) - a.keep; excess > 0 {
+		uris := make([]string, 0, excess)
+		for _, id := range a.ids[:excess] {
+			if id != r.ID {
+				uris = append(uris, URI(id))
+			}
+		}
+		a.server.DeleteResources(uris...)
+		a.ids = a.ids[excess:]
+	}
+	return nil
+}
+
+// contentsHandler serves r under uri as Markdown and HTML.
+func contentsHandler(uri string, r Report) server.ResourceHandlerFunc {
+	return func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: markdownMIMEType, Text: r.Markdown},
+			mcp.TextResourceContents{URI: uri, MIMEType: htmlMIMEType, Text: r.HTML},
+		}, nil
+	}
+}
+
+// WriteDir returns a PublishFunc that writes each report to dir as
+// compliance-report-<id>.md and compliance-report-<id>.html. Files are never
+// removed, so dir keeps every report.
+func WriteDir(dir string) PublishFunc {
+	return func(_ context.Context, r Report) error {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+		base := filepath.Join(dir, "compliance-report-"+r.ID)
+		for ext, content := range map[string]string{".md": r.Markdown, ".html": r.HTML} {
+			// Write then rename so readers never see a partly written report
+			tmp := base + ext + ".tmp"
+			if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+			if err := os.Rename(tmp, base+ext); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+func testReport(t *testing.T, at time.Time) Report {
+	t.Helper()
+	r, err := Render(history.Summary{Time: at, Score: 100}, nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return r
+}
+
+func TestArchivePublish(t *testing.T) {
+	t.Parallel()
+
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, false))
+	a := NewArchive(s, 2)
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	var reports []Report
+	for i := range 3 {
+		r := testReport(t, base.AddDate(0, 0, 7*i))
+		reports = append(reports, r)
+		if err := a.Publish(context.Background(), r); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	list, ok := request(t, s, "resources/list", nil).(mcp.ListResourcesResult)
+	if !ok {
+		t.Fatal("unexpected resources/list result")
+	}
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	want := []string{LatestURI, URI(reports[1].ID), URI(reports[2].ID)}
+	slices.Sort(uris)
+	slices.Sort(want)
+	if !slices.Equal(uris, want) {
+		t.Errorf("resources = %v, want the 2 kept reports and latest %v", uris, want)
+	}
+
+	read, ok := request(t, s, "resources/read", map[string]any{"uri": LatestURI}).(mcp.ReadResourceResult)
+	if !ok {
+		t.Fatal("unexpected resources/read result")
+	}
+	if len(read.Contents) != 2 {
+		t.Fatalf("got %d contents, want Markdown and HTML", len(read.Contents))
+	}
+	md, ok := read.Contents[0].(mcp.TextResourceContents)
+	if !ok || md.MIMEType != "text/markdown" || md.Text != reports[2].Markdown || md.URI != LatestURI {
+		t.Errorf("first content = %+v, want the latest report as Markdown", read.Contents[0])
+	}
+	page, ok := read.Contents[1].(mcp.TextResourceContents)
+	if !ok || page.MIMEType != "text/html" || page.Text != reports[2].HTML {
+		t.Errorf("second content = %+v, want the latest report as HTML", read.Contents[1])
+	}
+}
+
+// request sends a JSON-RPC request to s and returns its result.
+func request(t *testing.T, s *server.MCPServer, method string, params map[string]any) any {
+	t.Helper()
+	msg, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, ok := s.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("%s failed", method)
+	}
+	return resp.Result
+}
+
+func TestWriteDir(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "reports")
+	r := testReport(t, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	if err := WriteDir(dir)(context.Background(), r); err != nil {
+		t.Fatalf("WriteDir() error = %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"compliance-report-2026-03-02T0900Z.md":   r.Markdown,
+		"compliance-report-2026-03-02T0900Z.html": r.HTML,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s does not hold the report", name)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d files, want only the two reports", len(entries))
+	}
+}
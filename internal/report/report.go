@@ -0,0 +1,104 @@
+// Package report renders compliance summaries as Markdown and HTML reports and
+// publishes them on a schedule, as MCP resources and optionally as files.
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"maps"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+// idLayout formats a report's time as its ID, e.g. 2026-03-09T0900Z.
+const idLayout = "2006-01-02T1504Z"
+
+var (
+	//go:embed report.md.tmpl
+	markdownSource string
+	//go:embed report.html.tmpl
+	htmlSource string
+
+	funcs = map[string]any{
+		"pct":    func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
+		"signed": func(v float64) string { return fmt.Sprintf("%+.1f", v) },
+		"count":  func(v int) string { return fmt.Sprintf("%+d", v) },
+		"date":   func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	}
+	markdownTemplate = template.Must(template.New("report.md").Funcs(funcs).Parse(markdownSource))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("report.html").Funcs(funcs).Parse(htmlSource))
+)
+
+// Report is a rendered compliance report.
+type Report struct {
+	// ID identifies the report by the UTC time it was generated for.
+	ID       string
+	Time     time.Time
+	Summary  history.Summary
+	Markdown string
+	HTML     string
+}
+
+// entityRules lists rules of one entity, for stable ordering in templates.
+type entityRules struct {
+	Entity string
+	Rules  []string
+}
+
+// changes is the template view of a history.Delta.
+type changes struct {
+	history.Delta
+	NewlyFailing []entityRules
+	Recovered    []entityRules
+}
+
+// view is the data the report templates are executed with.
+type view struct {
+	Time    time.Time
+	Summary history.Summary
+	Failing []entityRules
+	Changes *changes
+}
+
+// Render renders sum as a report. When prev is not nil the report also lists
+// the changes since that summary.
+func Render(sum history.Summary, prev *history.Summary) (Report, error) {
+	v := view{Time: sum.Time, Summary: sum, Failing: sortedEntities(sum.FailingByEntity)}
+	if prev != nil {
+		d := history.Compare(*prev, sum)
+		v.Changes = &changes{
+			Delta:        d,
+			NewlyFailing: sortedEntities(d.NewlyFailing),
+			Recovered:    sortedEntities(d.Recovered),
+		}
+	}
+
+	var md, page bytes.Buffer
+	if err := markdownTemplate.Execute(&md, v); err != nil {
+		return Report{}, fmt.Errorf("failed to render Markdown report: %w", err)
+	}
+	if err := htmlTemplate.Execute(&page, v); err != nil {
+		return Report{}, fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return Report{
+		ID:       sum.Time.UTC().Format(idLayout),
+		Time:     sum.Time,
+		Summary:  sum,
+		Markdown: md.String(),
+		HTML:     page.String(),
+	}, nil
+}
+
+// sortedEntities returns the entries of m ordered by entity name.
+func sortedEntities(m map[string][]string) []entityRules {
+	out := make([]entityRules, 0, len(m))
+	for _, entity := range slices.Sorted(maps.Keys(m)) {
+		out = append(out, entityRules{Entity: entity, Rules: m[entity]})
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	prev := history.Summary{
+		Time:         at.AddDate(0, 0, -7),
+		Score:        50,
+		Rules:        4,
+		FailingRules: 2,
+		FailingByEntity: map[string][]string{
+			"acme/api": {"baseline/branch_protection"},
+			"acme/web": {"baseline/secret_scanning"},
+		},
+	}
+	sum := history.Summary{
+		Time:            at,
+		Score:           75,
+		Profiles:        2,
+		FailingProfiles: 1,
+		Rules:           4,
+		FailingRules:    1,
+		FailingByEntity: map[string][]string{
+			"acme/<script>": {"baseline/dependabot"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		prev        *history.Summary
+		wantMD      []string
+		wantHTML    []string
+		notInOutput []string
+	}{
+		{
+			name: "first report",
+			wantMD: []string{
+				"# Minder Compliance Report",
+				"Generated 2026-03-09 09:00 UTC.",
+				"| Compliance score | 75.0% |",
+				"| Failing profiles | 1 of 2 |",
+				"| Failing rule evaluations | 1 of 4 |",
+				"- **acme/<script>**: `baseline/dependabot`",
+			},
+			wantHTML: []string{
+				"<td>75.0%</td>",
+				"<strong>acme/&lt;script&gt;</strong>: <code>baseline/dependabot</code>",
+			},
+			notInOutput: []string{"Changes Since", "<script>:"},
+		},
+		{
+			name: "with changes",
+			prev: &prev,
+			wantMD: []string{
+				"## Changes Since 2026-03-02 09:00 UTC",
+				"Score +25.0 points (50.0% to 75.0%), failing rule evaluations -1.",
+				"### Newly Failing\n\n- **acme/<script>**: `baseline/dependabot`",
+				"### Recovered\n\n- **acme/api**: `baseline/branch_protection`\n- **acme/web**: `baseline/secret_scanning`",
+				"### Newly Compliant\n\n- **acme/api**\n- **acme/web**",
+			},
+			wantHTML: []string{
+				`<span class="up">&#43;25.0 points</span>`,
+				"<h3>Recovered</h3>",
+				"<li><strong>acme/web</strong></li>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := Render(sum, tt.prev)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if r.ID != "2026-03-09T0900Z" {
+				t.Errorf("ID = %q, want 2026-03-09T0900Z", r.ID)
+			}
+			for _, want := range tt.wantMD {
+				if !strings.Contains(r.Markdown, want) {
+					t.Errorf("Markdown missing %q:\n%s", want, r.Markdown)
+				}
+			}
+			for _, want := range tt.wantHTML {
+				if !strings.Contains(r.HTML, want) {
+					t.Errorf("HTML missing %q:\n%s", want, r.HTML)
+				}
+			}
+			for _, unwanted := range tt.notInOutput {
+				if strings.Contains(r.HTML, unwanted) {
+					t.Errorf("HTML contains %q:\n%s", unwanted, r.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_NoFailures(t *testing.T) {
+	t.Parallel()
+
+	r, err := Render(history.Summary{Time: time.Now(), Score: 100}, nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(r.Markdown, "No evaluated rule is failing.") ||
+		!strings.Contains(r.HTML, "<p>No evaluated rule is failing.</p>") {
+		t.Errorf("report does not say nothing fails:\n%s\n%s", r.Markdown, r.HTML)
+	}
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/cron"
+	"github.com/stacklok/minder-mcp/internal/history"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// PublishFunc makes a generated report available somewhere.
+type PublishFunc func(ctx context.Context, r Report) error
+
+// Scheduler generates a report at each run of a cron schedule.
+type Scheduler struct {
+	schedule *cron.Schedule
+	fetch    watcher.FetchFunc
+	publish  []PublishFunc
+	logger   *slog.Logger
+	now      func() time.Time
+
+	// prev is the summary of the last generated report, which the next
+	// report lists its changes against.
+	prev *history.Summary
+}
+
+// NewScheduler returns a Scheduler that renders a report of each fetched
+// snapshot at every run of schedule and hands it to each publisher.
+func NewScheduler(schedule *cron.Schedule, fetch watcher.FetchFunc, logger *slog.Logger, publish ...PublishFunc) *Scheduler {
+	return &Scheduler{
+		schedule: schedule,
+		fetch:    fetch,
+		publish:  publish,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Run generates reports at the scheduled times until ctx is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		next := s.schedule.Next(s.now())
+		if next.IsZero() {
+			s.logger.WarnContext(ctx, "report schedule never runs", "schedule", s.schedule.String())
+			return
+		}
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.generate(ctx, next)
+		}
+	}
+}
+
+// generate fetches a snapshot and publishes its report, dated at.
+func (s *Scheduler) generate(ctx context.Context, at time.Time) {
+	snapshot, err := s.fetch(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "compliance snapshot for report failed", "error", err)
+		return
+	}
+
+	sum := history.Summarize(at, snapshot)
+	r, err := Render(sum, s.prev)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to render compliance report", "error", err)
+		return
+	}
+	s.prev = &sum
+
+	for _, publish := range s.publish {
+		if err := publish(ctx, r); err != nil {
+			s.logger.WarnContext(ctx, "failed to publish compliance report", "report", r.ID, "error", err)
+		}
+	}
+	s.logger.InfoContext(ctx, "compliance report generated", "report", r.ID, "score", sum.Score)
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/cron"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+func TestSchedulerGenerate(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := cron.Parse("@weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := watcher.NewSnapshot()
+	snapshot.Rules["proj/baseline/rule/repo-1"] = "failure"
+	snapshot.Entities["repo-1"] = "acme/api"
+	calls := 0
+	fetch := func(_ context.Context) (watcher.Snapshot, error) {
+		calls++
+		if calls == 2 {
+			return watcher.Snapshot{}, errors.New("unavailable")
+		}
+		return snapshot, nil
+	}
+	var published []Report
+	publish := func(_ context.Context, r Report) error {
+		published = append(published, r)
+		return errors.New("publisher errors do not stop the others")
+	}
+	s := NewScheduler(schedule, fetch, slog.New(slog.NewTextHandler(io.Discard, nil)), publish, publish)
+
+	first := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	s.generate(context.Background(), first)
+	s.generate(context.Background(), first.AddDate(0, 0, 7)) // fetch error: nothing published
+	s.generate(context.Background(), first.AddDate(0, 0, 14))
+
+	if len(published) != 4 {
+		t.Fatalf("got %d publications, want 2 reports to each of 2 publishers", len(published))
+	}
+	if published[0].ID != "2026-03-02T0000Z" || published[2].ID != "2026-03-16T0000Z" {
+		t.Errorf("report IDs = %q, %q", published[0].ID, published[2].ID)
+	}
+	if strings.Contains(published[0].Markdown, "Changes Since") {
+		t.Error("first report lists changes without an earlier report")
+	}
+	if !strings.Contains(published[2].Markdown, "## Changes Since 2026-03-02 00:00 UTC") {
+		t.Errorf("second report does not list changes since the first:\n%s", published[2].Markdown)
+	}
+	if !strings.Contains(published[2].Markdown, "- **acme/api**: `baseline/rule`") {
+		t.Errorf("report does not list the failing rule:\n%s", published[2].Markdown)
+	}
+}
+
+func TestSchedulerRunStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := cron.Parse("@monthly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewScheduler(schedule, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -1,6 +1,13 @@
 package resources
 
-import _ "embed"
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // dashboardHTML contains the bundled compliance dashboard HTML.
 // This file is generated by the Vite build process from ui/compliance-dashboard.
@@ -8,3 +15,46 @@ import _ "embed"
 //
 //go:embed dist/index.html
 var dashboardHTML string
+
+// dashboardSHA256 is the hex SHA-256 of dist/index.html, written by the same
+// Vite build.
+//
+//go:embed dist/index.html.sha256
+var dashboardSHA256 string
+
+// dashboardCSPMeta is the _meta.ui.csp of the dashboard. The MCP Apps host
+// builds the sandboxed iframe's Content-Security-Policy from it; empty domain
+// lists allow no network connections and no external scripts, styles or images.
+func dashboardCSPMeta() map[string]any {
+	return map[string]any{
+		"ui": map[string]any{
+			"csp": map[string]any{
+				"connectDomains":  []string{},
+				"resourceDomains": []string{},
+			},
+		},
+	}
+}
+
+// VerifyDashboard checks the embedded dashboard against the hash recorded
+// when it was built and that it declares its own Content-Security-Policy, so
+// a corrupted or tampered dist/index.html is detected at startup.
+func VerifyDashboard() error {
+	return verifyDashboard(dashboardHTML, dashboardSHA256)
+}
+
+// verifyDashboard checks html against the hex SHA-256 in want.
+func verifyDashboard(html, want string) error {
+	if html == "" {
+		return errors.New("dashboard HTML is empty - ensure dist/index.html was built before compiling")
+	}
+	want = strings.TrimSpace(want)
+	sum := sha256.Sum256([]byte(html))
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return fmt.Errorf("dashboard HTML has SHA-256 %s, but the build recorded %q; rebuild the UI with `task build:ui`", got, want)
+	}
+	if !strings.Contains(html, `http-equiv="Content-Security-Policy"`) {
+		return errors.New("dashboard HTML does not declare a Content-Security-Policy")
+	}
+	return nil
+}
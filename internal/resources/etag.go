@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// ETagMetaKey is the _meta key holding the hash of a resource's contents.
+	ETagMetaKey = "etag"
+	// NotModifiedMetaKey is set in _meta when a conditional read matched and
+	// the contents were left out.
+	NotModifiedMetaKey = "notModified"
+	// IfNoneMatchArgument is the resources/read argument carrying the ETag a
+	// client last saw. The If-None-Match HTTP header is honored as well.
+	IfNoneMatchArgument = "if_none_match"
+)
+
+// withETag stamps the contents served by handler with an ETag and, when the
+// request carries a matching ETag, replaces them with an empty "not modified"
+// content so polling clients do not download an unchanged payload again.
+func withETag(handler server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		contents, err := handler(ctx, req)
+		if err != nil || len(contents) == 0 {
+			return contents, err
+		}
+		etag := contentETag(contents)
+		if etagMatches(ifNoneMatch(req), etag) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:  req.Params.URI,
+					Meta: map[string]any{ETagMetaKey: etag, NotModifiedMetaKey: true},
+				},
+			}, nil
+		}
+		for i, c := range contents {
+			switch c := c.(type) {
+			case mcp.TextResourceContents:
+				c.Meta = withMeta(c.Meta, ETagMetaKey, etag)
+				contents[i] = c
+			case mcp.BlobResourceContents:
+				c.Meta = withMeta(c.Meta, ETagMetaKey, etag)
+				contents[i] = c
+			}
+		}
+		return contents, nil
+	}
+}
+
+// contentETag hashes the MIME types and payloads of the contents.
+func contentETag(contents []mcp.ResourceContents) string {
+	h := sha256.New()
+	for _, c := range contents {
+		switch c := c.(type) {
+		case mcp.TextResourceContents:
+			h.Write([]byte(c.MIMEType + "\x00" + c.Text + "\x00"))
+		case mcp.BlobResourceContents:
+			h.Write([]byte(c.MIMEType + "\x00" + c.Blob + "\x00"))
+		}
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// ifNoneMatch returns the ETags the client already holds, from the request
+// arguments or the If-None-Match header.
+func ifNoneMatch(req mcp.ReadResourceRequest) string {
+	if v, ok := req.Params.Arguments[IfNoneMatchArgument].(string); ok && v != "" {
+		return v
+	}
+	return req.Header.Get("If-None-Match")
+}
+
+// etagMatches reports whether a comma-separated If-None-Match value names
+// etag. Quotes and weak prefixes are ignored, since the contents are compared
+// byte for byte anyway.
+func etagMatches(header, etag string) bool {
+	want := normalizeETag(etag)
+	for candidate := range strings.SplitSeq(header, ",") {
+		if c := normalizeETag(candidate); c == "*" || (c != "" && c == want) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeETag strips whitespace, a weak prefix and quotes from an ETag.
+func normalizeETag(etag string) string {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	return strings.Trim(etag, `"`)
+}
+
+// withMeta returns meta with key set, allocating it when nil.
+func withMeta(meta map[string]any, key string, value any) map[string]any {
+	if meta == nil {
+		meta = make(map[string]any, 1)
+	}
+	meta[key] = value
+	return meta
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithETag(t *testing.T) {
+	r := New(slog.New(slog.NewTextHandler(nil, nil)), BuildInfo{Version: "v1.2.3"})
+	handler := withETag(r.serveServerInfo)
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = ServerInfoURI
+	contents, err := handler(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	full, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	etag, ok := full.Meta[ETagMetaKey].(string)
+	require.True(t, ok, "contents carry no ETag")
+	assert.Contains(t, full.Text, "v1.2.3")
+
+	tests := []struct {
+		name         string
+		arguments    map[string]any
+		header       http.Header
+		wantModified bool
+	}{
+		{name: "matching argument", arguments: map[string]any{IfNoneMatchArgument: etag}},
+		{name: "matching header", header: http.Header{"If-None-Match": {`W/` + etag}}},
+		{name: "one of several ETags", arguments: map[string]any{IfNoneMatchArgument: `"stale", ` + etag}},
+		{name: "stale ETag", arguments: map[string]any{IfNoneMatchArgument: `"stale"`}, wantModified: true},
+		{name: "unconditional read", wantModified: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.ReadResourceRequest{Header: tt.header}
+			req.Params.URI = ServerInfoURI
+			req.Params.Arguments = tt.arguments
+			contents, err := handler(context.Background(), req)
+			require.NoError(t, err)
+			require.Len(t, contents, 1)
+			text, ok := contents[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, etag, text.Meta[ETagMetaKey])
+			if tt.wantModified {
+				assert.Equal(t, full.Text, text.Text)
+				assert.NotContains(t, text.Meta, NotModifiedMetaKey)
+			} else {
+				assert.Empty(t, text.Text)
+				assert.Equal(t, true, text.Meta[NotModifiedMetaKey])
+			}
+		})
+	}
+}
+
+func TestContentETag(t *testing.T) {
+	a := []mcp.ResourceContents{mcp.TextResourceContents{URI: "x", MIMEType: "application/json", Text: "{}"}}
+	b := []mcp.ResourceContents{mcp.TextResourceContents{URI: "x", MIMEType: "application/json", Text: "[]"}}
+	assert.Equal(t, contentETag(a), contentETag(a))
+	assert.NotEqual(t, contentETag(a), contentETag(b))
+}
@@ -23,12 +23,14 @@ const (
 // Resources holds the resource handlers and configuration.
 type Resources struct {
 	logger *slog.Logger
+	build  BuildInfo
 }
 
-// New creates a new Resources instance.
-func New(logger *slog.Logger) *Resources {
+// New creates a new Resources instance reporting the given build information.
+func New(logger *slog.Logger, build BuildInfo) *Resources {
 	return &Resources{
 		logger: logger,
+		build:  build,
 	}
 }
 
@@ -43,13 +45,38 @@ func (r *Resources) Register(s *server.MCPServer) {
 				"Interactive compliance status dashboard showing real-time "+
 					"compliance across repositories with drill-down capabilities"),
 			mcp.WithMIMEType(DashboardMIMEType),
+			withResourceMeta(dashboardCSPMeta()),
 		),
 		r.wrapHandler(DashboardURI, r.serveDashboardHTML),
 	)
+
+	// Server version and build information
+	s.AddResource(
+		mcp.NewResource(
+			ServerInfoURI,
+			"Server Info",
+			mcp.WithResourceDescription("Version and build information for this Minder MCP server"),
+			mcp.WithMIMEType("application/json"),
+		),
+		r.wrapHandler(ServerInfoURI, r.serveServerInfo),
+	)
+}
+
+// NotifyDashboardUpdated tells connected clients that the compliance dashboard
+// content has changed so MCP Apps hosts can re-read and re-render it.
+// The SDK does not track resources/subscribe requests, so the notification is
+// broadcast to every initialized session.
+func (r *Resources) NotifyDashboardUpdated(s *server.MCPServer) {
+	r.logger.Debug("notifying clients of dashboard update", "uri", DashboardURI)
+	s.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{
+		"uri": DashboardURI,
+	})
 }
 
-// wrapHandler wraps a resource handler with debug logging.
+// wrapHandler wraps a resource handler with debug logging and ETag support
+// for conditional reads.
 func (r *Resources) wrapHandler(uri string, handler server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+	handler = withETag(handler)
 	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 		start := time.Now()
 		r.logger.DebugContext(ctx, "resource requested", "uri", uri)
@@ -59,12 +86,30 @@ func (r *Resources) wrapHandler(uri string, handler server.ResourceHandlerFunc)
 			"uri", uri,
 			"duration", time.Since(start),
 			"error", hasError,
-			"content_length", len(dashboardHTML),
+			"content_length", contentLength(result),
 		)
 		return result, err
 	}
 }
 
+// contentLength returns the total size of the text contents.
+func contentLength(contents []mcp.ResourceContents) int {
+	n := 0
+	for _, c := range contents {
+		if text, ok := c.(mcp.TextResourceContents); ok {
+			n += len(text.Text)
+		}
+	}
+	return n
+}
+
+// withResourceMeta sets the _meta of a resource listing.
+func withResourceMeta(meta map[string]any) mcp.ResourceOption {
+	return func(r *mcp.Resource) {
+		r.Meta = mcp.NewMetaFromMap(meta)
+	}
+}
+
 // serveDashboardHTML serves the embedded compliance dashboard HTML.
 func (*Resources) serveDashboardHTML(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	if dashboardHTML == "" {
@@ -75,6 +120,7 @@ func (*Resources) serveDashboardHTML(_ context.Context, _ mcp.ReadResourceReques
 			URI:      DashboardURI,
 			MIMEType: DashboardMIMEType,
 			Text:     dashboardHTML,
+			Meta:     dashboardCSPMeta(),
 		},
 	}, nil
 }
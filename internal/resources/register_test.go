@@ -2,6 +2,8 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"log/slog"
 	"strings"
 	"testing"
@@ -20,7 +22,7 @@ func TestRegister(t *testing.T) {
 	)
 
 	logger := slog.New(slog.NewTextHandler(nil, nil))
-	r := New(logger)
+	r := New(logger, BuildInfo{})
 
 	// Should not panic
 	r.Register(mcpServer)
@@ -35,7 +37,7 @@ func TestServeDashboardHTML(t *testing.T) {
 	req := mcp.ReadResourceRequest{}
 
 	logger := slog.New(slog.NewTextHandler(nil, nil))
-	r := New(logger)
+	r := New(logger, BuildInfo{})
 
 	contents, err := r.serveDashboardHTML(ctx, req)
 
@@ -49,6 +51,46 @@ func TestServeDashboardHTML(t *testing.T) {
 	assert.Equal(t, DashboardMIMEType, textContent.MIMEType)
 	assert.Contains(t, textContent.Text, "<!DOCTYPE html>")
 	assert.Contains(t, textContent.Text, "Minder Compliance Dashboard")
+
+	ui, ok := textContent.Meta["ui"].(map[string]any)
+	require.True(t, ok, "expected _meta.ui")
+	assert.Equal(t, map[string]any{"connectDomains": []string{}, "resourceDomains": []string{}}, ui["csp"])
+}
+
+func TestVerifyDashboard(t *testing.T) {
+	require.NoError(t, VerifyDashboard(), "embedded dashboard should match its recorded hash")
+
+	const html = `<html><head><meta http-equiv="Content-Security-Policy" content="default-src 'none'"></head></html>`
+	const noCSP = `<html><head></head></html>`
+	tests := []struct {
+		name    string
+		html    string
+		hash    string
+		wantErr string
+	}{
+		{name: "matching hash", html: html, hash: sha256Hex(html) + "\n"},
+		{name: "uppercase hash", html: html, hash: strings.ToUpper(sha256Hex(html))},
+		{name: "tampered HTML", html: html + "<script>", hash: sha256Hex(html), wantErr: "SHA-256"},
+		{name: "missing hash", html: html, wantErr: "SHA-256"},
+		{name: "empty HTML", hash: sha256Hex(""), wantErr: "empty"},
+		{name: "no CSP", html: noCSP, hash: sha256Hex(noCSP), wantErr: "Content-Security-Policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyDashboard(tt.html, tt.hash)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
 }
 
 func TestDashboardHTMLContent(t *testing.T) {
@@ -72,3 +114,28 @@ func TestDashboardURIFormat(t *testing.T) {
 	// Verify the URI follows the expected ui:// scheme
 	assert.True(t, strings.HasPrefix(DashboardURI, "ui://"), "Dashboard URI should use ui:// scheme")
 }
+
+func TestServeServerInfo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(nil, nil))
+	r := New(logger, BuildInfo{
+		Name:      "minder-mcp",
+		Version:   "v1.2.3",
+		Commit:    "abc1234",
+		BuildTime: "2026-01-01T00:00:00Z",
+		GoVersion: "go1.25.0",
+	})
+
+	contents, err := r.serveServerInfo(context.Background(), mcp.ReadResourceRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	textContent, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok, "expected TextResourceContents")
+
+	assert.Equal(t, ServerInfoURI, textContent.URI)
+	assert.Equal(t, "application/json", textContent.MIMEType)
+	assert.Contains(t, textContent.Text, `"version": "v1.2.3"`)
+	assert.Contains(t, textContent.Text, `"commit": "abc1234"`)
+	assert.Contains(t, textContent.Text, `"build_time": "2026-01-01T00:00:00Z"`)
+}
@@ -0,0 +1,36 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerInfoURI is the resource URI for the server's version and build information.
+const ServerInfoURI = "minder://server/info"
+
+// BuildInfo describes the running server binary.
+type BuildInfo struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// serveServerInfo serves the build information as JSON.
+func (r *Resources) serveServerInfo(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	data, err := json.MarshalIndent(r.build, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server info: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      ServerInfoURI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
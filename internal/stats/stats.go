@@ -0,0 +1,113 @@
+// Package stats tracks in-memory tool usage statistics.
+package stats
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSamples bounds the number of recent durations kept per tool for percentile calculation.
+const maxSamples = 1000
+
+// ToolStats summarizes usage of a single tool since server start.
+type ToolStats struct {
+	Tool        string  `json:"tool"`
+	Invocations int64   `json:"invocations"`
+	Errors      int64   `json:"errors"`
+	ErrorRate   float64 `json:"error_rate"`
+	P95Millis   float64 `json:"p95_ms"`
+}
+
+// toolCounters holds the running counters and recent samples for one tool.
+type toolCounters struct {
+	invocations int64
+	errors      int64
+	samples     []time.Duration // ring buffer of recent durations
+	next        int
+}
+
+// Collector records tool invocations.
+// Collector is safe for concurrent use by multiple goroutines.
+type Collector struct {
+	mu    sync.Mutex
+	tools map[string]*toolCounters
+}
+
+// NewCollector creates an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		tools: make(map[string]*toolCounters),
+	}
+}
+
+// Record adds a single tool invocation.
+func (c *Collector) Record(tool string, isError bool, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tc, ok := c.tools[tool]
+	if !ok {
+		tc = &toolCounters{}
+		c.tools[tool] = tc
+	}
+	tc.invocations++
+	if isError {
+		tc.errors++
+	}
+	if len(tc.samples) < maxSamples {
+		tc.samples = append(tc.samples, duration)
+	} else {
+		tc.samples[tc.next] = duration
+		tc.next = (tc.next + 1) % maxSamples
+	}
+}
+
+// Snapshot returns the current statistics for every tool, sorted by tool name.
+func (c *Collector) Snapshot() []ToolStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]ToolStats, 0, len(c.tools))
+	for name, tc := range c.tools {
+		ts := ToolStats{
+			Tool:        name,
+			Invocations: tc.invocations,
+			Errors:      tc.errors,
+			P95Millis:   float64(percentile(tc.samples, 0.95).Microseconds()) / 1000,
+		}
+		if tc.invocations > 0 {
+			ts.ErrorRate = float64(tc.errors) / float64(tc.invocations)
+		}
+		out = append(out, ts)
+	}
+	slices.SortFunc(out, func(a, b ToolStats) int {
+		return strings.Compare(a.Tool, b.Tool)
+	})
+	return out
+}
+
+// Handler returns an http.Handler that serves the statistics as JSON.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"tools": c.Snapshot(),
+		})
+	})
+}
+
+// percentile returns the p-th percentile (0-1) of samples using nearest-rank.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
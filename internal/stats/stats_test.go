@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCollector_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector()
+	for i := 1; i <= 100; i++ {
+		c.Record("minder_list_profiles", i%10 == 0, time.Duration(i)*time.Millisecond)
+	}
+	c.Record("minder_get_profile", false, 5*time.Millisecond)
+
+	snap := c.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("got %d tools, want 2", len(snap))
+	}
+
+	// Sorted by name
+	if snap[0].Tool != "minder_get_profile" || snap[1].Tool != "minder_list_profiles" {
+		t.Errorf("unexpected order: %q, %q", snap[0].Tool, snap[1].Tool)
+	}
+
+	list := snap[1]
+	if list.Invocations != 100 {
+		t.Errorf("Invocations = %d, want 100", list.Invocations)
+	}
+	if list.Errors != 10 {
+		t.Errorf("Errors = %d, want 10", list.Errors)
+	}
+	if list.ErrorRate != 0.1 {
+		t.Errorf("ErrorRate = %v, want 0.1", list.ErrorRate)
+	}
+	if list.P95Millis != 95 {
+		t.Errorf("P95Millis = %v, want 95", list.P95Millis)
+	}
+}
+
+func TestCollector_BoundedSamples(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector()
+	for range maxSamples * 2 {
+		c.Record("tool", false, time.Millisecond)
+	}
+
+	if got := len(c.tools["tool"].samples); got != maxSamples {
+		t.Errorf("kept %d samples, want %d", got, maxSamples)
+	}
+	if got := c.Snapshot()[0].Invocations; got != maxSamples*2 {
+		t.Errorf("Invocations = %d, want %d", got, maxSamples*2)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	if got := percentile(nil, 0.95); got != 0 {
+		t.Errorf("percentile(nil) = %v, want 0", got)
+	}
+	if got := percentile([]time.Duration{3, 1, 2}, 0.5); got != 2 {
+		t.Errorf("percentile(0.5) = %v, want 2", got)
+	}
+}
+
+func TestCollector_Handler(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector()
+	c.Record("minder_list_projects", false, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var body struct {
+		Tools []ToolStats `json:"tools"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Tools) != 1 || body.Tools[0].Tool != "minder_list_projects" {
+		t.Errorf("unexpected body: %+v", body)
+	}
+}
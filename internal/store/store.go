@@ -0,0 +1,436 @@
+// Package store is an embedded bbolt database shared by subsystems that keep
+// state across restarts. Entries are kept in named buckets with optional entry
+// limits and lifetimes, and the file is compacted in the background so it
+// stays within a size cap in long-lived deployments.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// compactMinBytes is the file size below which free space is not worth
+	// reclaiming.
+	compactMinBytes = 1 << 20
+	// compactTxMaxBytes bounds the size of each transaction while compacting.
+	compactTxMaxBytes = 16 << 20
+	// evictTarget is the fraction of the size cap left used after evicting
+	// entries from a store over its cap, so eviction does not run on every pass.
+	evictTarget = 0.75
+)
+
+// Store is an embedded key-value store in a single bbolt file.
+// Store is safe for concurrent use by multiple goroutines.
+type Store struct {
+	path     string
+	maxBytes int64
+	logger   *slog.Logger
+	now      func() time.Time
+
+	// mu is held for reading by transactions and for writing while the file
+	// is compacted and reopened.
+	mu sync.RWMutex
+	db *bolt.DB
+	// buckets are the buckets opened with Bucket, whose entries may be expired
+	// and evicted. Buckets used directly through View and Update are not.
+	buckets map[string]*Bucket
+}
+
+// Open opens, or creates, the store at path. When maxBytes is positive,
+// Maintain evicts the oldest entries once the data exceeds it.
+func Open(path string, maxBytes int64, logger *slog.Logger) (*Store, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		path:     path,
+		maxBytes: maxBytes,
+		logger:   logger,
+		now:      time.Now,
+		db:       db,
+		buckets:  make(map[string]*Bucket),
+	}, nil
+}
+
+// openDB opens the bbolt file at path, readable only by the current user.
+func openDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open store %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
+}
+
+// Path returns the database file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// View runs fn in a read-only transaction.
+func (s *Store) View(fn func(*bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.db.View(fn)
+}
+
+// Update runs fn in a read-write transaction.
+func (s *Store) Update(fn func(*bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.db.Update(fn)
+}
+
+// Limits bound the entries of a bucket.
+type Limits struct {
+	// MaxEntries is the number of entries kept; writing beyond it evicts the
+	// least recently written. Zero does not limit entries.
+	MaxEntries int
+	// TTL is how long an entry is returned after it was written. Zero keeps
+	// entries until they are deleted or evicted.
+	TTL time.Duration
+}
+
+// Bucket is a named set of JSON values in a Store.
+type Bucket struct {
+	store  *Store
+	name   []byte
+	limits Limits
+}
+
+// entry is the stored form of a bucket value.
+type entry struct {
+	Written time.Time       `json:"written"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Bucket returns the bucket with the given name, creating it if needed.
+func (s *Store) Bucket(name string, limits Limits) (*Bucket, error) {
+	err := s.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(name))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create store bucket %s: %w", name, err)
+	}
+	b := &Bucket{store: s, name: []byte(name), limits: limits}
+	s.mu.Lock()
+	s.buckets[name] = b
+	s.mu.Unlock()
+	return b, nil
+}
+
+// expired reports whether e has outlived the bucket's TTL at now.
+func (b *Bucket) expired(e entry, now time.Time) bool {
+	return b.limits.TTL > 0 && now.Sub(e.Written) > b.limits.TTL
+}
+
+// Get decodes the value stored under key into v. It reports false when there
+// is no such value or it has expired.
+func (b *Bucket) Get(key string, v any) (bool, error) {
+	var e entry
+	found := false
+	err := b.store.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(b.name).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, &e); err != nil {
+			return fmt.Errorf("decode %s/%s: %w", b.name, key, err)
+		}
+		found = !b.expired(e, b.store.now())
+		return nil
+	})
+	if err != nil || !found {
+		return false, err
+	}
+	if err := json.Unmarshal(e.Value, v); err != nil {
+		return false, fmt.Errorf("decode %s/%s: %w", b.name, key, err)
+	}
+	return true, nil
+}
+
+// Put stores v under key as JSON, replacing any previous value. When the
+// bucket then holds more than MaxEntries, the least recently written entries
+// are removed.
+func (b *Bucket) Put(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s/%s: %w", b.name, key, err)
+	}
+	data, err := json.Marshal(entry{Written: b.store.now().UTC(), Value: value})
+	if err != nil {
+		return fmt.Errorf("encode %s/%s: %w", b.name, key, err)
+	}
+	return b.store.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.name)
+		if err := bucket.Put([]byte(key), data); err != nil {
+			return err
+		}
+		if b.limits.MaxEntries <= 0 {
+			return nil
+		}
+		entries, err := b.entries(tx)
+		if err != nil || len(entries) <= b.limits.MaxEntries {
+			return err
+		}
+		for _, e := range entries[:len(entries)-b.limits.MaxEntries] {
+			if err := bucket.Delete(e.key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes the value stored under key, if any.
+func (b *Bucket) Delete(key string) error {
+	return b.store.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.name).Delete([]byte(key))
+	})
+}
+
+// ForEach calls fn with the key and JSON value of each unexpired entry, in
+// key order. An error from fn stops the iteration and is returned.
+func (b *Bucket) ForEach(fn func(key string, value json.RawMessage) error) error {
+	now := b.store.now()
+	return b.store.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.name).ForEach(func(k, data []byte) error {
+			var e entry
+			if err := json.Unmarshal(data, &e); err != nil {
+				return fmt.Errorf("decode %s/%s: %w", b.name, k, err)
+			}
+			if b.expired(e, now) {
+				return nil
+			}
+			return fn(string(k), e.Value)
+		})
+	})
+}
+
+// storedEntry locates an entry for expiry and eviction.
+type storedEntry struct {
+	bucket  *Bucket
+	key     []byte
+	written time.Time
+	size    int
+}
+
+// entries returns the bucket's entries, least recently written first.
+// Entries that cannot be decoded sort first, so they are evicted first.
+func (b *Bucket) entries(tx *bolt.Tx) ([]storedEntry, error) {
+	var out []storedEntry
+	err := tx.Bucket(b.name).ForEach(func(k, data []byte) error {
+		var e entry
+		_ = json.Unmarshal(data, &e)
+		out = append(out, storedEntry{bucket: b, key: slices.Clone(k), written: e.Written, size: len(k) + len(data)})
+		return nil
+	})
+	slices.SortStableFunc(out, func(a, b storedEntry) int { return a.written.Compare(b.written) })
+	return out, err
+}
+
+// Size returns the size of the database file and how many of its bytes are in use.
+func (s *Store) Size() (fileBytes, usedBytes int64, err error) {
+	err = s.View(func(tx *bolt.Tx) error {
+		fileBytes = tx.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	s.mu.RLock()
+	stats := s.db.Stats()
+	pageSize := int64(s.db.Info().PageSize)
+	s.mu.RUnlock()
+	free := int64(stats.FreePageN+stats.PendingPageN) * pageSize
+	return fileBytes, max(fileBytes-free, 0), nil
+}
+
+// Run maintains the store every interval until ctx is canceled.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Maintain(); err != nil {
+				s.logger.WarnContext(ctx, "store maintenance failed", "path", s.path, "error", err)
+			}
+		}
+	}
+}
+
+// Maintain removes expired entries, evicts the least recently written entries
+// across buckets while the store is over its size cap, and compacts the file
+// when at least half of it is free space.
+func (s *Store) Maintain() error {
+	expired, err := s.expire()
+	if err != nil {
+		return err
+	}
+	evicted, err := s.evict()
+	if err != nil {
+		return err
+	}
+	fileBytes, usedBytes, err := s.Size()
+	if err != nil {
+		return err
+	}
+	s.logger.Debug("store maintained", "path", s.path, "expired", expired, "evicted", evicted,
+		"file_bytes", fileBytes, "used_bytes", usedBytes)
+	if fileBytes < compactMinBytes || usedBytes > fileBytes/2 {
+		return nil
+	}
+	return s.Compact()
+}
+
+// expire deletes entries past their bucket's TTL and returns how many were removed.
+func (s *Store) expire() (int, error) {
+	removed := 0
+	now := s.now()
+	buckets := s.bucketList()
+	err := s.Update(func(tx *bolt.Tx) error {
+		for _, b := range buckets {
+			if b.limits.TTL <= 0 {
+				continue
+			}
+			entries, err := b.entries(tx)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if now.Sub(e.written) <= b.limits.TTL {
+					break
+				}
+				if err := tx.Bucket(b.name).Delete(e.key); err != nil {
+					return err
+				}
+				removed++
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// evict deletes the least recently written entries across buckets until the
+// used size is below evictTarget of the cap, and returns how many were removed.
+func (s *Store) evict() (int, error) {
+	if s.maxBytes <= 0 {
+		return 0, nil
+	}
+	_, used, err := s.Size()
+	if err != nil || used <= s.maxBytes {
+		return 0, err
+	}
+
+	excess := used - int64(float64(s.maxBytes)*evictTarget)
+	removed := 0
+	buckets := s.bucketList()
+	err = s.Update(func(tx *bolt.Tx) error {
+		var all []storedEntry
+		for _, b := range buckets {
+			entries, err := b.entries(tx)
+			if err != nil {
+				return err
+			}
+			all = append(all, entries...)
+		}
+		slices.SortStableFunc(all, func(a, b storedEntry) int { return a.written.Compare(b.written) })
+		for _, e := range all {
+			if excess <= 0 {
+				break
+			}
+			if err := tx.Bucket(e.bucket.name).Delete(e.key); err != nil {
+				return err
+			}
+			excess -= int64(e.size)
+			removed++
+		}
+		return nil
+	})
+	if removed > 0 {
+		s.logger.Warn("store over its size cap, evicted oldest entries", "path", s.path, "evicted", removed,
+			"max_bytes", s.maxBytes)
+	}
+	return removed, err
+}
+
+// bucketList returns the buckets opened with Bucket, by name.
+func (s *Store) bucketList() []*Bucket {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	out := make([]*Bucket, 0, len(names))
+	for _, name := range names {
+		out = append(out, s.buckets[name])
+	}
+	return out
+}
+
+// Compact rewrites the database into a new file without free pages and
+// replaces the original with it. bbolt never shrinks its file, so space freed
+// by deletions is only returned to the filesystem this way. Transactions wait
+// while the store is compacted.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path + ".compact"
+	_ = os.Remove(tmp)
+	dst, err := openDB(tmp)
+	if err != nil {
+		return err
+	}
+	if err := bolt.Compact(dst, s.db, compactTxMaxBytes); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compact store %s: %w", s.path, err)
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compact store %s: %w", s.path, err)
+	}
+
+	if err := s.db.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compact store %s: %w", s.path, err)
+	}
+	renameErr := os.Rename(tmp, s.path)
+	// Reopen whichever file is now at the path, so the store stays usable
+	// when the rename failed
+	db, err := openDB(s.path)
+	if err != nil {
+		return errors.Join(renameErr, err)
+	}
+	s.db = db
+	if renameErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compact store %s: %w", s.path, renameErr)
+	}
+	return nil
+}
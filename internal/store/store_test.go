@@ -0,0 +1,227 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestStore(t *testing.T, maxBytes int64) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), maxBytes, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// clock returns a now function for s that tests advance by assigning to *at.
+func clock(s *Store) *time.Time {
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return at }
+	return &at
+}
+
+type record struct {
+	Name string `json:"name"`
+}
+
+func TestBucketRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.db")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := Open(path, 0, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.Bucket("records", Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Put("a", record{Name: "alpha"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := b.Put("b", record{Name: "beta"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Delete("b"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Values survive reopening the file
+	s, err = Open(path, 0, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = s.Close() }()
+	b, err = s.Bucket("records", Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got record
+	if ok, err := b.Get("a", &got); err != nil || !ok || got.Name != "alpha" {
+		t.Errorf("Get(a) = %v, %v, %+v; want alpha", ok, err, got)
+	}
+	if ok, err := b.Get("b", &got); err != nil || ok {
+		t.Errorf("Get(b) = %v, %v; want deleted", ok, err)
+	}
+	var keys []string
+	err = b.ForEach(func(key string, value json.RawMessage) error {
+		keys = append(keys, key+"="+string(value))
+		return nil
+	})
+	if err != nil || len(keys) != 1 || keys[0] != `a={"name":"alpha"}` {
+		t.Errorf("ForEach() = %v, %v", keys, err)
+	}
+}
+
+func TestBucketLimits(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t, 0)
+	at := clock(s)
+	b, err := s.Bucket("records", Limits{MaxEntries: 2, TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"c", "a", "b"} {
+		*at = at.Add(time.Minute)
+		if err := b.Put(key, record{Name: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got record
+	if ok, _ := b.Get("c", &got); ok {
+		t.Error("least recently written entry was not evicted beyond MaxEntries")
+	}
+	if ok, _ := b.Get("a", &got); !ok {
+		t.Error("entry a was evicted")
+	}
+
+	// a was written a minute before b, so only a has expired
+	*at = at.Add(time.Hour)
+	if ok, _ := b.Get("a", &got); ok {
+		t.Error("expired entry a was returned")
+	}
+	if ok, _ := b.Get("b", &got); !ok {
+		t.Error("entry b expired early")
+	}
+
+	if err := s.Maintain(); err != nil {
+		t.Fatalf("Maintain() error = %v", err)
+	}
+	err = s.View(func(tx *bolt.Tx) error {
+		if n := tx.Bucket([]byte("records")).Stats().KeyN; n != 1 {
+			return fmt.Errorf("bucket holds %d entries after Maintain, want 1", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMaintainEnforcesSizeCap(t *testing.T) {
+	t.Parallel()
+
+	const maxBytes = 2 << 20
+	s := openTestStore(t, maxBytes)
+	at := clock(s)
+	b, err := s.Bucket("records", Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := strings.Repeat("x", 64<<10)
+	for i := range 64 {
+		*at = at.Add(time.Minute)
+		if err := b.Put(fmt.Sprintf("%03d", i), record{Name: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fileBefore, usedBefore, err := s.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diskBefore, err := os.Stat(s.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usedBefore <= maxBytes {
+		t.Fatalf("used %d bytes, want more than the %d byte cap for the test", usedBefore, maxBytes)
+	}
+
+	if err := s.Maintain(); err != nil {
+		t.Fatalf("Maintain() error = %v", err)
+	}
+	fileAfter, usedAfter, err := s.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usedAfter > maxBytes {
+		t.Errorf("used %d bytes after Maintain, want at most %d", usedAfter, maxBytes)
+	}
+	if fileAfter >= fileBefore {
+		t.Errorf("file is %d bytes after Maintain, want it compacted below %d", fileAfter, fileBefore)
+	}
+	diskAfter, err := os.Stat(s.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diskAfter.Size() >= diskBefore.Size() {
+		t.Errorf("file on disk is %d bytes after Maintain, want less than %d", diskAfter.Size(), diskBefore.Size())
+	}
+
+	// The oldest entries were evicted and the newest kept
+	var got record
+	if ok, _ := b.Get("000", &got); ok {
+		t.Error("oldest entry survived eviction")
+	}
+	if ok, err := b.Get("063", &got); !ok || err != nil {
+		t.Errorf("newest entry lost after compaction: %v", err)
+	}
+}
+
+func TestCompactKeepsRawBuckets(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t, 0)
+	err := s.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte("raw"))
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte("k"), []byte("v"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	err = s.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket([]byte("raw")).Get([]byte("k")); string(v) != "v" {
+			return fmt.Errorf("raw value = %q after compaction, want v", v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(s.Path() + ".compact"); !os.IsNotExist(err) {
+		t.Errorf("temporary compaction file left behind: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package systemd integrates the server with systemd service management:
+// socket activation, readiness and stop notifications, and the watchdog.
+// Every function is a no-op when the process was not started by systemd.
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// listenFDsStart is the first file descriptor passed by socket activation.
+const listenFDsStart = 3
+
+// Listeners returns the sockets passed by systemd socket activation, in the
+// order of the socket unit's Listen directives, or nil when there are none.
+// The LISTEN_* variables are unset so child processes do not inherit them.
+func Listeners() ([]net.Listener, error) {
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+		_ = os.Unsetenv("LISTEN_FDNAMES")
+	}()
+	return listeners(os.Getenv, os.Getpid(), listenFDsStart)
+}
+
+// listeners converts the descriptors described by the LISTEN_* variables,
+// starting at fd start, into listeners.
+func listeners(getenv func(string) string, pid, start int) ([]net.Listener, error) {
+	if getenv("LISTEN_PID") != strconv.Itoa(pid) {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, nil
+	}
+	names := strings.Split(getenv("LISTEN_FDNAMES"), ":")
+
+	lns := make([]net.Listener, 0, n)
+	for i := range n {
+		name := "LISTEN_FD_" + strconv.Itoa(start+i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		f := os.NewFile(uintptr(start+i), name)
+		// FileListener duplicates the descriptor, so the original is closed.
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			for _, l := range lns {
+				_ = l.Close()
+			}
+			return nil, fmt.Errorf("socket %s passed by systemd is not a listening socket: %w", name, err)
+		}
+		lns = append(lns, ln)
+	}
+	return lns, nil
+}
+
+// Notify sends state, such as "READY=1" or "STOPPING=1", to the service
+// manager. It does nothing when NOTIFY_SOCKET is unset, i.e. when the
+// service is not of Type=notify.
+func Notify(state string) error {
+	return notify(os.Getenv("NOTIFY_SOCKET"), state)
+}
+
+// notify sends state to the datagram socket at path. A leading '@' names an
+// abstract socket.
+func notify(path, state string) error {
+	if path == "" {
+		return nil
+	}
+	if path[0] == '@' {
+		path = "\x00" + path[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("failed to connect to systemd notify socket: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("failed to notify systemd: %w", err)
+	}
+	return nil
+}
+
+// WatchdogInterval returns how often systemd expects a keep-alive, or zero
+// when the watchdog is not enabled for this process.
+func WatchdogInterval() time.Duration {
+	return watchdogInterval(os.Getenv, os.Getpid())
+}
+
+// watchdogInterval reads WATCHDOG_USEC, honoring WATCHDOG_PID when set.
+func watchdogInterval(getenv func(string) string, pid int) time.Duration {
+	if p := getenv("WATCHDOG_PID"); p != "" && p != strconv.Itoa(pid) {
+		return 0
+	}
+	usec, err := strconv.ParseInt(getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// RunWatchdog sends keep-alives at half the watchdog interval until ctx is
+// cancelled. It returns at once when the watchdog is not enabled.
+func RunWatchdog(ctx context.Context) error {
+	interval := WatchdogInterval()
+	if interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := Notify("WATCHDOG=1"); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package systemd
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func envOf(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestListeners(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	f, err := ln.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	fd := int(f.Fd())
+
+	got, err := listeners(envOf(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "1"}), 42, fd)
+	if err != nil {
+		t.Fatalf("listeners() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("listeners() returned %d listeners, want 1", len(got))
+	}
+	defer func() { _ = got[0].Close() }()
+	if got[0].Addr().String() != ln.Addr().String() {
+		t.Errorf("listener address = %s, want %s", got[0].Addr(), ln.Addr())
+	}
+}
+
+func TestListeners_NotActivated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{name: "no variables", env: map[string]string{}},
+		{name: "another process", env: map[string]string{"LISTEN_PID": "7", "LISTEN_FDS": "1"}},
+		{name: "no sockets", env: map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := listeners(envOf(tt.env), 42, listenFDsStart)
+			if err != nil || got != nil {
+				t.Errorf("listeners() = %v, %v, want nil, nil", got, err)
+			}
+		})
+	}
+}
+
+func TestListeners_NotASocket(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	env := envOf(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "1", "LISTEN_FDNAMES": "mcp"})
+	if _, err := listeners(env, 42, int(f.Fd())); err == nil {
+		t.Error("listeners() accepted a descriptor that is not a socket")
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("ListenUnixgram() error = %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := notify(path, "READY=1"); err != nil {
+		t.Fatalf("notify() error = %v", err)
+	}
+	buf := make([]byte, 64)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got := string(buf[:n]); got != "READY=1" {
+		t.Errorf("received %q, want %q", got, "READY=1")
+	}
+}
+
+func TestNotify_NoSocket(t *testing.T) {
+	t.Parallel()
+
+	if err := notify("", "READY=1"); err != nil {
+		t.Errorf("notify() without a socket error = %v", err)
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	t.Parallel()
+
+	pid := strconv.Itoa(42)
+	tests := []struct {
+		name string
+		env  map[string]string
+		want time.Duration
+	}{
+		{name: "disabled", env: map[string]string{}},
+		{name: "enabled", env: map[string]string{"WATCHDOG_USEC": "30000000"}, want: 30 * time.Second},
+		{name: "for this process", env: map[string]string{"WATCHDOG_USEC": "1000000", "WATCHDOG_PID": pid}, want: time.Second},
+		{name: "for another process", env: map[string]string{"WATCHDOG_USEC": "1000000", "WATCHDOG_PID": "7"}},
+		{name: "invalid", env: map[string]string{"WATCHDOG_USEC": "soon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := watchdogInterval(envOf(tt.env), 42); got != tt.want {
+				t.Errorf("watchdogInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWatchdog_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := RunWatchdog(ctx); err != nil {
+		t.Errorf("RunWatchdog() error = %v", err)
+	}
+}
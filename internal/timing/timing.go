@@ -0,0 +1,71 @@
+// Package timing records per-call latency breakdowns.
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Phase is a named, timed step within a tool call.
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Recorder collects phases for a single tool call.
+// Recorder is safe for concurrent use by multiple goroutines.
+type Recorder struct {
+	mu     sync.Mutex
+	phases []Phase
+}
+
+// recorderKey is the unexported context key for the Recorder.
+type recorderKey struct{}
+
+// NewContext returns a context carrying a new Recorder.
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// FromContext returns the Recorder in ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Record adds a phase that started at start and ends now to the Recorder in ctx.
+// It is a no-op when ctx carries no Recorder.
+func Record(ctx context.Context, name string, start time.Time) {
+	r := FromContext(ctx)
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases = append(r.phases, Phase{Name: name, Duration: time.Since(start)})
+}
+
+// Phases returns a copy of the recorded phases in the order they completed.
+func (r *Recorder) Phases() []Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Phase(nil), r.phases...)
+}
+
+// Breakdown formats the recorded phases as "name=duration" strings, followed by
+// the time within total not covered by any phase.
+func (r *Recorder) Breakdown(total time.Duration) []string {
+	phases := r.Phases()
+	out := make([]string, 0, len(phases)+1)
+	var accounted time.Duration
+	for _, p := range phases {
+		out = append(out, p.Name+"="+p.Duration.String())
+		accounted += p.Duration
+	}
+	if other := total - accounted; other > 0 {
+		out = append(out, "other="+other.String())
+	}
+	return out
+}
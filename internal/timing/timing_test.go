@@ -0,0 +1,52 @@
+package timing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	ctx, rec := NewContext(context.Background())
+	Record(ctx, "client_create", time.Now().Add(-10*time.Millisecond))
+	Record(ctx, "rpc /minder.v1.HealthService/CheckHealth", time.Now())
+
+	phases := rec.Phases()
+	if len(phases) != 2 {
+		t.Fatalf("got %d phases, want 2", len(phases))
+	}
+	if phases[0].Name != "client_create" {
+		t.Errorf("phases[0].Name = %q, want %q", phases[0].Name, "client_create")
+	}
+	if phases[0].Duration < 10*time.Millisecond {
+		t.Errorf("phases[0].Duration = %v, want >= 10ms", phases[0].Duration)
+	}
+}
+
+func TestRecord_NoRecorder(t *testing.T) {
+	t.Parallel()
+
+	// Must not panic without a recorder in the context
+	Record(context.Background(), "noop", time.Now())
+	if FromContext(context.Background()) != nil {
+		t.Error("expected nil recorder")
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	t.Parallel()
+
+	rec := &Recorder{phases: []Phase{
+		{Name: "client_create", Duration: 20 * time.Millisecond},
+		{Name: "marshal", Duration: 5 * time.Millisecond},
+	}}
+
+	got := strings.Join(rec.Breakdown(100*time.Millisecond), ",")
+	want := "client_create=20ms,marshal=5ms,other=75ms"
+	if got != want {
+		t.Errorf("Breakdown() = %q, want %q", got, want)
+	}
+}
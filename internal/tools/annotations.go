@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// formatJSON returns the Minder response as JSON.
+	formatJSON = "json"
+	// formatGitHubAnnotations returns GitHub Actions workflow command lines.
+	formatGitHubAnnotations = "github_annotations"
+)
+
+// profileStatusResponse is satisfied by both profile status responses.
+type profileStatusResponse interface {
+	GetProfileStatus() *minderv1.ProfileStatus
+	GetRuleEvaluationStatus() []*minderv1.RuleEvaluationStatus
+}
+
+// profileStatusResult renders a profile status response in the requested format.
+func profileStatusResult(ctx context.Context, resp profileStatusResponse, format string) (*mcp.CallToolResult, error) {
+	if format == formatGitHubAnnotations {
+		return mcp.NewToolResultText(githubAnnotations(resp)), nil
+	}
+	return marshalResult(ctx, resp)
+}
+
+// githubAnnotations renders failing rule evaluations as GitHub Actions workflow
+// commands, one per line: failures as ::error and evaluation errors as ::warning.
+// When nothing is failing a single ::notice line says so, so CI logs always
+// show that the check ran.
+func githubAnnotations(resp profileStatusResponse) string {
+	profile := resp.GetProfileStatus().GetProfileName()
+	if profile == "" {
+		profile = resp.GetProfileStatus().GetProfileId()
+	}
+
+	var b strings.Builder
+	evaluated := 0
+	for _, rule := range resp.GetRuleEvaluationStatus() {
+		evaluated++
+		var command string
+		switch rule.Status {
+		case "failure":
+			command = "error"
+		case "error":
+			command = "warning"
+		default:
+			continue
+		}
+
+		ruleName := rule.RuleDescriptionName
+		if ruleName == "" {
+			ruleName = rule.RuleTypeName
+		}
+		message := entityDisplayName(rule.EntityInfo)
+		if rule.Details != "" {
+			message += ": " + rule.Details
+		}
+		fmt.Fprintf(&b, "::%s title=%s::%s\n",
+			command, escapeAnnotationProperty("Minder "+profile+"/"+ruleName), escapeAnnotationData(message))
+	}
+
+	if b.Len() == 0 {
+		fmt.Fprintf(&b, "::notice title=%s::%s\n", escapeAnnotationProperty("Minder "+profile),
+			escapeAnnotationData(fmt.Sprintf("%d rule evaluations, none failing", evaluated)))
+	}
+	return b.String()
+}
+
+// escapeAnnotationData escapes a workflow command message.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a workflow command property value.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
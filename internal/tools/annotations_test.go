@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestGithubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	resp := &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{
+				RuleDescriptionName: "branch_protection",
+				Status:              "failure",
+				Details:             "100% unprotected\nmain has no rules",
+				EntityInfo:          map[string]string{"repo_owner": "stacklok", "repo_name": "minder"},
+			},
+			{
+				RuleTypeName: "secret_scanning",
+				Status:       "error",
+				EntityInfo:   map[string]string{"entity_id": "repo-2"},
+			},
+			{
+				RuleDescriptionName: "dependabot",
+				Status:              "success",
+			},
+		},
+	}
+
+	got := githubAnnotations(resp)
+	want := "::error title=Minder baseline/branch_protection::stacklok/minder: 100%25 unprotected%0Amain has no rules\n" +
+		"::warning title=Minder baseline/secret_scanning::repo-2\n"
+	if got != want {
+		t.Errorf("githubAnnotations() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGithubAnnotations_NoneFailing(t *testing.T) {
+	t.Parallel()
+
+	resp := &minderv1.GetProfileStatusByIdResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{RuleDescriptionName: "dependabot", Status: "success"},
+		},
+	}
+
+	want := "::notice title=Minder baseline::1 rule evaluations, none failing\n"
+	if got := githubAnnotations(resp); got != want {
+		t.Errorf("githubAnnotations() = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeAnnotationProperty(t *testing.T) {
+	t.Parallel()
+
+	if got := escapeAnnotationProperty("a:b,c%"); got != "a%3Ab%2Cc%25" {
+		t.Errorf("escapeAnnotationProperty() = %q, want %q", got, "a%3Ab%2Cc%25")
+	}
+}
+
+func TestGetProfileStatus_GitHubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.getStatusByIDResp = &minderv1.GetProfileStatusByIdResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{RuleDescriptionName: "branch_protection", Status: "failure",
+				EntityInfo: map[string]string{"entity_id": "repo-1"}},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getProfileStatus(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"profile_id": "prof-1", "format": "github_annotations"}},
+	})
+	if err != nil {
+		t.Fatalf("getProfileStatus() returned Go error: %v", err)
+	}
+	if text := getResultText(t, result); !strings.HasPrefix(text, "::error title=Minder baseline/branch_protection::repo-1") {
+		t.Errorf("result = %q, want an ::error annotation", text)
+	}
+
+	result, err = tools.getProfileStatus(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"profile_id": "prof-1", "format": "sarif"}},
+	})
+	if err != nil {
+		t.Fatalf("getProfileStatus() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected error result for unknown format")
+	}
+}
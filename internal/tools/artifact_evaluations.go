@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// vulnerabilityRuleKeywords identify rule types that report vulnerability scans,
+// such as OSV, Trivy or Grype based rules.
+var vulnerabilityRuleKeywords = []string{"vuln", "cve", "osv", "trivy", "grype"}
+
+// artifactFinding is one rule evaluation of an artifact.
+type artifactFinding struct {
+	Profile      string `json:"profile"`
+	Rule         string `json:"rule"`
+	RuleType     string `json:"rule_type"`
+	Status       string `json:"status"`
+	Severity     string `json:"severity,omitempty"`
+	Details      string `json:"details,omitempty"`
+	Guidance     string `json:"guidance,omitempty"`
+	LastUpdated  string `json:"last_updated,omitempty"`
+	EvaluationID string `json:"evaluation_id,omitempty"`
+}
+
+// artifactSummary identifies the artifact a tool result is about.
+type artifactSummary struct {
+	ID         string                      `json:"id"`
+	Name       string                      `json:"name"`
+	Owner      string                      `json:"owner,omitempty"`
+	Type       string                      `json:"type,omitempty"`
+	Repository string                      `json:"repository,omitempty"`
+	Version    *minderv1.ArtifactVersion   `json:"version,omitempty"`
+	Versions   []*minderv1.ArtifactVersion `json:"versions,omitempty"`
+}
+
+// artifactEvaluations returns the rule evaluations of an artifact whose rule
+// type or rule name satisfies match.
+func artifactEvaluations(
+	ctx context.Context, client MinderClient, artifact *minderv1.Artifact, match func(ruleType, rule string) bool,
+) ([]artifactFinding, error) {
+	projectID := artifact.GetContext().GetProject()
+	resp, err := client.EvalResults().ListEvaluationResults(ctx, &minderv1.ListEvaluationResultsRequest{
+		Context: &minderv1.Context{Project: &projectID},
+		Entity: []*minderv1.EntityTypedId{
+			{Type: minderv1.Entity_ENTITY_ARTIFACTS, Id: artifact.GetArtifactPk()},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []artifactFinding{}
+	for _, entity := range resp.GetEntities() {
+		for _, profile := range entity.GetProfiles() {
+			for _, rule := range profile.GetResults() {
+				ruleName := rule.RuleDescriptionName
+				if ruleName == "" {
+					ruleName = rule.RuleName
+				}
+				if !match(rule.RuleTypeName, ruleName) {
+					continue
+				}
+				finding := artifactFinding{
+					Profile:      profile.GetProfileStatus().GetProfileName(),
+					Rule:         ruleName,
+					RuleType:     rule.RuleTypeName,
+					Status:       rule.Status,
+					Severity:     severityName(rule.GetSeverity()),
+					Details:      rule.Details,
+					Guidance:     rule.Guidance,
+					EvaluationID: rule.RuleEvaluationId,
+				}
+				if rule.LastUpdated != nil {
+					finding.LastUpdated = rule.LastUpdated.AsTime().Format(time.RFC3339)
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// severityName returns a rule severity as a lowercase name, e.g. "high",
+// or "" when unspecified.
+func severityName(severity *minderv1.Severity) string {
+	value := severity.GetValue()
+	if value == minderv1.Severity_VALUE_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(value.String(), "VALUE_"))
+}
+
+// findArtifactVersion returns the version whose tag or digest equals version.
+func findArtifactVersion(versions []*minderv1.ArtifactVersion, version string) *minderv1.ArtifactVersion {
+	for _, v := range versions {
+		if v.Sha == version || slices.Contains(v.Tags, version) {
+			return v
+		}
+	}
+	return nil
+}
+
+// summarizeArtifact identifies an artifact, narrowing its versions to version when given.
+func summarizeArtifact(
+	artifact *minderv1.Artifact, versions []*minderv1.ArtifactVersion, version string,
+) (artifactSummary, error) {
+	if len(versions) == 0 {
+		versions = artifact.Versions
+	}
+	summary := artifactSummary{
+		ID:         artifact.ArtifactPk,
+		Name:       artifact.Name,
+		Owner:      artifact.Owner,
+		Type:       artifact.Type,
+		Repository: artifact.Repository,
+	}
+	if version == "" {
+		summary.Versions = versions
+		return summary, nil
+	}
+	summary.Version = findArtifactVersion(versions, version)
+	if summary.Version == nil {
+		return summary, fmt.Errorf("artifact %s has no version with tag or digest %q", artifact.Name, version)
+	}
+	return summary, nil
+}
+
+// isVulnerabilityRule reports whether a rule reports vulnerability scan results.
+func isVulnerabilityRule(ruleType, rule string) bool {
+	name := strings.ToLower(ruleType + " " + rule)
+	return slices.ContainsFunc(vulnerabilityRuleKeywords, func(keyword string) bool {
+		return strings.Contains(name, keyword)
+	})
+}
+
+// getArtifactVulnerabilities reports the vulnerability findings Minder has
+// evaluated for an artifact, from rules whose type or name refers to
+// vulnerability scanning.
+func (t *Tools) getArtifactVulnerabilities(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	artifactID := req.GetString("artifact_id", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	version := req.GetString("version", "")
+
+	if errMsg := ValidateLookupParams(artifactID, name, "artifact_id", "name", map[string]string{
+		"project_id": projectID,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	artifact, versions, err := lookupArtifact(ctx, client, artifactID, name, projectID, "")
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	summary, err := summarizeArtifact(artifact, versions, version)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	findings, err := artifactEvaluations(ctx, client, artifact, isVulnerabilityRule)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	result := map[string]any{
+		"artifact": summary,
+		"findings": findings,
+		"failing":  countFailing(findings),
+	}
+	if len(findings) == 0 {
+		result["note"] = "no vulnerability rules have evaluated this artifact; rules are matched by type or name " +
+			"containing " + strings.Join(vulnerabilityRuleKeywords, ", ")
+	}
+	return marshalResult(ctx, result)
+}
+
+// countFailing returns how many findings failed or errored.
+func countFailing(findings []artifactFinding) int {
+	n := 0
+	for _, f := range findings {
+		if watcher.IsFailing(f.Status) {
+			n++
+		}
+	}
+	return n
+}
+
+// provenanceRuleKeywords identify rule types that verify artifact signatures,
+// attestations or build provenance, such as Minder's artifact_signature rule.
+var provenanceRuleKeywords = []string{"signature", "signed", "provenance", "attestation", "slsa", "sigstore", "cosign"}
+
+// Consolidated provenance verification states.
+const (
+	provenanceVerified     = "verified"
+	provenanceFailing      = "failing"
+	provenanceIncomplete   = "incomplete"
+	provenanceNotEvaluated = "not_evaluated"
+)
+
+// isProvenanceRule reports whether a rule verifies signatures or provenance.
+func isProvenanceRule(ruleType, rule string) bool {
+	name := strings.ToLower(ruleType + " " + rule)
+	return slices.ContainsFunc(provenanceRuleKeywords, func(keyword string) bool {
+		return strings.Contains(name, keyword)
+	})
+}
+
+// provenanceStatus consolidates provenance rule evaluations into one state:
+// failing if any rule fails, verified if every rule passed, not_evaluated when
+// there are none, and incomplete otherwise (e.g. skipped or pending rules).
+func provenanceStatus(findings []artifactFinding) string {
+	if len(findings) == 0 {
+		return provenanceNotEvaluated
+	}
+	passed := 0
+	for _, f := range findings {
+		if watcher.IsFailing(f.Status) {
+			return provenanceFailing
+		}
+		if f.Status == "success" {
+			passed++
+		}
+	}
+	if passed == len(findings) {
+		return provenanceVerified
+	}
+	return provenanceIncomplete
+}
+
+// getArtifactProvenance reports the signature and provenance verification
+// status of an artifact, consolidated from the rules that verify it.
+func (t *Tools) getArtifactProvenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	artifactID := req.GetString("artifact_id", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	version := req.GetString("version", "")
+
+	if errMsg := ValidateLookupParams(artifactID, name, "artifact_id", "name", map[string]string{
+		"project_id": projectID,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	artifact, versions, err := lookupArtifact(ctx, client, artifactID, name, projectID, "")
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	summary, err := summarizeArtifact(artifact, versions, version)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	findings, err := artifactEvaluations(ctx, client, artifact, isProvenanceRule)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	return marshalResult(ctx, map[string]any{
+		"artifact": summary,
+		"status":   provenanceStatus(findings),
+		"rules":    findings,
+	})
+}
@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// newArtifactEvalClient returns a mock client with one artifact evaluated by a
+// vulnerability rule and a signature rule.
+func newArtifactEvalClient() *mockMinderClient {
+	mockClient := newMockClient()
+	mockClient.artifacts.getByIDResp = &minderv1.GetArtifactByIdResponse{
+		Artifact: &minderv1.Artifact{
+			ArtifactPk: "art-1",
+			Name:       "minder-server",
+			Context:    &minderv1.Context{Project: ptr("proj-1")},
+		},
+		Versions: []*minderv1.ArtifactVersion{
+			{VersionId: 1, Tags: []string{"v1.0.0"}, Sha: "sha256:aaa"},
+			{VersionId: 2, Tags: []string{"latest", "v1.1.0"}, Sha: "sha256:bbb"},
+		},
+	}
+	mockClient.evalResults.listResultsResp = &minderv1.ListEvaluationResultsResponse{
+		Entities: []*minderv1.ListEvaluationResultsResponse_EntityEvaluationResults{
+			{
+				Profiles: []*minderv1.ListEvaluationResultsResponse_EntityProfileEvaluationResults{
+					{
+						ProfileStatus: &minderv1.ProfileStatus{ProfileName: "supply-chain"},
+						Results: []*minderv1.RuleEvaluationStatus{
+							{
+								RuleTypeName:        "osv_vulnerabilities",
+								RuleDescriptionName: "no_critical_cves",
+								Status:              "failure",
+								Details:             "CVE-2026-0001 in libfoo",
+								Severity:            &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH},
+							},
+							{
+								RuleTypeName: "artifact_signature",
+								RuleName:     "signed",
+								Status:       "success",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return mockClient
+}
+
+func TestGetArtifactVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newArtifactEvalClient()
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getArtifactVulnerabilities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"artifact_id": "art-1", "version": "latest"}},
+	})
+	if err != nil {
+		t.Fatalf("getArtifactVulnerabilities() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Artifact artifactSummary   `json:"artifact"`
+		Findings []artifactFinding `json:"findings"`
+		Failing  int               `json:"failing"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Findings) != 1 || got.Findings[0].Rule != "no_critical_cves" || got.Findings[0].Severity != "high" {
+		t.Errorf("findings = %+v, want only the high severity OSV finding", got.Findings)
+	}
+	if got.Failing != 1 {
+		t.Errorf("failing = %d, want 1", got.Failing)
+	}
+	if got.Artifact.Version == nil || got.Artifact.Version.Sha != "sha256:bbb" {
+		t.Errorf("version = %+v, want the version tagged latest", got.Artifact.Version)
+	}
+
+	req := mockClient.evalResults.listResultsReq
+	if req.GetContext().GetProject() != "proj-1" || req.Entity[0].Id != "art-1" ||
+		req.Entity[0].Type != minderv1.Entity_ENTITY_ARTIFACTS {
+		t.Errorf("ListEvaluationResults request = %v, want artifact art-1 in proj-1", req)
+	}
+}
+
+func TestGetArtifactVulnerabilities_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "unknown version",
+			args:    map[string]any{"artifact_id": "art-1", "version": "v9.9.9"},
+			wantMsg: `no version with tag or digest "v9.9.9"`,
+		},
+		{
+			name:    "missing lookup",
+			args:    map[string]any{},
+			wantMsg: "either artifact_id or name must be provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tools := newTestTools(newArtifactEvalClient())
+			result, err := tools.getArtifactVulnerabilities(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("getArtifactVulnerabilities() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestIsVulnerabilityRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ruleType string
+		rule     string
+		want     bool
+	}{
+		{ruleType: "pr_vulnerability_check", want: true},
+		{ruleType: "custom", rule: "Trivy scan", want: true},
+		{ruleType: "artifact_signature", rule: "signed", want: false},
+	}
+	for _, tt := range tests {
+		if got := isVulnerabilityRule(tt.ruleType, tt.rule); got != tt.want {
+			t.Errorf("isVulnerabilityRule(%q, %q) = %v, want %v", tt.ruleType, tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestGetArtifactProvenance(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newArtifactEvalClient())
+
+	result, err := tools.getArtifactProvenance(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"artifact_id": "art-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getArtifactProvenance() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Artifact artifactSummary   `json:"artifact"`
+		Status   string            `json:"status"`
+		Rules    []artifactFinding `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Status != provenanceVerified {
+		t.Errorf("status = %q, want %q", got.Status, provenanceVerified)
+	}
+	if len(got.Rules) != 1 || got.Rules[0].RuleType != "artifact_signature" {
+		t.Errorf("rules = %+v, want only the signature rule", got.Rules)
+	}
+	if len(got.Artifact.Versions) != 2 {
+		t.Errorf("versions = %+v, want both tracked versions", got.Artifact.Versions)
+	}
+}
+
+func TestProvenanceStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []string
+		want     string
+	}{
+		{name: "no rules", want: provenanceNotEvaluated},
+		{name: "all passing", statuses: []string{"success", "success"}, want: provenanceVerified},
+		{name: "one failing", statuses: []string{"success", "failure"}, want: provenanceFailing},
+		{name: "evaluation error", statuses: []string{"error"}, want: provenanceFailing},
+		{name: "skipped", statuses: []string{"success", "skipped"}, want: provenanceIncomplete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var findings []artifactFinding
+			for _, status := range tt.statuses {
+				findings = append(findings, artifactFinding{Status: status})
+			}
+			if got := provenanceStatus(findings); got != tt.want {
+				t.Errorf("provenanceStatus(%v) = %q, want %q", tt.statuses, got, tt.want)
+			}
+		})
+	}
+}
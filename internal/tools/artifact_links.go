@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// linkedArtifact is an artifact in an artifact-to-repository link.
+type linkedArtifact struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// linkedRepository is the source repository of an artifact. ID is empty when
+// the repository is not registered in Minder.
+type linkedRepository struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Registered bool   `json:"registered"`
+}
+
+// artifactLink connects an artifact to the repository it was built from.
+// Repository is nil when Minder does not know the artifact's source.
+type artifactLink struct {
+	Project    string            `json:"project"`
+	Artifact   linkedArtifact    `json:"artifact"`
+	Repository *linkedRepository `json:"repository"`
+}
+
+// artifactSource returns the full name of the repository an artifact was
+// built from. Minder records it as "owner/name" or as a name under the
+// artifact's owner.
+func artifactSource(a *minderv1.Artifact) string {
+	repo := a.GetRepository()
+	if repo == "" || strings.Contains(repo, "/") || a.GetOwner() == "" {
+		return repo
+	}
+	return a.GetOwner() + "/" + repo
+}
+
+// projectArtifactLinks links the artifacts of a project to its registered
+// repositories by source repository name.
+func projectArtifactLinks(
+	projectID string, artifacts []*minderv1.Artifact, repos []*minderv1.Repository,
+) []artifactLink {
+	byName := map[string]*minderv1.Repository{}
+	for _, r := range repos {
+		byName[strings.ToLower(r.GetOwner()+"/"+r.GetName())] = r
+	}
+	links := make([]artifactLink, 0, len(artifacts))
+	for _, a := range artifacts {
+		link := artifactLink{
+			Project:  projectID,
+			Artifact: linkedArtifact{ID: a.GetArtifactPk(), Name: a.GetName(), Type: a.GetType()},
+		}
+		if source := artifactSource(a); source != "" {
+			link.Repository = &linkedRepository{Name: source}
+			if r, ok := byName[strings.ToLower(source)]; ok {
+				link.Repository = &linkedRepository{ID: r.GetId(), Name: r.GetOwner() + "/" + r.GetName(), Registered: true}
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// getArtifactLinks maps artifacts to the repositories they were built from,
+// for one artifact, for the artifacts of one repository, or for every
+// artifact in a project or all accessible projects.
+func (t *Tools) getArtifactLinks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	repoID := req.GetString("repository_id", "")
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	artifactID := req.GetString("artifact_id", "")
+	artifactName := req.GetString("artifact_name", "")
+	projectID := req.GetString("project_id", "")
+
+	byArtifact := artifactID != "" || artifactName != ""
+	byRepo := repoID != "" || owner != "" || name != ""
+	if byArtifact || byRepo {
+		if errMsg := validateEntityLookup(repoID, owner, name, artifactID, artifactName, projectID, ""); errMsg != "" {
+			return mcp.NewToolResultError(errMsg), nil
+		}
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectID, keep, err := linkFilter(ctx, client, repoID, owner, name, artifactID, artifactName, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	projectIDs, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	links := collectArtifactLinks(ctx, client, projectIDs, keep)
+	slices.SortFunc(links, func(a, b artifactLink) int {
+		return cmp.Or(cmp.Compare(a.Artifact.Name, b.Artifact.Name), cmp.Compare(a.Artifact.ID, b.Artifact.ID))
+	})
+
+	links, total := capResults(links, t.cfg.MCP.MaxResults)
+	result := map[string]any{"links": links}
+	if byRepo && len(links) == 0 {
+		result["note"] = "no artifact tracked by Minder was built from this repository"
+	}
+	return t.marshalCapped(ctx, result, len(links), total)
+}
+
+// linkFilter resolves the artifact or repository asked about, if any, and
+// returns the project to search and a filter for its links.
+func linkFilter(
+	ctx context.Context, client MinderClient, repoID, owner, name, artifactID, artifactName, projectID string,
+) (string, func(artifactLink) bool, error) {
+	switch {
+	case artifactID != "" || artifactName != "":
+		artifact, _, err := lookupArtifact(ctx, client, artifactID, artifactName, projectID, "")
+		if err != nil {
+			return "", nil, err
+		}
+		keep := func(l artifactLink) bool { return l.Artifact.ID == artifact.GetArtifactPk() }
+		return cmp.Or(artifact.GetContext().GetProject(), projectID), keep, nil
+	case repoID != "" || owner != "" || name != "":
+		repo, err := lookupRepository(ctx, client, repoID, owner, name, projectID, "")
+		if err != nil {
+			return "", nil, err
+		}
+		keep := func(l artifactLink) bool { return l.Repository != nil && l.Repository.ID == repo.GetId() }
+		return cmp.Or(repo.GetContext().GetProject(), projectID), keep, nil
+	}
+	return projectID, func(artifactLink) bool { return true }, nil
+}
+
+// collectArtifactLinks links the artifacts of each project that keep accepts.
+// Projects whose artifacts or repositories cannot be listed are skipped.
+func collectArtifactLinks(
+	ctx context.Context, client MinderClient, projectIDs []string, keep func(artifactLink) bool,
+) []artifactLink {
+	links := []artifactLink{}
+	for _, projID := range projectIDs {
+		artifacts, err := client.Artifacts().ListArtifacts(ctx, &minderv1.ListArtifactsRequest{
+			Context: &minderv1.Context{Project: &projID},
+		})
+		if err != nil {
+			continue
+		}
+		repos, err := client.Repositories().ListRepositories(ctx, &minderv1.ListRepositoriesRequest{
+			Context: &minderv1.Context{Project: &projID},
+		})
+		if err != nil {
+			continue
+		}
+		for _, link := range projectArtifactLinks(projID, artifacts.GetResults(), repos.GetResults()) {
+			if keep(link) {
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
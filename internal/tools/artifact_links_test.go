@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestArtifactSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		artifact *minderv1.Artifact
+		want     string
+	}{
+		{"full name", &minderv1.Artifact{Owner: "acme", Repository: "acme/api"}, "acme/api"},
+		{"name under owner", &minderv1.Artifact{Owner: "acme", Repository: "api"}, "acme/api"},
+		{"no owner", &minderv1.Artifact{Repository: "api"}, "api"},
+		{"unknown", &minderv1.Artifact{Owner: "acme"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := artifactSource(tt.artifact); got != tt.want {
+				t.Errorf("artifactSource() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newArtifactLinksMock() *mockMinderClient {
+	projectID := "proj-1"
+	mockClient := newMockClient()
+	mockClient.artifacts.listResp = &minderv1.ListArtifactsResponse{
+		Results: []*minderv1.Artifact{
+			{ArtifactPk: "art-2", Name: "web", Type: "container", Owner: "acme", Repository: "web"},
+			{ArtifactPk: "art-1", Name: "api", Type: "container", Owner: "acme", Repository: "Acme/API"},
+			{ArtifactPk: "art-3", Name: "tools", Type: "container", Owner: "acme"},
+		},
+	}
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{
+		Results: []*minderv1.Repository{
+			{Id: ptr("repo-1"), Owner: "acme", Name: "api"},
+			{Id: ptr("repo-2"), Owner: "acme", Name: "docs"},
+		},
+	}
+	mockClient.artifacts.getByIDResp = &minderv1.GetArtifactByIdResponse{
+		Artifact: &minderv1.Artifact{ArtifactPk: "art-1", Name: "api", Context: &minderv1.Context{Project: &projectID}},
+	}
+	return mockClient
+}
+
+type artifactLinksResult struct {
+	Links []artifactLink `json:"links"`
+	Note  string         `json:"note"`
+}
+
+func callGetArtifactLinks(t *testing.T, mockClient *mockMinderClient, args map[string]any) artifactLinksResult {
+	t.Helper()
+	result, err := newTestTools(mockClient).getArtifactLinks(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("getArtifactLinks() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got artifactLinksResult
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	return got
+}
+
+func TestGetArtifactLinks_Project(t *testing.T) {
+	t.Parallel()
+
+	got := callGetArtifactLinks(t, newArtifactLinksMock(), map[string]any{"project_id": "proj-1"})
+	if len(got.Links) != 3 {
+		t.Fatalf("got %d links, want 3: %+v", len(got.Links), got.Links)
+	}
+	api, tools, web := got.Links[0], got.Links[1], got.Links[2]
+	if api.Artifact.ID != "art-1" || api.Project != "proj-1" || api.Repository == nil ||
+		api.Repository.ID != "repo-1" || api.Repository.Name != "acme/api" || !api.Repository.Registered {
+		t.Errorf("api link = %+v, want registered repository repo-1", api)
+	}
+	if tools.Artifact.Name != "tools" || tools.Repository != nil {
+		t.Errorf("tools link = %+v, want no repository", tools)
+	}
+	if web.Artifact.Name != "web" || web.Repository == nil ||
+		web.Repository.Name != "acme/web" || web.Repository.Registered || web.Repository.ID != "" {
+		t.Errorf("web link = %+v, want unregistered acme/web", web)
+	}
+}
+
+func TestGetArtifactLinks_Artifact(t *testing.T) {
+	t.Parallel()
+
+	got := callGetArtifactLinks(t, newArtifactLinksMock(), map[string]any{"artifact_id": "art-1"})
+	if len(got.Links) != 1 || got.Links[0].Artifact.ID != "art-1" || got.Links[0].Repository.ID != "repo-1" {
+		t.Errorf("links = %+v, want art-1 linked to repo-1", got.Links)
+	}
+}
+
+func TestGetArtifactLinks_Repository(t *testing.T) {
+	t.Parallel()
+
+	projectID := "proj-1"
+	mockClient := newArtifactLinksMock()
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{
+		Repository: &minderv1.Repository{Id: ptr("repo-1"), Context: &minderv1.Context{Project: &projectID}},
+	}
+	got := callGetArtifactLinks(t, mockClient, map[string]any{"repository_id": "repo-1"})
+	if len(got.Links) != 1 || got.Links[0].Artifact.ID != "art-1" || got.Note != "" {
+		t.Errorf("result = %+v, want only art-1", got)
+	}
+
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{
+		Repository: &minderv1.Repository{Id: ptr("repo-2"), Context: &minderv1.Context{Project: &projectID}},
+	}
+	got = callGetArtifactLinks(t, mockClient, map[string]any{"repository_id": "repo-2"})
+	if len(got.Links) != 0 || got.Note == "" {
+		t.Errorf("result = %+v, want no links and a note", got)
+	}
+}
+
+func TestGetArtifactLinks_InvalidLookup(t *testing.T) {
+	t.Parallel()
+
+	result, err := newTestTools(newArtifactLinksMock()).getArtifactLinks(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"artifact_id": "art-1", "repository_id": "repo-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getArtifactLinks() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected error result for artifact and repository together, got %s", getResultText(t, result))
+	}
+}
@@ -10,7 +10,7 @@ import (
 func (t *Tools) listArtifacts(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -38,10 +38,14 @@ func (t *Tools) listArtifacts(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return resp.Results, nil
 	})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(artifacts)
+	sortByProject(artifacts, func(a *minderv1.Artifact) (string, string) {
+		return a.GetContext().GetProject(), a.GetOwner() + "/" + a.GetName()
+	})
+	artifacts, total := capResults(artifacts, t.cfg.MCP.MaxResults)
+	return t.marshalCapped(ctx, artifacts, len(artifacts), total)
 }
 
 func (t *Tools) getArtifact(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -60,7 +64,7 @@ func (t *Tools) getArtifact(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -68,20 +72,32 @@ func (t *Tools) getArtifact(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		return errResult, nil
 	}
 
-	var artifact *minderv1.Artifact
+	artifact, _, err := lookupArtifact(ctx, client, artifactID, name, projectID, provider)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	return marshalResult(ctx, artifact)
+}
 
+// lookupArtifact gets an artifact by ID, or by name searching across projects
+// if projectID is empty, along with any versions returned alongside it.
+func lookupArtifact(
+	ctx context.Context, client MinderClient, artifactID, name, projectID, provider string,
+) (*minderv1.Artifact, []*minderv1.ArtifactVersion, error) {
 	if artifactID != "" {
 		// Lookup by ID - no project context needed
 		resp, err := client.Artifacts().GetArtifactById(ctx, &minderv1.GetArtifactByIdRequest{
 			Id: artifactID,
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return nil, nil, err
 		}
-		artifact = resp.Artifact
-	} else {
-		// Lookup by name - search across projects if none specified
-		artifact, err = findInProjects(ctx, client, projectID, func(ctx context.Context, projID string) (*minderv1.Artifact, error) {
+		return resp.Artifact, resp.Versions, nil
+	}
+
+	resp, err := findOneInProjects(ctx, client, projectID, "artifact "+name,
+		func(ctx context.Context, projID string) (*minderv1.GetArtifactByNameResponse, error) {
 			reqProto := &minderv1.GetArtifactByNameRequest{
 				Name: name,
 				Context: &minderv1.Context{
@@ -91,16 +107,10 @@ func (t *Tools) getArtifact(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 			if provider != "" {
 				reqProto.Context.Provider = &provider
 			}
-			resp, err := client.Artifacts().GetArtifactByName(ctx, reqProto)
-			if err != nil {
-				return nil, err
-			}
-			return resp.Artifact, nil
+			return client.Artifacts().GetArtifactByName(ctx, reqProto)
 		})
-		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
-		}
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return marshalResult(artifact)
+	return resp.Artifact, resp.Versions, nil
 }
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// reauthError reports a Minder token that can no longer be used, with the
+// steps to obtain a new one.
+type reauthError struct {
+	server string
+	// realmURL is the identity provider the user signs in to. Empty when it
+	// could not be discovered.
+	realmURL string
+	err      error
+}
+
+func (e *reauthError) Error() string {
+	msg := fmt.Sprintf("the Minder token for server %q can no longer be used: %v. %s", e.server, e.err, e.nextStep())
+	if e.realmURL != "" {
+		msg += " Identity provider: " + e.realmURL
+	}
+	return msg
+}
+
+func (e *reauthError) Unwrap() error {
+	return e.err
+}
+
+// nextStep tells the user how to replace the token.
+func (*reauthError) nextStep() string {
+	return "Sign in again with `minder auth login`, create a new offline token with " +
+		"`minder auth offline-token get`, and save it for this session with minder_save_credentials, " +
+		"or set it as MINDER_AUTH_TOKEN or send it in the Authorization header."
+}
+
+// tokenError adds re-authentication guidance to a token validation error that
+// signing in again would fix, such as an expired access token or a revoked
+// refresh token. Other errors are returned wrapped but unchanged.
+func (t *Tools) tokenError(ctx context.Context, srv config.NamedServer, serverCfg minder.ServerConfig, err error) error {
+	if !errors.Is(err, minder.ErrTokenExpired) && !errors.Is(err, minder.ErrReauthenticationRequired) {
+		return fmt.Errorf("token validation failed: %w", err)
+	}
+	reauth := &reauthError{server: srv.Name, err: err}
+	if t.tokenRefresher != nil {
+		// The realm is only a hint; a discovery failure must not hide the token error
+		if realmURL, realmErr := t.tokenRefresher.RealmURL(ctx, serverCfg); realmErr == nil {
+			reauth.realmURL = realmURL
+		}
+	}
+	return reauth
+}
+
+// clientErrorResult returns the error result for a Minder client that could
+// not be created.
+func clientErrorResult(err error) *mcp.CallToolResult {
+	return errorResult(err.Error(), NewErrorDetail(err))
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+func TestTokenError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReauth bool
+	}{
+		{
+			name:       "expired access token",
+			err:        fmt.Errorf("%w: provide a fresh access token", minder.ErrTokenExpired),
+			wantReauth: true,
+		},
+		{
+			name: "revoked refresh token",
+			err: fmt.Errorf("%w: refresh token is expired or revoked; %w",
+				minder.ErrRefreshFailed, minder.ErrReauthenticationRequired),
+			wantReauth: true,
+		},
+		{
+			name: "malformed token",
+			err:  fmt.Errorf("%w: bad segment", minder.ErrTokenMalformed),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tools := newTestTools(newMockClient())
+			srv := config.NamedServer{Name: config.DefaultServerName}
+			err := tools.tokenError(context.Background(), srv, minder.ServerConfig{}, tt.err)
+			require.ErrorIs(t, err, tt.err)
+
+			var reauth *reauthError
+			assert.Equal(t, tt.wantReauth, errors.As(err, &reauth))
+			if tt.wantReauth {
+				assert.Contains(t, err.Error(), "minder auth login")
+				assert.Contains(t, err.Error(), `server "default"`)
+			} else {
+				assert.Contains(t, err.Error(), "token validation failed")
+			}
+		})
+	}
+}
+
+func TestClientErrorResult_Reauth(t *testing.T) {
+	t.Parallel()
+
+	err := &reauthError{
+		server:   "default",
+		realmURL: "https://auth.example.com/realms/stacklok",
+		err:      minder.ErrTokenExpired,
+	}
+	result := clientErrorResult(err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getResultText(t, result), "Identity provider: https://auth.example.com/realms/stacklok")
+
+	detail, ok := errorDetailOf(result)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeUnauthenticated, detail.Code)
+	assert.False(t, detail.Retryable)
+	assert.Equal(t, "https://auth.example.com/realms/stacklok", detail.RealmURL)
+	assert.Contains(t, detail.SuggestedAction, "minder auth offline-token get")
+	assert.Contains(t, detail.SuggestedAction, "minder_save_credentials")
+}
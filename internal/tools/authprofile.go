@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// authProfileParam selects a configured service-account token for one call.
+const authProfileParam = "auth_profile"
+
+// authProfileKey is the context key holding the auth profile a call selected.
+type authProfileKey struct{}
+
+// authProfileFromContext returns the auth profile the call in ctx selected, if any.
+func authProfileFromContext(ctx context.Context) (config.AuthProfile, bool) {
+	profile, ok := ctx.Value(authProfileKey{}).(config.AuthProfile)
+	return profile, ok
+}
+
+// withAuthProfile adds the auth_profile argument to the tool when auth
+// profiles are configured. A call naming a profile uses its token in place
+// of the configured token, so clients run with a least-privilege token by
+// default and escalate one call at a time.
+func (t *Tools) withAuthProfile(tool mcp.Tool, handler server.ToolHandlerFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	names := t.cfg.Minder.AuthProfileNames()
+	if len(names) == 0 {
+		return tool, handler
+	}
+	properties := maps.Clone(tool.InputSchema.Properties)
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	properties[authProfileParam] = map[string]any{
+		"type": "string",
+		"enum": names,
+		"description": "Configured service-account token to call Minder with instead of the default one. " +
+			"Omit it unless the default token lacks the access the call needs.",
+	}
+	tool.InputSchema.Properties = properties
+
+	return tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.GetString(authProfileParam, "")
+		if name == "" {
+			return handler(ctx, req)
+		}
+		profile, ok := t.cfg.Minder.AuthProfile(name)
+		if !ok {
+			return errorResult(fmt.Sprintf("unknown auth_profile %q: use one of %s", name, strings.Join(names, ", ")),
+				ErrorDetail{Code: ErrCodeInvalidArgument, SuggestedAction: "Omit auth_profile or pass one of the listed profiles."}), nil
+		}
+		if err := t.authProfileAllowed(ctx, profile); err != nil {
+			t.logger.WarnContext(ctx, "auth profile refused", "tool", t.toolName(tool.Name), "auth_profile", name, "error", err)
+			return errorResult(err.Error(), ErrorDetail{
+				Code:            ErrCodePermissionDenied,
+				SuggestedAction: "Call again without auth_profile.",
+			}), nil
+		}
+		t.logger.InfoContext(ctx, "tool call selected auth profile", "tool", t.toolName(tool.Name), "auth_profile", name)
+		return handler(context.WithValue(ctx, authProfileKey{}, profile), req)
+	}
+}
+
+// authProfileAllowed checks that the call in ctx may select profile. The call
+// must come from one of the profile's allowed networks, and would otherwise
+// use the configured token: a profile never replaces a caller's own
+// Authorization header or saved credentials.
+func (t *Tools) authProfileAllowed(ctx context.Context, profile config.AuthProfile) error {
+	srv, _ := t.cfg.Minder.Server(profile.Server)
+	if middleware.TokenFromHeader(ctx) || t.sessionToken(ctx, srv) != "" {
+		return fmt.Errorf("auth_profile %s only applies to calls using the configured token, "+
+			"not to calls with their own credentials", profile.Name)
+	}
+	addr, ok := middleware.ClientAddrFromContext(ctx)
+	prefixes, err := profile.AllowedPrefixes()
+	if !ok || err != nil || !slices.ContainsFunc(prefixes, func(p netip.Prefix) bool { return p.Contains(addr.Unmap()) }) {
+		return fmt.Errorf("this client may not use auth_profile %s", profile.Name)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+func newAuthProfileTools() *Tools {
+	tools := newMultiServerTools()
+	tools.cfg.Minder.AuthToken = "read-only-token"
+	tools.cfg.Minder.AuthProfiles = []config.AuthProfile{
+		{Name: "admin", Token: "admin-token", Server: config.DefaultServerName, AllowedCIDRs: []string{"10.0.0.0/8"}},
+		{Name: "staging-admin", Token: "staging-admin-token", Server: "staging", AllowedCIDRs: []string{"10.0.0.0/8"}},
+	}
+	return tools
+}
+
+func TestWithAuthProfile_Schema(t *testing.T) {
+	t.Parallel()
+
+	tool := mcp.NewTool("minder_list_profiles", mcp.WithString("project_id"))
+	noop := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) { return nil, nil }
+
+	plain, _ := newMultiServerTools().withAuthProfile(tool, noop)
+	if _, ok := plain.InputSchema.Properties[authProfileParam]; ok {
+		t.Error("auth_profile added without configured auth profiles")
+	}
+
+	got, _ := newAuthProfileTools().withAuthProfile(tool, noop)
+	prop, ok := got.InputSchema.Properties[authProfileParam].(map[string]any)
+	if !ok {
+		t.Fatalf("auth_profile not added: %v", got.InputSchema.Properties)
+	}
+	if enum, _ := prop["enum"].([]string); !slices.Equal(enum, []string{"admin", "staging-admin"}) {
+		t.Errorf("auth_profile enum = %v, want the profile names", prop["enum"])
+	}
+	if _, ok := got.InputSchema.Properties["project_id"]; !ok {
+		t.Error("existing parameters were dropped")
+	}
+	if _, ok := tool.InputSchema.Properties[authProfileParam]; ok {
+		t.Error("the original tool definition was modified")
+	}
+}
+
+func TestWithAuthProfile_Call(t *testing.T) {
+	t.Parallel()
+
+	tools := newAuthProfileTools()
+	defaultServer, _ := tools.cfg.Minder.Server(config.DefaultServerName)
+	staging, _ := tools.cfg.Minder.Server("staging")
+	allowed := middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("10.1.2.3"))
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		profile      string
+		wantDefault  string
+		wantStaging  string
+		wantContains string
+		wantCode     string
+	}{
+		{name: "default token", ctx: allowed, wantDefault: "read-only-token"},
+		{name: "escalated", ctx: allowed, profile: "admin", wantDefault: "admin-token", wantStaging: ""},
+		{name: "other server's profile", ctx: allowed, profile: "staging-admin", wantDefault: "", wantStaging: "staging-admin-token"},
+		{
+			name:         "unknown profile",
+			ctx:          allowed,
+			profile:      "root",
+			wantContains: `unknown auth_profile "root": use one of admin, staging-admin`,
+			wantCode:     ErrCodeInvalidArgument,
+		},
+		{
+			name:         "caller with its own token",
+			ctx:          middleware.ContextWithHeaderToken(allowed, "caller-token"),
+			profile:      "admin",
+			wantContains: "only applies to calls using the configured token",
+			wantCode:     ErrCodePermissionDenied,
+		},
+		{
+			name:         "client outside the allowed networks",
+			ctx:          middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("192.168.1.5")),
+			profile:      "admin",
+			wantContains: "this client may not use auth_profile admin",
+			wantCode:     ErrCodePermissionDenied,
+		},
+		{
+			name:         "unknown client address",
+			ctx:          context.Background(),
+			profile:      "admin",
+			wantContains: "this client may not use auth_profile admin",
+			wantCode:     ErrCodePermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var gotDefault, gotStaging string
+			called := false
+			_, handler := tools.withAuthProfile(mcp.NewTool("minder_list_profiles"),
+				func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					called = true
+					gotDefault, gotStaging = tools.tokenFor(ctx, defaultServer), tools.tokenFor(ctx, staging)
+					return mcp.NewToolResultText("ok"), nil
+				})
+			args := map[string]any{}
+			if tt.profile != "" {
+				args[authProfileParam] = tt.profile
+			}
+			result, err := handler(tt.ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			if err != nil {
+				t.Fatalf("handler returned Go error: %v", err)
+			}
+
+			if tt.wantContains != "" {
+				if called || !result.IsError || !strings.Contains(getResultText(t, result), tt.wantContains) {
+					t.Errorf("result = %s, want an error containing %q", getResultText(t, result), tt.wantContains)
+				}
+				if detail, _ := errorDetailOf(result); detail.Code != tt.wantCode {
+					t.Errorf("error code = %q, want %q", detail.Code, tt.wantCode)
+				}
+				return
+			}
+			if gotDefault != tt.wantDefault {
+				t.Errorf("tokenFor(default) = %q, want %q", gotDefault, tt.wantDefault)
+			}
+			if tt.profile != "" && gotStaging != tt.wantStaging {
+				t.Errorf("tokenFor(staging) = %q, want %q", gotStaging, tt.wantStaging)
+			}
+		})
+	}
+}
+
+func TestAddTool_AuthProfileRefusal(t *testing.T) {
+	t.Parallel()
+
+	tools := newAuthProfileTools()
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.addTool(s, mcp.NewTool("minder_test_read", mcp.WithReadOnlyHintAnnotation(true)), okHandler)
+	handler := s.GetTool("minder_test_read").Handler
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{authProfileParam: "admin"}}}
+	outside := middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("192.0.2.1"))
+
+	// The refusal is an error result like any other, with a request ID
+	result, err := handler(middleware.ContextWithRequestID(outside, "req-42"), req)
+	if err != nil || result == nil || !result.IsError {
+		t.Fatalf("handler() = %v, %v, want an error result", result, err)
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok || detail.Code != ErrCodePermissionDenied || detail.RequestID != "req-42" {
+		t.Errorf("error detail = %+v, want permission_denied with the request ID", detail)
+	}
+
+	// A disabled tool is reported as such before auth_profile is considered
+	tools.SetEnabledTools([]string{"minder_list_profiles"})
+	result, err = handler(outside, req)
+	if err != nil || !result.IsError || !strings.Contains(getResultText(t, result), "disabled") {
+		t.Errorf("handler() = %v, %v, want the disabled tool error", result, err)
+	}
+}
+
+func TestClientFor_AuthProfileOfAnotherServer(t *testing.T) {
+	t.Parallel()
+
+	tools := newAuthProfileTools()
+	staging, _ := tools.cfg.Minder.Server("staging")
+	admin, _ := tools.cfg.Minder.AuthProfile("admin")
+	ctx := context.WithValue(context.Background(), authProfileKey{}, admin)
+
+	_, err := tools.clientFor(ctx, staging)
+	if err == nil || !strings.Contains(err.Error(), "auth profile admin holds a token for server default") {
+		t.Errorf("clientFor() error = %v, want the profile's token kept from the staging server", err)
+	}
+}
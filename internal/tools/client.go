@@ -4,6 +4,7 @@ import (
 	"context"
 
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc"
 
 	"github.com/stacklok/minder-mcp/internal/minder"
 )
@@ -17,9 +18,12 @@ type MinderClient interface {
 	RuleTypes() minderv1.RuleTypeServiceClient
 	DataSources() minderv1.DataSourceServiceClient
 	Providers() minderv1.ProvidersServiceClient
+	OAuth() minderv1.OAuthServiceClient
 	Projects() minderv1.ProjectsServiceClient
 	Artifacts() minderv1.ArtifactServiceClient
 	EvalResults() minderv1.EvalResultsServiceClient
+	// Conn is used by minder_raw_call to invoke RPCs by name.
+	Conn() grpc.ClientConnInterface
 }
 
 // ClientFactory creates MinderClient instances.
@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/callmeta"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// ComplianceSnapshot collects the status of every profile, and each of its rule
+// evaluations, across the projects in MCP_WATCH_PROJECTS, or every project
+// accessible to the token in ctx when none are configured.
+func (t *Tools) ComplianceSnapshot(ctx context.Context) (watcher.Snapshot, error) {
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return watcher.Snapshot{}, err
+	}
+	defer func() { _ = client.Close() }()
+
+	return collectSnapshot(ctx, client, "", t.cfg.Watch.Projects...)
+}
+
+// LabeledComplianceSnapshot returns a function collecting the snapshot
+// ComplianceSnapshot does, limited to the profiles matching labelFilter,
+// e.g. "team:payments".
+func (t *Tools) LabeledComplianceSnapshot(labelFilter string) watcher.FetchFunc {
+	return func(ctx context.Context) (watcher.Snapshot, error) {
+		client, err := t.getClient(ctx)
+		if err != nil {
+			return watcher.Snapshot{}, err
+		}
+		defer func() { _ = client.Close() }()
+
+		return collectSnapshot(ctx, client, labelFilter, t.cfg.Watch.Projects...)
+	}
+}
+
+// collectSnapshot collects the compliance snapshot of the given projects, or of
+// every accessible project when none are given, of the profiles matching
+// labelFilter, or of every profile when it is empty. A project or profile that
+// cannot be read fails the snapshot: a partial one would report the missing
+// profiles as changed on the next poll.
+func collectSnapshot(
+	ctx context.Context, client MinderClient, labelFilter string, projectIDs ...string,
+) (watcher.Snapshot, error) {
+	if len(projectIDs) == 0 {
+		projects, err := listAllProjects(ctx, client)
+		if err != nil {
+			return watcher.Snapshot{}, err
+		}
+		for _, project := range projects {
+			projectIDs = append(projectIDs, project.ProjectId)
+		}
+	}
+	callmeta.AddProjects(ctx, len(projectIDs))
+	if labelFilter == "" {
+		labelFilter = "*"
+	}
+
+	snapshot := watcher.NewSnapshot()
+	for _, projID := range projectIDs {
+		profiles, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+			Context: &minderv1.Context{
+				Project: &projID,
+			},
+			LabelFilter: labelFilter,
+		})
+		if err != nil {
+			return watcher.Snapshot{}, fmt.Errorf("listing profiles of project %s: %w", projID, err)
+		}
+
+		for _, profile := range profiles.Profiles {
+			resp, err := client.Profiles().GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
+				Name: profile.Name,
+				All:  true,
+				Context: &minderv1.Context{
+					Project: &projID,
+				},
+			})
+			if err != nil {
+				return watcher.Snapshot{}, fmt.Errorf("reading status of profile %s in project %s: %w", profile.Name, projID, err)
+			}
+
+			profileKey := projID + "/" + profile.Name
+			snapshot.Profiles[profileKey] = resp.GetProfileStatus().GetProfileStatus()
+			for _, rule := range resp.RuleEvaluationStatus {
+				entityID := rule.EntityInfo["entity_id"]
+				ruleKey := profileKey + "/" + rule.RuleDescriptionName + "/" + entityID
+				snapshot.Rules[ruleKey] = rule.Status
+				snapshot.Entities[entityID] = entityDisplayName(rule.EntityInfo)
+				if severity := severityName(rule.GetSeverity()); severity != "" {
+					snapshot.Severities[ruleKey] = severity
+				}
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// entityDisplayName names an evaluated entity from its entity info,
+// preferring "owner/repo" for repositories and falling back to the entity ID.
+func entityDisplayName(info map[string]string) string {
+	if owner, name := info["repo_owner"], info["repo_name"]; owner != "" && name != "" {
+		return owner + "/" + name
+	}
+	if name := info["name"]; name != "" {
+		return name
+	}
+	return info["entity_id"]
+}
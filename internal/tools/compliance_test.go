@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestComplianceSnapshot(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}},
+	}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline", ProfileStatus: "failure"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{
+				RuleDescriptionName: "branch_protection",
+				Status:              "failure",
+				Severity:            &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH},
+				EntityInfo: map[string]string{
+					"entity_id":  "repo-1",
+					"repo_owner": "stacklok",
+					"repo_name":  "minder",
+				},
+			},
+		},
+	}
+
+	tools := newTestTools(mockClient)
+	snapshot, err := tools.ComplianceSnapshot(context.Background())
+	if err != nil {
+		t.Fatalf("ComplianceSnapshot() returned error: %v", err)
+	}
+
+	if got := snapshot.Profiles["test-project-id/baseline"]; got != "failure" {
+		t.Errorf("profile status = %q, want %q", got, "failure")
+	}
+	if got := snapshot.Rules["test-project-id/baseline/branch_protection/repo-1"]; got != "failure" {
+		t.Errorf("rule status = %q, want %q", got, "failure")
+	}
+	if got := snapshot.Entities["repo-1"]; got != "stacklok/minder" {
+		t.Errorf("entity name = %q, want %q", got, "stacklok/minder")
+	}
+	if got := snapshot.Severities["test-project-id/baseline/branch_protection/repo-1"]; got != "high" {
+		t.Errorf("rule severity = %q, want %q", got, "high")
+	}
+	if got := mockClient.profiles.listReq.GetLabelFilter(); got != "*" {
+		t.Errorf("ListProfiles label filter = %q, want * to include labelled profiles", got)
+	}
+}
+
+func TestComplianceSnapshot_UnreadableProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		listErr   error
+		statusErr error
+	}{
+		{name: "profiles cannot be listed", listErr: status.Error(codes.Unavailable, "down")},
+		{name: "profile status cannot be read", statusErr: status.Error(codes.PermissionDenied, "denied")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+				Profiles: []*minderv1.Profile{{Name: "baseline"}},
+			}
+			mockClient.profiles.listErr = tt.listErr
+			mockClient.profiles.getStatusByNameErr = tt.statusErr
+
+			// A partial snapshot would report the missing profiles as changed
+			if _, err := newTestTools(mockClient).ComplianceSnapshot(context.Background()); err == nil {
+				t.Error("expected error when a profile cannot be read")
+			}
+		})
+	}
+}
+
+func TestComplianceSnapshot_ProjectListError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.projects.listErr = status.Error(codes.Unavailable, "down")
+
+	tools := newTestTools(mockClient)
+	if _, err := tools.ComplianceSnapshot(context.Background()); err == nil {
+		t.Error("expected error when projects cannot be listed")
+	}
+}
+
+func TestComplianceSnapshot_ConfiguredProjects(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.projects.listErr = status.Error(codes.Unavailable, "down")
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}},
+	}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline", ProfileStatus: "success"},
+	}
+
+	tools := newTestTools(mockClient)
+	tools.cfg.Watch.Projects = []string{"watched-project"}
+
+	snapshot, err := tools.ComplianceSnapshot(context.Background())
+	if err != nil {
+		t.Fatalf("ComplianceSnapshot() returned error: %v", err)
+	}
+	if got := snapshot.Profiles["watched-project/baseline"]; got != "success" {
+		t.Errorf("profile status = %q, want %q", got, "success")
+	}
+	if len(snapshot.Profiles) != 1 {
+		t.Errorf("expected only the configured project, got %v", snapshot.Profiles)
+	}
+}
+
+func TestLabeledComplianceSnapshot(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "payments-baseline", Labels: []string{"team:payments"}}},
+	}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "payments-baseline", ProfileStatus: "failure"},
+	}
+
+	tools := newTestTools(mockClient)
+	tools.cfg.Watch.Projects = []string{"proj-1"}
+
+	snapshot, err := tools.LabeledComplianceSnapshot("team:payments")(context.Background())
+	if err != nil {
+		t.Fatalf("LabeledComplianceSnapshot() returned error: %v", err)
+	}
+	if got := mockClient.profiles.listReq.GetLabelFilter(); got != "team:payments" {
+		t.Errorf("ListProfiles label filter = %q, want team:payments", got)
+	}
+	if got := snapshot.Profiles["proj-1/payments-baseline"]; got != "failure" {
+		t.Errorf("profile status = %q, want failure", got)
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// callLimiter bounds how many tool calls run at once, in total and per tool,
+// so a client firing many parallel calls cannot exhaust file descriptors or
+// flood Minder. Calls over a limit wait for a running call to finish.
+type callLimiter struct {
+	global  chan struct{}
+	perTool int
+
+	mu    sync.Mutex
+	tools map[string]chan struct{}
+}
+
+// newCallLimiter returns a limiter admitting global calls in total and
+// perTool calls of each tool; zero leaves that limit off. Without any limit
+// it returns nil, which admits every call.
+func newCallLimiter(global, perTool int) *callLimiter {
+	if global <= 0 && perTool <= 0 {
+		return nil
+	}
+	l := &callLimiter{perTool: perTool, tools: make(map[string]chan struct{})}
+	if global > 0 {
+		l.global = make(chan struct{}, global)
+	}
+	return l
+}
+
+// acquire waits until a call of tool may run and returns the function that
+// ends it. It returns ctx's error if ctx ends first.
+func (l *callLimiter) acquire(ctx context.Context, tool string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	// Take the tool's slot first, so calls queued behind a busy tool do not
+	// hold global slots other tools could use.
+	toolSem := l.toolSemaphore(tool)
+	if err := take(ctx, toolSem); err != nil {
+		return nil, err
+	}
+	if err := take(ctx, l.global); err != nil {
+		give(toolSem)
+		return nil, err
+	}
+	return func() {
+		give(l.global)
+		give(toolSem)
+	}, nil
+}
+
+// toolSemaphore returns the semaphore of tool, or nil without a per-tool limit.
+func (l *callLimiter) toolSemaphore(tool string) chan struct{} {
+	if l.perTool <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sem, ok := l.tools[tool]
+	if !ok {
+		sem = make(chan struct{}, l.perTool)
+		l.tools[tool] = sem
+	}
+	return sem
+}
+
+// take acquires a slot of sem; a nil sem is unlimited.
+func take(ctx context.Context, sem chan struct{}) error {
+	if sem == nil {
+		return nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// give releases a slot taken from sem.
+func give(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
+
+// concurrencyLimitResult reports a call that gave up waiting for a slot.
+func concurrencyLimitResult(tool string, err error) *mcp.CallToolResult {
+	return errorResult("Too many concurrent tool calls: "+tool+" was not started: "+err.Error(), ErrorDetail{
+		Code:            ErrCodeRateLimited,
+		Retryable:       true,
+		SuggestedAction: "Wait for earlier calls to finish and make fewer calls in parallel.",
+	})
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCallLimiter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		global   int
+		perTool  int
+		held     []string
+		tool     string
+		wantWait bool
+	}{
+		{name: "no limits", held: []string{"a", "a", "a"}, tool: "a"},
+		{name: "global limit reached", global: 2, held: []string{"a", "b"}, tool: "c", wantWait: true},
+		{name: "global limit not reached", global: 2, held: []string{"a"}, tool: "b"},
+		{name: "tool limit reached", perTool: 1, held: []string{"a"}, tool: "a", wantWait: true},
+		{name: "other tool under its limit", perTool: 1, held: []string{"a"}, tool: "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newCallLimiter(tt.global, tt.perTool)
+			for _, tool := range tt.held {
+				if _, err := l.acquire(context.Background(), tool); err != nil {
+					t.Fatalf("acquire(%q) error = %v", tool, err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			release, err := l.acquire(ctx, tt.tool)
+			if tt.wantWait {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("acquire(%q) error = %v, want %v", tt.tool, err, context.DeadlineExceeded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("acquire(%q) error = %v", tt.tool, err)
+			}
+			release()
+		})
+	}
+}
+
+func TestCallLimiter_ReleaseAdmitsWaitingCall(t *testing.T) {
+	t.Parallel()
+
+	l := newCallLimiter(1, 1)
+	release, err := l.acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	acquired := make(chan error)
+	go func() {
+		_, err := l.acquire(context.Background(), "a")
+		acquired <- err
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second call started while the first was running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	if err := <-acquired; err != nil {
+		t.Errorf("acquire() after release error = %v", err)
+	}
+}
+
+func TestWrapHandler_ConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.limiter = newCallLimiter(0, 1)
+	running := make(chan struct{})
+	done := make(chan struct{})
+	handler := tools.wrapHandler("test_tool", func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		running <- struct{}{}
+		<-done
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	go func() { _, _ = handler(context.Background(), mcp.CallToolRequest{}) }()
+	<-running
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result, err := handler(ctx, mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok || detail.Code != ErrCodeRateLimited || !detail.Retryable {
+		t.Errorf("error detail = %#v, want a retryable %q error", detail, ErrCodeRateLimited)
+	}
+}
+
+func TestWrapHandler_PanicRecovered(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.limiter = newCallLimiter(0, 1)
+	panicking := tools.wrapHandler("test_tool", func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		panic("tool bug")
+	})
+	result, err := panicking(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok || detail.Code != ErrCodeInternal || detail.RequestID == "" {
+		t.Errorf("error detail = %#v, want a %q error with a request ID", detail, ErrCodeInternal)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	release, err := tools.limiter.acquire(ctx, "test_tool")
+	if err != nil {
+		t.Fatalf("acquire() after a panicking call error = %v, want its slot released", err)
+	}
+	release()
+}
@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// formatMarkdown renders a result as a Markdown table.
+const formatMarkdown = "markdown"
+
+// coverageRow is one repository of the coverage matrix. Profiles maps the
+// name of each profile that evaluated the repository to its combined status;
+// profiles that do not apply to the repository are absent.
+type coverageRow struct {
+	Repository string            `json:"repository"`
+	ID         string            `json:"id"`
+	Project    string            `json:"project"`
+	Profiles   map[string]string `json:"profiles"`
+	// statuses collects rule statuses per profile until the row is complete
+	statuses map[string][]string
+}
+
+// coverageMatrix records which profiles apply to which repositories.
+type coverageMatrix struct {
+	profiles map[string]bool
+	rows     map[string]*coverageRow
+}
+
+func newCoverageMatrix() *coverageMatrix {
+	return &coverageMatrix{profiles: map[string]bool{}, rows: map[string]*coverageRow{}}
+}
+
+// row returns the row of a repository, creating it if needed.
+func (m *coverageMatrix) row(id, name, projectID string) *coverageRow {
+	r, ok := m.rows[id]
+	if !ok {
+		r = &coverageRow{ID: id, Repository: name, Project: projectID, statuses: map[string][]string{}}
+		m.rows[id] = r
+	}
+	return r
+}
+
+// addRepositories adds rows for repositories whether or not any profile applies.
+func (m *coverageMatrix) addRepositories(projectID string, repos []*minderv1.Repository) {
+	for _, repo := range repos {
+		m.row(repo.GetId(), repo.GetOwner()+"/"+repo.GetName(), projectID)
+	}
+}
+
+// addProfile records the repositories a profile evaluated and how each rule did.
+func (m *coverageMatrix) addProfile(projectID, profile string, evaluations []*minderv1.RuleEvaluationStatus) {
+	m.profiles[profile] = true
+	for _, eval := range evaluations {
+		if eval.GetEntity() != "repository" {
+			continue
+		}
+		info := eval.GetEntityInfo()
+		r := m.row(info["entity_id"], entityDisplayName(info), projectID)
+		r.statuses[profile] = append(r.statuses[profile], eval.GetStatus())
+	}
+}
+
+// coverageResult is the coverage matrix as returned by the tool.
+type coverageResult struct {
+	Profiles     []string      `json:"profiles"`
+	Repositories []coverageRow `json:"repositories"`
+	// Uncovered lists repositories no profile applies to.
+	Uncovered []string `json:"uncovered_repositories"`
+	// Unused lists profiles that apply to no repository.
+	Unused []string `json:"profiles_without_repositories"`
+	// Skipped lists the projects and profiles that could not be read and are
+	// missing from the matrix.
+	Skipped []skippedRead `json:"skipped,omitempty"`
+}
+
+// result combines the statuses of each cell and orders profiles and rows by name.
+func (m *coverageMatrix) result() coverageResult {
+	res := coverageResult{Profiles: []string{}, Repositories: []coverageRow{}, Uncovered: []string{}, Unused: []string{}}
+	used := map[string]bool{}
+	for _, r := range m.rows {
+		r.Profiles = map[string]string{}
+		for profile, statuses := range r.statuses {
+			r.Profiles[profile] = combinedStatus(statuses)
+			used[profile] = true
+		}
+		if len(r.Profiles) == 0 {
+			res.Uncovered = append(res.Uncovered, r.Repository)
+		}
+		res.Repositories = append(res.Repositories, *r)
+	}
+	for profile := range m.profiles {
+		res.Profiles = append(res.Profiles, profile)
+		if !used[profile] {
+			res.Unused = append(res.Unused, profile)
+		}
+	}
+	slices.Sort(res.Profiles)
+	slices.Sort(res.Uncovered)
+	slices.Sort(res.Unused)
+	slices.SortFunc(res.Repositories, func(a, b coverageRow) int {
+		return cmp.Or(cmp.Compare(a.Repository, b.Repository), cmp.Compare(a.ID, b.ID))
+	})
+	return res
+}
+
+// combinedStatus is "failure" if any rule failed or errored, otherwise
+// "success" if any rule passed, otherwise the status of the first rule.
+func combinedStatus(statuses []string) string {
+	passed := false
+	for _, status := range statuses {
+		if watcher.IsFailing(status) {
+			return "failure"
+		}
+		passed = passed || status == "success"
+	}
+	switch {
+	case passed:
+		return "success"
+	case len(statuses) > 0:
+		return statuses[0]
+	}
+	return ""
+}
+
+// coverageMarkdown renders the matrix as a Markdown table with a repository per
+// row and a profile per column. A dash marks profiles that do not apply.
+func coverageMarkdown(res coverageResult, total int) string {
+	var b strings.Builder
+	b.WriteString("| Repository |")
+	for _, profile := range res.Profiles {
+		fmt.Fprintf(&b, " %s |", markdownCell(profile))
+	}
+	b.WriteString("\n| --- |")
+	b.WriteString(strings.Repeat(" --- |", len(res.Profiles)))
+	b.WriteString("\n")
+	for _, r := range res.Repositories {
+		fmt.Fprintf(&b, "| %s |", markdownCell(r.Repository))
+		for _, profile := range res.Profiles {
+			status, ok := r.Profiles[profile]
+			if !ok {
+				status = "—"
+			}
+			fmt.Fprintf(&b, " %s |", status)
+		}
+		b.WriteString("\n")
+	}
+	if len(res.Repositories) < total {
+		fmt.Fprintf(&b, "\nShowing %d of %d repositories.\n", len(res.Repositories), total)
+	}
+	if len(res.Uncovered) > 0 {
+		fmt.Fprintf(&b, "\nNo profile applies to: %s\n", strings.Join(res.Uncovered, ", "))
+	}
+	if len(res.Unused) > 0 {
+		fmt.Fprintf(&b, "\nProfiles applying to no repository: %s\n", strings.Join(res.Unused, ", "))
+	}
+	for _, s := range res.Skipped {
+		what := "project " + s.Project
+		if s.Profile != "" {
+			what = "profile " + s.Profile + " in " + what
+		}
+		fmt.Fprintf(&b, "\nCould not read %s, which is missing above: %s\n", markdownCell(what), s.Error)
+	}
+	return b.String()
+}
+
+// markdownCell escapes pipes so a value cannot break out of its table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// getCoverageMatrix reports which profiles apply to which repositories and the
+// current status of each, for reviewing coverage at a glance.
+func (t *Tools) getCoverageMatrix(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	format := req.GetString("format", formatJSON)
+	if format != formatJSON && format != formatMarkdown {
+		return mcp.NewToolResultError(fmt.Sprintf("format must be %s or %s, got %q",
+			formatJSON, formatMarkdown, format)), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectIDs, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	matrix := newCoverageMatrix()
+	var skipped []skippedRead
+	for _, projID := range projectIDs {
+		repos, err := client.Repositories().ListRepositories(ctx, &minderv1.ListRepositoriesRequest{
+			Context: &minderv1.Context{Project: &projID},
+		})
+		if err != nil {
+			skipped = append(skipped, skippedRead{Project: projID, Error: "listing repositories: " + MapGRPCError(err)})
+		} else {
+			matrix.addRepositories(projID, repos.GetResults())
+		}
+		statuses, projSkipped := readProfileStatuses(ctx, client, projID)
+		skipped = append(skipped, projSkipped...)
+		for _, status := range statuses {
+			matrix.addProfile(projID, status.Profile.GetName(), status.Evaluations)
+		}
+	}
+
+	res := matrix.result()
+	res.Skipped = skipped
+	var total int
+	res.Repositories, total = capResults(res.Repositories, t.cfg.MCP.MaxResults)
+	if format == formatMarkdown {
+		return mcp.NewToolResultText(coverageMarkdown(res, total)), nil
+	}
+	return t.marshalCapped(ctx, res, len(res.Repositories), total)
+}
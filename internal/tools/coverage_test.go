@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func coverageMockClient() *mockMinderClient {
+	repo := func(id, name string) *minderv1.Repository {
+		return &minderv1.Repository{Id: ptr(id), Owner: "acme", Name: name}
+	}
+	eval := func(entityID, repo, status string) *minderv1.RuleEvaluationStatus {
+		return &minderv1.RuleEvaluationStatus{
+			Entity:     "repository",
+			EntityInfo: map[string]string{"entity_id": entityID, "repo_owner": "acme", "repo_name": repo},
+			Status:     status,
+		}
+	}
+
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{
+		Results: []*minderv1.Repository{repo("repo-1", "api"), repo("repo-2", "web"), repo("repo-3", "docs")},
+	}
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}, {Name: "secrets"}, {Name: "artifacts"}},
+	}
+	mockClient.profiles.getStatusByNameResps = map[string]*minderv1.GetProfileStatusByNameResponse{
+		"baseline": {RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			eval("repo-1", "api", "success"),
+			eval("repo-1", "api", "error"),
+			eval("repo-2", "web", "success"),
+			eval("repo-2", "web", "skipped"),
+		}},
+		"secrets": {RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			eval("repo-1", "api", "skipped"),
+		}},
+		"artifacts": {RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{Entity: "artifact", EntityInfo: map[string]string{"entity_id": "art-1"}, Status: "failure"},
+		}},
+	}
+	return mockClient
+}
+
+func TestGetCoverageMatrix(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(coverageMockClient())
+	result, err := tools.getCoverageMatrix(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getCoverageMatrix() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got coverageResult
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if want := []string{"artifacts", "baseline", "secrets"}; !slices.Equal(got.Profiles, want) {
+		t.Errorf("profiles = %v, want %v", got.Profiles, want)
+	}
+	if len(got.Repositories) != 3 {
+		t.Fatalf("repositories = %+v, want 3 rows", got.Repositories)
+	}
+	api, docs, web := got.Repositories[0], got.Repositories[1], got.Repositories[2]
+	if api.Repository != "acme/api" || api.Profiles["baseline"] != "failure" || api.Profiles["secrets"] != "skipped" {
+		t.Errorf("acme/api row = %+v, want baseline failure and secrets skipped", api)
+	}
+	if web.Profiles["baseline"] != "success" || len(web.Profiles) != 1 {
+		t.Errorf("acme/web row = %+v, want only baseline success", web)
+	}
+	if docs.Repository != "acme/docs" || len(docs.Profiles) != 0 {
+		t.Errorf("acme/docs row = %+v, want no profiles", docs)
+	}
+	if !slices.Equal(got.Uncovered, []string{"acme/docs"}) {
+		t.Errorf("uncovered_repositories = %v, want [acme/docs]", got.Uncovered)
+	}
+	if !slices.Equal(got.Unused, []string{"artifacts"}) {
+		t.Errorf("profiles_without_repositories = %v, want [artifacts]", got.Unused)
+	}
+}
+
+func TestGetCoverageMatrix_Markdown(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(coverageMockClient())
+	result, err := tools.getCoverageMatrix(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "format": "markdown"}},
+	})
+	if err != nil {
+		t.Fatalf("getCoverageMatrix() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	want := "| Repository | artifacts | baseline | secrets |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| acme/api | — | failure | skipped |\n" +
+		"| acme/docs | — | — | — |\n" +
+		"| acme/web | — | success | — |\n" +
+		"\nNo profile applies to: acme/docs\n" +
+		"\nProfiles applying to no repository: artifacts\n"
+	if got := getResultText(t, result); got != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetCoverageMatrix_InvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	result, err := tools.getCoverageMatrix(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"format": "csv"}},
+	})
+	if err != nil {
+		t.Fatalf("getCoverageMatrix() returned Go error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(getResultText(t, result), "format must be json or markdown") {
+		t.Errorf("result = %s, want a format error", getResultText(t, result))
+	}
+}
+
+func TestMarkdownCell(t *testing.T) {
+	t.Parallel()
+
+	if got := markdownCell("a|b"); got != `a\|b` {
+		t.Errorf("markdownCell() = %q, want the pipe escaped", got)
+	}
+}
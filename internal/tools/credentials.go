@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// sessionToken returns the token saved with minder_save_credentials for srv
+// in the session in ctx, or "" when none was saved or the caller is not the
+// one that saved it.
+func (t *Tools) sessionToken(ctx context.Context, srv config.NamedServer) string {
+	id := sessionID(ctx)
+	if id == "" {
+		return ""
+	}
+	if saved, ok := t.sessions.get(id).tokens[srv.Name]; ok && saved.caller == callerKey(ctx) {
+		return saved.token
+	}
+	return ""
+}
+
+// saveCredentials stores a token for the selected Minder server, used by later
+// tool calls in this session in place of the configured or header token. Only
+// calls sending the same Authorization header as this one use it. The token
+// is checked first so a bad paste fails here rather than on every call.
+func (t *Tools) saveCredentials(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	token := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(req.GetString("token", "")), "Bearer "))
+	if token == "" {
+		return mcp.NewToolResultError("token must be provided"), nil
+	}
+	id := sessionID(ctx)
+	if id == "" {
+		return mcp.NewToolResultError("saving credentials requires an MCP session"), nil
+	}
+	srv, err := t.resolveServer(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+
+	info, err := minder.ParseTokenInfo(token)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	if t.tokenRefresher != nil {
+		serverCfg := minder.ServerConfig{Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins}
+		if _, err := t.tokenRefresher.GetValidAccessToken(ctx, token, serverCfg); err != nil {
+			return clientErrorResult(t.tokenError(ctx, srv, serverCfg, err)), nil
+		}
+	}
+
+	t.sessions.update(id, func(s *sessionState) {
+		tokens := maps.Clone(s.tokens)
+		if tokens == nil {
+			tokens = map[string]savedToken{}
+		}
+		tokens[srv.Name] = savedToken{token: token, caller: callerKey(ctx)}
+		s.tokens = tokens
+	})
+	t.logger.InfoContext(ctx, "session credentials saved", "server", srv.Name, "offline", info.Offline)
+
+	result := map[string]any{"server": srv.Name, "token_type": "offline"}
+	if !info.Offline {
+		result["token_type"] = "access"
+		if !info.ExpiresAt.IsZero() {
+			result["expires_at"] = info.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		result["note"] = "access tokens expire within minutes; save an offline token from " +
+			"`minder auth offline-token get` to stay signed in for the session"
+	}
+	return marshalResult(ctx, result)
+}
+
+// logout forgets the tokens this session saved and the access tokens cached
+// for them and for the token the session uses for the selected server, so
+// the next call must authenticate again.
+func (t *Tools) logout(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id := sessionID(ctx)
+	if id == "" {
+		return mcp.NewToolResultError("logging out requires an MCP session"), nil
+	}
+	srv, err := t.resolveServer(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+
+	// Read the current token before the saved ones are dropped
+	tokens := []string{t.tokenFor(ctx, srv)}
+	var servers []string
+	t.sessions.update(id, func(s *sessionState) {
+		for name, saved := range s.tokens {
+			servers = append(servers, name)
+			tokens = append(tokens, saved.token)
+		}
+		s.tokens = nil
+	})
+	forgotten := 0
+	if t.tokenRefresher != nil {
+		for _, token := range tokens {
+			if token != "" && t.tokenRefresher.Forget(token) {
+				forgotten++
+			}
+		}
+	}
+	slices.Sort(servers)
+	t.logger.InfoContext(ctx, "session logged out", "servers", servers, "cached_tokens", forgotten)
+
+	result := map[string]any{
+		"cleared_credentials":   append([]string{}, servers...),
+		"cached_tokens_cleared": forgotten,
+	}
+	if t.tokenFor(ctx, srv) != "" {
+		result["note"] = "calls still authenticate with the token configured for this server or sent " +
+			"in the Authorization header; remove it from the client or server configuration to sign out fully"
+	}
+	return marshalResult(ctx, result)
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// testJWT returns an unsigned JWT carrying claims.
+func testJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + enc.EncodeToString(payload) + "."
+}
+
+func callCredentialsTool(
+	ctx context.Context, t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error),
+	args map[string]any,
+) *mcp.CallToolResult {
+	t.Helper()
+	result, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	return result
+}
+
+func TestSaveCredentials(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	tools.cfg.Minder.AuthToken = "configured-token"
+	ctx := contextWithSession("session-1")
+	offline := testJWT(t, map[string]any{"typ": "Offline"})
+	defaultServer, _ := tools.cfg.Minder.Server(config.DefaultServerName)
+	staging, _ := tools.cfg.Minder.Server("staging")
+
+	result := callCredentialsTool(ctx, t, tools.saveCredentials, map[string]any{"token": "Bearer " + offline})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if text := getResultText(t, result); !strings.Contains(text, `"token_type": "offline"`) ||
+		!strings.Contains(text, `"server": "default"`) {
+		t.Errorf("result = %s, want an offline token saved for the default server", text)
+	}
+
+	if got := tools.tokenFor(ctx, defaultServer); got != offline {
+		t.Errorf("tokenFor(default) = %q, want the saved token", got)
+	}
+	if got := tools.tokenFor(ctx, staging); got == offline {
+		t.Error("tokenFor(staging) returned the token saved for the default server")
+	}
+	if got := tools.tokenFor(contextWithSession("session-2"), defaultServer); got != "configured-token" {
+		t.Errorf("tokenFor() in another session = %q, want the configured token", got)
+	}
+	otherCaller := middleware.ContextWithHeaderToken(ctx, "other-caller-token")
+	if got := tools.tokenFor(otherCaller, defaultServer); got != "other-caller-token" {
+		t.Errorf("tokenFor() for another caller presenting the session ID = %q, want its own token", got)
+	}
+}
+
+func TestSaveCredentials_BoundToCaller(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	ctx := middleware.ContextWithHeaderToken(contextWithSession("session-1"), "caller-token")
+	offline := testJWT(t, map[string]any{"typ": "Offline"})
+	defaultServer, _ := tools.cfg.Minder.Server(config.DefaultServerName)
+
+	result := callCredentialsTool(ctx, t, tools.saveCredentials, map[string]any{"token": offline})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if got := tools.tokenFor(ctx, defaultServer); got != offline {
+		t.Errorf("tokenFor() for the saving caller = %q, want the saved token", got)
+	}
+	if got := tools.tokenFor(contextWithSession("session-1"), defaultServer); got == offline {
+		t.Error("tokenFor() without the saving caller's Authorization header returned the saved token")
+	}
+}
+
+func TestSaveCredentials_AccessToken(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	access := testJWT(t, map[string]any{"typ": "Bearer", "exp": time.Now().Add(5 * time.Minute).Unix()})
+
+	result := callCredentialsTool(contextWithSession("session-1"), t, tools.saveCredentials, map[string]any{"token": access})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	text := getResultText(t, result)
+	if !strings.Contains(text, `"token_type": "access"`) || !strings.Contains(text, "expires_at") ||
+		!strings.Contains(text, "offline-token get") {
+		t.Errorf("result = %s, want an access token with its expiry and a note", text)
+	}
+}
+
+func TestSaveCredentials_Errors(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	offline := testJWT(t, map[string]any{"typ": "Offline"})
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		token   string
+		wantMsg string
+	}{
+		{name: "missing token", ctx: contextWithSession("session-1"), wantMsg: "token must be provided"},
+		{name: "no session", ctx: context.Background(), token: offline, wantMsg: "requires an MCP session"},
+		{name: "malformed token", ctx: contextWithSession("session-1"), token: "not-a-jwt", wantMsg: "malformed"},
+	}
+	// Runs once the parallel subtests finish
+	t.Cleanup(func() {
+		if len(tools.sessions.get("session-1").tokens) != 0 {
+			t.Error("a token was saved by a failed call")
+		}
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := callCredentialsTool(tt.ctx, t, tools.saveCredentials, map[string]any{"token": tt.token})
+			if !result.IsError {
+				t.Fatalf("expected error result, got %s", getResultText(t, result))
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	tools.cfg.Minder.AuthToken = "configured-token"
+	ctx := contextWithSession("session-1")
+	defaultServer, _ := tools.cfg.Minder.Server(config.DefaultServerName)
+	tools.sessions.update("session-1", func(s *sessionState) {
+		s.tokens = map[string]savedToken{
+			"default": {token: "saved-default", caller: callerKey(ctx)},
+			"staging": {token: "saved-staging", caller: callerKey(ctx)},
+		}
+	})
+
+	result := callCredentialsTool(ctx, t, tools.logout, nil)
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got struct {
+		Cleared []string `json:"cleared_credentials"`
+		Note    string   `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Cleared) != 2 || got.Cleared[0] != "default" || got.Cleared[1] != "staging" {
+		t.Errorf("cleared_credentials = %v, want [default staging]", got.Cleared)
+	}
+	if got.Note == "" {
+		t.Error("expected a note that the configured token is still used")
+	}
+	if token := tools.tokenFor(ctx, defaultServer); token != "configured-token" {
+		t.Errorf("tokenFor() after logout = %q, want the configured token", token)
+	}
+
+	result = callCredentialsTool(context.Background(), t, tools.logout, nil)
+	if !result.IsError {
+		t.Errorf("expected error result without a session, got %s", getResultText(t, result))
+	}
+}
@@ -11,7 +11,8 @@ import (
 // showComplianceDashboard displays the interactive Minder Compliance Dashboard.
 // This tool returns metadata that triggers MCP Apps-enabled clients to render the embedded
 // TypeScript dashboard showing repository security posture and profile compliance.
-func (*Tools) showComplianceDashboard(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// The tool prefix in the metadata tells the dashboard which tool names to call.
+func (t *Tools) showComplianceDashboard(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	result := &mcp.CallToolResult{
 		Content: []mcp.Content{
 			mcp.TextContent{
@@ -24,6 +25,7 @@ func (*Tools) showComplianceDashboard(_ context.Context, _ mcp.CallToolRequest)
 		"ui": map[string]any{
 			"resourceUri": resources.DashboardURI,
 		},
+		"toolPrefix": t.toolPrefix(),
 	})
 	return result, nil
 }
@@ -12,7 +12,7 @@ import (
 )
 
 func TestShowComplianceDashboard(t *testing.T) {
-	tools := &Tools{}
+	tools := newTestTools(newMockClient())
 
 	result, err := tools.showComplianceDashboard(context.Background(), mcp.CallToolRequest{})
 
@@ -36,4 +36,5 @@ func TestShowComplianceDashboard(t *testing.T) {
 	resourceUri, ok := ui["resourceUri"].(string)
 	require.True(t, ok, "expected resourceUri string")
 	assert.Equal(t, resources.DashboardURI, resourceUri)
+	assert.Equal(t, "minder_", result.Meta.AdditionalFields["toolPrefix"])
 }
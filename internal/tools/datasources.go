@@ -10,7 +10,7 @@ import (
 func (t *Tools) listDataSources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -35,10 +35,14 @@ func (t *Tools) listDataSources(ctx context.Context, req mcp.CallToolRequest) (*
 			return resp.DataSources, nil
 		})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(dataSources)
+	sortByProject(dataSources, func(ds *minderv1.DataSource) (string, string) {
+		return ds.GetContext().GetProjectId(), ds.GetName()
+	})
+	dataSources, total := capResults(dataSources, t.cfg.MCP.MaxResults)
+	return t.marshalCapped(ctx, dataSources, len(dataSources), total)
 }
 
 func (t *Tools) getDataSource(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -55,7 +59,7 @@ func (t *Tools) getDataSource(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -71,13 +75,13 @@ func (t *Tools) getDataSource(ctx context.Context, req mcp.CallToolRequest) (*mc
 			Id: dataSourceID,
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 		dataSource = resp.DataSource
 	} else {
 		// Lookup by name - search across projects if none specified
-		dataSource, err = findInProjects(
-			ctx, client, projectID,
+		dataSource, err = findOneInProjects(
+			ctx, client, projectID, "data source "+name,
 			func(ctx context.Context, projID string) (*minderv1.DataSource, error) {
 				resp, err := client.DataSources().GetDataSourceByName(ctx, &minderv1.GetDataSourceByNameRequest{
 					Name: name,
@@ -91,9 +95,9 @@ func (t *Tools) getDataSource(ctx context.Context, req mcp.CallToolRequest) (*mc
 				return resp.DataSource, nil
 			})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 	}
 
-	return marshalResult(dataSource)
+	return marshalResult(ctx, dataSource)
 }
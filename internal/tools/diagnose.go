@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/doctor"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// diagnosis is the self-check report returned by minder_diagnose.
+type diagnosis struct {
+	Server    string          `json:"server"`
+	Address   string          `json:"address,omitempty"`
+	ProjectID string          `json:"project_id,omitempty"`
+	Passed    bool            `json:"passed"`
+	Checks    []doctor.Result `json:"checks"`
+}
+
+// serviceProbe is a cheap list call showing whether a Minder service is
+// reachable and permitted for the current token.
+type serviceProbe struct {
+	name  string
+	probe func(ctx context.Context, client MinderClient, projectID string) (int, error)
+}
+
+// serviceProbes cover each Minder service the tools read from, requesting as
+// little data as each RPC allows.
+var serviceProbes = []serviceProbe{
+	{"list repositories", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.Repositories().ListRepositories(ctx, &minderv1.ListRepositoriesRequest{
+			Context: &minderv1.Context{Project: &projectID}, Limit: 1,
+		})
+		return len(resp.GetResults()), err
+	}},
+	{"list profiles", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+			Context: &minderv1.Context{Project: &projectID},
+		})
+		return len(resp.GetProfiles()), err
+	}},
+	{"list rule types", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{
+			Context: &minderv1.Context{Project: &projectID},
+		})
+		return len(resp.GetRuleTypes()), err
+	}},
+	{"list data sources", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.DataSources().ListDataSources(ctx, &minderv1.ListDataSourcesRequest{
+			Context: &minderv1.ContextV2{ProjectId: projectID},
+		})
+		return len(resp.GetDataSources()), err
+	}},
+	{"list providers", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.Providers().ListProviders(ctx, &minderv1.ListProvidersRequest{
+			Context: &minderv1.Context{Project: &projectID}, Limit: 1,
+		})
+		return len(resp.GetProviders()), err
+	}},
+	{"list artifacts", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.Artifacts().ListArtifacts(ctx, &minderv1.ListArtifactsRequest{
+			Context: &minderv1.Context{Project: &projectID},
+		})
+		return len(resp.GetResults()), err
+	}},
+	{"list evaluation history", func(ctx context.Context, client MinderClient, projectID string) (int, error) {
+		resp, err := client.EvalResults().ListEvaluationHistory(ctx, &minderv1.ListEvaluationHistoryRequest{
+			Context: &minderv1.Context{Project: &projectID}, Cursor: &minderv1.Cursor{Size: 1},
+		})
+		return len(resp.GetData()), err
+	}},
+}
+
+// diagnose runs connectivity, authentication and permission checks against the
+// session's Minder server and reports each as PASS, FAIL or SKIP. Checks after
+// a failed token or health check are skipped.
+func (t *Tools) diagnose(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	srv, err := t.resolveServer(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	serverCfg := minder.ServerConfig{Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins}
+	projectID := req.GetString("project_id", "")
+
+	// The client is created by the health check and shared by the later checks
+	var client MinderClient
+	defer func() {
+		if client != nil {
+			_ = client.Close()
+		}
+	}()
+
+	checks := []doctor.Check{
+		{
+			Name: "realm discovery",
+			Run: func(ctx context.Context) (string, error) {
+				if t.tokenRefresher == nil {
+					return "", doctor.Skip("this server does not authenticate to Minder directly")
+				}
+				return t.tokenRefresher.RealmURL(ctx, serverCfg)
+			},
+		},
+		{Name: "token", Critical: true, Run: t.checkToken(srv, serverCfg)},
+		{
+			Name:     "health check",
+			Critical: true,
+			Run: func(ctx context.Context) (string, error) {
+				c, err := t.getClient(ctx)
+				if err != nil {
+					return "", err
+				}
+				client = c
+				resp, err := client.Health().CheckHealth(ctx, &minderv1.CheckHealthRequest{})
+				if err != nil {
+					return "", errors.New(MapGRPCError(err))
+				}
+				return "status " + resp.GetStatus(), nil
+			},
+		},
+		{
+			Name:     "list projects",
+			Critical: true,
+			Run: func(ctx context.Context) (string, error) {
+				resp, err := client.Projects().ListProjects(ctx, &minderv1.ListProjectsRequest{})
+				if err != nil {
+					return "", errors.New(MapGRPCError(err))
+				}
+				if projectID == "" && len(resp.GetProjects()) > 0 {
+					projectID = resp.GetProjects()[0].GetProjectId()
+				}
+				return fmt.Sprintf("%d project(s) accessible", len(resp.GetProjects())), nil
+			},
+		},
+	}
+	for _, p := range serviceProbes {
+		checks = append(checks, doctor.Check{
+			Name: p.name,
+			Run: func(ctx context.Context) (string, error) {
+				if projectID == "" {
+					return "", doctor.Skip("no accessible project")
+				}
+				n, err := p.probe(ctx, client, projectID)
+				if err != nil {
+					return "", errors.New(MapGRPCError(err))
+				}
+				return fmt.Sprintf("ok, %d returned", n), nil
+			},
+		})
+	}
+
+	results := doctor.Run(ctx, checks)
+	report := diagnosis{
+		Server:    srv.Name,
+		ProjectID: projectID,
+		Passed:    doctor.Passed(results),
+		Checks:    results,
+	}
+	if srv.Host != "" {
+		report.Address = fmt.Sprintf("%s:%d", srv.Host, srv.Port)
+	}
+	return marshalResult(ctx, report)
+}
+
+// checkToken returns a check that resolves the token used for srv, exchanges
+// it for an access token if needed, and reports the access token's expiry.
+func (t *Tools) checkToken(srv config.NamedServer, serverCfg minder.ServerConfig) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		if t.tokenRefresher == nil {
+			return "", doctor.Skip("this server does not authenticate to Minder directly")
+		}
+		token := t.tokenFor(ctx, srv)
+		if token == "" {
+			return "", errors.New("no token: set MINDER_AUTH_TOKEN or pass an Authorization header")
+		}
+		info, err := minder.ParseTokenInfo(token)
+		if err != nil {
+			return "", err
+		}
+		accessToken, err := t.tokenRefresher.GetValidAccessToken(ctx, token, serverCfg)
+		if err != nil {
+			return "", t.tokenError(ctx, srv, serverCfg, err)
+		}
+
+		kind := "access token"
+		if info.Offline {
+			kind = "offline token, exchanged for an access token"
+			if info, err = minder.ParseTokenInfo(accessToken); err != nil {
+				return "", err
+			}
+		}
+		if info.ExpiresAt.IsZero() {
+			return kind + " without expiry", nil
+		}
+		return fmt.Sprintf("%s expiring %s (in %s)", kind,
+			info.ExpiresAt.UTC().Format(time.RFC3339), time.Until(info.ExpiresAt).Round(time.Second)), nil
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/doctor"
+)
+
+func TestDiagnose(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(*mockMinderClient)
+		args       map[string]any
+		wantPassed bool
+		wantStatus map[string]doctor.Status
+		wantDetail map[string]string
+		wantProjID string
+	}{
+		{
+			name:       "healthy",
+			wantPassed: true,
+			wantStatus: map[string]doctor.Status{
+				"realm discovery":   doctor.StatusSkip,
+				"token":             doctor.StatusSkip,
+				"health check":      doctor.StatusPass,
+				"list projects":     doctor.StatusPass,
+				"list repositories": doctor.StatusPass,
+			},
+			wantDetail: map[string]string{"list projects": "1 project(s) accessible"},
+			wantProjID: "test-project-id",
+		},
+		{
+			name: "health check fails",
+			setup: func(m *mockMinderClient) {
+				m.health.checkErr = status.Error(codes.Unavailable, "connection refused")
+			},
+			wantStatus: map[string]doctor.Status{
+				"health check":      doctor.StatusFail,
+				"list projects":     doctor.StatusSkip,
+				"list repositories": doctor.StatusSkip,
+			},
+			wantDetail: map[string]string{"list repositories": "health check failed"},
+		},
+		{
+			name: "permission denied on one service",
+			setup: func(m *mockMinderClient) {
+				m.repositories.listErr = status.Error(codes.PermissionDenied, "no access")
+			},
+			args: map[string]any{"project_id": "other-project"},
+			wantStatus: map[string]doctor.Status{
+				"list repositories": doctor.StatusFail,
+				"list profiles":     doctor.StatusPass,
+			},
+			wantProjID: "other-project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := newMockClient()
+			if tt.setup != nil {
+				tt.setup(mockClient)
+			}
+			tools := newTestTools(mockClient)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+			result, err := tools.diagnose(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var report diagnosis
+			require.NoError(t, json.Unmarshal([]byte(getResultText(t, result)), &report))
+			assert.Equal(t, tt.wantPassed, report.Passed)
+			assert.Equal(t, tt.wantProjID, report.ProjectID)
+
+			byName := map[string]doctor.Result{}
+			for _, r := range report.Checks {
+				byName[r.Name] = r
+			}
+			for name, want := range tt.wantStatus {
+				assert.Equal(t, want, byName[name].Status, name)
+			}
+			for name, want := range tt.wantDetail {
+				assert.Contains(t, byName[name].Detail, want, name)
+			}
+		})
+	}
+}
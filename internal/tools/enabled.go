@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolSet is an immutable set of tool names. A nil set enables every tool.
+type toolSet map[string]struct{}
+
+// enabledTools holds the current tool allowlist so it can be swapped at runtime.
+type enabledTools struct {
+	set atomic.Pointer[toolSet]
+}
+
+// SetEnabledTools restricts the tools offered to clients to names.
+// An empty list enables every tool. Safe to call while serving requests.
+func (t *Tools) SetEnabledTools(names []string) {
+	if len(names) == 0 {
+		t.enabled.set.Store(nil)
+		return
+	}
+	set := make(toolSet, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	t.enabled.set.Store(&set)
+}
+
+// toolEnabled reports whether the named tool is currently enabled.
+func (t *Tools) toolEnabled(name string) bool {
+	set := t.enabled.set.Load()
+	if set == nil {
+		return true
+	}
+	_, ok := (*set)[name]
+	return ok
+}
+
+// FilterTools hides disabled tools from tools/list. It satisfies server.ToolFilterFunc.
+func (t *Tools) FilterTools(_ context.Context, tools []mcp.Tool) []mcp.Tool {
+	if t.enabled.set.Load() == nil {
+		return tools
+	}
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if t.toolEnabled(tool.Name) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// disabledToolResult is returned when a client calls a tool that is not enabled.
+func disabledToolResult(name string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("tool %s is disabled on this server", name))
+}
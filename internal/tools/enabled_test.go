@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestFilterTools(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	all := []mcp.Tool{
+		mcp.NewTool("minder_list_projects"),
+		mcp.NewTool("minder_list_profiles"),
+		mcp.NewTool("minder_server_stats"),
+	}
+
+	if got := tools.FilterTools(context.Background(), all); len(got) != len(all) {
+		t.Errorf("FilterTools() with no allowlist returned %d tools, want %d", len(got), len(all))
+	}
+
+	tools.SetEnabledTools([]string{"minder_list_profiles"})
+	got := tools.FilterTools(context.Background(), all)
+	if len(got) != 1 || got[0].Name != "minder_list_profiles" {
+		t.Errorf("FilterTools() = %v, want only minder_list_profiles", got)
+	}
+
+	tools.SetEnabledTools(nil)
+	if got := tools.FilterTools(context.Background(), all); len(got) != len(all) {
+		t.Errorf("FilterTools() after clearing allowlist returned %d tools, want %d", len(got), len(all))
+	}
+}
+
+func TestWrapHandler_DisabledTool(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.SetEnabledTools([]string{"minder_list_profiles"})
+
+	handler := tools.wrapHandler("minder_list_projects", tools.listProjects)
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result for disabled tool")
+	}
+	if text := getResultText(t, result); !strings.Contains(text, "disabled") {
+		t.Errorf("error %q should mention the tool is disabled", text)
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// enrollmentPollInterval is how often Minder is asked whether an enrollment completed.
+	enrollmentPollInterval = 2 * time.Second
+	// defaultEnrollmentWait and maxEnrollmentWait bound how long one call waits.
+	defaultEnrollmentWait = 60 * time.Second
+	maxEnrollmentWait     = 120 * time.Second
+)
+
+// waitForEnrollment polls Minder until the provider enrollment identified by
+// its nonce has created a provider, or the wait runs out, and reports the
+// provider's final state and capabilities.
+func (t *Tools) waitForEnrollment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nonce := req.GetString("enrollment_nonce", "")
+	projectID := req.GetString("project_id", "")
+	wait := time.Duration(req.GetInt("timeout_seconds", 0)) * time.Second
+	if nonce == "" {
+		return mcp.NewToolResultError("enrollment_nonce must be provided"), nil
+	}
+	if wait <= 0 {
+		wait = defaultEnrollmentWait
+	}
+	wait = min(wait, maxEnrollmentWait)
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	verifyReq := &minderv1.VerifyProviderCredentialRequest{EnrollmentNonce: nonce, Context: &minderv1.Context{}}
+	if projectID != "" {
+		verifyReq.Context.Project = &projectID
+	}
+	start := time.Now()
+	deadline := start.Add(wait)
+	for {
+		resp, err := client.OAuth().VerifyProviderCredential(ctx, verifyReq)
+		if err != nil {
+			return grpcErrorResult(err), nil
+		}
+		if resp.GetCreated() {
+			return t.enrolledProvider(ctx, client, resp.GetProviderName(), projectID, time.Since(start))
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return mcp.NewToolResultError("stopped waiting for the enrollment: " + ctx.Err().Error()), nil
+		case <-time.After(min(enrollmentPollInterval, remaining)):
+		}
+	}
+
+	return marshalResult(ctx, map[string]any{
+		"status": "pending",
+		"message": fmt.Sprintf("The enrollment has not completed after %s. The user may not have finished "+
+			"authorizing in the browser yet; call this tool again with the same enrollment_nonce to keep waiting.",
+			wait.Round(time.Second)),
+	})
+}
+
+// enrolledProvider reports a provider created by an enrollment. The provider
+// was created even if it cannot be read back, so that is not an error.
+func (t *Tools) enrolledProvider(
+	ctx context.Context, client MinderClient, name, projectID string, waited time.Duration,
+) (*mcp.CallToolResult, error) {
+	result := map[string]any{
+		"status":   "connected",
+		"provider": name,
+		"waited":   waited.Round(time.Second).String(),
+	}
+	getReq := &minderv1.GetProviderRequest{Name: name, Context: &minderv1.Context{}}
+	if projectID != "" {
+		getReq.Context.Project = &projectID
+	}
+	resp, err := client.Providers().GetProvider(ctx, getReq)
+	if err != nil {
+		t.logger.DebugContext(ctx, "enrolled provider lookup failed", "provider", name, "error", err)
+		result["note"] = "the provider was created but its details could not be read: " + MapGRPCError(err)
+		return marshalResult(ctx, result)
+	}
+	result["capabilities"] = capabilitiesOf(resp.GetProvider())
+	return marshalResult(ctx, result)
+}
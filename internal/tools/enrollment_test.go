@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestWaitForEnrollment(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.oauth.verifyResps = []*minderv1.VerifyProviderCredentialResponse{
+		{},
+		{Created: true, ProviderName: "github-app-acme"},
+	}
+	mockClient.providers.getResp = &minderv1.GetProviderResponse{Provider: &minderv1.Provider{
+		Name:       "github-app-acme",
+		Implements: []minderv1.ProviderType{minderv1.ProviderType_PROVIDER_TYPE_GITHUB},
+	}}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.waitForEnrollment(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"enrollment_nonce": "nonce-1", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("waitForEnrollment() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got struct {
+		Status       string               `json:"status"`
+		Provider     string               `json:"provider"`
+		Capabilities providerCapabilities `json:"capabilities"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Status != "connected" || got.Provider != "github-app-acme" || !got.Capabilities.EntityTypes["pull_request"] {
+		t.Errorf("result = %+v, want the connected provider with its capabilities", got)
+	}
+	if calls := mockClient.oauth.verifyCalls.Load(); calls != 2 {
+		t.Errorf("verified %d times, want polling until the provider was created", calls)
+	}
+}
+
+func TestWaitForEnrollment_Pending(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	result, err := tools.waitForEnrollment(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"enrollment_nonce": "nonce-1", "timeout_seconds": 1}},
+	})
+	if err != nil {
+		t.Fatalf("waitForEnrollment() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if text := getResultText(t, result); !strings.Contains(text, `"status": "pending"`) {
+		t.Errorf("result = %s, want pending after the timeout", text)
+	}
+}
+
+func TestWaitForEnrollment_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		verifyErr error
+		wantMsg   string
+	}{
+		{
+			name:    "missing nonce",
+			args:    map[string]any{},
+			wantMsg: "enrollment_nonce must be provided",
+		},
+		{
+			name:      "verify fails",
+			args:      map[string]any{"enrollment_nonce": "nonce-1"},
+			verifyErr: errors.New("boom"),
+			wantMsg:   "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.oauth.verifyErr = tt.verifyErr
+			tools := newTestTools(mockClient)
+			result, err := tools.waitForEnrollment(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("waitForEnrollment() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// entityRef identifies the entity an entity status result is about.
+type entityRef struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+}
+
+// entityRuleStatus is one rule evaluation of an entity.
+type entityRuleStatus struct {
+	Rule         string `json:"rule"`
+	RuleType     string `json:"rule_type"`
+	Status       string `json:"status"`
+	Severity     string `json:"severity,omitempty"`
+	Details      string `json:"details,omitempty"`
+	Guidance     string `json:"guidance,omitempty"`
+	LastUpdated  string `json:"last_updated,omitempty"`
+	EvaluationID string `json:"evaluation_id,omitempty"`
+}
+
+// entityProfileStatus is how one profile evaluated an entity. Status is
+// "failure" if any rule failed or errored, otherwise "success" if any rule
+// passed, otherwise the status of the first rule.
+type entityProfileStatus struct {
+	Profile   string             `json:"profile"`
+	ProfileID string             `json:"profile_id,omitempty"`
+	Status    string             `json:"status"`
+	Failing   int                `json:"failing"`
+	Rules     []entityRuleStatus `json:"rules"`
+}
+
+// validateEntityLookup checks that exactly one repository or artifact lookup is given.
+func validateEntityLookup(repoID, owner, name, artifactID, artifactName, projectID, provider string) string {
+	isRepo := repoID != "" || owner != "" || name != ""
+	isArtifact := artifactID != "" || artifactName != ""
+	aux := map[string]string{"project_id": projectID, "provider": provider}
+	switch {
+	case isRepo && isArtifact:
+		return "specify either a repository or an artifact, not both"
+	case isArtifact:
+		return ValidateLookupParams(artifactID, artifactName, "artifact_id", "artifact_name", aux)
+	default:
+		return ValidateRepositoryLookupParams(repoID, owner, name, aux)
+	}
+}
+
+// getEntityStatus reports how every profile selecting a repository or artifact
+// evaluated it, answering whether that one entity is compliant.
+func (t *Tools) getEntityStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	repoID := req.GetString("repository_id", "")
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	artifactID := req.GetString("artifact_id", "")
+	artifactName := req.GetString("artifact_name", "")
+	projectID := req.GetString("project_id", "")
+	provider := req.GetString("provider", "")
+
+	if errMsg := validateEntityLookup(repoID, owner, name, artifactID, artifactName, projectID, provider); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	var entity entityRef
+	var kind minderv1.Entity
+	if artifactID != "" || artifactName != "" {
+		artifact, _, err := lookupArtifact(ctx, client, artifactID, artifactName, projectID, provider)
+		if err != nil {
+			return grpcErrorResult(err), nil
+		}
+		kind = minderv1.Entity_ENTITY_ARTIFACTS
+		entity = entityRef{
+			Type: "artifact", ID: artifact.GetArtifactPk(), Name: artifact.GetName(),
+			Project: artifact.GetContext().GetProject(),
+		}
+	} else {
+		repo, err := lookupRepository(ctx, client, repoID, owner, name, projectID, provider)
+		if err != nil {
+			return grpcErrorResult(err), nil
+		}
+		kind = minderv1.Entity_ENTITY_REPOSITORIES
+		entity = entityRef{
+			Type: "repository", ID: repo.GetId(), Name: repo.GetOwner() + "/" + repo.GetName(),
+			Project: repo.GetContext().GetProject(),
+		}
+	}
+
+	resp, err := client.EvalResults().ListEvaluationResults(ctx, &minderv1.ListEvaluationResultsRequest{
+		Context: &minderv1.Context{Project: &entity.Project},
+		Entity:  []*minderv1.EntityTypedId{{Type: kind, Id: entity.ID}},
+	})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	profiles := entityProfileStatuses(resp)
+	failing := 0
+	for _, p := range profiles {
+		failing += p.Failing
+	}
+	result := map[string]any{
+		"entity":        entity,
+		"compliant":     failing == 0,
+		"failing_rules": failing,
+		"profiles":      profiles,
+	}
+	if len(profiles) == 0 {
+		result["note"] = "no profile has evaluated this " + entity.Type + "; it may not be selected by any profile yet"
+	}
+	return marshalResult(ctx, result)
+}
+
+// entityProfileStatuses groups the rule evaluations of the requested entity by profile.
+func entityProfileStatuses(resp *minderv1.ListEvaluationResultsResponse) []entityProfileStatus {
+	profiles := []entityProfileStatus{}
+	for _, entity := range resp.GetEntities() {
+		for _, profile := range entity.GetProfiles() {
+			status := entityProfileStatus{
+				Profile:   profile.GetProfileStatus().GetProfileName(),
+				ProfileID: profile.GetProfileStatus().GetProfileId(),
+				Rules:     []entityRuleStatus{},
+			}
+			var statuses []string
+			for _, rule := range profile.GetResults() {
+				ruleName := rule.RuleDescriptionName
+				if ruleName == "" {
+					ruleName = rule.RuleName
+				}
+				rs := entityRuleStatus{
+					Rule:         ruleName,
+					RuleType:     rule.RuleTypeName,
+					Status:       rule.Status,
+					Severity:     severityName(rule.GetSeverity()),
+					Details:      rule.Details,
+					Guidance:     rule.Guidance,
+					EvaluationID: rule.RuleEvaluationId,
+				}
+				if rule.LastUpdated != nil {
+					rs.LastUpdated = rule.LastUpdated.AsTime().Format(time.RFC3339)
+				}
+				if watcher.IsFailing(rule.Status) {
+					status.Failing++
+				}
+				statuses = append(statuses, rule.Status)
+				status.Rules = append(status.Rules, rs)
+			}
+			status.Status = combinedStatus(statuses)
+			profiles = append(profiles, status)
+		}
+	}
+	return profiles
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestGetEntityStatus(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{
+		Repository: &minderv1.Repository{
+			Id:      ptr("repo-1"),
+			Owner:   "acme",
+			Name:    "api",
+			Context: &minderv1.Context{Project: ptr("proj-1")},
+		},
+	}
+	mockClient.evalResults.listResultsResp = &minderv1.ListEvaluationResultsResponse{
+		Entities: []*minderv1.ListEvaluationResultsResponse_EntityEvaluationResults{{
+			Profiles: []*minderv1.ListEvaluationResultsResponse_EntityProfileEvaluationResults{
+				{
+					ProfileStatus: &minderv1.ProfileStatus{ProfileName: "security", ProfileId: "prof-1"},
+					Results: []*minderv1.RuleEvaluationStatus{
+						{RuleTypeName: "secret_scanning", RuleName: "secret_scanning", Status: "success"},
+						{RuleTypeName: "branch_protection", RuleDescriptionName: "main_protected", Status: "failure"},
+					},
+				},
+				{
+					ProfileStatus: &minderv1.ProfileStatus{ProfileName: "hygiene"},
+					Results: []*minderv1.RuleEvaluationStatus{
+						{RuleTypeName: "license", RuleName: "license", Status: "skipped"},
+					},
+				},
+			},
+		}},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getEntityStatus(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"repository_id": "repo-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getEntityStatus() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Entity       entityRef             `json:"entity"`
+		Compliant    bool                  `json:"compliant"`
+		FailingRules int                   `json:"failing_rules"`
+		Profiles     []entityProfileStatus `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	want := entityRef{Type: "repository", ID: "repo-1", Name: "acme/api", Project: "proj-1"}
+	if got.Entity != want {
+		t.Errorf("entity = %+v, want %+v", got.Entity, want)
+	}
+	if got.Compliant || got.FailingRules != 1 {
+		t.Errorf("compliant, failing_rules = %v, %d, want false, 1", got.Compliant, got.FailingRules)
+	}
+	if len(got.Profiles) != 2 || got.Profiles[0].Status != "failure" || got.Profiles[1].Status != "skipped" {
+		t.Fatalf("profiles = %+v, want security failing and hygiene skipped", got.Profiles)
+	}
+	if rule := got.Profiles[0].Rules[1]; rule.Rule != "main_protected" {
+		t.Errorf("rule = %q, want the rule description name %q", rule.Rule, "main_protected")
+	}
+
+	req := mockClient.evalResults.listResultsReq
+	if req.GetContext().GetProject() != "proj-1" || len(req.Entity) != 1 ||
+		req.Entity[0].Id != "repo-1" || req.Entity[0].Type != minderv1.Entity_ENTITY_REPOSITORIES {
+		t.Errorf("ListEvaluationResults request = %v, want repo-1 in proj-1", req)
+	}
+}
+
+func TestValidateEntityLookup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    [7]string // repository_id, owner, name, artifact_id, artifact_name, project_id, provider
+		wantErr bool
+	}{
+		{name: "repository by ID", args: [7]string{"repo-1"}},
+		{name: "repository by owner and name", args: [7]string{"", "acme", "api", "", "", "proj-1"}},
+		{name: "artifact by name", args: [7]string{"", "", "", "", "ghcr.io/acme/api", "proj-1"}},
+		{name: "nothing given", wantErr: true},
+		{name: "repository and artifact", args: [7]string{"repo-1", "", "", "art-1"}, wantErr: true},
+		{name: "artifact ID with project", args: [7]string{"", "", "", "art-1", "", "proj-1"}, wantErr: true},
+		{name: "owner without name", args: [7]string{"", "acme"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := tt.args
+			got := validateEntityLookup(a[0], a[1], a[2], a[3], a[4], a[5], a[6])
+			if (got != "") != tt.wantErr {
+				t.Errorf("validateEntityLookup() = %q, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,25 +4,195 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/minder"
+	"github.com/stacklok/minder-mcp/internal/timing"
 )
 
-// marshalResult converts a value to pretty-printed JSON and returns it as an MCP tool result.
+// compactJSONKey is the context key marking calls whose results are marshaled without indentation.
+type compactJSONKey struct{}
+
+// withCompactJSON records in ctx whether results are marshaled without indentation.
+func withCompactJSON(ctx context.Context, compact bool) context.Context {
+	return context.WithValue(ctx, compactJSONKey{}, compact)
+}
+
+// marshalJSON marshals v with a two-space indent, or compactly when ctx asks
+// for it: indentation roughly doubles the tokens large lists cost a client.
+func marshalJSON(ctx context.Context, v any) ([]byte, error) {
+	if compact, _ := ctx.Value(compactJSONKey{}).(bool); compact {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// marshalResult converts a value to JSON, pretty-printed unless MCP_COMPACT_JSON
+// is set, and returns it as an MCP tool result.
 // On marshal failure, returns an error result (not a Go error).
 //
 //nolint:unparam // error return matches tool handler signature for direct return
-func marshalResult(v any) (*mcp.CallToolResult, error) {
-	data, err := json.MarshalIndent(v, "", "  ")
+func marshalResult(ctx context.Context, v any) (*mcp.CallToolResult, error) {
+	start := time.Now()
+	data, err := marshalJSON(ctx, v)
+	timing.Record(ctx, "marshal", start)
 	if err != nil {
 		return mcp.NewToolResultError("failed to marshal response: " + err.Error()), nil
 	}
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// Error codes reported in ErrorDetail.Code. Agents should branch on these
+// rather than on the human-readable message.
+const (
+	ErrCodeCanceled           = "canceled"
+	ErrCodeUnknown            = "unknown"
+	ErrCodeInvalidArgument    = "invalid_argument"
+	ErrCodeTimeout            = "timeout"
+	ErrCodeNotFound           = "not_found"
+	ErrCodeAlreadyExists      = "already_exists"
+	ErrCodePermissionDenied   = "permission_denied"
+	ErrCodeRateLimited        = "rate_limited"
+	ErrCodeFailedPrecondition = "failed_precondition"
+	ErrCodeConflict           = "conflict"
+	ErrCodeUnimplemented      = "unimplemented"
+	ErrCodeInternal           = "internal"
+	ErrCodeUnavailable        = "unavailable"
+	ErrCodeUnauthenticated    = "unauthenticated"
+	// ErrCodeToolError marks errors raised by the tool itself, such as a
+	// missing argument, rather than by Minder.
+	ErrCodeToolError = "tool_error"
+)
+
+// ErrorDetail is the machine-readable form of a tool error. Error results
+// carry it as structured content under "error", next to the text message.
+type ErrorDetail struct {
+	Code            string `json:"code"`
+	Message         string `json:"message"`
+	Retryable       bool   `json:"retryable"`
+	GRPCCode        string `json:"grpc_code,omitempty"`
+	SuggestedAction string `json:"suggested_action,omitempty"`
+	RequestID       string `json:"request_id,omitempty"`
+	// RetryAfterSeconds is how long Minder, or this server's rate limit, asked
+	// callers to wait before retrying.
+	RetryAfterSeconds float64 `json:"retry_after_seconds,omitempty"`
+	// QuotaViolations are the Minder quotas a rate-limited call exceeded.
+	QuotaViolations []minder.QuotaViolation `json:"quota_violations,omitempty"`
+	// RealmURL is the identity provider to sign in to when the token must be replaced.
+	RealmURL string `json:"realm_url,omitempty"`
+	// Projects are the projects a name looked up across projects was found in.
+	Projects []string `json:"projects,omitempty"`
+}
+
+// errorClass is how a gRPC status code is reported in ErrorDetail.
+type errorClass struct {
+	code      string
+	retryable bool
+	action    string
+}
+
+// grpcErrorClasses classifies the gRPC status codes Minder returns.
+var grpcErrorClasses = map[codes.Code]errorClass{
+	codes.Canceled: {code: ErrCodeCanceled},
+	codes.Unknown:  {code: ErrCodeUnknown},
+	codes.InvalidArgument: {code: ErrCodeInvalidArgument,
+		action: "Check the arguments against the tool's input schema and call again with corrected values."},
+	codes.DeadlineExceeded: {code: ErrCodeTimeout, retryable: true,
+		action: "Retry; if the call keeps timing out, narrow it with project_id or a smaller limit."},
+	codes.NotFound: {code: ErrCodeNotFound,
+		action: "Check the ID or name with the matching list tool and that it belongs to the project."},
+	codes.AlreadyExists: {code: ErrCodeAlreadyExists},
+	codes.PermissionDenied: {code: ErrCodePermissionDenied,
+		action: "Use a project the token can access, or ask a project admin for the needed role."},
+	codes.ResourceExhausted: {code: ErrCodeRateLimited, retryable: true,
+		action: "Wait before retrying and make fewer calls."},
+	codes.FailedPrecondition: {code: ErrCodeFailedPrecondition},
+	codes.Aborted:            {code: ErrCodeConflict, retryable: true, action: "Retry the call."},
+	codes.OutOfRange:         {code: ErrCodeInvalidArgument, action: "Check cursors and limits and call again."},
+	codes.Unimplemented: {code: ErrCodeUnimplemented,
+		action: "This Minder server does not support the operation; it may run an older version."},
+	codes.Internal: {code: ErrCodeInternal,
+		action: "Retry later and report the request_id to the Minder operators if it persists."},
+	codes.Unavailable: {code: ErrCodeUnavailable, retryable: true,
+		action: "Retry shortly; if it persists, check connectivity with the diagnose tool."},
+	codes.DataLoss: {code: ErrCodeInternal,
+		action: "Report the request_id to the Minder operators."},
+	codes.Unauthenticated: {code: ErrCodeUnauthenticated,
+		action: "Refresh the Minder token or send a valid Authorization header."},
+}
+
+// NewErrorDetail classifies err for an error result. Tokens that must be
+// replaced are reported as ErrCodeUnauthenticated, names found in several
+// projects as ErrCodeInvalidArgument and other errors that are not gRPC
+// statuses as ErrCodeUnknown.
+func NewErrorDetail(err error) ErrorDetail {
+	detail := ErrorDetail{Code: ErrCodeUnknown, Message: MapGRPCError(err)}
+	var reauth *reauthError
+	if errors.As(err, &reauth) {
+		detail.Code = ErrCodeUnauthenticated
+		detail.SuggestedAction = reauth.nextStep()
+		detail.RealmURL = reauth.realmURL
+		return detail
+	}
+	var ambiguous *ambiguousError
+	if errors.As(err, &ambiguous) {
+		detail.Code = ErrCodeInvalidArgument
+		detail.SuggestedAction = "Call again with project_id set to the project meant."
+		detail.Projects = ambiguous.projectIDs()
+		return detail
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return detail
+	}
+	detail.GRPCCode = st.Code().String()
+	if class, ok := grpcErrorClasses[st.Code()]; ok {
+		detail.Code = class.code
+		detail.Retryable = class.retryable
+		detail.SuggestedAction = class.action
+	}
+	if st.Code() == codes.ResourceExhausted {
+		detail.QuotaViolations = minder.QuotaViolations(err)
+		if wait := minder.RetryAfter(err); wait > 0 {
+			detail.RetryAfterSeconds = wait.Seconds()
+			detail.SuggestedAction = fmt.Sprintf("Wait %s before retrying and make fewer calls.", wait)
+		}
+	}
+	return detail
+}
+
+// errorResult returns an error result with message as its text and detail,
+// carrying the same message, as its structured content.
+func errorResult(message string, detail ErrorDetail) *mcp.CallToolResult {
+	detail.Message = message
+	result := mcp.NewToolResultError(message)
+	result.StructuredContent = map[string]any{"error": detail}
+	return result
+}
+
+// grpcErrorResult returns the error result for a failed Minder call.
+func grpcErrorResult(err error) *mcp.CallToolResult {
+	return errorResult(MapGRPCError(err), NewErrorDetail(err))
+}
+
+// errorDetailOf returns the structured error detail of an error result, if any.
+func errorDetailOf(result *mcp.CallToolResult) (ErrorDetail, bool) {
+	content, ok := result.StructuredContent.(map[string]any)
+	if !ok {
+		return ErrorDetail{}, false
+	}
+	detail, ok := content["error"].(ErrorDetail)
+	return detail, ok
+}
+
 // MapGRPCError converts a gRPC error to a user-friendly error message.
 //
 //nolint:gocyclo // Switch statement on error codes is readable and complete
@@ -54,7 +224,7 @@ func MapGRPCError(err error) string {
 	case codes.PermissionDenied:
 		return "Permission denied: " + st.Message()
 	case codes.ResourceExhausted:
-		return "Resource exhausted: " + st.Message()
+		return resourceExhaustedMessage(err, st.Message())
 	case codes.FailedPrecondition:
 		return "Failed precondition: " + st.Message()
 	case codes.Aborted:
@@ -76,26 +246,73 @@ func MapGRPCError(err error) string {
 	}
 }
 
+// resourceExhaustedMessage describes a rate-limited call with the quotas it
+// exceeded and how long to wait, when Minder reports them.
+func resourceExhaustedMessage(err error, message string) string {
+	var b strings.Builder
+	b.WriteString("Resource exhausted: " + message)
+	for _, v := range minder.QuotaViolations(err) {
+		b.WriteString("; quota exceeded")
+		if v.Subject != "" {
+			b.WriteString(" for " + v.Subject)
+		}
+		if v.Description != "" {
+			b.WriteString(": " + v.Description)
+		}
+	}
+	if wait := minder.RetryAfter(err); wait > 0 {
+		b.WriteString("; retry after " + wait.String())
+	}
+	return b.String()
+}
+
+// healthCheckTimeoutKey is the context key holding how long checkHealth waits for Minder.
+type healthCheckTimeoutKey struct{}
+
+// withHealthCheckTimeout records in ctx how long checkHealth waits for
+// Minder to answer. Zero leaves the health check bounded only by ctx.
+func withHealthCheckTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, healthCheckTimeoutKey{}, timeout)
+}
+
 // checkHealth verifies the Minder server is available by calling the health check endpoint.
 // Returns nil if healthy, or an MCP error result if the server is unavailable.
+// The call is cut short after the timeout recorded by withHealthCheckTimeout,
+// so a server that accepts connections but never answers fails calls quickly.
 func checkHealth(ctx context.Context, client MinderClient) *mcp.CallToolResult {
-	_, err := client.Health().CheckHealth(ctx, &minderv1.CheckHealthRequest{})
+	healthCtx := ctx
+	timeout, _ := ctx.Value(healthCheckTimeoutKey{}).(time.Duration)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		healthCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	_, err := client.Health().CheckHealth(healthCtx, &minderv1.CheckHealthRequest{})
 	if err != nil {
+		detail := NewErrorDetail(err)
+		if ctx.Err() == nil && errors.Is(healthCtx.Err(), context.DeadlineExceeded) {
+			detail.Code, detail.Retryable = ErrCodeTimeout, true
+			detail.SuggestedAction = grpcErrorClasses[codes.Unavailable].action
+			return errorResult(fmt.Sprintf("Minder server did not answer a health check within %s; "+
+				"it may be overloaded or unreachable.", timeout), detail)
+		}
 		st, ok := status.FromError(err)
 		if ok && st.Code() == codes.Unavailable {
-			return mcp.NewToolResultError("Minder server is unavailable. Please check if the server is running and accessible.")
+			return errorResult("Minder server is unavailable. Please check if the server is running and accessible.", detail)
 		}
 		// For other connection errors (deadline exceeded, etc.), provide a clear message
 		if ok && (st.Code() == codes.DeadlineExceeded || st.Code() == codes.Canceled) {
-			return mcp.NewToolResultError("Unable to reach Minder server: " + MapGRPCError(err))
+			return errorResult("Unable to reach Minder server: "+MapGRPCError(err), detail)
 		}
 		// For non-gRPC errors (like connection refused), provide a generic message
 		if !ok {
-			return mcp.NewToolResultError("Unable to connect to Minder server: " + err.Error())
+			detail.Code, detail.Retryable = ErrCodeUnavailable, true
+			detail.SuggestedAction = grpcErrorClasses[codes.Unavailable].action
+			return errorResult("Unable to connect to Minder server: "+err.Error(), detail)
 		}
 		// For other gRPC errors during health check, the server might be having issues
 		if st.Code() == codes.Internal {
-			return mcp.NewToolResultError("Minder server health check failed: the server may be experiencing issues")
+			return errorResult("Minder server health check failed: the server may be experiencing issues", detail)
 		}
 	}
 	return nil
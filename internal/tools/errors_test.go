@@ -5,10 +5,13 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestMarshalResult(t *testing.T) {
@@ -55,7 +58,7 @@ func TestMarshalResult(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := marshalResult(tt.input)
+			result, err := marshalResult(context.Background(), tt.input)
 			if err != nil {
 				t.Fatalf("marshalResult() returned error: %v", err)
 			}
@@ -87,6 +90,24 @@ func TestMarshalResult(t *testing.T) {
 	}
 }
 
+func TestMarshalResult_Compact(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.cfg.MCP.CompactJSON = true
+	handler := tools.wrapHandler("test_tool", func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return marshalResult(ctx, map[string]any{"items": []string{"a", "b"}})
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if got, want := getResultText(t, result), `{"items":["a","b"]}`; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+}
+
 func TestMapGRPCError(t *testing.T) {
 	t.Parallel()
 
@@ -203,6 +224,126 @@ func TestMapGRPCError(t *testing.T) {
 	}
 }
 
+func TestNewErrorDetail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      string
+		wantRetryable bool
+		wantGRPCCode  string
+	}{
+		{
+			name:         "not found",
+			err:          status.Error(codes.NotFound, "profile missing"),
+			wantCode:     ErrCodeNotFound,
+			wantGRPCCode: "NotFound",
+		},
+		{
+			name:          "unavailable is retryable",
+			err:           status.Error(codes.Unavailable, "down"),
+			wantCode:      ErrCodeUnavailable,
+			wantRetryable: true,
+			wantGRPCCode:  "Unavailable",
+		},
+		{
+			name:          "resource exhausted is rate limited",
+			err:           status.Error(codes.ResourceExhausted, "slow down"),
+			wantCode:      ErrCodeRateLimited,
+			wantRetryable: true,
+			wantGRPCCode:  "ResourceExhausted",
+		},
+		{
+			name:          "rate limit details",
+			err:           rateLimitedError(t),
+			wantCode:      ErrCodeRateLimited,
+			wantRetryable: true,
+			wantGRPCCode:  "ResourceExhausted",
+		},
+		{
+			name:         "out of range is an invalid argument",
+			err:          status.Error(codes.OutOfRange, "bad cursor"),
+			wantCode:     ErrCodeInvalidArgument,
+			wantGRPCCode: "OutOfRange",
+		},
+		{
+			name:     "non-gRPC error",
+			err:      errors.New("connection refused"),
+			wantCode: ErrCodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewErrorDetail(tt.err)
+			if got.Code != tt.wantCode || got.Retryable != tt.wantRetryable || got.GRPCCode != tt.wantGRPCCode {
+				t.Errorf("NewErrorDetail() = %+v, want code %q, retryable %v, grpc code %q",
+					got, tt.wantCode, tt.wantRetryable, tt.wantGRPCCode)
+			}
+			if got.Message != MapGRPCError(tt.err) {
+				t.Errorf("Message = %q, want %q", got.Message, MapGRPCError(tt.err))
+			}
+		})
+	}
+}
+
+// rateLimitedError returns a ResourceExhausted error with retry and quota details.
+func rateLimitedError(t *testing.T) error {
+	t.Helper()
+	st, err := status.New(codes.ResourceExhausted, "too many requests").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "project:p1", Description: "100 requests per minute"},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("adding status details: %v", err)
+	}
+	return st.Err()
+}
+
+func TestResourceExhaustedDetails(t *testing.T) {
+	t.Parallel()
+
+	err := rateLimitedError(t)
+	wantMsg := "Resource exhausted: too many requests; quota exceeded for project:p1: 100 requests per minute; retry after 30s"
+	if got := MapGRPCError(err); got != wantMsg {
+		t.Errorf("MapGRPCError() = %q, want %q", got, wantMsg)
+	}
+
+	detail := NewErrorDetail(err)
+	if detail.RetryAfterSeconds != 30 {
+		t.Errorf("RetryAfterSeconds = %v, want 30", detail.RetryAfterSeconds)
+	}
+	if len(detail.QuotaViolations) != 1 || detail.QuotaViolations[0].Subject != "project:p1" {
+		t.Errorf("QuotaViolations = %v, want one for project:p1", detail.QuotaViolations)
+	}
+	if !strings.Contains(detail.SuggestedAction, "30s") {
+		t.Errorf("SuggestedAction = %q, want the retry delay", detail.SuggestedAction)
+	}
+}
+
+func TestGRPCErrorResult(t *testing.T) {
+	t.Parallel()
+
+	result := grpcErrorResult(status.Error(codes.PermissionDenied, "no access to project"))
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok {
+		t.Fatalf("structured content = %#v, want an error detail", result.StructuredContent)
+	}
+	if detail.Code != ErrCodePermissionDenied || detail.SuggestedAction == "" {
+		t.Errorf("detail = %+v, want code %q with a suggested action", detail, ErrCodePermissionDenied)
+	}
+	if text := getResultText(t, result); text != detail.Message {
+		t.Errorf("text %q differs from detail message %q", text, detail.Message)
+	}
+}
+
 func TestCheckHealth(t *testing.T) {
 	t.Parallel()
 
@@ -300,3 +441,26 @@ func TestCheckHealth(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckHealth_Timeout(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.health.hang = true
+	ctx := withHealthCheckTimeout(context.Background(), 20*time.Millisecond)
+
+	start := time.Now()
+	result := checkHealth(ctx, mockClient)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("checkHealth() took %v, want it cut short", elapsed)
+	}
+	if result == nil || !result.IsError {
+		t.Fatalf("checkHealth() = %v, want an error result", result)
+	}
+	if text := getResultText(t, result); !strings.Contains(text, "did not answer a health check within 20ms") {
+		t.Errorf("error message = %q", text)
+	}
+	if detail, _ := errorDetailOf(result); detail.Code != ErrCodeTimeout || !detail.Retryable {
+		t.Errorf("error detail = %+v, want retryable %s", detail, ErrCodeTimeout)
+	}
+}
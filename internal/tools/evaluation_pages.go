@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// historyCursor is a position in an evaluation history listing: the project
+// being listed and Minder's cursor within it. Carrying the project lets a
+// listing across all projects resume in the project where the last page ended.
+type historyCursor struct {
+	Project string `json:"p"`
+	Cursor  string `json:"c,omitempty"`
+}
+
+// encode returns the opaque cursor string handed to clients.
+func (c historyCursor) encode() string {
+	return encodeCursor(c)
+}
+
+// encodeCursor returns a cursor position as an opaque string, base64 encoded
+// JSON. The position must be a struct of strings, which always marshals.
+func encodeCursor(position any) string {
+	b, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a string from encodeCursor into position, reporting
+// whether it is one.
+func decodeCursor(s string, position any) bool {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	return err == nil && json.Unmarshal(b, position) == nil
+}
+
+// errInvalidHistoryCursor is returned for a cursor not produced by
+// minder_list_evaluation_history.
+var errInvalidHistoryCursor = errors.New("invalid cursor: pass next_cursor from a previous response unchanged")
+
+// errHistoryCursorProject is returned for a cursor from a listing of other projects.
+var errHistoryCursorProject = errors.New("invalid cursor: it belongs to a project outside this listing")
+
+// decodeHistoryCursor parses a cursor from encode. An empty string is the
+// start of the listing.
+func decodeHistoryCursor(s string) (historyCursor, error) {
+	var c historyCursor
+	if s == "" {
+		return c, nil
+	}
+	if !decodeCursor(s, &c) || c.Project == "" {
+		return historyCursor{}, errInvalidHistoryCursor
+	}
+	return c, nil
+}
+
+// historyPageFunc lists one page of evaluation history in a project, starting
+// at Minder's cursor and returning at most size evaluations when size is positive.
+type historyPageFunc func(
+	ctx context.Context, projectID, cursor string, size int,
+) (*minderv1.ListEvaluationHistoryResponse, error)
+
+// historyPage is a page of evaluation history.
+type historyPage struct {
+	evaluations []*minderv1.EvaluationHistory
+	// next is the cursor of the next page, nil after the last project.
+	next *historyCursor
+	// totals is the number of evaluations Minder matched in each project it
+	// reported a count for.
+	totals map[string]int
+	// skipped is the projects that could not be listed and are left out of
+	// the listing.
+	skipped []skippedRead
+}
+
+// pageEvaluationHistory lists a page of up to pageSize evaluations from
+// projects, in order, starting at start. The page ends early in a project
+// Minder has more evaluations for, so every evaluation is listed exactly
+// once across pages. The page's next cursor is nil after the last project.
+// When listing several projects, one that fails is skipped and reported in
+// the page's skipped list.
+func pageEvaluationHistory(
+	ctx context.Context, projects []string, start historyCursor, pageSize int, list historyPageFunc,
+) (*historyPage, error) {
+	i := 0
+	if start.Project != "" {
+		if i = slices.Index(projects, start.Project); i < 0 {
+			return nil, errHistoryCursorProject
+		}
+	}
+	cursor := start.Cursor
+
+	page := &historyPage{totals: map[string]int{}}
+	for ; i < len(projects); i++ {
+		size := 0
+		if pageSize > 0 {
+			size = pageSize - len(page.evaluations)
+		}
+		resp, err := list(ctx, projects[i], cursor, size)
+		cursor = ""
+		if err != nil {
+			if len(projects) == 1 {
+				return nil, err
+			}
+			page.skipped = append(page.skipped, skippedRead{Project: projects[i], Error: MapGRPCError(err)})
+			continue
+		}
+		page.evaluations = append(page.evaluations, resp.GetData()...)
+		if total, ok := historyTotal(resp); ok {
+			page.totals[projects[i]] = total
+		}
+		if next := resp.GetPage().GetNext().GetCursor(); next != "" {
+			page.next = &historyCursor{Project: projects[i], Cursor: next}
+			return page, nil
+		}
+		if pageSize > 0 && len(page.evaluations) >= pageSize && i+1 < len(projects) {
+			page.next = &historyCursor{Project: projects[i+1]}
+			return page, nil
+		}
+	}
+	return page, nil
+}
+
+// historyTotal returns the number of evaluations Minder matched for a page
+// request. Minder may leave the count out, which is only told apart from
+// zero matches when the page has evaluations or a next page.
+func historyTotal(resp *minderv1.ListEvaluationHistoryResponse) (int, bool) {
+	page := resp.GetPage()
+	if page == nil {
+		return 0, false
+	}
+	total := int(page.GetTotalRecords())
+	if total == 0 && (len(resp.GetData()) > 0 || page.GetNext().GetCursor() != "") {
+		return 0, false
+	}
+	return total, true
+}
+
+// countEvaluationHistory adds to totals the counts of the projects not
+// listed on the page, requesting a single evaluation from each.
+func countEvaluationHistory(ctx context.Context, projects []string, totals map[string]int, list historyPageFunc) {
+	for _, projID := range projects {
+		if _, ok := totals[projID]; ok {
+			continue
+		}
+		resp, err := list(ctx, projID, "", 1)
+		if err != nil {
+			continue
+		}
+		if total, ok := historyTotal(resp); ok {
+			totals[projID] = total
+		}
+	}
+}
+
+// addHistoryTotals adds the number of evaluations matched across projects to
+// result, when every project reported one, and the count of each project
+// when several were listed. Counts made before where clauses Minder cannot
+// apply are labelled as such, since they include evaluations the listing drops.
+func addHistoryTotals(result map[string]any, projects []string, totals map[string]int, beforeWhere bool) {
+	suffix := ""
+	if beforeWhere {
+		suffix = "_before_where"
+	}
+	if len(totals) == len(projects) {
+		sum := 0
+		for _, total := range totals {
+			sum += total
+		}
+		result["total_records"+suffix] = sum
+	}
+	if len(projects) > 1 && len(totals) > 0 {
+		result["total_records_by_project"+suffix] = totals
+	}
+}
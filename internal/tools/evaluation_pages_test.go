@@ -0,0 +1,312 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestHistoryCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := historyCursor{Project: "proj-2", Cursor: "minder-cursor"}
+	got, err := decodeHistoryCursor(want.encode())
+	if err != nil || got != want {
+		t.Errorf("decodeHistoryCursor(encode()) = %+v, %v, want %+v", got, err, want)
+	}
+	for _, invalid := range []string{"not base64!", "e30", "bm90IGpzb24"} {
+		if _, err := decodeHistoryCursor(invalid); !errors.Is(err, errInvalidHistoryCursor) {
+			t.Errorf("decodeHistoryCursor(%q) error = %v, want errInvalidHistoryCursor", invalid, err)
+		}
+	}
+}
+
+// fakeHistoryPages serves the evaluation IDs of each project in pages, using
+// the offset of the next evaluation as Minder's cursor.
+func fakeHistoryPages(history map[string][]string, failing string) historyPageFunc {
+	return func(_ context.Context, projectID, cursor string, size int) (*minderv1.ListEvaluationHistoryResponse, error) {
+		if projectID == failing {
+			return nil, errors.New("unavailable")
+		}
+		ids := history[projectID]
+		offset, _ := strconv.Atoi(cursor)
+		end := len(ids)
+		if size > 0 {
+			end = min(offset+size, len(ids))
+		}
+		resp := &minderv1.ListEvaluationHistoryResponse{Page: &minderv1.CursorPage{TotalRecords: uint32(len(ids))}}
+		for _, id := range ids[offset:end] {
+			resp.Data = append(resp.Data, &minderv1.EvaluationHistory{Id: id})
+		}
+		if end < len(ids) {
+			resp.Page.Next = &minderv1.Cursor{Cursor: strconv.Itoa(end)}
+		}
+		return resp, nil
+	}
+}
+
+func TestPageEvaluationHistory(t *testing.T) {
+	t.Parallel()
+
+	history := map[string][]string{
+		"proj-1": {"a1", "a2", "a3"},
+		"proj-2": {},
+		"proj-3": {"c1", "c2"},
+	}
+	projects := []string{"proj-1", "proj-2", "proj-3"}
+	tests := []struct {
+		name     string
+		projects []string
+		pageSize int
+		failing  string
+		want     []string // each page's IDs
+	}{
+		{name: "pages across projects", projects: projects, pageSize: 2, want: []string{"a1,a2", "a3,c1", "c2"}},
+		{name: "page fills at project end", projects: projects, pageSize: 3, want: []string{"a1,a2,a3", "c1,c2"}},
+		{name: "one page", projects: projects, pageSize: 10, want: []string{"a1,a2,a3,c1,c2"}},
+		{name: "no page size", projects: projects, want: []string{"a1,a2,a3,c1,c2"}},
+		{name: "single project", projects: []string{"proj-3"}, pageSize: 1, want: []string{"c1", "c2"}},
+		{name: "failing project skipped", projects: projects, pageSize: 2, failing: "proj-1", want: []string{"c1,c2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			list := fakeHistoryPages(history, tt.failing)
+			var got []string
+			start := historyCursor{}
+			for range 10 {
+				page, err := pageEvaluationHistory(context.Background(), tt.projects, start, tt.pageSize, list)
+				if err != nil {
+					t.Fatalf("pageEvaluationHistory() error = %v", err)
+				}
+				ids := make([]string, 0, len(page.evaluations))
+				for _, e := range page.evaluations {
+					ids = append(ids, e.GetId())
+				}
+				got = append(got, strings.Join(ids, ","))
+				if page.next == nil {
+					break
+				}
+				// Resume from the cursor as a client would
+				if start, err = decodeHistoryCursor(page.next.encode()); err != nil {
+					t.Fatalf("decodeHistoryCursor() error = %v", err)
+				}
+			}
+			if strings.Join(got, " | ") != strings.Join(tt.want, " | ") {
+				t.Errorf("pages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageEvaluationHistory_Errors(t *testing.T) {
+	t.Parallel()
+
+	list := fakeHistoryPages(map[string][]string{}, "proj-1")
+	if _, err := pageEvaluationHistory(context.Background(), []string{"proj-1"}, historyCursor{}, 10, list); err == nil {
+		t.Error("expected the error of the only project listed")
+	}
+	start := historyCursor{Project: "proj-9", Cursor: "1"}
+	_, err := pageEvaluationHistory(context.Background(), []string{"proj-2"}, start, 10, list)
+	if !errors.Is(err, errHistoryCursorProject) {
+		t.Errorf("error = %v, want errHistoryCursorProject for another project's cursor", err)
+	}
+}
+
+func TestPageEvaluationHistory_Skipped(t *testing.T) {
+	t.Parallel()
+
+	history := map[string][]string{"proj-2": {"b1"}}
+	list := fakeHistoryPages(history, "proj-1")
+	page, err := pageEvaluationHistory(context.Background(), []string{"proj-1", "proj-2"}, historyCursor{}, 10, list)
+	if err != nil {
+		t.Fatalf("pageEvaluationHistory() error = %v", err)
+	}
+	if len(page.evaluations) != 1 || page.evaluations[0].GetId() != "b1" {
+		t.Errorf("evaluations = %v, want b1 from the readable project", page.evaluations)
+	}
+	if len(page.skipped) != 1 || page.skipped[0].Project != "proj-1" || page.skipped[0].Error == "" {
+		t.Errorf("skipped = %+v, want proj-1 with its error", page.skipped)
+	}
+}
+
+func TestHistoryTotals(t *testing.T) {
+	t.Parallel()
+
+	history := map[string][]string{
+		"proj-1": {"a1", "a2", "a3"},
+		"proj-2": {},
+		"proj-3": {"c1", "c2"},
+	}
+	projects := []string{"proj-1", "proj-2", "proj-3"}
+	list := fakeHistoryPages(history, "")
+	page, err := pageEvaluationHistory(context.Background(), projects, historyCursor{}, 2, list)
+	if err != nil {
+		t.Fatalf("pageEvaluationHistory() error = %v", err)
+	}
+	if len(page.totals) != 1 || page.totals["proj-1"] != 3 {
+		t.Errorf("page totals = %v, want only proj-1, the project the page reached", page.totals)
+	}
+	countEvaluationHistory(context.Background(), projects, page.totals, list)
+	result := map[string]any{}
+	addHistoryTotals(result, projects, page.totals, false)
+	byProject, _ := result["total_records_by_project"].(map[string]int)
+	if result["total_records"] != 5 || byProject["proj-1"] != 3 || byProject["proj-2"] != 0 || byProject["proj-3"] != 2 {
+		t.Errorf("result = %v, want 5 records: 3, 0 and 2 per project", result)
+	}
+
+	// Without a count from every project there is no aggregate
+	result = map[string]any{}
+	addHistoryTotals(result, projects, map[string]int{"proj-1": 3}, false)
+	if _, ok := result["total_records"]; ok || result["total_records_by_project"] == nil {
+		t.Errorf("result = %v, want only the per-project count", result)
+	}
+
+	// Counts that ignore part of the where expression are labelled so
+	result = map[string]any{}
+	addHistoryTotals(result, projects, page.totals, true)
+	if _, ok := result["total_records"]; ok || result["total_records_before_where"] != 5 ||
+		result["total_records_by_project_before_where"] == nil {
+		t.Errorf("result = %v, want the totals labelled as before where", result)
+	}
+}
+
+func TestHistoryTotal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		resp   *minderv1.ListEvaluationHistoryResponse
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "reported",
+			resp:   &minderv1.ListEvaluationHistoryResponse{Page: &minderv1.CursorPage{TotalRecords: 132}},
+			want:   132,
+			wantOK: true,
+		},
+		{name: "no matches", resp: &minderv1.ListEvaluationHistoryResponse{Page: &minderv1.CursorPage{}}, wantOK: true},
+		{name: "no page", resp: &minderv1.ListEvaluationHistoryResponse{}},
+		{
+			name: "left out",
+			resp: &minderv1.ListEvaluationHistoryResponse{
+				Data: []*minderv1.EvaluationHistory{{Id: "eval-1"}},
+				Page: &minderv1.CursorPage{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, ok := historyTotal(tt.resp); got != tt.want || ok != tt.wantOK {
+				t.Errorf("historyTotal() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestListEvaluationHistory_Cursor(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{
+		Data: []*minderv1.EvaluationHistory{{Id: "eval-1"}},
+		Page: &minderv1.CursorPage{Next: &minderv1.Cursor{Cursor: "minder-next"}, TotalRecords: 132},
+	}
+	tools := newTestTools(mockClient)
+
+	cursor := historyCursor{Project: "proj-1", Cursor: "minder-prev"}.encode()
+	result, err := tools.listEvaluationHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "cursor": cursor, "page_size": 5}},
+	})
+	if err != nil {
+		t.Fatalf("listEvaluationHistory() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if c := mockClient.evalResults.listReq.GetCursor(); c.GetCursor() != "minder-prev" || c.GetSize() != 5 {
+		t.Errorf("request cursor = %+v, want Minder's cursor with size 5", c)
+	}
+	var got struct {
+		HasMore      bool   `json:"has_more"`
+		NextCursor   string `json:"next_cursor"`
+		TotalRecords int    `json:"total_records"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	next, err := decodeHistoryCursor(got.NextCursor)
+	if !got.HasMore || err != nil || next != (historyCursor{Project: "proj-1", Cursor: "minder-next"}) {
+		t.Errorf("result = %+v (cursor %+v, %v), want more results after minder-next", got, next, err)
+	}
+	if got.TotalRecords != 132 {
+		t.Errorf("total_records = %d, want 132", got.TotalRecords)
+	}
+
+	for _, invalid := range []string{"garbage", historyCursor{Project: "proj-2"}.encode()} {
+		result, err = tools.listEvaluationHistory(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "cursor": invalid}},
+		})
+		if err != nil {
+			t.Fatalf("listEvaluationHistory() returned Go error: %v", err)
+		}
+		if !result.IsError || !strings.Contains(getResultText(t, result), "invalid cursor") {
+			t.Errorf("cursor %q: result = %s, want an invalid cursor error", invalid, getResultText(t, result))
+		}
+	}
+}
+
+func TestListEvaluationHistory_MaxResultsPages(t *testing.T) {
+	t.Parallel()
+
+	// Minder's own page of 3 is larger than MCP_MAX_RESULTS
+	ids := []string{"e1", "e2", "e3", "e4", "e5"}
+	pages := fakeHistoryPages(map[string][]string{"proj-1": ids}, "")
+	mockClient := newMockClient()
+	mockClient.evalResults.listFunc = func(req *minderv1.ListEvaluationHistoryRequest) (*minderv1.ListEvaluationHistoryResponse, error) {
+		size := int(req.GetCursor().GetSize())
+		if size == 0 {
+			size = 3
+		}
+		return pages(context.Background(), req.GetContext().GetProject(), req.GetCursor().GetCursor(), size)
+	}
+	tools := newLimitedTools(mockClient, 0, 2)
+
+	var listed []string
+	cursor := ""
+	for range 10 {
+		result, err := tools.listEvaluationHistory(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "cursor": cursor}},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("listEvaluationHistory() = %v, %v", result, err)
+		}
+		var got struct {
+			Results    []*minderv1.EvaluationHistory `json:"results"`
+			NextCursor string                        `json:"next_cursor"`
+		}
+		if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		if len(got.Results) > 2 {
+			t.Errorf("page has %d evaluations, want at most MCP_MAX_RESULTS", len(got.Results))
+		}
+		for _, e := range got.Results {
+			listed = append(listed, e.GetId())
+		}
+		if cursor = got.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if strings.Join(listed, ",") != strings.Join(ids, ",") {
+		t.Errorf("listed %v across pages, want every evaluation once: %v", listed, ids)
+	}
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// evaluationsURI is the base of the evaluation history resource; filters
+	// are given as query parameters.
+	evaluationsURI = "minder://evaluations"
+	// defaultEvaluationDays is how far back the resource looks without days.
+	defaultEvaluationDays = 7
+	// maxEvaluationDays bounds the days query parameter.
+	maxEvaluationDays = 90
+	// maxEvaluationResourcePages bounds the history pages read per project.
+	maxEvaluationResourcePages = 5
+)
+
+// evaluationQueryParams maps the resource's query parameters to a setter on
+// the history request.
+var evaluationQueryParams = map[string]func(*minderv1.ListEvaluationHistoryRequest, string){
+	"profile":     func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.ProfileName = []string{v} },
+	"entity_type": func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.EntityType = []string{v} },
+	"entity_name": func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.EntityName = []string{v} },
+	"status":      func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.Status = []string{v} },
+	"remediation": func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.Remediation = []string{v} },
+	"alert":       func(r *minderv1.ListEvaluationHistoryRequest, v string) { r.Alert = []string{v} },
+}
+
+// evaluationQuery is the parsed query of an evaluation history resource URI.
+type evaluationQuery struct {
+	projectID string
+	days      int
+	filters   map[string]string
+}
+
+// parseEvaluationQuery reads the filters from uri. Parameters may appear in
+// any order; unknown parameters are rejected rather than ignored, so a typo
+// does not silently return unfiltered history.
+func parseEvaluationQuery(uri string) (evaluationQuery, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return evaluationQuery{}, fmt.Errorf("invalid resource URI %q: %w", uri, err)
+	}
+	q := evaluationQuery{days: defaultEvaluationDays, filters: map[string]string{}}
+	for key, values := range u.Query() {
+		value := values[len(values)-1]
+		switch {
+		case key == "project_id":
+			q.projectID = value
+		case key == "days":
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 1 || days > maxEvaluationDays {
+				return evaluationQuery{}, fmt.Errorf("days must be a whole number from 1 to %d, got %q", maxEvaluationDays, value)
+			}
+			q.days = days
+		case evaluationQueryParams[key] != nil:
+			if value != "" {
+				q.filters[key] = value
+			}
+		default:
+			known := append(slices.Sorted(maps.Keys(evaluationQueryParams)), "days", "project_id")
+			slices.Sort(known)
+			return evaluationQuery{}, fmt.Errorf("unknown query parameter %q; use %s", key, strings.Join(known, ", "))
+		}
+	}
+	return q, nil
+}
+
+// registerEvaluationResource lets resource-oriented clients pull filtered
+// evaluation history declaratively, e.g.
+// minder://evaluations?profile=baseline&status=failure&days=7.
+func (t *Tools) registerEvaluationResource(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(evaluationsURI+"{?project_id,profile,entity_type,entity_name,status,remediation,alert,days}",
+			"Evaluation History",
+			mcp.WithTemplateDescription(fmt.Sprintf("Rule evaluations of the last days (default %d, at most %d), newest first, "+
+				"filtered by profile, entity_type, entity_name, status, remediation and alert. "+
+				"Without project_id every accessible project is included", defaultEvaluationDays, maxEvaluationDays)),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		t.readEvaluations,
+	)
+}
+
+// readEvaluations materializes the evaluation history selected by the URI's query.
+func (t *Tools) readEvaluations(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	q, err := parseEvaluationQuery(req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := t.resourceClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	from := time.Now().AddDate(0, 0, -q.days).UTC()
+	truncated := false
+	rows, err := forEachProject(ctx, client, q.projectID,
+		func(ctx context.Context, projID string) ([]*minderv1.EvaluationHistory, error) {
+			reqProto := &minderv1.ListEvaluationHistoryRequest{
+				Context:     &minderv1.Context{Project: &projID},
+				LabelFilter: []string{"*"},
+				From:        timestamppb.New(from),
+				Cursor:      &minderv1.Cursor{Size: maxPageSize},
+			}
+			for key, value := range q.filters {
+				evaluationQueryParams[key](reqProto, value)
+			}
+			var rows []*minderv1.EvaluationHistory
+			for page := 0; ; page++ {
+				resp, err := client.EvalResults().ListEvaluationHistory(ctx, reqProto)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, resp.Data...)
+				next := resp.GetPage().GetNext().GetCursor()
+				if next == "" || len(resp.Data) == 0 {
+					return rows, nil
+				}
+				if page+1 == maxEvaluationResourcePages {
+					truncated = true
+					return rows, nil
+				}
+				reqProto.Cursor = &minderv1.Cursor{Cursor: next, Size: maxPageSize}
+			}
+		})
+	if err != nil {
+		return nil, resourceError(err)
+	}
+
+	slices.SortStableFunc(rows, func(a, b *minderv1.EvaluationHistory) int {
+		return b.GetEvaluatedAt().AsTime().Compare(a.GetEvaluatedAt().AsTime())
+	})
+	rows, total := capResults(rows, t.cfg.MCP.MaxResults)
+	result := map[string]any{
+		"from":    from.Format(time.RFC3339),
+		"filters": q.filters,
+		"count":   len(rows),
+		"results": rows,
+	}
+	if q.projectID != "" {
+		result["project_id"] = q.projectID
+	}
+	switch {
+	case truncated:
+		result["note"] = "only the most recent evaluations of some projects were read; narrow the filters or reduce days"
+	case total > len(rows):
+		result["note"] = fmt.Sprintf("showing the %d most recent of %d evaluations; narrow the filters or reduce days",
+			len(rows), total)
+	}
+
+	data, err := marshalJSON(withCompactJSON(ctx, t.cfg.MCP.CompactJSON), result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", req.Params.URI, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "application/json", Text: string(data)},
+	}, nil
+}
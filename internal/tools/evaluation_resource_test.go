@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseEvaluationQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		uri         string
+		wantProject string
+		wantDays    int
+		wantFilters map[string]string
+		wantErr     string
+	}{
+		{name: "no query", uri: "minder://evaluations", wantDays: 7, wantFilters: map[string]string{}},
+		{
+			name:        "filters in any order",
+			uri:         "minder://evaluations?days=30&status=failure&profile=baseline&project_id=p1",
+			wantProject: "p1",
+			wantDays:    30,
+			wantFilters: map[string]string{"status": "failure", "profile": "baseline"},
+		},
+		{
+			name:        "escaped entity name",
+			uri:         "minder://evaluations?entity_name=acme%2Fapi",
+			wantDays:    7,
+			wantFilters: map[string]string{"entity_name": "acme/api"},
+		},
+		{name: "days out of range", uri: "minder://evaluations?days=365", wantErr: "days must be"},
+		{name: "days not a number", uri: "minder://evaluations?days=week", wantErr: "days must be"},
+		{name: "unknown parameter", uri: "minder://evaluations?staus=failure", wantErr: `unknown query parameter "staus"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := parseEvaluationQuery(tt.uri)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProject, q.projectID)
+			assert.Equal(t, tt.wantDays, q.days)
+			assert.Equal(t, tt.wantFilters, q.filters)
+		})
+	}
+}
+
+func TestReadEvaluations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	mockClient := newMockClient()
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{Data: []*minderv1.EvaluationHistory{
+		{Id: "older", EvaluatedAt: timestamppb.New(now.Add(-2 * time.Hour))},
+		{Id: "newer", EvaluatedAt: timestamppb.New(now.Add(-time.Hour))},
+	}}
+	tools := newTestTools(mockClient)
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, false))
+	tools.Register(s)
+
+	uri := "minder://evaluations?status=failure&profile=baseline&days=3&project_id=p1"
+	result, rpcErr := readResource(t, s, uri)
+	require.Nil(t, rpcErr)
+	require.Len(t, result.Contents, 1)
+	text, ok := result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, uri, text.URI)
+	assert.Equal(t, "application/json", text.MIMEType)
+
+	var body struct {
+		Count   int    `json:"count"`
+		Project string `json:"project_id"`
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &body))
+	assert.Equal(t, 2, body.Count)
+	assert.Equal(t, "p1", body.Project)
+	require.Len(t, body.Results, 2)
+	assert.Equal(t, "newer", body.Results[0].ID, "results should be newest first")
+
+	req := mockClient.evalResults.listReq
+	require.NotNil(t, req)
+	assert.Equal(t, []string{"failure"}, req.Status)
+	assert.Equal(t, []string{"baseline"}, req.ProfileName)
+	assert.Equal(t, "p1", req.GetContext().GetProject())
+	assert.WithinDuration(t, now.AddDate(0, 0, -3), req.GetFrom().AsTime(), time.Minute)
+}
+
+func TestReadEvaluations_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "minder://evaluations?days=0"
+	_, err := tools.readEvaluations(t.Context(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "days must be")
+}
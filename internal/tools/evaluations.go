@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,7 +14,7 @@ import (
 func (t *Tools) listEvaluationHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -30,10 +31,21 @@ func (t *Tools) listEvaluationHistory(ctx context.Context, req mcp.CallToolReque
 	alertStatus := req.GetString("alert_status", "")
 	fromStr := req.GetString("from", "")
 	toStr := req.GetString("to", "")
-	cursor := req.GetString("cursor", "")
-	pageSize := req.GetInt("page_size", 0)
+	start, err := decodeHistoryCursor(req.GetString("cursor", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pageSize := t.pageSize(req.GetInt("page_size", 0))
 	labelFilter := req.GetString("label_filter", "*") // Default to "*" to include all profiles
 
+	var where whereQuery
+	if expr := req.GetString("where", ""); expr != "" {
+		if where, err = parseWhere(expr); err != nil {
+			return mcp.NewToolResultError("invalid where: " + err.Error()), nil
+		}
+	}
+	now := time.Now()
+
 	// Parse time filters once
 	var fromTime, toTime *timestamppb.Timestamp
 	if fromStr != "" {
@@ -47,70 +59,205 @@ func (t *Tools) listEvaluationHistory(ctx context.Context, req mcp.CallToolReque
 		}
 	}
 
-	// Use multi-project aggregation when no project_id specified
-	// Note: pagination only works within a single project when aggregating
-	evaluations, err := forEachProject(
-		ctx, client, projectID,
-		func(ctx context.Context, projID string) ([]*minderv1.EvaluationHistory, error) {
-			reqProto := &minderv1.ListEvaluationHistoryRequest{
-				Context: &minderv1.Context{
-					Project: &projID,
-				},
-			}
+	projects, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
 
-			if profileName != "" {
-				reqProto.ProfileName = []string{profileName}
-			}
-			if entityType != "" {
-				reqProto.EntityType = []string{entityType}
-			}
-			if entityName != "" {
-				reqProto.EntityName = []string{entityName}
-			}
-			if evalStatus != "" {
-				reqProto.Status = []string{evalStatus}
-			}
-			if remediationStatus != "" {
-				reqProto.Remediation = []string{remediationStatus}
-			}
-			if alertStatus != "" {
-				reqProto.Alert = []string{alertStatus}
-			}
-			if labelFilter != "" {
-				reqProto.LabelFilter = []string{labelFilter}
-			}
-			if fromTime != nil {
-				reqProto.From = fromTime
-			}
-			if toTime != nil {
-				reqProto.To = toTime
-			}
+	// Whether Minder's counts reflect the where expression, known once it is compiled
+	whereApplied := true
+	list := func(ctx context.Context, projID, cursor string, size int) (*minderv1.ListEvaluationHistoryResponse, error) {
+		reqProto := &minderv1.ListEvaluationHistoryRequest{
+			Context: &minderv1.Context{
+				Project: &projID,
+			},
+		}
 
-			// Add pagination parameters (advanced cursor)
-			if cursor != "" || pageSize > 0 {
-				reqProto.Cursor = &minderv1.Cursor{}
-				if cursor != "" {
-					reqProto.Cursor.Cursor = cursor
-				}
-				if pageSize > 0 && pageSize <= 100 {
-					reqProto.Cursor.Size = uint32(pageSize) //nolint:gosec // pageSize is bounded by schema validation (1-100)
-				}
+		if profileName != "" {
+			reqProto.ProfileName = []string{profileName}
+		}
+		if entityType != "" {
+			reqProto.EntityType = []string{entityType}
+		}
+		if entityName != "" {
+			reqProto.EntityName = []string{entityName}
+		}
+		if evalStatus != "" {
+			reqProto.Status = []string{evalStatus}
+		}
+		if remediationStatus != "" {
+			reqProto.Remediation = []string{remediationStatus}
+		}
+		if alertStatus != "" {
+			reqProto.Alert = []string{alertStatus}
+		}
+		if labelFilter != "" {
+			reqProto.LabelFilter = []string{labelFilter}
+		}
+		if fromTime != nil {
+			reqProto.From = fromTime
+		}
+		if toTime != nil {
+			reqProto.To = toTime
+		}
+		where.compile(reqProto, now)
+		whereApplied = where.minderApplies(reqProto)
+
+		// Add pagination parameters (advanced cursor)
+		if cursor != "" || size > 0 {
+			reqProto.Cursor = &minderv1.Cursor{
+				Cursor: cursor,
+				Size:   uint32(size), //nolint:gosec // size is bounded by t.pageSize (1-100)
 			}
+		}
+
+		return client.EvalResults().ListEvaluationHistory(ctx, reqProto)
+	}
+
+	// Page through the projects in turn; the cursor records where this page ended
+	page, err := pageEvaluationHistory(ctx, projects, start, pageSize, list)
+	if errors.Is(err, errHistoryCursorProject) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
 
-			resp, err := client.EvalResults().ListEvaluationHistory(ctx, reqProto)
-			if err != nil {
-				return nil, err
+	// Count the projects this page did not reach so the totals cover them all
+	countEvaluationHistory(ctx, projects, page.totals, list)
+
+	// Minder cannot filter on every clause, so apply them all to what it returned
+	evaluations := page.evaluations
+	excluded := 0
+	if where != nil {
+		matched := evaluations[:0]
+		for _, e := range evaluations {
+			if where.match(e, now) {
+				matched = append(matched, e)
 			}
-			return resp.Data, nil
-		})
+		}
+		excluded = len(evaluations) - len(matched)
+		evaluations = matched
+	}
+
+	// The page size already honours MCP_MAX_RESULTS; cutting the page here
+	// would drop evaluations the next cursor has moved past
+	result := map[string]any{
+		"results":  evaluations,
+		"has_more": page.next != nil,
+	}
+	if page.next != nil {
+		result["next_cursor"] = page.next.encode()
+	}
+	addHistoryTotals(result, projects, page.totals, !whereApplied)
+	if len(page.skipped) > 0 {
+		result["skipped"] = page.skipped
+	}
+	if excluded > 0 {
+		result["excluded_by_where"] = excluded
+	}
+
+	return marshalResult(ctx, result)
+}
+
+// evaluationRuleType is the rule definition an evaluation was made against.
+type evaluationRuleType struct {
+	ID                  string `json:"id,omitempty"`
+	Name                string `json:"name"`
+	DisplayName         string `json:"display_name,omitempty"`
+	Description         string `json:"description,omitempty"`
+	Guidance            string `json:"guidance,omitempty"`
+	ShortFailureMessage string `json:"short_failure_message,omitempty"`
+}
+
+// getEvaluation gets one evaluation by ID with its entity, status, alert and
+// remediation details, and a reference to the rule type it was evaluated against.
+func (t *Tools) getEvaluation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	evaluationID := req.GetString("evaluation_id", "")
+	projectID := req.GetString("project_id", "")
+	if evaluationID == "" {
+		return mcp.NewToolResultError("evaluation_id must be provided"), nil
+	}
+
+	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	evaluation, evalProjectID, err := findEvaluation(ctx, client, evaluationID, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
 	}
 
-	// Build response (pagination info not reliable when aggregating multiple projects)
 	result := map[string]any{
-		"results": evaluations,
+		"evaluation": evaluation,
+		"project_id": evalProjectID,
+	}
+	if rt := t.evaluationRuleType(ctx, client, evaluation, evalProjectID); rt != nil {
+		result["rule_type"] = evaluationRuleType{
+			ID:                  rt.GetId(),
+			Name:                rt.GetName(),
+			DisplayName:         rt.GetDisplayName(),
+			Description:         rt.GetDescription(),
+			Guidance:            rt.GetGuidance(),
+			ShortFailureMessage: rt.GetShortFailureMessage(),
+		}
 	}
 
-	return marshalResult(result)
+	return marshalResult(ctx, result)
+}
+
+// findEvaluation gets an evaluation by ID, searching across projects if
+// projectID is empty, and returns the project it was found in.
+func findEvaluation(
+	ctx context.Context, client MinderClient, evaluationID, projectID string,
+) (*minderv1.EvaluationHistory, string, error) {
+	type found struct {
+		evaluation *minderv1.EvaluationHistory
+		projectID  string
+	}
+	match, err := findInProjects(ctx, client, projectID, func(ctx context.Context, projID string) (found, error) {
+		resp, err := client.EvalResults().GetEvaluationHistory(ctx, &minderv1.GetEvaluationHistoryRequest{
+			Id: evaluationID,
+			Context: &minderv1.Context{
+				Project: &projID,
+			},
+		})
+		if err != nil {
+			return found{}, err
+		}
+		return found{evaluation: resp.Evaluation, projectID: projID}, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return match.evaluation, match.projectID, nil
+}
+
+// evaluationRuleType gets the rule type an evaluation was made against, or
+// nil if it cannot be found. The rule type adds guidance; the evaluation is
+// still useful without it.
+func (t *Tools) evaluationRuleType(
+	ctx context.Context, client MinderClient, evaluation *minderv1.EvaluationHistory, projectID string,
+) *minderv1.RuleType {
+	ruleType := evaluation.GetRule().GetRuleType()
+	if ruleType == "" {
+		return nil
+	}
+	resp, err := client.RuleTypes().GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{
+		Name: ruleType,
+		Context: &minderv1.Context{
+			Project: &projectID,
+		},
+	})
+	if err != nil {
+		t.logger.DebugContext(ctx, "rule type lookup failed", "rule_type", ruleType, "error", err)
+		return nil
+	}
+	return resp.GetRuleType()
 }
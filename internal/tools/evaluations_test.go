@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetEvaluation(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.getResp = &minderv1.GetEvaluationHistoryResponse{
+		Evaluation: &minderv1.EvaluationHistory{
+			Id:     "eval-1",
+			Entity: &minderv1.EvaluationHistoryEntity{Id: "repo-1", Name: "stacklok/minder"},
+			Rule: &minderv1.EvaluationHistoryRule{
+				Name:     "branch_protection",
+				RuleType: "branch_protection_enabled",
+				Profile:  "baseline",
+			},
+			Status: &minderv1.EvaluationHistoryStatus{Status: "failure", Details: "main is not protected"},
+		},
+	}
+	mockClient.ruleTypes.getByNameResp = &minderv1.GetRuleTypeByNameResponse{
+		RuleType: &minderv1.RuleType{
+			Id:       ptr("rt-1"),
+			Name:     "branch_protection_enabled",
+			Guidance: "Enable branch protection on the default branch",
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getEvaluation(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"evaluation_id": "eval-1", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getEvaluation() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Evaluation struct {
+			Status struct {
+				Details string `json:"details"`
+			} `json:"status"`
+		} `json:"evaluation"`
+		ProjectID string             `json:"project_id"`
+		RuleType  evaluationRuleType `json:"rule_type"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Evaluation.Status.Details != "main is not protected" {
+		t.Errorf("details = %q, want the failure output", got.Evaluation.Status.Details)
+	}
+	if got.ProjectID != "proj-1" {
+		t.Errorf("project_id = %q, want proj-1", got.ProjectID)
+	}
+	if got.RuleType.ID != "rt-1" || got.RuleType.Guidance == "" {
+		t.Errorf("rule_type = %+v, want the rule type with guidance", got.RuleType)
+	}
+}
+
+func TestGetEvaluation_RuleTypeLookupFails(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.getResp = &minderv1.GetEvaluationHistoryResponse{
+		Evaluation: &minderv1.EvaluationHistory{
+			Id:   "eval-1",
+			Rule: &minderv1.EvaluationHistoryRule{RuleType: "deleted_rule"},
+		},
+	}
+	mockClient.ruleTypes.getByNameErr = errors.New("not found")
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getEvaluation(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"evaluation_id": "eval-1", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getEvaluation() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if _, ok := got["rule_type"]; ok {
+		t.Errorf("rule_type = %v, want it omitted when the lookup fails", got["rule_type"])
+	}
+}
+
+func TestGetEvaluation_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		getErr  error
+		wantMsg string
+	}{
+		{
+			name:    "missing id",
+			args:    map[string]any{},
+			wantMsg: "evaluation_id must be provided",
+		},
+		{
+			name:    "lookup fails",
+			args:    map[string]any{"evaluation_id": "eval-1", "project_id": "proj-1"},
+			getErr:  errors.New("boom"),
+			wantMsg: "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.evalResults.getErr = tt.getErr
+			tools := newTestTools(mockClient)
+			result, err := tools.getEvaluation(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("getEvaluation() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestListEvaluationHistory_Where(t *testing.T) {
+	t.Parallel()
+
+	recent := timestamppb.New(time.Now().Add(-time.Hour))
+	mockClient := newMockClient()
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{
+		Data: []*minderv1.EvaluationHistory{
+			{
+				Id:          "eval-1",
+				Rule:        &minderv1.EvaluationHistoryRule{Name: "protect-main", RuleType: "branch_protection_enabled"},
+				Status:      &minderv1.EvaluationHistoryStatus{Status: "failure"},
+				EvaluatedAt: recent,
+			},
+			{
+				Id:          "eval-2",
+				Rule:        &minderv1.EvaluationHistoryRule{Name: "no-secrets", RuleType: "secret_scanning"},
+				Status:      &minderv1.EvaluationHistoryStatus{Status: "failure"},
+				EvaluatedAt: recent,
+			},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.listEvaluationHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"project_id": "proj-1",
+			"where":      `status = failure AND rule ~ "branch" AND age < 7d`,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("listEvaluationHistory() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	listReq := mockClient.evalResults.listReq
+	if len(listReq.GetStatus()) != 1 || listReq.GetStatus()[0] != "failure" || listReq.GetFrom() == nil {
+		t.Errorf("request = %+v, want the status and age clauses sent to Minder", listReq)
+	}
+	var got struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+		ExcludedByWhere int `json:"excluded_by_where"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].ID != "eval-1" || got.ExcludedByWhere != 1 {
+		t.Errorf("result = %+v, want only eval-1 with one excluded", got)
+	}
+}
+
+func TestListEvaluationHistory_InvalidWhere(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	result, err := tools.listEvaluationHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"where": "severity = high"}},
+	})
+	if err != nil {
+		t.Fatalf("listEvaluationHistory() returned Go error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(getResultText(t, result), "invalid where: unknown field") {
+		t.Errorf("result = %s, want an invalid where error", getResultText(t, result))
+	}
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// Minder's settings for profiles that leave remediate or alert unset.
+const (
+	defaultProfileRemediate = "off"
+	defaultProfileAlert     = "on"
+)
+
+// failureExplanation combines an evaluation with the rule type and profile
+// settings needed to understand and fix it.
+type failureExplanation struct {
+	EvaluationID   string              `json:"evaluation_id"`
+	ProjectID      string              `json:"project_id"`
+	EvaluatedAt    string              `json:"evaluated_at,omitempty"`
+	Entity         explainedEntity     `json:"entity"`
+	Profile        string              `json:"profile"`
+	Rule           string              `json:"rule"`
+	RuleType       string              `json:"rule_type"`
+	Severity       string              `json:"severity,omitempty"`
+	Status         string              `json:"status"`
+	FailureDetails string              `json:"failure_details,omitempty"`
+	Explanation    *evaluationRuleType `json:"explanation,omitempty"`
+	Remediation    explainedAction     `json:"remediation"`
+	Alert          explainedAction     `json:"alert"`
+	NextStep       string              `json:"next_step"`
+}
+
+// explainedEntity is the entity an explained evaluation was made on.
+type explainedEntity struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// explainedAction is what Minder did, and is configured to do, about a failure.
+type explainedAction struct {
+	Status         string `json:"status,omitempty"`
+	Details        string `json:"details,omitempty"`
+	ProfileSetting string `json:"profile_setting,omitempty"`
+	// Method is how the rule type remediates, e.g. "pull_request" or "rest".
+	// Empty if the rule type cannot remediate.
+	Method string `json:"method,omitempty"`
+}
+
+// explainEvaluation returns one evaluation together with its rule type's
+// description and guidance and the remediation and alert state, so a failure
+// can be explained and fixed from a single call.
+func (t *Tools) explainEvaluation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	evaluationID := req.GetString("evaluation_id", "")
+	projectID := req.GetString("project_id", "")
+	if evaluationID == "" {
+		return mcp.NewToolResultError("evaluation_id must be provided"), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	evaluation, evalProjectID, err := findEvaluation(ctx, client, evaluationID, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	rule := evaluation.GetRule()
+	explanation := failureExplanation{
+		EvaluationID: evaluation.GetId(),
+		ProjectID:    evalProjectID,
+		Entity: explainedEntity{
+			Type: strings.ToLower(strings.TrimPrefix(evaluation.GetEntity().GetType().String(), "ENTITY_")),
+			ID:   evaluation.GetEntity().GetId(),
+			Name: evaluation.GetEntity().GetName(),
+		},
+		Profile:        rule.GetProfile(),
+		Rule:           rule.GetName(),
+		RuleType:       rule.GetRuleType(),
+		Severity:       severityName(rule.GetSeverity()),
+		Status:         evaluation.GetStatus().GetStatus(),
+		FailureDetails: evaluation.GetStatus().GetDetails(),
+		Remediation: explainedAction{
+			Status:  evaluation.GetRemediation().GetStatus(),
+			Details: evaluation.GetRemediation().GetDetails(),
+		},
+		Alert: explainedAction{
+			Status:  evaluation.GetAlert().GetStatus(),
+			Details: evaluation.GetAlert().GetDetails(),
+		},
+	}
+	if at := evaluation.GetEvaluatedAt(); at != nil {
+		explanation.EvaluatedAt = at.AsTime().Format(time.RFC3339)
+	}
+	if rt := t.evaluationRuleType(ctx, client, evaluation, evalProjectID); rt != nil {
+		explanation.Explanation = &evaluationRuleType{
+			ID:                  rt.GetId(),
+			Name:                rt.GetName(),
+			DisplayName:         rt.GetDisplayName(),
+			Description:         rt.GetDescription(),
+			Guidance:            rt.GetGuidance(),
+			ShortFailureMessage: rt.GetShortFailureMessage(),
+		}
+		explanation.Remediation.Method = rt.GetDef().GetRemediate().GetType()
+	}
+	if profile := t.evaluationProfile(ctx, client, rule.GetProfile(), evalProjectID); profile != nil {
+		explanation.Remediation.ProfileSetting = settingOrDefault(profile.Remediate, defaultProfileRemediate)
+		explanation.Alert.ProfileSetting = settingOrDefault(profile.Alert, defaultProfileAlert)
+	}
+	explanation.NextStep = explanation.nextStep()
+
+	return marshalResult(ctx, explanation)
+}
+
+// evaluationProfile gets the profile an evaluation was made for, or nil if it
+// cannot be found.
+func (t *Tools) evaluationProfile(ctx context.Context, client MinderClient, name, projectID string) *minderv1.Profile {
+	if name == "" {
+		return nil
+	}
+	resp, err := client.Profiles().GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{
+		Name: name,
+		Context: &minderv1.Context{
+			Project: &projectID,
+		},
+	})
+	if err != nil {
+		t.logger.DebugContext(ctx, "profile lookup failed", "profile", name, "error", err)
+		return nil
+	}
+	return resp.GetProfile()
+}
+
+// settingOrDefault returns a profile's remediate or alert setting, or Minder's
+// default when it is unset.
+func settingOrDefault(setting *string, def string) string {
+	if setting == nil || *setting == "" {
+		return def
+	}
+	return *setting
+}
+
+// nextStep suggests what to do about the evaluation.
+func (e failureExplanation) nextStep() string {
+	fix := "Fix the failure manually"
+	if e.Explanation != nil && e.Explanation.Guidance != "" {
+		fix += " following the guidance"
+	}
+	switch {
+	case !watcher.IsFailing(e.Status):
+		return fmt.Sprintf("The rule did not fail (status %q); nothing needs fixing.", e.Status)
+	case e.Status == "error":
+		return "Minder could not evaluate the rule; check failure_details for the cause, " +
+			"such as missing provider permissions or an invalid rule definition, and re-evaluate."
+	case e.Remediation.Status == "success":
+		return "Minder remediated the failure; it clears on the next evaluation."
+	case e.Remediation.Status == "pending":
+		return "Minder opened a remediation, such as a pull request, that is waiting to be merged; " +
+			"review and merge it. " + fix + " otherwise."
+	case e.Remediation.Method != "" && e.Remediation.ProfileSetting == defaultProfileRemediate:
+		return fmt.Sprintf("%s, or set remediate to on in profile %s to let Minder fix it with a %s remediation.",
+			fix, e.Profile, e.Remediation.Method)
+	default:
+		return fix + ", then re-evaluate the entity."
+	}
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestExplainEvaluation(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.getResp = &minderv1.GetEvaluationHistoryResponse{
+		Evaluation: &minderv1.EvaluationHistory{
+			Id: "eval-1",
+			Entity: &minderv1.EvaluationHistoryEntity{
+				Id: "repo-1", Name: "stacklok/minder", Type: minderv1.Entity_ENTITY_REPOSITORIES,
+			},
+			Rule: &minderv1.EvaluationHistoryRule{
+				Name:     "branch_protection",
+				RuleType: "branch_protection_enabled",
+				Profile:  "baseline",
+				Severity: &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH},
+			},
+			Status:      &minderv1.EvaluationHistoryStatus{Status: "failure", Details: "main is not protected"},
+			Remediation: &minderv1.EvaluationHistoryRemediation{Status: "skipped"},
+		},
+	}
+	mockClient.ruleTypes.getByNameResp = &minderv1.GetRuleTypeByNameResponse{
+		RuleType: &minderv1.RuleType{
+			Name:        "branch_protection_enabled",
+			Description: "Verifies the default branch is protected",
+			Guidance:    "Enable branch protection on the default branch",
+			Def: &minderv1.RuleType_Definition{
+				Remediate: &minderv1.RuleType_Definition_Remediate{Type: "gh_branch_protection"},
+			},
+		},
+	}
+	mockClient.profiles.getByNameResp = &minderv1.GetProfileByNameResponse{
+		Profile: &minderv1.Profile{Name: "baseline", Alert: ptr("on")},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.explainEvaluation(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"evaluation_id": "eval-1", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("explainEvaluation() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got failureExplanation
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Entity.Type != "repositories" || got.Severity != "high" || got.FailureDetails != "main is not protected" {
+		t.Errorf("entity, severity, failure_details = %+v, %q, %q", got.Entity, got.Severity, got.FailureDetails)
+	}
+	if got.Explanation == nil || got.Explanation.Guidance == "" {
+		t.Errorf("explanation = %+v, want the rule type guidance", got.Explanation)
+	}
+	want := explainedAction{Status: "skipped", ProfileSetting: "off", Method: "gh_branch_protection"}
+	if got.Remediation != want {
+		t.Errorf("remediation = %+v, want %+v", got.Remediation, want)
+	}
+	if got.Alert.ProfileSetting != "on" {
+		t.Errorf("alert profile setting = %q, want on", got.Alert.ProfileSetting)
+	}
+	if !strings.Contains(got.NextStep, "set remediate to on in profile baseline") {
+		t.Errorf("next_step = %q, want a suggestion to enable remediation", got.NextStep)
+	}
+}
+
+func TestFailureExplanation_NextStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		explanation failureExplanation
+		want        string
+	}{
+		{
+			name:        "passing",
+			explanation: failureExplanation{Status: "success"},
+			want:        "nothing needs fixing",
+		},
+		{
+			name:        "evaluation error",
+			explanation: failureExplanation{Status: "error"},
+			want:        "could not evaluate",
+		},
+		{
+			name: "remediated",
+			explanation: failureExplanation{Status: "failure",
+				Remediation: explainedAction{Status: "success"}},
+			want: "remediated the failure",
+		},
+		{
+			name: "remediation pending",
+			explanation: failureExplanation{Status: "failure",
+				Remediation: explainedAction{Status: "pending"}},
+			want: "review and merge it",
+		},
+		{
+			name: "no automatic remediation",
+			explanation: failureExplanation{Status: "failure",
+				Explanation: &evaluationRuleType{Guidance: "Add a LICENSE file"}},
+			want: "Fix the failure manually following the guidance, then re-evaluate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.explanation.nextStep(); !strings.Contains(got, tt.want) {
+				t.Errorf("nextStep() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
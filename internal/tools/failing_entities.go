@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// failingEntity is an entity currently failing a rule of the requested type.
+type failingEntity struct {
+	Entity       string `json:"entity"`
+	EntityID     string `json:"entity_id"`
+	EntityType   string `json:"entity_type,omitempty"`
+	Project      string `json:"project"`
+	Profile      string `json:"profile"`
+	Rule         string `json:"rule"`
+	Status       string `json:"status"`
+	Severity     string `json:"severity,omitempty"`
+	Details      string `json:"details,omitempty"`
+	LastUpdated  string `json:"last_updated,omitempty"`
+	EvaluationID string `json:"evaluation_id,omitempty"`
+}
+
+// listFailingEntities lists the entities whose current evaluation of a rule
+// type failed or errored, in one project or across all accessible projects.
+func (t *Tools) listFailingEntities(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ruleType := req.GetString("rule_type", "")
+	projectID := req.GetString("project_id", "")
+	if ruleType == "" {
+		return mcp.NewToolResultError("rule_type must be provided"), nil
+	}
+	threshold, errMsg := minSeverityParam(req)
+	if errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectIDs, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	failing := []failingEntity{}
+	var skipped []skippedRead
+	evaluated := 0
+	for _, projID := range projectIDs {
+		statuses, projSkipped := readProfileStatuses(ctx, client, projID)
+		skipped = append(skipped, projSkipped...)
+		for _, status := range statuses {
+			for _, eval := range filterRuleStatuses(status.Evaluations, threshold) {
+				if eval.GetRuleTypeName() != ruleType {
+					continue
+				}
+				evaluated++
+				if watcher.IsFailing(eval.GetStatus()) {
+					failing = append(failing, newFailingEntity(projID, status.Profile.GetName(), eval))
+				}
+			}
+		}
+	}
+	slices.SortFunc(failing, func(a, b failingEntity) int {
+		return cmp.Or(cmp.Compare(a.Entity, b.Entity), cmp.Compare(a.Profile, b.Profile), cmp.Compare(a.Rule, b.Rule))
+	})
+
+	entities := map[string]bool{}
+	for _, f := range failing {
+		entities[f.EntityID] = true
+	}
+	failing, total := capResults(failing, t.cfg.MCP.MaxResults)
+	result := map[string]any{
+		"rule_type":        ruleType,
+		"failing":          failing,
+		"failing_entities": len(entities),
+		"evaluations":      evaluated,
+	}
+	if len(skipped) > 0 {
+		// Entities failing in these may be missing from the list
+		result["skipped"] = skipped
+	}
+	if evaluated == 0 && len(skipped) == 0 {
+		result["note"] = "no profile currently evaluates rule type " + ruleType +
+			"; check the name with minder_list_rule_types"
+	}
+	return t.marshalCapped(ctx, result, len(failing), total)
+}
+
+func newFailingEntity(projectID, profile string, eval *minderv1.RuleEvaluationStatus) failingEntity {
+	rule := eval.GetRuleDescriptionName()
+	if rule == "" {
+		rule = eval.GetRuleName()
+	}
+	f := failingEntity{
+		Entity:       entityDisplayName(eval.GetEntityInfo()),
+		EntityID:     eval.GetEntityInfo()["entity_id"],
+		EntityType:   eval.GetEntity(),
+		Project:      projectID,
+		Profile:      profile,
+		Rule:         rule,
+		Status:       eval.GetStatus(),
+		Severity:     severityName(eval.GetSeverity()),
+		Details:      eval.GetDetails(),
+		EvaluationID: eval.GetRuleEvaluationId(),
+	}
+	if eval.GetLastUpdated() != nil {
+		f.LastUpdated = eval.GetLastUpdated().AsTime().Format(time.RFC3339)
+	}
+	return f
+}
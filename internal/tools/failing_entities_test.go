@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListFailingEntities(t *testing.T) {
+	t.Parallel()
+
+	eval := func(ruleType, entityID, repo, status string) *minderv1.RuleEvaluationStatus {
+		return &minderv1.RuleEvaluationStatus{
+			RuleTypeName:        ruleType,
+			RuleDescriptionName: ruleType + "_main",
+			Entity:              "repository",
+			EntityInfo:          map[string]string{"entity_id": entityID, "repo_owner": "acme", "repo_name": repo},
+			Status:              status,
+			Details:             "details of " + repo,
+		}
+	}
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{{Name: "baseline"}}}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			eval("branch_protection", "repo-2", "web", "failure"),
+			eval("branch_protection", "repo-1", "api", "error"),
+			eval("branch_protection", "repo-3", "docs", "success"),
+			eval("secret_scanning", "repo-3", "docs", "failure"),
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.listFailingEntities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"rule_type": "branch_protection", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listFailingEntities() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got struct {
+		Failing         []failingEntity `json:"failing"`
+		FailingEntities int             `json:"failing_entities"`
+		Evaluations     int             `json:"evaluations"`
+		Note            string          `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Failing) != 2 || got.Failing[0].Entity != "acme/api" || got.Failing[1].Entity != "acme/web" {
+		t.Fatalf("failing = %+v, want acme/api and acme/web in order", got.Failing)
+	}
+	if f := got.Failing[0]; f.Status != "error" || f.Profile != "baseline" || f.Rule != "branch_protection_main" ||
+		f.Project != "proj-1" || f.Details != "details of api" {
+		t.Errorf("first failing entity = %+v", f)
+	}
+	if got.FailingEntities != 2 || got.Evaluations != 3 || got.Note != "" {
+		t.Errorf("counts = %d failing of %d evaluations, note %q", got.FailingEntities, got.Evaluations, got.Note)
+	}
+}
+
+func TestListFailingEntities_ReportsSkipped(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{{Name: "baseline"}}}
+	mockClient.profiles.getStatusByNameErr = status.Error(codes.Unavailable, "down")
+
+	result, err := newTestTools(mockClient).listFailingEntities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"rule_type": "secret_scanning", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listFailingEntities() returned Go error: %v", err)
+	}
+	var got struct {
+		Skipped []skippedRead `json:"skipped"`
+		Note    string        `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Skipped) != 1 || got.Skipped[0].Profile != "baseline" || got.Skipped[0].Project != "proj-1" {
+		t.Errorf("skipped = %+v, want the unreadable profile", got.Skipped)
+	}
+	if got.Note != "" {
+		t.Errorf("note = %q, want none while profiles were skipped", got.Note)
+	}
+}
+
+func TestListFailingEntities_NotEvaluated(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{{Name: "baseline"}}}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.listFailingEntities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"rule_type": "typo", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listFailingEntities() returned Go error: %v", err)
+	}
+	if text := getResultText(t, result); !strings.Contains(text, "no profile currently evaluates rule type typo") {
+		t.Errorf("result = %s, want a note that the rule type is not evaluated", text)
+	}
+
+	result, err = tools.listFailingEntities(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("listFailingEntities() returned Go error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(getResultText(t, result), "rule_type must be provided") {
+		t.Errorf("result = %s, want a missing rule_type error", getResultText(t, result))
+	}
+}
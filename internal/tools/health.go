@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// Health statuses reported by the health endpoints.
+const (
+	healthOK          = "ok"
+	healthUnavailable = "unavailable"
+	healthUnknown     = "unknown"
+)
+
+// healthReport is the body of the health endpoints. It is built from the
+// outcome of calls tools already made, so probes never call Minder.
+type healthReport struct {
+	// Status is ok when any server can serve tools, unavailable when every
+	// server with a known status is failing, and unknown before the first
+	// Minder call.
+	Status  string         `json:"status"`
+	Mode    string         `json:"mode,omitempty"`
+	Servers []serverHealth `json:"servers,omitempty"`
+}
+
+// serverHealth is the reachability of one configured Minder server.
+type serverHealth struct {
+	Name               string              `json:"name"`
+	Address            string              `json:"address"`
+	Status             string              `json:"status"`
+	LastSuccessfulCall time.Time           `json:"last_successful_call,omitzero"`
+	LastFailedCall     time.Time           `json:"last_failed_call,omitzero"`
+	LastCallError      string              `json:"last_call_error,omitempty"`
+	TokenRefresh       *tokenRefreshHealth `json:"token_refresh,omitempty"`
+	Realm              *realmHealth        `json:"realm,omitempty"`
+}
+
+// tokenRefreshHealth is the outcome of the latest token refreshes for a server.
+type tokenRefreshHealth struct {
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	LastFailure time.Time `json:"last_failure,omitzero"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// realmHealth is the identity provider realm of a server.
+type realmHealth struct {
+	URL          string    `json:"url"`
+	DiscoveredAt time.Time `json:"discovered_at"`
+	Reachable    *bool     `json:"reachable,omitempty"`
+}
+
+// health reports whether the configured Minder servers can serve tools.
+func (t *Tools) health() healthReport {
+	if t.cfg.Offline() {
+		return healthReport{Status: healthOK, Mode: t.cfg.MCP.Mode}
+	}
+	report := healthReport{Status: healthUnknown}
+	for _, name := range t.cfg.Minder.ServerNames() {
+		srv, _ := t.cfg.Minder.Server(name)
+		sh := serverHealth{Name: name, Address: fmt.Sprintf("%s:%d", srv.Host, srv.Port), Status: healthUnknown}
+		if t.calls != nil {
+			if cs, ok := t.calls.Status(sh.Address); ok {
+				sh.LastSuccessfulCall, sh.LastFailedCall = cs.LastSuccess, cs.LastFailure
+				sh.Status = healthOK
+				if cs.Failing() {
+					sh.LastCallError = cs.LastError
+					sh.Status = healthUnavailable
+				}
+			}
+		}
+		if t.tokenRefresher != nil {
+			serverCfg := minder.ServerConfig{Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins}
+			if rs, ok := t.tokenRefresher.RefreshStatus(serverCfg); ok {
+				sh.TokenRefresh = &tokenRefreshHealth{LastSuccess: rs.LastSuccess, LastFailure: rs.LastFailure}
+				if rs.Failing() {
+					sh.TokenRefresh.LastError = rs.LastError
+				}
+			}
+			if realm, ok := t.tokenRefresher.RealmStatus(serverCfg); ok {
+				sh.Realm = &realmHealth{URL: realm.URL, DiscoveredAt: realm.DiscoveredAt, Reachable: realm.Reachable}
+				if realm.Reachable != nil && !*realm.Reachable {
+					// Offline tokens cannot be exchanged, so tools fail even if Minder answers
+					sh.Status = healthUnavailable
+				}
+			}
+		}
+		report.Servers = append(report.Servers, sh)
+		switch {
+		case sh.Status == healthOK:
+			report.Status = healthOK
+		case sh.Status == healthUnavailable && report.Status == healthUnknown:
+			report.Status = healthUnavailable
+		}
+	}
+	return report
+}
+
+// HealthHandler returns a liveness handler. It always answers 200 OK while the
+// process serves HTTP; the JSON body tells whether Minder can be reached, so
+// restarting the server is not the response to a Minder outage.
+func (t *Tools) HealthHandler() http.Handler {
+	return t.healthHandler(false)
+}
+
+// ReadinessHandler returns a readiness handler, which answers 503 Service
+// Unavailable while every Minder server with a known status is failing.
+func (t *Tools) ReadinessHandler() http.Handler {
+	return t.healthHandler(true)
+}
+
+// healthHandler serves the health report, failing when readiness is set and
+// no server is available.
+func (t *Tools) healthHandler(readiness bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report := t.health()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if readiness && report.Status == healthUnavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/minder"
+)
+
+// recordCalls runs one RPC per error through tracker's interceptor against address.
+func recordCalls(t *testing.T, tracker *minder.CallTracker, address string, errs ...error) {
+	t.Helper()
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	for _, callErr := range errs {
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return callErr
+		}
+		_ = tracker.Interceptor()(context.Background(), "/minder.v1.HealthService/CheckHealth", nil, nil, conn, invoker)
+	}
+}
+
+func TestHealthHandlers(t *testing.T) {
+	t.Parallel()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	tests := []struct {
+		name       string
+		mode       string
+		calls      map[string][]error
+		wantStatus string
+		wantReady  int
+	}{
+		{name: "no calls yet", wantStatus: healthUnknown, wantReady: http.StatusOK},
+		{
+			name:       "default server answering",
+			calls:      map[string][]error{"minder.example.com:443": {nil}},
+			wantStatus: healthOK,
+			wantReady:  http.StatusOK,
+		},
+		{
+			name:       "default server down",
+			calls:      map[string][]error{"minder.example.com:443": {nil, unavailable}},
+			wantStatus: healthUnavailable,
+			wantReady:  http.StatusServiceUnavailable,
+		},
+		{
+			name: "one of two servers down",
+			calls: map[string][]error{
+				"minder.example.com:443":  {unavailable},
+				"staging.example.com:443": {nil},
+			},
+			wantStatus: healthOK,
+			wantReady:  http.StatusOK,
+		},
+		{name: "demo mode", mode: config.ModeDemo, wantStatus: healthOK, wantReady: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{}
+			cfg.MCP.Mode = tt.mode
+			cfg.Minder.Host, cfg.Minder.Port = "minder.example.com", 443
+			cfg.Minder.Servers = []config.NamedServer{{Name: "staging", Host: "staging.example.com", Port: 443}}
+			tools := NewWithClientFactory(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+			tools.calls = minder.NewCallTracker()
+			for address, errs := range tt.calls {
+				recordCalls(t, tools.calls, address, errs...)
+			}
+
+			for path, handler := range map[string]http.Handler{
+				"/healthz": tools.HealthHandler(),
+				"/readyz":  tools.ReadinessHandler(),
+			} {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+				wantCode := http.StatusOK
+				if path == "/readyz" {
+					wantCode = tt.wantReady
+				}
+				if rec.Code != wantCode {
+					t.Errorf("%s status code = %d, want %d", path, rec.Code, wantCode)
+				}
+				var report healthReport
+				if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+					t.Fatalf("%s returned invalid JSON: %v", path, err)
+				}
+				if report.Status != tt.wantStatus {
+					t.Errorf("%s status = %q, want %q", path, report.Status, tt.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestHealth_ReportsLastCallDetail(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	cfg.Minder.Host, cfg.Minder.Port = "minder.example.com", 443
+	tools := NewWithClientFactory(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+	tools.calls = minder.NewCallTracker()
+	recordCalls(t, tools.calls, "minder.example.com:443", nil, status.Error(codes.DeadlineExceeded, "too slow"))
+
+	report := tools.health()
+	if len(report.Servers) != 1 {
+		t.Fatalf("health() reported %d servers, want 1", len(report.Servers))
+	}
+	srv := report.Servers[0]
+	if srv.Name != config.DefaultServerName || srv.Address != "minder.example.com:443" {
+		t.Errorf("server = %s at %s, want %s at minder.example.com:443", srv.Name, srv.Address, config.DefaultServerName)
+	}
+	if srv.LastSuccessfulCall.IsZero() || srv.LastFailedCall.IsZero() {
+		t.Errorf("call times not reported: %+v", srv)
+	}
+	if srv.LastCallError == "" {
+		t.Error("last call error not reported for a failing server")
+	}
+}
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/chart"
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+// SetHistory makes the compliance summaries recorded in store queryable through
+// the minder_get_compliance_history tool. Call it before Register.
+func (t *Tools) SetHistory(store *history.Store) {
+	t.history = store
+}
+
+// getComplianceHistory returns the compliance summaries recorded locally,
+// either individually or aggregated per day or week.
+func (t *Tools) getComplianceHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from, err := parseTimeParam(req, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseTimeParam(req, "to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !to.IsZero() && to.Before(from) {
+		return mcp.NewToolResultError("to must not be before from"), nil
+	}
+	period, err := parsePeriodParam(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summaries, err := t.history.List(from, to)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "failed to read compliance history", "error", err)
+		return mcp.NewToolResultError("failed to read compliance history: " + err.Error()), nil
+	}
+
+	if period == "" {
+		return marshalResult(ctx, map[string]any{
+			"summaries": summaries,
+		})
+	}
+	return marshalResult(ctx, map[string]any{
+		"period":  period,
+		"buckets": history.Aggregate(summaries, period),
+	})
+}
+
+// defaultCompareDays is how far back compareComplianceHistory looks when
+// neither from nor days_ago is given, suiting weekly reviews.
+const defaultCompareDays = 7
+
+// compareComplianceHistory reports what changed between the summaries recorded
+// at two points in time, defaulting to now versus a week ago.
+func (t *Tools) compareComplianceHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from, err := parseTimeParam(req, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseTimeParam(req, "to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	daysAgo := req.GetInt("days_ago", 0)
+	if daysAgo != 0 && !from.IsZero() {
+		return mcp.NewToolResultError("cannot specify both from and days_ago"), nil
+	}
+	if daysAgo < 0 {
+		return mcp.NewToolResultError("days_ago must not be negative"), nil
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		if daysAgo == 0 {
+			daysAgo = defaultCompareDays
+		}
+		from = to.AddDate(0, 0, -daysAgo)
+	}
+	if !from.Before(to) {
+		return mcp.NewToolResultError("from must be before to"), nil
+	}
+
+	fromSummary, err := t.history.Latest(from)
+	if err != nil {
+		return t.historyLookupError(ctx, from, err), nil
+	}
+	toSummary, err := t.history.Latest(to)
+	if err != nil {
+		return t.historyLookupError(ctx, to, err), nil
+	}
+
+	return marshalResult(ctx, history.Compare(fromSummary, toSummary))
+}
+
+// historyLookupError converts a failed summary lookup at the given time to a tool error.
+func (t *Tools) historyLookupError(ctx context.Context, at time.Time, err error) *mcp.CallToolResult {
+	if errors.Is(err, history.ErrNoSummary) {
+		return mcp.NewToolResultError(fmt.Sprintf("no compliance summary recorded at or before %s",
+			at.UTC().Format(time.RFC3339)))
+	}
+	t.logger.ErrorContext(ctx, "failed to read compliance history", "error", err)
+	return mcp.NewToolResultError("failed to read compliance history: " + err.Error())
+}
+
+// parseTimeParam parses an optional RFC3339 time parameter, returning the zero time when omitted.
+func parseTimeParam(req mcp.CallToolRequest, name string) (time.Time, error) {
+	value := req.GetString(name, "")
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 time (e.g., 2024-01-15T09:00:00Z), got %q", name, value)
+	}
+	return ts, nil
+}
+
+// parsePeriodParam parses the optional period parameter, returning "" when
+// omitted. Any other value than a day or week is rejected rather than
+// aggregated into a nonsensical bucket.
+func parsePeriodParam(req mcp.CallToolRequest) (history.Period, error) {
+	switch period := history.Period(req.GetString("period", "")); period {
+	case "", history.PeriodDay, history.PeriodWeek:
+		return period, nil
+	default:
+		return "", fmt.Errorf("period must be %q or %q, got %q", history.PeriodDay, history.PeriodWeek, period)
+	}
+}
+
+// Chart kinds and image formats accepted by renderComplianceChart.
+const (
+	chartScore          = "score"
+	chartFailuresByRule = "failures_by_rule"
+	chartFormatSVG      = "svg"
+	chartFormatPNG      = "png"
+	// maxChartBars bounds the rules drawn in a failures_by_rule chart.
+	maxChartBars = 20
+)
+
+// renderComplianceChart draws the recorded compliance history as an image:
+// either the score over time or the repositories failing each rule in the
+// latest summary. The data is also described in text for clients that cannot
+// display images.
+func (t *Tools) renderComplianceChart(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from, err := parseTimeParam(req, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseTimeParam(req, "to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !to.IsZero() && to.Before(from) {
+		return mcp.NewToolResultError("to must not be before from"), nil
+	}
+	format := req.GetString("format", chartFormatSVG)
+	if format != chartFormatSVG && format != chartFormatPNG {
+		return mcp.NewToolResultError(fmt.Sprintf("format must be %q or %q, got %q",
+			chartFormatSVG, chartFormatPNG, format)), nil
+	}
+	period, err := parsePeriodParam(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summaries, err := t.history.List(from, to)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "failed to read compliance history", "error", err)
+		return mcp.NewToolResultError("failed to read compliance history: " + err.Error()), nil
+	}
+	if len(summaries) == 0 {
+		return mcp.NewToolResultError("no compliance summaries recorded in the requested range"), nil
+	}
+
+	var (
+		description string
+		svg         func() []byte
+		pngImage    func() ([]byte, error)
+	)
+	switch kind := req.GetString("chart", chartScore); kind {
+	case chartScore:
+		line := scoreChart(summaries, period)
+		description, svg, pngImage = describeScoreChart(line), line.SVG, line.PNG
+	case chartFailuresByRule:
+		bars := failuresByRuleChart(summaries[len(summaries)-1])
+		description, svg, pngImage = describeFailuresChart(bars), bars.SVG, bars.PNG
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("chart must be %q or %q, got %q",
+			chartScore, chartFailuresByRule, kind)), nil
+	}
+
+	data, mimeType := svg(), "image/svg+xml"
+	if format == chartFormatPNG {
+		if data, err = pngImage(); err != nil {
+			t.logger.ErrorContext(ctx, "failed to render chart", "error", err)
+			return mcp.NewToolResultError("failed to render chart: " + err.Error()), nil
+		}
+		mimeType = "image/png"
+	}
+	return mcp.NewToolResultImage(description, base64.StdEncoding.EncodeToString(data), mimeType), nil
+}
+
+// scoreChart plots the compliance score of each summary, or the average score
+// per period when one is given.
+func scoreChart(summaries []history.Summary, period history.Period) chart.Line {
+	line := chart.Line{Title: "Compliance score"}
+	if period == "" {
+		for _, s := range summaries {
+			line.Points = append(line.Points, chart.Point{Time: s.Time, Value: s.Score})
+		}
+		return line
+	}
+	line.Title = fmt.Sprintf("Average compliance score per %s", period)
+	for _, b := range history.Aggregate(summaries, period) {
+		line.Points = append(line.Points, chart.Point{Time: b.Start, Value: b.AvgScore})
+	}
+	return line
+}
+
+// failuresByRuleChart counts the entities failing each profile rule in a
+// summary, most failures first.
+func failuresByRuleChart(summary history.Summary) chart.Bars {
+	counts := make(map[string]int)
+	for _, rules := range summary.FailingByEntity {
+		for _, rule := range rules {
+			counts[rule]++
+		}
+	}
+	bars := chart.Bars{Title: "Failing repositories per rule at " + summary.Time.UTC().Format(time.RFC3339)}
+	for rule, n := range counts {
+		bars.Bars = append(bars.Bars, chart.Bar{Label: rule, Value: float64(n)})
+	}
+	slices.SortFunc(bars.Bars, func(a, b chart.Bar) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Label, b.Label)
+	})
+	if len(bars.Bars) > maxChartBars {
+		bars.Bars = bars.Bars[:maxChartBars]
+	}
+	return bars
+}
+
+// describeScoreChart summarizes a score chart in text.
+func describeScoreChart(line chart.Line) string {
+	first, last := line.Points[0], line.Points[len(line.Points)-1]
+	low, high := first.Value, first.Value
+	for _, p := range line.Points {
+		low, high = min(low, p.Value), max(high, p.Value)
+	}
+	return fmt.Sprintf("%s from %s to %s (%d points): latest %.1f%%, lowest %.1f%%, highest %.1f%%.",
+		line.Title, first.Time.UTC().Format(time.RFC3339), last.Time.UTC().Format(time.RFC3339),
+		len(line.Points), last.Value, low, high)
+}
+
+// describeFailuresChart lists the bars of a failures chart in text.
+func describeFailuresChart(bars chart.Bars) string {
+	if len(bars.Bars) == 0 {
+		return bars.Title + ": no failing rules."
+	}
+	parts := make([]string, len(bars.Bars))
+	for i, b := range bars.Bars {
+		parts[i] = fmt.Sprintf("%s (%g)", b.Label, b.Value)
+	}
+	return bars.Title + ": " + strings.Join(parts, ", ") + "."
+}
@@ -0,0 +1,340 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+func newHistoryTools(t *testing.T) *Tools {
+	t.Helper()
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("history.Open() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	// 2026-03-02 is a Monday
+	base := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	for i, score := range []float64{50, 70, 90} {
+		if err := store.Put(history.Summary{Time: base.Add(time.Duration(i) * 24 * time.Hour), Score: score}); err != nil {
+			t.Fatalf("Put() returned error: %v", err)
+		}
+	}
+
+	tools := newTestTools(newMockClient())
+	tools.SetHistory(store)
+	return tools
+}
+
+func TestGetComplianceHistory(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	result, err := tools.getComplianceHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"from": "2026-03-03T00:00:00Z"}},
+	})
+	if err != nil {
+		t.Fatalf("getComplianceHistory() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got struct {
+		Summaries []history.Summary `json:"summaries"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Summaries) != 2 || got.Summaries[0].Score != 70 {
+		t.Errorf("summaries = %+v, want the two summaries from 2026-03-03", got.Summaries)
+	}
+}
+
+func TestGetComplianceHistory_Weekly(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	result, err := tools.getComplianceHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"period": "week"}},
+	})
+	if err != nil {
+		t.Fatalf("getComplianceHistory() returned Go error: %v", err)
+	}
+	var got struct {
+		Buckets []history.Bucket `json:"buckets"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Buckets) != 1 || got.Buckets[0].AvgScore != 70 || got.Buckets[0].Samples != 3 {
+		t.Errorf("buckets = %+v, want one week of 3 samples averaging 70", got.Buckets)
+	}
+}
+
+func TestGetComplianceHistory_InvalidTime(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	result, err := tools.getComplianceHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"to": "last week"}},
+	})
+	if err != nil {
+		t.Fatalf("getComplianceHistory() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	if text := getResultText(t, result); !strings.Contains(text, "RFC3339") {
+		t.Errorf("error %q does not mention RFC3339", text)
+	}
+}
+
+func TestGetComplianceHistory_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	result, err := tools.getComplianceHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"period": "month"}},
+	})
+	if err != nil {
+		t.Fatalf("getComplianceHistory() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	if text := getResultText(t, result); !strings.Contains(text, `period must be "day" or "week"`) {
+		t.Errorf("error %q does not name the accepted periods", text)
+	}
+}
+
+func TestRegister_ComplianceHistoryOnlyWithStore(t *testing.T) {
+	t.Parallel()
+
+	without := server.NewMCPServer("test", "0.0.0")
+	newTestTools(newMockClient()).Register(without)
+	if without.GetTool("minder_get_compliance_history") != nil {
+		t.Error("minder_get_compliance_history should not be registered without a history store")
+	}
+
+	with := server.NewMCPServer("test", "0.0.0")
+	newHistoryTools(t).Register(with)
+	if with.GetTool("minder_get_compliance_history") == nil {
+		t.Error("minder_get_compliance_history should be registered with a history store")
+	}
+}
+
+func TestCompareComplianceHistory(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	result, err := tools.compareComplianceHistory(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"to": "2026-03-04T13:00:00Z", "days_ago": float64(2)}},
+	})
+	if err != nil {
+		t.Fatalf("compareComplianceHistory() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got history.Delta
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.ScoreFrom != 50 || got.ScoreTo != 90 || got.ScoreDelta != 40 {
+		t.Errorf("scores = %v -> %v (delta %v), want 50 -> 90 (delta 40)", got.ScoreFrom, got.ScoreTo, got.ScoreDelta)
+	}
+}
+
+func TestCompareComplianceHistory_Errors(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "before first summary",
+			args:    map[string]any{"to": "2026-03-04T13:00:00Z", "days_ago": float64(30)},
+			wantMsg: "no compliance summary recorded at or before 2026-02-02T13:00:00Z",
+		},
+		{
+			name:    "from and days_ago",
+			args:    map[string]any{"from": "2026-03-02T00:00:00Z", "days_ago": float64(1)},
+			wantMsg: "cannot specify both from and days_ago",
+		},
+		{
+			name:    "from after to",
+			args:    map[string]any{"from": "2026-03-04T00:00:00Z", "to": "2026-03-03T00:00:00Z"},
+			wantMsg: "from must be before to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.compareComplianceHistory(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("compareComplianceHistory() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRenderComplianceChart(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+	err := tools.history.Put(history.Summary{
+		Time:  time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC),
+		Score: 60,
+		FailingByEntity: map[string][]string{
+			"stacklok/minder":     {"baseline/branch_protection", "baseline/dependabot"},
+			"stacklok/minder-mcp": {"baseline/branch_protection"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantMIME string
+		wantText string
+		wantData string
+	}{
+		{
+			name:     "score svg",
+			args:     map[string]any{"to": "2026-03-04T23:00:00Z"},
+			wantMIME: "image/svg+xml",
+			wantText: "latest 90.0%, lowest 50.0%, highest 90.0%",
+			wantData: "<polyline",
+		},
+		{
+			name:     "weekly score png",
+			args:     map[string]any{"period": "week", "format": "png"},
+			wantMIME: "image/png",
+			wantText: "Average compliance score per week",
+			wantData: "\x89PNG",
+		},
+		{
+			name:     "failures by rule",
+			args:     map[string]any{"chart": "failures_by_rule"},
+			wantMIME: "image/svg+xml",
+			wantText: "baseline/branch_protection (2), baseline/dependabot (1)",
+			wantData: "baseline/dependabot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.renderComplianceChart(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("renderComplianceChart() returned Go error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", getResultText(t, result))
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantText) {
+				t.Errorf("description %q does not contain %q", text, tt.wantText)
+			}
+			if len(result.Content) != 2 {
+				t.Fatalf("got %d content items, want text and image", len(result.Content))
+			}
+			img, ok := result.Content[1].(mcp.ImageContent)
+			if !ok {
+				t.Fatalf("second content is %T, want mcp.ImageContent", result.Content[1])
+			}
+			if img.MIMEType != tt.wantMIME {
+				t.Errorf("MIME type = %q, want %q", img.MIMEType, tt.wantMIME)
+			}
+			data, err := base64.StdEncoding.DecodeString(img.Data)
+			if err != nil {
+				t.Fatalf("image data is not base64: %v", err)
+			}
+			if !strings.Contains(string(data), tt.wantData) {
+				t.Errorf("image does not contain %q", tt.wantData)
+			}
+		})
+	}
+}
+
+func TestRenderComplianceChart_Errors(t *testing.T) {
+	t.Parallel()
+
+	tools := newHistoryTools(t)
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "empty range",
+			args:    map[string]any{"from": "2027-01-01T00:00:00Z"},
+			wantMsg: "no compliance summaries recorded in the requested range",
+		},
+		{
+			name:    "unknown chart",
+			args:    map[string]any{"chart": "pie"},
+			wantMsg: `chart must be "score" or "failures_by_rule"`,
+		},
+		{
+			name:    "unknown format",
+			args:    map[string]any{"format": "gif"},
+			wantMsg: `format must be "svg" or "png"`,
+		},
+		{
+			name:    "unknown period",
+			args:    map[string]any{"period": "month"},
+			wantMsg: `period must be "day" or "week", got "month"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.renderComplianceChart(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("renderComplianceChart() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
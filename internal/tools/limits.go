@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxPageSize is the largest page size Minder accepts for list requests.
+const maxPageSize = 100
+
+// pageSize returns the page size to request from Minder: the client's value,
+// the configured default or, failing both, the configured result limit, capped
+// at maxPageSize and the result limit. Zero lets Minder choose, which only
+// happens when no result limit is configured.
+func (t *Tools) pageSize(requested int) int {
+	limit := t.cfg.MCP.MaxResults
+	size := requested
+	if size <= 0 {
+		size = t.cfg.MCP.DefaultPageSize
+	}
+	if size <= 0 {
+		size = limit
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	if limit > 0 && size > limit {
+		size = limit
+	}
+	return size
+}
+
+// capResults truncates items to limit and returns the original length.
+// A limit of zero or less leaves items unchanged.
+func capResults[T any](items []T, limit int) ([]T, int) {
+	total := len(items)
+	if limit > 0 && total > limit {
+		return items[:limit], total
+	}
+	return items, total
+}
+
+// marshalCapped marshals a list result and, when shown < total, appends a note
+// telling the client the result was cut to the server's maximum.
+func (t *Tools) marshalCapped(ctx context.Context, v any, shown, total int) (*mcp.CallToolResult, error) {
+	result, err := marshalResult(ctx, v)
+	if err != nil || result.IsError || shown >= total {
+		return result, err
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+		"Showing %d of %d results: this server returns at most %d items per call. "+
+			"Narrow the query with filters such as project_id to see the rest.",
+		shown, total, t.cfg.MCP.MaxResults)))
+	return result, nil
+}
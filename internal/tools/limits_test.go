@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+)
+
+func newLimitedTools(mockClient *mockMinderClient, defaultPageSize, maxResults int) *Tools {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{DefaultPageSize: defaultPageSize, MaxResults: maxResults}}
+	return NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return mockClient, nil
+	})
+}
+
+func TestPageSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		defaultPageSize int
+		maxResults      int
+		requested       int
+		want            int
+	}{
+		{name: "no config, no request", want: 0},
+		{name: "request passes through", requested: 25, want: 25},
+		{name: "default applies when omitted", defaultPageSize: 20, want: 20},
+		{name: "request overrides default", defaultPageSize: 20, requested: 50, want: 50},
+		{name: "capped at Minder maximum", requested: 500, want: maxPageSize},
+		{name: "capped at max results", maxResults: 10, requested: 50, want: 10},
+		{name: "default capped at max results", defaultPageSize: 50, maxResults: 10, want: 10},
+		{name: "nothing requested, max results set", maxResults: 10, want: 10},
+		{name: "nothing requested, max results above Minder maximum", maxResults: 500, want: maxPageSize},
+		{name: "default below max results", defaultPageSize: 5, maxResults: 10, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tools := newLimitedTools(newMockClient(), tt.defaultPageSize, tt.maxResults)
+			if got := tools.pageSize(tt.requested); got != tt.want {
+				t.Errorf("pageSize(%d) = %d, want %d", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapResults(t *testing.T) {
+	t.Parallel()
+
+	items := []int{1, 2, 3, 4, 5}
+
+	got, total := capResults(items, 3)
+	if len(got) != 3 || total != 5 {
+		t.Errorf("capResults(items, 3) = %v, %d; want 3 items of 5", got, total)
+	}
+
+	got, total = capResults(items, 0)
+	if len(got) != 5 || total != 5 {
+		t.Errorf("capResults(items, 0) = %v, %d; want all 5 items", got, total)
+	}
+}
+
+func TestListProfiles_MaxResults(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{
+			{Name: "profile-1"},
+			{Name: "profile-2"},
+			{Name: "profile-3"},
+		},
+	}
+	tools := newLimitedTools(mockClient, 0, 2)
+
+	result, err := tools.listProfiles(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listProfiles() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", getResultText(t, result))
+	}
+
+	text := getResultText(t, result)
+	if strings.Contains(text, "profile-3") {
+		t.Errorf("response should be capped at 2 profiles: %s", text)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("expected a truncation note, got %d content items", len(result.Content))
+	}
+	note, ok := mcp.AsTextContent(result.Content[1])
+	if !ok || !strings.Contains(note.Text, "Showing 2 of 3 results") {
+		t.Errorf("unexpected truncation note: %+v", result.Content[1])
+	}
+}
+
+func TestListRepositories_DefaultPageSize(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{}
+	tools := newLimitedTools(mockClient, 30, 0)
+
+	result, err := tools.listRepositories(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listRepositories() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", getResultText(t, result))
+	}
+	if got := mockClient.repositories.listReq.GetLimit(); got != 30 {
+		t.Errorf("requested limit = %d, want 30", got)
+	}
+}
+
+func TestListRepositories_MaxResultsSingleProject(t *testing.T) {
+	t.Parallel()
+
+	// Minder returns more than asked for; the server still caps the result
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{
+		Results: []*minderv1.Repository{{Name: "repo-1"}, {Name: "repo-2"}, {Name: "repo-3"}},
+	}
+	tools := newLimitedTools(mockClient, 0, 2)
+
+	result, err := tools.listRepositories(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listRepositories() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", getResultText(t, result))
+	}
+	if got := mockClient.repositories.listReq.GetLimit(); got != 2 {
+		t.Errorf("requested limit = %d, want MCP_MAX_RESULTS when no limit is given", got)
+	}
+	if text := getResultText(t, result); strings.Contains(text, "repo-3") {
+		t.Errorf("response should be capped at 2 repositories: %s", text)
+	}
+	if len(result.Content) != 2 {
+		t.Errorf("expected a truncation note, got %d content items", len(result.Content))
+	}
+}
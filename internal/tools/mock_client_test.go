@@ -2,9 +2,12 @@ package tools
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 // mockMinderClient implements MinderClient for testing.
@@ -15,9 +18,12 @@ type mockMinderClient struct {
 	ruleTypes    *mockRuleTypeService
 	dataSources  *mockDataSourceService
 	providers    *mockProvidersService
+	oauth        *mockOAuthService
 	projects     *mockProjectsService
 	artifacts    *mockArtifactService
 	evalResults  *mockEvalResultsService
+	// conn serves Conn; tests of minder_raw_call point it at a fake Minder.
+	conn grpc.ClientConnInterface
 }
 
 func newMockClient() *mockMinderClient {
@@ -28,6 +34,7 @@ func newMockClient() *mockMinderClient {
 		ruleTypes:    &mockRuleTypeService{},
 		dataSources:  &mockDataSourceService{},
 		providers:    &mockProvidersService{},
+		oauth:        &mockOAuthService{},
 		projects:     &mockProjectsService{},
 		artifacts:    &mockArtifactService{},
 		evalResults:  &mockEvalResultsService{},
@@ -41,9 +48,11 @@ func (m *mockMinderClient) Repositories() minderv1.RepositoryServiceClient { ret
 func (m *mockMinderClient) RuleTypes() minderv1.RuleTypeServiceClient      { return m.ruleTypes }
 func (m *mockMinderClient) DataSources() minderv1.DataSourceServiceClient  { return m.dataSources }
 func (m *mockMinderClient) Providers() minderv1.ProvidersServiceClient     { return m.providers }
+func (m *mockMinderClient) OAuth() minderv1.OAuthServiceClient             { return m.oauth }
 func (m *mockMinderClient) Projects() minderv1.ProjectsServiceClient       { return m.projects }
 func (m *mockMinderClient) Artifacts() minderv1.ArtifactServiceClient      { return m.artifacts }
 func (m *mockMinderClient) EvalResults() minderv1.EvalResultsServiceClient { return m.evalResults }
+func (m *mockMinderClient) Conn() grpc.ClientConnInterface                 { return m.conn }
 
 // Mock service implementations
 
@@ -51,9 +60,14 @@ type mockHealthService struct {
 	minderv1.HealthServiceClient
 	checkResp *minderv1.CheckHealthResponse
 	checkErr  error
+	hang      bool // wait for the call's context to end, like a wedged server
 }
 
-func (m *mockHealthService) CheckHealth(_ context.Context, _ *minderv1.CheckHealthRequest, _ ...grpc.CallOption) (*minderv1.CheckHealthResponse, error) {
+func (m *mockHealthService) CheckHealth(ctx context.Context, _ *minderv1.CheckHealthRequest, _ ...grpc.CallOption) (*minderv1.CheckHealthResponse, error) {
+	if m.hang {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
 	// By default return healthy status if no response/error is set
 	if m.checkResp == nil && m.checkErr == nil {
 		return &minderv1.CheckHealthResponse{Status: "OK"}, nil
@@ -63,21 +77,30 @@ func (m *mockHealthService) CheckHealth(_ context.Context, _ *minderv1.CheckHeal
 
 type mockProfileService struct {
 	minderv1.ProfileServiceClient
+	mu                  sync.Mutex // guards captured requests of concurrent calls
 	listResp            *minderv1.ListProfilesResponse
 	listErr             error
+	listReq             *minderv1.ListProfilesRequest // captured request
 	getByIDResp         *minderv1.GetProfileByIdResponse
 	getByIDErr          error
 	getByNameResp       *minderv1.GetProfileByNameResponse
 	getByNameErr        error
 	getStatusByNameResp *minderv1.GetProfileStatusByNameResponse
-	getStatusByNameErr  error
+	// getStatusByNameResps overrides getStatusByNameResp for the profiles it names
+	getStatusByNameResps map[string]*minderv1.GetProfileStatusByNameResponse
+	getStatusByNameErr   error
+	// getStatusByNameErrs overrides getStatusByNameErr for the profiles it names
+	getStatusByNameErrs map[string]error
 	getStatusByNameReq  *minderv1.GetProfileStatusByNameRequest // captured request
 	getStatusByIDResp   *minderv1.GetProfileStatusByIdResponse
 	getStatusByIDErr    error
 	getStatusByIDReq    *minderv1.GetProfileStatusByIdRequest // captured request
 }
 
-func (m *mockProfileService) ListProfiles(_ context.Context, _ *minderv1.ListProfilesRequest, _ ...grpc.CallOption) (*minderv1.ListProfilesResponse, error) {
+func (m *mockProfileService) ListProfiles(_ context.Context, req *minderv1.ListProfilesRequest, _ ...grpc.CallOption) (*minderv1.ListProfilesResponse, error) {
+	m.mu.Lock()
+	m.listReq = req
+	m.mu.Unlock()
 	return m.listResp, m.listErr
 }
 
@@ -90,7 +113,15 @@ func (m *mockProfileService) GetProfileByName(_ context.Context, _ *minderv1.Get
 }
 
 func (m *mockProfileService) GetProfileStatusByName(_ context.Context, req *minderv1.GetProfileStatusByNameRequest, _ ...grpc.CallOption) (*minderv1.GetProfileStatusByNameResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.getStatusByNameReq = req
+	if err, ok := m.getStatusByNameErrs[req.GetName()]; ok {
+		return nil, err
+	}
+	if resp, ok := m.getStatusByNameResps[req.GetName()]; ok {
+		return resp, m.getStatusByNameErr
+	}
 	return m.getStatusByNameResp, m.getStatusByNameErr
 }
 
@@ -102,14 +133,21 @@ func (m *mockProfileService) GetProfileStatusById(_ context.Context, req *minder
 type mockRepositoryService struct {
 	minderv1.RepositoryServiceClient
 	listResp      *minderv1.ListRepositoriesResponse
+	listReq       *minderv1.ListRepositoriesRequest // captured request
 	listErr       error
 	getByIDResp   *minderv1.GetRepositoryByIdResponse
 	getByIDErr    error
 	getByNameResp *minderv1.GetRepositoryByNameResponse
 	getByNameErr  error
+	deleteReq     *minderv1.DeleteRepositoryByIdRequest // captured request
+	deleteErr     error
+	registerReq   *minderv1.RegisterRepositoryRequest // captured request
+	registerResp  *minderv1.RegisterRepositoryResponse
+	registerErr   error
 }
 
-func (m *mockRepositoryService) ListRepositories(_ context.Context, _ *minderv1.ListRepositoriesRequest, _ ...grpc.CallOption) (*minderv1.ListRepositoriesResponse, error) {
+func (m *mockRepositoryService) ListRepositories(_ context.Context, req *minderv1.ListRepositoriesRequest, _ ...grpc.CallOption) (*minderv1.ListRepositoriesResponse, error) {
+	m.listReq = req
 	return m.listResp, m.listErr
 }
 
@@ -121,6 +159,19 @@ func (m *mockRepositoryService) GetRepositoryByName(_ context.Context, _ *minder
 	return m.getByNameResp, m.getByNameErr
 }
 
+func (m *mockRepositoryService) DeleteRepositoryById(_ context.Context, req *minderv1.DeleteRepositoryByIdRequest, _ ...grpc.CallOption) (*minderv1.DeleteRepositoryByIdResponse, error) {
+	m.deleteReq = req
+	if m.deleteErr != nil {
+		return nil, m.deleteErr
+	}
+	return &minderv1.DeleteRepositoryByIdResponse{RepositoryId: req.RepositoryId}, nil
+}
+
+func (m *mockRepositoryService) RegisterRepository(_ context.Context, req *minderv1.RegisterRepositoryRequest, _ ...grpc.CallOption) (*minderv1.RegisterRepositoryResponse, error) {
+	m.registerReq = req
+	return m.registerResp, m.registerErr
+}
+
 type mockRuleTypeService struct {
 	minderv1.RuleTypeServiceClient
 	listResp      *minderv1.ListRuleTypesResponse
@@ -181,12 +232,40 @@ func (m *mockProvidersService) GetProvider(_ context.Context, _ *minderv1.GetPro
 	return m.getResp, m.getErr
 }
 
+type mockOAuthService struct {
+	minderv1.OAuthServiceClient
+	// verifyResps answers successive VerifyProviderCredential calls, repeating the last
+	verifyResps []*minderv1.VerifyProviderCredentialResponse
+	verifyErr   error
+	verifyCalls atomic.Int32
+}
+
+func (m *mockOAuthService) VerifyProviderCredential(_ context.Context, _ *minderv1.VerifyProviderCredentialRequest, _ ...grpc.CallOption) (*minderv1.VerifyProviderCredentialResponse, error) {
+	n := int(m.verifyCalls.Add(1))
+	if m.verifyErr != nil || len(m.verifyResps) == 0 {
+		return &minderv1.VerifyProviderCredentialResponse{}, m.verifyErr
+	}
+	return m.verifyResps[min(n, len(m.verifyResps))-1], nil
+}
+
 type mockProjectsService struct {
 	minderv1.ProjectsServiceClient
 	listResp      *minderv1.ListProjectsResponse
 	listErr       error
 	listChildResp *minderv1.ListChildProjectsResponse
 	listChildErr  error
+	// childResps, when set, answers ListChildProjects per parent project ID.
+	childResps   map[string]*minderv1.ListChildProjectsResponse
+	reconcileErr error
+	reconcileReq *minderv1.CreateEntityReconciliationTaskRequest // captured request
+}
+
+func (m *mockProjectsService) CreateEntityReconciliationTask(_ context.Context, req *minderv1.CreateEntityReconciliationTaskRequest, _ ...grpc.CallOption) (*minderv1.CreateEntityReconciliationTaskResponse, error) {
+	m.reconcileReq = req
+	if m.reconcileErr != nil {
+		return nil, m.reconcileErr
+	}
+	return &minderv1.CreateEntityReconciliationTaskResponse{}, nil
 }
 
 func (m *mockProjectsService) ListProjects(_ context.Context, _ *minderv1.ListProjectsRequest, _ ...grpc.CallOption) (*minderv1.ListProjectsResponse, error) {
@@ -201,7 +280,10 @@ func (m *mockProjectsService) ListProjects(_ context.Context, _ *minderv1.ListPr
 	return m.listResp, m.listErr
 }
 
-func (m *mockProjectsService) ListChildProjects(_ context.Context, _ *minderv1.ListChildProjectsRequest, _ ...grpc.CallOption) (*minderv1.ListChildProjectsResponse, error) {
+func (m *mockProjectsService) ListChildProjects(_ context.Context, req *minderv1.ListChildProjectsRequest, _ ...grpc.CallOption) (*minderv1.ListChildProjectsResponse, error) {
+	if m.childResps != nil {
+		return m.childResps[req.GetContext().GetProjectId()], nil
+	}
 	return m.listChildResp, m.listChildErr
 }
 
@@ -230,9 +312,30 @@ func (m *mockArtifactService) GetArtifactByName(_ context.Context, _ *minderv1.G
 type mockEvalResultsService struct {
 	minderv1.EvalResultsServiceClient
 	listResp *minderv1.ListEvaluationHistoryResponse
+	listReq  *minderv1.ListEvaluationHistoryRequest // captured request
 	listErr  error
+	// listFunc, when set, serves ListEvaluationHistory in place of listResp.
+	listFunc        func(*minderv1.ListEvaluationHistoryRequest) (*minderv1.ListEvaluationHistoryResponse, error)
+	listResultsResp *minderv1.ListEvaluationResultsResponse
+	listResultsErr  error
+	listResultsReq  *minderv1.ListEvaluationResultsRequest // captured request
+	getResp         *minderv1.GetEvaluationHistoryResponse
+	getErr          error
+}
+
+func (m *mockEvalResultsService) ListEvaluationHistory(_ context.Context, req *minderv1.ListEvaluationHistoryRequest, _ ...grpc.CallOption) (*minderv1.ListEvaluationHistoryResponse, error) {
+	m.listReq = req
+	if m.listFunc != nil {
+		return m.listFunc(req)
+	}
+	return m.listResp, m.listErr
 }
 
-func (m *mockEvalResultsService) ListEvaluationHistory(_ context.Context, _ *minderv1.ListEvaluationHistoryRequest, _ ...grpc.CallOption) (*minderv1.ListEvaluationHistoryResponse, error) {
-	return m.listResp, m.listErr
+func (m *mockEvalResultsService) ListEvaluationResults(_ context.Context, req *minderv1.ListEvaluationResultsRequest, _ ...grpc.CallOption) (*minderv1.ListEvaluationResultsResponse, error) {
+	m.listResultsReq = req
+	return m.listResultsResp, m.listResultsErr
+}
+
+func (m *mockEvalResultsService) GetEvaluationHistory(_ context.Context, _ *minderv1.GetEvaluationHistoryRequest, _ ...grpc.CallOption) (*minderv1.GetEvaluationHistoryResponse, error) {
+	return m.getResp, m.getErr
 }
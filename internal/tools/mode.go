@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/demo"
+	"github.com/stacklok/minder-mcp/internal/recording"
+)
+
+// NewForMode creates the tools for the configured mode: backed by an in-process
+// demo server, by recorded responses, or by Minder itself, optionally recording
+// its responses. The returned function releases their resources.
+func NewForMode(cfg *config.Config, logger *slog.Logger) (*Tools, func(), error) {
+	switch cfg.MCP.Mode {
+	case config.ModeDemo:
+		srv := demo.Start(time.Now())
+		t := NewWithClientFactory(cfg, logger, func(ctx context.Context) (MinderClient, error) {
+			return demo.NewClient(ctx, srv)
+		})
+		logger.Warn("demo mode: serving canned data, no Minder server is contacted", "project_id", demo.ProjectID)
+		return t, func() {
+			t.Close()
+			srv.Close()
+		}, nil
+
+	case config.ModeReplay:
+		replayer, err := recording.NewReplayer(cfg.MCP.RecordingDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		t := NewWithClientFactory(cfg, logger, func(ctx context.Context) (MinderClient, error) {
+			return replayer.NewClient(ctx)
+		})
+		logger.Warn("replay mode: serving recorded responses, no Minder server is contacted",
+			"recording_dir", cfg.MCP.RecordingDir)
+		return t, t.Close, nil
+
+	case config.ModeRecord:
+		recorder, err := recording.NewRecorder(cfg.MCP.RecordingDir, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		t := New(cfg, logger)
+		t.AddClientInterceptor(recorder.Interceptor())
+		logger.Warn("record mode: saving Minder responses to disk; recordings may contain sensitive data",
+			"recording_dir", cfg.MCP.RecordingDir)
+		return t, t.Close, nil
+
+	default:
+		t := New(cfg, logger)
+		return t, t.Close, nil
+	}
+}
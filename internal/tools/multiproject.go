@@ -1,18 +1,61 @@
 package tools
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/callmeta"
 )
 
-// listAllProjects returns all accessible projects for the current user.
+// listAllProjects returns all accessible projects for the current user,
+// ordered by ID so results gathered from them come back in a stable order.
 func listAllProjects(ctx context.Context, client MinderClient) ([]*minderv1.Project, error) {
 	resp, err := client.Projects().ListProjects(ctx, &minderv1.ListProjectsRequest{})
 	if err != nil {
 		return nil, err
 	}
-	return resp.Projects, nil
+	projects := slices.Clone(resp.Projects)
+	slices.SortStableFunc(projects, func(a, b *minderv1.Project) int {
+		return cmp.Compare(a.GetProjectId(), b.GetProjectId())
+	})
+	return projects, nil
+}
+
+// sortByProject orders items gathered across projects by project, then name,
+// so repeated calls return them in the same order. key returns an item's
+// project and name.
+func sortByProject[T any](items []T, key func(T) (project, name string)) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		projectA, nameA := key(a)
+		projectB, nameB := key(b)
+		return cmp.Or(cmp.Compare(projectA, projectB), cmp.Compare(nameA, nameB))
+	})
+}
+
+// projectIDsOrAll returns projectID, or the IDs of all accessible projects
+// if it is empty.
+func projectIDsOrAll(ctx context.Context, client MinderClient, projectID string) ([]string, error) {
+	if projectID != "" {
+		callmeta.AddProjects(ctx, 1)
+		return []string{projectID}, nil
+	}
+	projects, err := listAllProjects(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(projects))
+	for _, p := range projects {
+		ids = append(ids, p.ProjectId)
+	}
+	callmeta.AddProjects(ctx, len(ids))
+	return ids, nil
 }
 
 // forEachProject executes a function for each project, collecting results.
@@ -26,6 +69,7 @@ func forEachProject[T any](
 ) ([]T, error) {
 	if projectID != "" {
 		// Single project specified
+		callmeta.AddProjects(ctx, 1)
 		return fn(ctx, projectID)
 	}
 
@@ -34,6 +78,7 @@ func forEachProject[T any](
 	if err != nil {
 		return nil, err
 	}
+	callmeta.AddProjects(ctx, len(projects))
 
 	// Aggregate results from all projects
 	var allResults []T
@@ -49,8 +94,53 @@ func forEachProject[T any](
 	return allResults, nil
 }
 
+// profileStatus is a profile with the status of its rule evaluations.
+type profileStatus struct {
+	Profile     *minderv1.Profile
+	Evaluations []*minderv1.RuleEvaluationStatus
+}
+
+// skippedRead is a project, or one of its profiles, that a listing across
+// projects could not read and left out of its result.
+type skippedRead struct {
+	Project string `json:"project"`
+	Profile string `json:"profile,omitempty"`
+	Error   string `json:"error"`
+}
+
+// readProfileStatuses reads every profile of a project, labelled or not, with
+// the status of its rule evaluations. A project or profile that cannot be
+// read is returned as skipped, for the caller to report rather than
+// silently under-count.
+func readProfileStatuses(ctx context.Context, client MinderClient, projectID string) ([]profileStatus, []skippedRead) {
+	profiles, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+		Context:     &minderv1.Context{Project: &projectID},
+		LabelFilter: "*",
+	})
+	if err != nil {
+		return nil, []skippedRead{{Project: projectID, Error: "listing profiles: " + MapGRPCError(err)}}
+	}
+	var statuses []profileStatus
+	var skipped []skippedRead
+	for _, profile := range profiles.GetProfiles() {
+		resp, err := client.Profiles().GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
+			Name:    profile.GetName(),
+			All:     true,
+			Context: &minderv1.Context{Project: &projectID},
+		})
+		if err != nil {
+			skipped = append(skipped, skippedRead{Project: projectID, Profile: profile.GetName(), Error: MapGRPCError(err)})
+			continue
+		}
+		statuses = append(statuses, profileStatus{Profile: profile, Evaluations: resp.GetRuleEvaluationStatus()})
+	}
+	return statuses, skipped
+}
+
 // findInProjects searches for an item across all projects using a finder function.
 // Returns the first match found. If projectID is provided, only searches that project.
+// Use it for lookups by ID; a lookup by name should use findOneInProjects, as
+// the same name may be used in several projects.
 func findInProjects[T any](
 	ctx context.Context,
 	client MinderClient,
@@ -61,6 +151,7 @@ func findInProjects[T any](
 
 	if projectID != "" {
 		// Single project specified
+		callmeta.AddProjects(ctx, 1)
 		return fn(ctx, projectID)
 	}
 
@@ -71,15 +162,134 @@ func findInProjects[T any](
 	}
 
 	// Search each project
-	var lastErr error
+	var findErr error
 	for _, project := range projects {
+		callmeta.AddProjects(ctx, 1)
 		result, err := fn(ctx, project.ProjectId)
 		if err == nil {
 			return result, nil
 		}
-		lastErr = err
+		findErr = worseFindError(findErr, err)
 	}
+	if findErr == nil {
+		findErr = errNotFoundInProjects
+	}
+	return zero, findErr
+}
 
-	// Return last error if nothing found
-	return zero, lastErr
+// projectMatch is an item found by findAllInProjects and the project it was found in.
+type projectMatch[T any] struct {
+	Project *minderv1.Project
+	Item    T
+}
+
+// findAllInProjects calls fn in projectID, or in every accessible project if
+// it is empty, and returns every item found with its project. Projects the
+// item cannot be read from are skipped; when it is found in none, the first
+// error other than NotFound is returned, so a failure is not hidden behind
+// projects that simply lack the item.
+func findAllInProjects[T any](
+	ctx context.Context,
+	client MinderClient,
+	projectID string,
+	fn func(ctx context.Context, projectID string) (T, error),
+) ([]projectMatch[T], error) {
+	if projectID != "" {
+		callmeta.AddProjects(ctx, 1)
+		item, err := fn(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return []projectMatch[T]{{Project: &minderv1.Project{ProjectId: projectID}, Item: item}}, nil
+	}
+
+	projects, err := listAllProjects(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	callmeta.AddProjects(ctx, len(projects))
+
+	var matches []projectMatch[T]
+	var findErr error
+	for _, project := range projects {
+		item, err := fn(ctx, project.GetProjectId())
+		if err != nil {
+			findErr = worseFindError(findErr, err)
+			continue
+		}
+		matches = append(matches, projectMatch[T]{Project: project, Item: item})
+	}
+	if len(matches) == 0 {
+		if findErr == nil {
+			findErr = errNotFoundInProjects
+		}
+		return nil, findErr
+	}
+	return matches, nil
+}
+
+// findOneInProjects is findAllInProjects for a lookup by name, which must
+// match in a single project. what describes the item, such as "profile
+// baseline", for the *ambiguousError returned when it is found in several.
+func findOneInProjects[T any](
+	ctx context.Context,
+	client MinderClient,
+	projectID, what string,
+	fn func(ctx context.Context, projectID string) (T, error),
+) (T, error) {
+	var zero T
+	matches, err := findAllInProjects(ctx, client, projectID, fn)
+	if err != nil {
+		return zero, err
+	}
+	if len(matches) > 1 {
+		projects := make([]*minderv1.Project, 0, len(matches))
+		for _, m := range matches {
+			projects = append(projects, m.Project)
+		}
+		return zero, &ambiguousError{what: what, projects: projects}
+	}
+	return matches[0].Item, nil
+}
+
+// errNotFoundInProjects is returned by searches across projects when there
+// are no accessible projects to search.
+var errNotFoundInProjects = status.Error(codes.NotFound, "not found in any accessible project")
+
+// worseFindError returns the error to keep between the one kept so far, kept,
+// and err: the first error, unless it is NotFound and err is not.
+func worseFindError(kept, err error) error {
+	if kept == nil || status.Code(kept) == codes.NotFound && status.Code(err) != codes.NotFound {
+		return err
+	}
+	return kept
+}
+
+// ambiguousError reports that a name looked up across projects was found in
+// more than one of them, so the caller must say which project it meant.
+type ambiguousError struct {
+	what     string
+	projects []*minderv1.Project
+}
+
+func (e *ambiguousError) Error() string {
+	found := make([]string, 0, len(e.projects))
+	for _, p := range e.projects {
+		if p.GetName() == "" {
+			found = append(found, p.GetProjectId())
+			continue
+		}
+		found = append(found, fmt.Sprintf("%s (%s)", p.GetName(), p.GetProjectId()))
+	}
+	return fmt.Sprintf("%s found in multiple projects, please disambiguate with project_id: %s",
+		e.what, strings.Join(found, ", "))
+}
+
+// projectIDs returns the IDs of the projects the name was found in.
+func (e *ambiguousError) projectIDs() []string {
+	ids := make([]string, 0, len(e.projects))
+	for _, p := range e.projects {
+		ids = append(ids, p.GetProjectId())
+	}
+	return ids
 }
@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListAllProjects_SortedByID(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.projects.listResp = &minderv1.ListProjectsResponse{
+		Projects: []*minderv1.Project{{ProjectId: "proj-c"}, {ProjectId: "proj-a"}, {ProjectId: "proj-b"}},
+	}
+
+	projects, err := listAllProjects(context.Background(), mockClient)
+	if err != nil {
+		t.Fatalf("listAllProjects() error = %v", err)
+	}
+	var ids []string
+	for _, p := range projects {
+		ids = append(ids, p.GetProjectId())
+	}
+	if want := []string{"proj-a", "proj-b", "proj-c"}; !slices.Equal(ids, want) {
+		t.Errorf("project IDs = %v, want %v", ids, want)
+	}
+	if got := mockClient.projects.listResp.Projects[0].GetProjectId(); got != "proj-c" {
+		t.Errorf("listAllProjects() reordered Minder's response: first project = %s", got)
+	}
+}
+
+func TestSortByProject(t *testing.T) {
+	t.Parallel()
+
+	projA, projB := "proj-a", "proj-b"
+	profiles := []*minderv1.Profile{
+		{Name: "zeta", Context: &minderv1.Context{Project: &projB}},
+		{Name: "beta", Context: &minderv1.Context{Project: &projA}},
+		{Name: "alpha", Context: &minderv1.Context{Project: &projB}},
+		{Name: "alpha", Context: &minderv1.Context{Project: &projA}},
+	}
+
+	sortByProject(profiles, profileKey)
+
+	var got []string
+	for _, p := range profiles {
+		got = append(got, p.GetContext().GetProject()+"/"+p.GetName())
+	}
+	want := []string{"proj-a/alpha", "proj-a/beta", "proj-b/alpha", "proj-b/zeta"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sorted profiles = %v, want %v", got, want)
+	}
+}
+
+func TestFindInProjects(t *testing.T) {
+	t.Parallel()
+
+	notFound := status.Error(codes.NotFound, "not found")
+	denied := status.Error(codes.PermissionDenied, "no access")
+	tests := []struct {
+		name        string
+		found       map[string]error // result of the lookup in each project; nil means found
+		wantAll     []string
+		wantOne     string
+		wantErrCode codes.Code
+		ambiguous   bool
+	}{
+		{
+			name:    "found in one project",
+			found:   map[string]error{"proj-a": notFound, "proj-b": nil, "proj-c": denied},
+			wantAll: []string{"proj-b"},
+			wantOne: "proj-b",
+		},
+		{
+			name:      "found in several projects",
+			found:     map[string]error{"proj-a": nil, "proj-b": notFound, "proj-c": nil},
+			wantAll:   []string{"proj-a", "proj-c"},
+			ambiguous: true,
+		},
+		{
+			name:        "failure not hidden by later NotFound",
+			found:       map[string]error{"proj-a": denied, "proj-b": notFound, "proj-c": notFound},
+			wantErrCode: codes.PermissionDenied,
+		},
+		{
+			name:        "not found anywhere",
+			found:       map[string]error{"proj-a": notFound, "proj-b": notFound},
+			wantErrCode: codes.NotFound,
+		},
+		{
+			name:        "no projects",
+			found:       map[string]error{},
+			wantErrCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.projects.listResp = &minderv1.ListProjectsResponse{Projects: []*minderv1.Project{}}
+			for id := range tt.found {
+				mockClient.projects.listResp.Projects = append(mockClient.projects.listResp.Projects,
+					&minderv1.Project{ProjectId: id, Name: "name-" + id})
+			}
+			find := func(_ context.Context, projectID string) (string, error) {
+				return projectID, tt.found[projectID]
+			}
+			ctx := context.Background()
+
+			matches, err := findAllInProjects(ctx, mockClient, "", find)
+			if status.Code(err) != tt.wantErrCode {
+				t.Fatalf("findAllInProjects() error = %v, want code %v", err, tt.wantErrCode)
+			}
+			var got []string
+			for _, m := range matches {
+				if m.Project.GetProjectId() != m.Item {
+					t.Errorf("match %s reported in project %s", m.Item, m.Project.GetProjectId())
+				}
+				got = append(got, m.Item)
+			}
+			if !slices.Equal(got, tt.wantAll) {
+				t.Errorf("findAllInProjects() = %v, want %v", got, tt.wantAll)
+			}
+
+			one, err := findOneInProjects(ctx, mockClient, "", "profile baseline", find)
+			var ambiguous *ambiguousError
+			if errors.As(err, &ambiguous) != tt.ambiguous {
+				t.Fatalf("findOneInProjects() error = %v, want ambiguous %v", err, tt.ambiguous)
+			}
+			if tt.ambiguous {
+				want := "profile baseline found in multiple projects, please disambiguate with project_id: " +
+					"name-proj-a (proj-a), name-proj-c (proj-c)"
+				if err.Error() != want {
+					t.Errorf("ambiguous error = %q, want %q", err, want)
+				}
+				detail := NewErrorDetail(err)
+				if detail.Code != ErrCodeInvalidArgument || !slices.Equal(detail.Projects, tt.wantAll) {
+					t.Errorf("error detail = %+v, want code %s and projects %v", detail, ErrCodeInvalidArgument, tt.wantAll)
+				}
+			} else if one != tt.wantOne {
+				t.Errorf("findOneInProjects() = %q, want %q", one, tt.wantOne)
+			}
+
+			first, err := findInProjects(ctx, mockClient, "", find)
+			if len(tt.wantAll) > 0 && (err != nil || first != tt.wantAll[0]) {
+				t.Errorf("findInProjects() = %q, %v, want %q", first, err, tt.wantAll[0])
+			}
+			if len(tt.wantAll) == 0 && status.Code(err) != tt.wantErrCode {
+				t.Errorf("findInProjects() error = %v, want code %v", err, tt.wantErrCode)
+			}
+		})
+	}
+}
+
+func TestReadProfileStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		listErr      error
+		statusErr    error
+		wantStatuses int
+		wantSkipped  []skippedRead
+	}{
+		{name: "all read", wantStatuses: 1},
+		{
+			name:        "profiles cannot be listed",
+			listErr:     status.Error(codes.PermissionDenied, "denied"),
+			wantSkipped: []skippedRead{{Project: "proj-1"}},
+		},
+		{
+			name:        "profile status cannot be read",
+			statusErr:   status.Error(codes.Unavailable, "down"),
+			wantSkipped: []skippedRead{{Project: "proj-1", Profile: "baseline"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{{Name: "baseline"}}}
+			mockClient.profiles.listErr = tt.listErr
+			mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{}
+			mockClient.profiles.getStatusByNameErr = tt.statusErr
+
+			statuses, skipped := readProfileStatuses(context.Background(), mockClient, "proj-1")
+			if len(statuses) != tt.wantStatuses {
+				t.Errorf("got %d profile statuses, want %d", len(statuses), tt.wantStatuses)
+			}
+			if len(skipped) != len(tt.wantSkipped) {
+				t.Fatalf("skipped = %+v, want %+v", skipped, tt.wantSkipped)
+			}
+			for i, s := range skipped {
+				if s.Project != tt.wantSkipped[i].Project || s.Profile != tt.wantSkipped[i].Profile || s.Error == "" {
+					t.Errorf("skipped[%d] = %+v, want %+v with an error", i, s, tt.wantSkipped[i])
+				}
+			}
+			if got := mockClient.profiles.listReq.GetLabelFilter(); got != "*" {
+				t.Errorf("ListProfiles label filter = %q, want *", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"sigs.k8s.io/yaml"
+)
+
+// ToolOverride replaces the prose of one tool, e.g. to translate it or to add
+// organization-specific guidance. Empty fields keep the built-in text.
+type ToolOverride struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Parameters maps parameter names to replacement descriptions.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// ToolOverrides maps tool names, with the default minder_ prefix, to their overrides.
+type ToolOverrides map[string]ToolOverride
+
+// LoadToolOverrides reads tool overrides from a YAML or JSON file. Unknown
+// fields are rejected so a misspelt key does not silently change nothing.
+func LoadToolOverrides(path string) (ToolOverrides, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is set by the operator
+	if err != nil {
+		return nil, fmt.Errorf("reading tool overrides: %w", err)
+	}
+	var overrides ToolOverrides
+	if err := yaml.UnmarshalStrict(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing tool overrides %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// SetToolOverrides replaces the title, description and parameter descriptions
+// of the tools in overrides when they are registered. Call it before Register.
+func (t *Tools) SetToolOverrides(overrides ToolOverrides) {
+	t.overrides = overrides
+}
+
+// withOverride applies the configured override of tool, if any.
+func (t *Tools) withOverride(tool mcp.Tool) mcp.Tool {
+	override, ok := t.overrides[tool.Name]
+	if !ok {
+		return tool
+	}
+	if override.Title != "" {
+		tool.Annotations.Title = override.Title
+	}
+	if override.Description != "" {
+		tool.Description = override.Description
+	}
+	if len(override.Parameters) == 0 {
+		return tool
+	}
+	properties := maps.Clone(tool.InputSchema.Properties)
+	for name, desc := range override.Parameters {
+		prop, ok := properties[name].(map[string]any)
+		if !ok {
+			t.logger.Warn("tool override names an unknown parameter", "tool", tool.Name, "parameter", name)
+			continue
+		}
+		prop = maps.Clone(prop)
+		prop["description"] = desc
+		properties[name] = prop
+	}
+	tool.InputSchema.Properties = properties
+	return tool
+}
+
+// warnUnusedOverrides logs the overridden tools that were not registered,
+// which are usually misspelt or disabled by read-only mode.
+func (t *Tools) warnUnusedOverrides(s *server.MCPServer) {
+	for _, name := range slices.Sorted(maps.Keys(t.overrides)) {
+		if s.GetTool(t.toolName(name)) == nil {
+			t.logger.Warn("tool override matches no registered tool", "tool", name)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadToolOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    ToolOverrides
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			file: "tools.yaml",
+			content: "minder_list_projects:\n  title: Proyectos\n  description: Lista los proyectos\n" +
+				"  parameters:\n    project_id: Proyecto padre\n",
+			want: ToolOverrides{"minder_list_projects": {
+				Title:       "Proyectos",
+				Description: "Lista los proyectos",
+				Parameters:  map[string]string{"project_id": "Proyecto padre"},
+			}},
+		},
+		{
+			name:    "json",
+			file:    "tools.json",
+			content: `{"minder_list_projects": {"description": "Always use project X"}}`,
+			want:    ToolOverrides{"minder_list_projects": {Description: "Always use project X"}},
+		},
+		{
+			name:    "unknown field",
+			file:    "tools.yaml",
+			content: "minder_list_projects:\n  descripton: typo\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "missing.yaml")
+			if tt.file != "" {
+				path = filepath.Join(t.TempDir(), tt.file)
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			}
+			got, err := LoadToolOverrides(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToolOverrides_Definitions(t *testing.T) {
+	t.Parallel()
+
+	tools := newPrefixedTools("prod_", newMockClient())
+	tools.SetToolOverrides(ToolOverrides{
+		"minder_list_projects": {
+			Title:       "Proyectos",
+			Description: "Lista los proyectos. Luego usa minder_get_profile_status.",
+			Parameters:  map[string]string{"project_id": "Proyecto padre", "unknown": "ignored"},
+		},
+		"minder_no_such_tool": {Description: "ignored"},
+	})
+
+	var listProjects, getProfile *mcp.Tool
+	for _, def := range tools.Definitions() {
+		switch def.Name {
+		case "prod_list_projects":
+			listProjects = &def
+		case "prod_get_profile":
+			getProfile = &def
+		}
+	}
+	require.NotNil(t, listProjects)
+	require.NotNil(t, getProfile)
+
+	assert.Equal(t, "Proyectos", listProjects.Annotations.Title)
+	assert.Equal(t, "Lista los proyectos. Luego usa prod_get_profile_status.", listProjects.Description)
+	prop, _ := listProjects.InputSchema.Properties["project_id"].(map[string]any)
+	assert.Equal(t, "Proyecto padre", prop["description"])
+	assert.NotContains(t, listProjects.InputSchema.Properties, "unknown")
+	assert.Equal(t, "Get Profile", getProfile.Annotations.Title, "tools without an override keep their text")
+}
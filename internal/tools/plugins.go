@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/minder"
+	"github.com/stacklok/minder-mcp/internal/plugin"
+)
+
+// AddPlugins offers the tools of plugins alongside the built-in tools. Call
+// it before Register.
+func (t *Tools) AddPlugins(plugins ...*plugin.Plugin) {
+	t.plugins = append(t.plugins, plugins...)
+}
+
+// LoadExtensions loads the tool overrides file and the plugins named in the
+// configuration. Call it before Register.
+func (t *Tools) LoadExtensions(ctx context.Context) error {
+	if path := t.cfg.MCP.ToolOverridesPath; path != "" {
+		overrides, err := LoadToolOverrides(path)
+		if err != nil {
+			return fmt.Errorf("loading tool overrides: %w", err)
+		}
+		t.SetToolOverrides(overrides)
+	}
+	for _, path := range t.cfg.MCP.Plugins {
+		p, err := plugin.Load(ctx, path, t.cfg.MCP.PluginTimeout, t.cfg.MCP.PluginEnv, t.logger)
+		if err != nil {
+			return fmt.Errorf("loading plugin %s: %w", path, err)
+		}
+		t.AddPlugins(p)
+	}
+	return nil
+}
+
+// registerPlugins registers the tools of the configured plugins like the
+// built-in ones, so they share logging, metrics, limits and read-only mode.
+// A plugin tool named like a tool already registered is skipped.
+func (t *Tools) registerPlugins(s *server.MCPServer) {
+	for _, p := range t.plugins {
+		for _, tool := range p.Tools() {
+			if s.GetTool(t.toolName(tool.Name)) != nil {
+				t.logger.Warn("skipping plugin tool named like a registered tool", "plugin", p.Name, "tool", tool.Name)
+				continue
+			}
+			t.addTool(s, tool, t.pluginHandler(p, tool.Name))
+		}
+	}
+}
+
+// pluginHandler returns the handler running the named tool of p.
+func (t *Tools) pluginHandler(p *plugin.Plugin, name string) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := p.Call(ctx, plugin.Request{
+			Tool:      name,
+			Arguments: req.GetArguments(),
+			RequestID: middleware.RequestIDFromContext(ctx),
+			SessionID: sessionID(ctx),
+			Minder:    t.pluginCredentials(ctx),
+		})
+		if err != nil {
+			t.logger.WarnContext(ctx, "plugin tool failed", "plugin", p.Name, "tool", name, "error", err)
+			return errorResult(err.Error(), ErrorDetail{Code: ErrCodeToolError}), nil
+		}
+		return result, nil
+	}
+}
+
+// pluginCredentials returns the Minder server and access token a plugin call
+// in ctx may act with, or nil if the request has no usable token. Plugins
+// that do not call Minder work without one, so a missing token is not an error.
+func (t *Tools) pluginCredentials(ctx context.Context) *plugin.Minder {
+	srv, err := t.resolveServer(ctx)
+	if err != nil {
+		return nil
+	}
+	token := t.tokenFor(ctx, srv)
+	if token == "" {
+		return nil
+	}
+	if t.tokenRefresher != nil {
+		serverCfg := minder.ServerConfig{Host: srv.Host, Port: srv.Port, Insecure: srv.Insecure, CertPins: srv.CertPins}
+		if token, err = t.tokenRefresher.GetValidAccessToken(ctx, token, serverCfg); err != nil {
+			t.logger.DebugContext(ctx, "no valid token for plugin call", "server", srv.Name, "error", err)
+			return nil
+		}
+	}
+	return &plugin.Minder{
+		Server:   net.JoinHostPort(srv.Host, strconv.Itoa(srv.Port)),
+		Insecure: srv.Insecure,
+		Token:    token,
+	}
+}
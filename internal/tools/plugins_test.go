@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/plugin"
+)
+
+// echoPlugin provides file_ticket, which echoes its request, and a tool
+// clashing with a built-in one.
+const echoPlugin = `#!/bin/sh
+case "$1" in
+describe)
+  echo '{"tools": [{"name": "file_ticket", "description": "File a ticket", "annotations": {"readOnlyHint": false}},
+    {"name": "minder_list_projects", "description": "Clashes with a built-in tool"}]}'
+  ;;
+call)
+  printf 'request: '
+  cat
+  ;;
+esac
+`
+
+func loadEchoPlugin(t *testing.T) *plugin.Plugin {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tickets")
+	require.NoError(t, os.WriteFile(path, []byte(echoPlugin), 0o700)) //nolint:gosec // test plugin must be executable
+	p, err := plugin.Load(context.Background(), path, 5*time.Second, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return p
+}
+
+func TestPlugins(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.AddPlugins(loadEchoPlugin(t))
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+
+	tool := s.GetTool("file_ticket")
+	require.NotNil(t, tool, "plugin tool not registered")
+	assert.Equal(t, "File a ticket", tool.Tool.Description)
+	assert.NotEqual(t, "Clashes with a built-in tool", s.GetTool("minder_list_projects").Tool.Description)
+
+	result := callTool(contextWithSession("session-1"), t, s, "file_ticket", map[string]any{"title": "outage"})
+	require.False(t, result.IsError, getResultText(t, result))
+	text := getResultText(t, result)
+	assert.Contains(t, text, `"tool":"file_ticket"`)
+	assert.Contains(t, text, `"arguments":{"title":"outage"}`)
+	assert.Contains(t, text, `"session_id":"session-1"`)
+	assert.Contains(t, text, `"request_id":`)
+	assert.NotContains(t, text, `"minder"`, "credentials passed without a token")
+}
+
+func TestPlugins_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.cfg.MCP.ReadOnly = true
+	tools.AddPlugins(loadEchoPlugin(t))
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+
+	assert.Nil(t, s.GetTool("file_ticket"), "write plugin tool registered in read-only mode")
+}
@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// projectProfilesURITemplate lists the profiles of a project.
+	projectProfilesURITemplate = "minder://project/{id}/profiles"
+	// ruleTypeURITemplate is a rule type looked up by name across projects.
+	ruleTypeURITemplate = "minder://ruletype/{name}"
+	// yamlMIMEType is the MIME type of policy definition resources.
+	yamlMIMEType = "application/yaml"
+)
+
+// registerPolicyResources lets clients attach profile and rule type
+// definitions to a conversation as YAML, in the form minder accepts with
+// "minder profile create -f" and "minder ruletype create -f", without a tool call.
+func (t *Tools) registerPolicyResources(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(projectProfilesURITemplate, "Project Profiles",
+			mcp.WithTemplateDescription("Definitions of every profile in a Minder project, as YAML documents separated by ---"),
+			mcp.WithTemplateMIMEType(yamlMIMEType),
+		),
+		t.readProjectProfiles,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(ruleTypeURITemplate, "Rule Type",
+			mcp.WithTemplateDescription("Definition of a Minder rule type, as YAML. "+
+				"The name is looked up in every project the token can access"),
+			mcp.WithTemplateMIMEType(yamlMIMEType),
+		),
+		t.readRuleType,
+	)
+}
+
+// readProjectProfiles serves the profiles of the project named in the URI.
+func (t *Tools) readProjectProfiles(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	projectID := templateArg(req, "id")
+	if projectID == "" {
+		return nil, fmt.Errorf("%s does not name a project", req.Params.URI)
+	}
+
+	client, err := t.resourceClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	// Minder hides labelled profiles unless asked, yet every profile is policy
+	resp, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+		Context:     &minderv1.Context{Project: &projectID},
+		LabelFilter: "*",
+	})
+	if err != nil {
+		return nil, resourceError(err)
+	}
+	docs := make([]proto.Message, 0, len(resp.GetProfiles()))
+	for _, p := range resp.GetProfiles() {
+		docs = append(docs, p)
+	}
+	return yamlContents(req.Params.URI, docs...)
+}
+
+// readRuleType serves the rule type named in the URI.
+func (t *Tools) readRuleType(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	name := templateArg(req, "name")
+	if name == "" {
+		return nil, fmt.Errorf("%s does not name a rule type", req.Params.URI)
+	}
+
+	client, err := t.resourceClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	ruleType, err := findOneInProjects(
+		ctx, client, "", "rule type "+name,
+		func(ctx context.Context, projID string) (*minderv1.RuleType, error) {
+			resp, err := client.RuleTypes().GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{
+				Name:    name,
+				Context: &minderv1.Context{Project: &projID},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.RuleType, nil
+		})
+	if err != nil {
+		return nil, resourceError(err)
+	}
+	return yamlContents(req.Params.URI, ruleType)
+}
+
+// resourceClient returns a Minder client for a resource read.
+func (t *Tools) resourceClient(ctx context.Context) (MinderClient, error) {
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Minder: %w", err)
+	}
+	return client, nil
+}
+
+// resourceError describes a failed Minder call the way tool errors do.
+func resourceError(err error) error {
+	return errors.New(MapGRPCError(err))
+}
+
+// templateArg returns a variable matched from the resource template. The SDK
+// stores matched values as lists.
+func templateArg(req mcp.ReadResourceRequest, name string) string {
+	switch v := req.Params.Arguments[name].(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return ""
+	}
+}
+
+// yamlContents renders messages as YAML documents separated by "---", using
+// the protobuf field names minder's own YAML uses.
+func yamlContents(uri string, msgs ...proto.Message) ([]mcp.ResourceContents, error) {
+	docs := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		doc, err := protoYAML(msg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", uri, err)
+		}
+		docs = append(docs, doc)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: yamlMIMEType, Text: strings.Join(docs, "---\n")},
+	}, nil
+}
+
+// protoYAML renders msg as a YAML document with protobuf field names.
+func protoYAML(msg proto.Message) (string, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal: %w", err)
+	}
+	doc, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	return string(doc), nil
+}
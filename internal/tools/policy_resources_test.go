@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readResource reads uri through the server, so the resource template is matched as for a client.
+func readResource(t *testing.T, s *server.MCPServer, uri string) (mcp.ReadResourceResult, *mcp.JSONRPCError) {
+	t.Helper()
+	msg, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": map[string]any{"uri": uri},
+	})
+	require.NoError(t, err)
+	switch resp := s.HandleMessage(context.Background(), msg).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := resp.Result.(mcp.ReadResourceResult)
+		require.True(t, ok, "unexpected result %T", resp.Result)
+		return result, nil
+	case mcp.JSONRPCError:
+		return mcp.ReadResourceResult{}, &resp
+	default:
+		t.Fatalf("unexpected response %T", resp)
+		return mcp.ReadResourceResult{}, nil
+	}
+}
+
+func TestPolicyResources(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{
+		{Name: "baseline", Repository: []*minderv1.Profile_Rule{{Type: "secret_scanning"}}},
+		{Name: "strict", Remediate: ptr("on")},
+	}}
+	mockClient.ruleTypes.getByNameResp = &minderv1.GetRuleTypeByNameResponse{RuleType: &minderv1.RuleType{
+		Name:        "secret_scanning",
+		Description: "Verifies secret scanning is enabled",
+		Def:         &minderv1.RuleType_Definition{InEntity: "repository"},
+	}}
+	tools := newTestTools(mockClient)
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, false))
+	tools.Register(s)
+
+	result, rpcErr := readResource(t, s, "minder://project/proj-1/profiles")
+	require.Nil(t, rpcErr)
+	require.Len(t, result.Contents, 1)
+	text, ok := result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, yamlMIMEType, text.MIMEType)
+	assert.Equal(t, "name: baseline\nrepository:\n- type: secret_scanning\n---\nname: strict\nremediate: \"on\"\n", text.Text)
+	assert.Equal(t, "*", mockClient.profiles.listReq.GetLabelFilter(), "labelled profiles must be included")
+
+	result, rpcErr = readResource(t, s, "minder://ruletype/secret_scanning")
+	require.Nil(t, rpcErr)
+	text, ok = result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "name: secret_scanning\n")
+	assert.Contains(t, text.Text, "in_entity: repository")
+}
+
+func TestPolicyResources_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.ruleTypes.getByNameErr = status.Error(codes.NotFound, "rule type not found")
+	tools := newTestTools(mockClient)
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, false))
+	tools.Register(s)
+
+	_, rpcErr := readResource(t, s, "minder://ruletype/missing")
+	require.NotNil(t, rpcErr)
+	assert.Contains(t, rpcErr.Error.Message, "not found")
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+)
+
+// toolReference matches tool names mentioned in descriptions.
+var toolReference = regexp.MustCompile(`\b` + config.DefaultToolPrefix + `[a-z_]+`)
+
+// toolPrefix returns the prefix every tool name starts with.
+func (t *Tools) toolPrefix() string {
+	if t.cfg.MCP.ToolPrefix == "" {
+		return config.DefaultToolPrefix
+	}
+	return t.cfg.MCP.ToolPrefix
+}
+
+// toolName returns the name clients see for a tool registered as name, with
+// the configured prefix in place of config.DefaultToolPrefix.
+func (t *Tools) toolName(name string) string {
+	prefix := t.toolPrefix()
+	if prefix == config.DefaultToolPrefix {
+		return name
+	}
+	return prefix + strings.TrimPrefix(name, config.DefaultToolPrefix)
+}
+
+// withToolPrefix renames tool to its prefixed name and rewrites the names of
+// other tools mentioned in its descriptions to match.
+func (t *Tools) withToolPrefix(tool mcp.Tool) mcp.Tool {
+	if t.toolPrefix() == config.DefaultToolPrefix {
+		return tool
+	}
+	rename := func(s string) string { return toolReference.ReplaceAllStringFunc(s, t.toolName) }
+
+	tool.Name = t.toolName(tool.Name)
+	tool.Description = rename(tool.Description)
+	properties := make(map[string]any, len(tool.InputSchema.Properties))
+	for name, schema := range tool.InputSchema.Properties {
+		if prop, ok := schema.(map[string]any); ok {
+			if desc, ok := prop["description"].(string); ok {
+				prop = maps.Clone(prop)
+				prop["description"] = rename(desc)
+			}
+			schema = prop
+		}
+		properties[name] = schema
+	}
+	tool.InputSchema.Properties = properties
+	return tool
+}
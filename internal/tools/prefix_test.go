@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+)
+
+func newPrefixedTools(prefix string, mockClient *mockMinderClient) *Tools {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{ToolPrefix: prefix, EnabledTools: []string{prefix + "list_repositories"}}}
+	return NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return mockClient, nil
+	})
+}
+
+func TestToolName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "", want: "minder_list_projects"},
+		{prefix: "minder_", want: "minder_list_projects"},
+		{prefix: "prod_", want: "prod_list_projects"},
+		{prefix: "minder-prod_", want: "minder-prod_list_projects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			t.Parallel()
+
+			tools := newPrefixedTools(tt.prefix, newMockClient())
+			assert.Equal(t, tt.want, tools.toolName("minder_list_projects"))
+		})
+	}
+}
+
+func TestToolPrefix_Definitions(t *testing.T) {
+	t.Parallel()
+
+	tools := newPrefixedTools("prod_", newMockClient())
+	defs := tools.Definitions()
+	require.NotEmpty(t, defs)
+
+	for _, def := range defs {
+		assert.True(t, strings.HasPrefix(def.Name, "prod_"), "tool %s is not prefixed", def.Name)
+		assert.NotContains(t, def.Description, "minder_", "tool %s mentions an unprefixed tool", def.Name)
+		switch def.Name {
+		case "prod_reregister_repository":
+			assert.Contains(t, def.Description, "prod_get_repository_webhook")
+		case "prod_show_dashboard":
+			assert.Equal(t, "prod_", def.Meta.AdditionalFields["toolPrefix"])
+		}
+	}
+}
+
+func TestToolPrefix_Call(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{}
+	tools := newPrefixedTools("prod_", mockClient)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+
+	assert.Nil(t, s.GetTool("minder_list_repositories"))
+	result := callTool(context.Background(), t, s, "prod_list_repositories", map[string]any{"project_id": "p1"})
+	require.False(t, result.IsError, getResultText(t, result))
+
+	// The enabled-tools list and usage stats use prefixed names
+	result = callTool(context.Background(), t, s, "prod_list_projects", nil)
+	assert.True(t, result.IsError)
+	snap := tools.Stats().Snapshot()
+	require.Len(t, snap, 1)
+	assert.Equal(t, "prod_list_repositories", snap[0].Tool)
+}
@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// Modes a rule runs in, from what Minder does when it fails.
+const (
+	ruleModeRemediate    = "remediate"
+	ruleModeAlertOnly    = "alert_only"
+	ruleModeDryRun       = "dry_run"
+	ruleModeEvaluateOnly = "evaluate_only"
+)
+
+// actionUnsupported is the action of a rule whose rule type cannot alert or remediate.
+const actionUnsupported = "unsupported"
+
+// profileActions is the alert and remediation configuration of a profile.
+type profileActions struct {
+	Profile   string `json:"profile"`
+	ProjectID string `json:"project_id"`
+	// Alert and Remediate are the profile settings, with Minder's defaults
+	// for settings left unset.
+	Alert     string        `json:"alert"`
+	Remediate string        `json:"remediate"`
+	Rules     []ruleActions `json:"rules"`
+}
+
+// ruleActions is what Minder does when one rule of a profile fails.
+type ruleActions struct {
+	EntityType string `json:"entity_type"`
+	RuleType   string `json:"rule_type"`
+	Rule       string `json:"rule,omitempty"`
+	// Alert and Remediate are "on", "off", "dry_run", or "unsupported" when
+	// the rule type defines no such action.
+	Alert     string `json:"alert"`
+	Remediate string `json:"remediate"`
+	// AlertMethod and RemediateMethod are how the rule type acts, e.g.
+	// "security_advisory" or "pull_request".
+	AlertMethod     string `json:"alert_method,omitempty"`
+	RemediateMethod string `json:"remediate_method,omitempty"`
+	Mode            string `json:"mode"`
+	// RuleTypeUnknown is set when the rule type could not be read, in which
+	// case the rule is assumed to support both actions.
+	RuleTypeUnknown bool `json:"rule_type_unknown,omitempty"`
+}
+
+// alertOnlyRule identifies a rule that alerts on failures without remediating them.
+type alertOnlyRule struct {
+	ProjectID  string `json:"project_id"`
+	Profile    string `json:"profile"`
+	EntityType string `json:"entity_type"`
+	RuleType   string `json:"rule_type"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+// ruleAction returns the action Minder takes for a profile setting when the
+// rule type supports it.
+func ruleAction(setting string, supported bool) string {
+	if !supported {
+		return actionUnsupported
+	}
+	return setting
+}
+
+// ruleMode classifies a rule by its alert and remediate actions. A rule that
+// remediates is in remediate mode whether or not it also alerts.
+func ruleMode(alert, remediate string) string {
+	switch {
+	case remediate == "on":
+		return ruleModeRemediate
+	case alert == "on":
+		return ruleModeAlertOnly
+	case alert == "dry_run" || remediate == "dry_run":
+		return ruleModeDryRun
+	default:
+		return ruleModeEvaluateOnly
+	}
+}
+
+// newProfileActions returns the actions of each rule of profile, using
+// ruleTypes, keyed by name, to tell which actions each rule type supports.
+func newProfileActions(projectID string, profile *minderv1.Profile, ruleTypes map[string]*minderv1.RuleType) profileActions {
+	actions := profileActions{
+		Profile:   profile.GetName(),
+		ProjectID: projectID,
+		Alert:     settingOrDefault(profile.Alert, defaultProfileAlert),
+		Remediate: settingOrDefault(profile.Remediate, defaultProfileRemediate),
+		Rules:     []ruleActions{},
+	}
+	for entityType, rules := range profileRules(profile) {
+		for _, rule := range rules {
+			ra := ruleActions{EntityType: entityType, RuleType: rule.GetType(), Rule: rule.GetName()}
+			if rt, ok := ruleTypes[rule.GetType()]; ok {
+				def := rt.GetDef()
+				ra.Alert = ruleAction(actions.Alert, def.GetAlert() != nil)
+				ra.Remediate = ruleAction(actions.Remediate, def.GetRemediate() != nil)
+				ra.AlertMethod = def.GetAlert().GetType()
+				ra.RemediateMethod = def.GetRemediate().GetType()
+			} else {
+				ra.Alert, ra.Remediate = actions.Alert, actions.Remediate
+				ra.RuleTypeUnknown = true
+			}
+			ra.Mode = ruleMode(ra.Alert, ra.Remediate)
+			actions.Rules = append(actions.Rules, ra)
+		}
+	}
+	slices.SortFunc(actions.Rules, func(a, b ruleActions) int {
+		return cmp.Or(
+			cmp.Compare(a.EntityType, b.EntityType),
+			cmp.Compare(a.RuleType, b.RuleType),
+			cmp.Compare(a.Rule, b.Rule),
+		)
+	})
+	return actions
+}
+
+// getProfileActions reports, per profile and rule, whether Minder alerts on
+// and remediates failures, flagging rules that alert without remediating.
+func (t *Tools) getProfileActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	name := req.GetString("name", "")
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectIDs, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	profiles, err := listProfileActions(ctx, client, projectIDs, name)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	if name != "" && len(profiles) == 0 {
+		return mcp.NewToolResultError("profile " + name + " not found"), nil
+	}
+
+	modes, alertOnly := summarizeRuleModes(profiles)
+	return marshalResult(ctx, map[string]any{
+		"profiles":   profiles,
+		"modes":      modes,
+		"alert_only": alertOnly,
+	})
+}
+
+// listProfileActions returns the actions of the profiles in projects, only
+// the profile called name when it is set. When listing several projects, one
+// that fails is skipped, as in the compliance snapshot.
+func listProfileActions(ctx context.Context, client MinderClient, projectIDs []string, name string) ([]profileActions, error) {
+	profiles := []profileActions{}
+	for _, projID := range projectIDs {
+		resp, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+			Context:     &minderv1.Context{Project: &projID},
+			LabelFilter: "*",
+		})
+		if err != nil {
+			if len(projectIDs) == 1 {
+				return nil, err
+			}
+			continue
+		}
+		ruleTypes := map[string]*minderv1.RuleType{}
+		if rts, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{
+			Context: &minderv1.Context{Project: &projID},
+		}); err == nil {
+			for _, rt := range rts.GetRuleTypes() {
+				ruleTypes[rt.GetName()] = rt
+			}
+		}
+		for _, profile := range resp.GetProfiles() {
+			if name == "" || profile.GetName() == name {
+				profiles = append(profiles, newProfileActions(projID, profile, ruleTypes))
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// summarizeRuleModes counts the rules of profiles in each mode and lists the
+// rules in alert only mode.
+func summarizeRuleModes(profiles []profileActions) (map[string]int, []alertOnlyRule) {
+	modes := map[string]int{}
+	alertOnly := []alertOnlyRule{}
+	for _, p := range profiles {
+		for _, rule := range p.Rules {
+			modes[rule.Mode]++
+			if rule.Mode == ruleModeAlertOnly {
+				alertOnly = append(alertOnly, alertOnlyRule{
+					ProjectID:  p.ProjectID,
+					Profile:    p.Profile,
+					EntityType: rule.EntityType,
+					RuleType:   rule.RuleType,
+					Rule:       rule.Rule,
+				})
+			}
+		}
+	}
+	return modes, alertOnly
+}
@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestRuleMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		alert     string
+		remediate string
+		want      string
+	}{
+		{alert: "on", remediate: "on", want: ruleModeRemediate},
+		{alert: "off", remediate: "on", want: ruleModeRemediate},
+		{alert: "on", remediate: "off", want: ruleModeAlertOnly},
+		{alert: "on", remediate: actionUnsupported, want: ruleModeAlertOnly},
+		{alert: "on", remediate: "dry_run", want: ruleModeAlertOnly},
+		{alert: "dry_run", remediate: "off", want: ruleModeDryRun},
+		{alert: actionUnsupported, remediate: "dry_run", want: ruleModeDryRun},
+		{alert: "off", remediate: "off", want: ruleModeEvaluateOnly},
+		{alert: actionUnsupported, remediate: actionUnsupported, want: ruleModeEvaluateOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alert+"/"+tt.remediate, func(t *testing.T) {
+			t.Parallel()
+			if got := ruleMode(tt.alert, tt.remediate); got != tt.want {
+				t.Errorf("ruleMode(%q, %q) = %q, want %q", tt.alert, tt.remediate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProfileActions(t *testing.T) {
+	t.Parallel()
+
+	on, off := "on", "off"
+	mockClient := newMockClient()
+	mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{
+		RuleTypes: []*minderv1.RuleType{
+			{Name: "branch_protection", Def: &minderv1.RuleType_Definition{
+				Alert:     &minderv1.RuleType_Definition_Alert{Type: "security_advisory"},
+				Remediate: &minderv1.RuleType_Definition_Remediate{Type: "rest"},
+			}},
+			{Name: "license", Def: &minderv1.RuleType_Definition{
+				Alert: &minderv1.RuleType_Definition_Alert{Type: "security_advisory"},
+			}},
+			{Name: "secret_scanning", Def: &minderv1.RuleType_Definition{}},
+		},
+	}
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{
+			{
+				Name:      "baseline",
+				Remediate: &on,
+				Repository: []*minderv1.Profile_Rule{
+					{Type: "license"},
+					{Type: "branch_protection", Name: "main"},
+					{Type: "secret_scanning"},
+				},
+			},
+			{
+				Name:       "quiet",
+				Alert:      &off,
+				Repository: []*minderv1.Profile_Rule{{Type: "branch_protection"}, {Type: "custom"}},
+			},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getProfileActions(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getProfileActions() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Profiles  []profileActions `json:"profiles"`
+		Modes     map[string]int   `json:"modes"`
+		AlertOnly []alertOnlyRule  `json:"alert_only"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Profiles) != 2 {
+		t.Fatalf("profiles = %+v, want 2", got.Profiles)
+	}
+	baseline := got.Profiles[0]
+	if baseline.Alert != "on" || baseline.Remediate != "on" {
+		t.Errorf("baseline settings = %s/%s, want alert on by default and remediate on", baseline.Alert, baseline.Remediate)
+	}
+	wantModes := map[string]string{
+		"branch_protection": ruleModeRemediate,
+		"license":           ruleModeAlertOnly,
+		"secret_scanning":   ruleModeEvaluateOnly,
+	}
+	for _, rule := range baseline.Rules {
+		if rule.Mode != wantModes[rule.RuleType] {
+			t.Errorf("baseline %s mode = %q, want %q", rule.RuleType, rule.Mode, wantModes[rule.RuleType])
+		}
+	}
+	if rule := baseline.Rules[1]; rule.RuleType != "license" || rule.Remediate != actionUnsupported {
+		t.Errorf("baseline rules[1] = %+v, want license with remediation unsupported", rule)
+	}
+
+	quiet := got.Profiles[1]
+	if quiet.Remediate != "off" {
+		t.Errorf("quiet remediate = %q, want the default off", quiet.Remediate)
+	}
+	if custom := quiet.Rules[1]; !custom.RuleTypeUnknown || custom.Mode != ruleModeEvaluateOnly {
+		t.Errorf("quiet custom rule = %+v, want an unknown rule type evaluated only", custom)
+	}
+
+	if len(got.AlertOnly) != 1 || got.AlertOnly[0].Profile != "baseline" || got.AlertOnly[0].RuleType != "license" {
+		t.Errorf("alert_only = %+v, want baseline's license rule", got.AlertOnly)
+	}
+	if got.Modes[ruleModeEvaluateOnly] != 3 || got.Modes[ruleModeRemediate] != 1 {
+		t.Errorf("modes = %v, want 3 evaluate_only and 1 remediate", got.Modes)
+	}
+}
+
+func TestGetProfileActions_NameNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getProfileActions(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "name": "missing"}},
+	})
+	if err != nil {
+		t.Fatalf("getProfileActions() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected error result for an unknown profile, got %s", getResultText(t, result))
+	}
+}
@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/alert"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// profileStatusConcurrency bounds how many profile statuses are fetched at once.
+const profileStatusConcurrency = 8
+
+// unlabeledGroup is the group_by=label group of profiles without labels.
+const unlabeledGroup = "(unlabeled)"
+
+// profileRef identifies a profile whose status is fetched.
+type profileRef struct {
+	project string
+	name    string
+	labels  []string
+}
+
+// failingRule is a rule evaluation of a profile that failed or errored.
+type failingRule struct {
+	RuleType string `json:"rule_type"`
+	Rule     string `json:"rule"`
+	Entity   string `json:"entity"`
+	Status   string `json:"status"`
+	Severity string `json:"severity,omitempty"`
+}
+
+// profileStatusSummary is the consolidated status of one profile.
+type profileStatusSummary struct {
+	Project     string         `json:"project"`
+	Profile     string         `json:"profile"`
+	ProfileID   string         `json:"profile_id,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
+	Status      string         `json:"status"`
+	LastUpdated string         `json:"last_updated,omitempty"`
+	Rules       map[string]int `json:"rules"`
+	Failing     []failingRule  `json:"failing,omitempty"`
+}
+
+// profileStatusError is a profile whose status could not be read.
+type profileStatusError struct {
+	Project string `json:"project"`
+	Profile string `json:"profile"`
+	Error   string `json:"error"`
+}
+
+// profileGroup summarizes the profiles sharing a label.
+type profileGroup struct {
+	Profiles     int            `json:"profiles"`
+	ByStatus     map[string]int `json:"by_status"`
+	FailingRules int            `json:"failing_rules"`
+}
+
+// getAllProfileStatuses fetches the status of every profile in a project, or
+// across all accessible projects, concurrently and summarizes them, sparing
+// clients a minder_get_profile_status call per profile.
+func (t *Tools) getAllProfileStatuses(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	labelFilter := req.GetString("label_filter", "*")
+	groupBy := req.GetString("group_by", "")
+	if groupBy != "" && groupBy != "label" {
+		return mcp.NewToolResultError("group_by must be label"), nil
+	}
+	threshold, errMsg := minSeverityParam(req)
+	if errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	refs, err := forEachProject(ctx, client, projectID, func(ctx context.Context, projID string) ([]profileRef, error) {
+		resp, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+			Context:     &minderv1.Context{Project: &projID},
+			LabelFilter: labelFilter,
+		})
+		if err != nil {
+			return nil, err
+		}
+		refs := make([]profileRef, 0, len(resp.GetProfiles()))
+		for _, profile := range resp.GetProfiles() {
+			refs = append(refs, profileRef{project: projID, name: profile.GetName(), labels: profile.GetLabels()})
+		}
+		return refs, nil
+	})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	summaries, failed := fetchProfileStatuses(ctx, client, refs, threshold)
+	byStatus := map[string]int{}
+	for _, s := range summaries {
+		byStatus[s.Status]++
+	}
+	shown, total := capResults(summaries, t.cfg.MCP.MaxResults)
+	result := map[string]any{
+		"summary":  map[string]any{"profiles": len(refs), "by_status": byStatus},
+		"profiles": shown,
+	}
+	if groupBy == "label" {
+		result["groups"] = groupByLabel(summaries)
+	}
+	if len(failed) > 0 {
+		result["errors"] = failed
+	}
+	return t.marshalCapped(ctx, result, len(shown), total)
+}
+
+// fetchProfileStatuses fetches the status of each profile, at most
+// profileStatusConcurrency at a time, counting the rule evaluations that meet
+// threshold. Summaries are sorted with failing profiles first, then by
+// project and name; profiles whose status cannot be read are returned as
+// errors instead.
+func fetchProfileStatuses(
+	ctx context.Context, client MinderClient, refs []profileRef, threshold alert.Severity,
+) ([]profileStatusSummary, []profileStatusError) {
+	summaries := make([]*profileStatusSummary, len(refs))
+	failures := make([]*profileStatusError, len(refs))
+	sem := make(chan struct{}, profileStatusConcurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			resp, err := client.Profiles().GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
+				Name:    ref.name,
+				All:     true,
+				Context: &minderv1.Context{Project: &ref.project},
+			})
+			if err != nil {
+				failures[i] = &profileStatusError{Project: ref.project, Profile: ref.name, Error: MapGRPCError(err)}
+				return
+			}
+			summaries[i] = summarizeProfileStatus(ref, resp, threshold)
+		})
+	}
+	wg.Wait()
+
+	result := []profileStatusSummary{}
+	var failed []profileStatusError
+	for i := range refs {
+		if summaries[i] != nil {
+			result = append(result, *summaries[i])
+		} else if failures[i] != nil {
+			failed = append(failed, *failures[i])
+		}
+	}
+	slices.SortFunc(result, func(a, b profileStatusSummary) int {
+		aFailing, bFailing := watcher.IsFailing(a.Status), watcher.IsFailing(b.Status)
+		if aFailing != bFailing {
+			if aFailing {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(cmp.Compare(a.Project, b.Project), cmp.Compare(a.Profile, b.Profile))
+	})
+	return result, failed
+}
+
+// summarizeProfileStatus counts a profile's rule evaluations meeting
+// threshold by status and lists the failing ones, most severe first.
+func summarizeProfileStatus(
+	ref profileRef, resp *minderv1.GetProfileStatusByNameResponse, threshold alert.Severity,
+) *profileStatusSummary {
+	status := resp.GetProfileStatus()
+	summary := &profileStatusSummary{
+		Project:   ref.project,
+		Profile:   ref.name,
+		ProfileID: status.GetProfileId(),
+		Labels:    ref.labels,
+		Status:    status.GetProfileStatus(),
+		Rules:     map[string]int{},
+	}
+	if status.GetLastUpdated() != nil {
+		summary.LastUpdated = status.GetLastUpdated().AsTime().Format(time.RFC3339)
+	}
+	for _, rule := range filterRuleStatuses(resp.GetRuleEvaluationStatus(), threshold) {
+		summary.Rules[rule.GetStatus()]++
+		if watcher.IsFailing(rule.GetStatus()) {
+			summary.Failing = append(summary.Failing, failingRule{
+				RuleType: rule.GetRuleTypeName(),
+				Rule:     rule.GetRuleName(),
+				Entity:   entityDisplayName(rule.GetEntityInfo()),
+				Status:   rule.GetStatus(),
+				Severity: severityName(rule.GetSeverity()),
+			})
+		}
+	}
+	slices.SortStableFunc(summary.Failing, func(a, b failingRule) int {
+		return bySeverity(a.Severity, b.Severity)
+	})
+	return summary
+}
+
+// groupByLabel summarizes profiles per label, e.g. per team:payments, for
+// team-sliced compliance views. A profile with several labels counts in each
+// of their groups.
+func groupByLabel(summaries []profileStatusSummary) map[string]*profileGroup {
+	groups := map[string]*profileGroup{}
+	for _, s := range summaries {
+		labels := s.Labels
+		if len(labels) == 0 {
+			labels = []string{unlabeledGroup}
+		}
+		for _, label := range labels {
+			g := groups[label]
+			if g == nil {
+				g = &profileGroup{ByStatus: map[string]int{}}
+				groups[label] = g
+			}
+			g.Profiles++
+			g.ByStatus[s.Status]++
+			g.FailingRules += len(s.Failing)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type allProfileStatusesResult struct {
+	Summary struct {
+		Profiles int            `json:"profiles"`
+		ByStatus map[string]int `json:"by_status"`
+	} `json:"summary"`
+	Profiles []profileStatusSummary  `json:"profiles"`
+	Groups   map[string]profileGroup `json:"groups"`
+	Errors   []profileStatusError    `json:"errors"`
+}
+
+func TestGetAllProfileStatuses(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}, {Name: "branches"}, {Name: "secrets"}, {Name: "broken"}},
+	}
+	mockClient.profiles.getStatusByNameResps = map[string]*minderv1.GetProfileStatusByNameResponse{
+		"baseline": {
+			ProfileStatus: &minderv1.ProfileStatus{ProfileId: "profile-1", ProfileStatus: "success"},
+			RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+				{RuleTypeName: "dependabot", Status: "success"},
+				{RuleTypeName: "dependabot", Status: "skipped"},
+			},
+		},
+		"branches": {ProfileStatus: &minderv1.ProfileStatus{ProfileStatus: "success"}},
+		"secrets": {
+			ProfileStatus: &minderv1.ProfileStatus{ProfileId: "profile-3", ProfileStatus: "failure"},
+			RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+				{RuleTypeName: "secret_scanning", RuleName: "secrets", Status: "failure",
+					EntityInfo: map[string]string{"repo_owner": "acme", "repo_name": "api"}},
+				{RuleTypeName: "secret_scanning", RuleName: "secrets", Status: "success"},
+			},
+		},
+	}
+	mockClient.profiles.getStatusByNameErrs = map[string]error{"broken": status.Error(codes.NotFound, "not found")}
+
+	result, err := newTestTools(mockClient).getAllProfileStatuses(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getAllProfileStatuses() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got allProfileStatusesResult
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+
+	if got.Summary.Profiles != 4 || got.Summary.ByStatus["success"] != 2 || got.Summary.ByStatus["failure"] != 1 {
+		t.Errorf("summary = %+v, want 4 profiles, 2 passing and 1 failing", got.Summary)
+	}
+	if len(got.Profiles) != 3 {
+		t.Fatalf("got %d profiles, want 3: %+v", len(got.Profiles), got.Profiles)
+	}
+	secrets := got.Profiles[0]
+	if secrets.Profile != "secrets" || secrets.Project != "proj-1" || secrets.Rules["failure"] != 1 ||
+		len(secrets.Failing) != 1 || secrets.Failing[0].Entity != "acme/api" {
+		t.Errorf("profiles[0] = %+v, want the failing secrets profile with acme/api failing", secrets)
+	}
+	if got.Profiles[1].Profile != "baseline" || got.Profiles[1].Rules["skipped"] != 1 || got.Profiles[2].Profile != "branches" {
+		t.Errorf("profiles = %+v, want passing profiles sorted by name after failing ones", got.Profiles)
+	}
+	if len(got.Errors) != 1 || got.Errors[0].Profile != "broken" || got.Errors[0].Error == "" {
+		t.Errorf("errors = %+v, want the broken profile", got.Errors)
+	}
+}
+
+func TestGetAllProfileStatuses_ListError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listErr = status.Error(codes.PermissionDenied, "denied")
+	result, err := newTestTools(mockClient).getAllProfileStatuses(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getAllProfileStatuses() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected error result, got %s", getResultText(t, result))
+	}
+}
+
+func TestGetAllProfileStatuses_GroupByLabel(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{
+			{Name: "payments-baseline", Labels: []string{"team:payments"}},
+			{Name: "shared", Labels: []string{"team:payments", "team:search"}},
+			{Name: "legacy"},
+		},
+	}
+	mockClient.profiles.getStatusByNameResps = map[string]*minderv1.GetProfileStatusByNameResponse{
+		"payments-baseline": {ProfileStatus: &minderv1.ProfileStatus{ProfileStatus: "success"}},
+		"shared": {
+			ProfileStatus: &minderv1.ProfileStatus{ProfileStatus: "failure"},
+			RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+				{RuleTypeName: "secret_scanning", Status: "failure"},
+			},
+		},
+		"legacy": {ProfileStatus: &minderv1.ProfileStatus{ProfileStatus: "success"}},
+	}
+
+	result, err := newTestTools(mockClient).getAllProfileStatuses(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"project_id": "proj-1", "label_filter": "team:*", "group_by": "label",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("getAllProfileStatuses() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if got := mockClient.profiles.listReq.GetLabelFilter(); got != "team:*" {
+		t.Errorf("ListProfiles label filter = %q, want team:*", got)
+	}
+	var got allProfileStatusesResult
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	payments, search, unlabeled := got.Groups["team:payments"], got.Groups["team:search"], got.Groups[unlabeledGroup]
+	if payments.Profiles != 2 || payments.ByStatus["failure"] != 1 || payments.FailingRules != 1 {
+		t.Errorf("team:payments = %+v, want 2 profiles with the shared one failing", payments)
+	}
+	if search.Profiles != 1 || search.FailingRules != 1 || unlabeled.Profiles != 1 || len(got.Groups) != 3 {
+		t.Errorf("groups = %+v, want team:search with shared and legacy unlabeled", got.Groups)
+	}
+}
+
+func TestGetAllProfileStatuses_InvalidGroupBy(t *testing.T) {
+	t.Parallel()
+
+	result, err := newTestTools(newMockClient()).getAllProfileStatuses(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"group_by": "project"}},
+	})
+	if err != nil {
+		t.Fatalf("getAllProfileStatuses() returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected error result, got %s", getResultText(t, result))
+	}
+}
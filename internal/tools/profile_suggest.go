@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	_ "embed" // for the profile templates
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed profile_templates.yaml
+var profileTemplatesYAML []byte
+
+// profileTemplateRule is a rule a profile template suggests.
+type profileTemplateRule struct {
+	Type string `json:"type"`
+	// PerArtifact repeats the rule for every registered artifact, with the
+	// artifact's name as the name parameter.
+	PerArtifact bool           `json:"per_artifact"`
+	Params      map[string]any `json:"params"`
+	Def         map[string]any `json:"def"`
+}
+
+// profileTemplate is a starter rule set for one entity type.
+type profileTemplate struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Entity      string                `json:"entity"`
+	Rules       []profileTemplateRule `json:"rules"`
+}
+
+// profileTemplates parses the embedded templates once.
+var profileTemplates = sync.OnceValues(func() ([]profileTemplate, error) {
+	var templates []profileTemplate
+	if err := yaml.Unmarshal(profileTemplatesYAML, &templates); err != nil {
+		return nil, fmt.Errorf("invalid profile templates: %w", err)
+	}
+	return templates, nil
+})
+
+// profileTemplateNames returns the names of the embedded templates.
+func profileTemplateNames() []string {
+	templates, _ := profileTemplates()
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	return names
+}
+
+// suggestedRule is a rule included in a suggested profile.
+type suggestedRule struct {
+	Template string `json:"template"`
+	Entity   string `json:"entity"`
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+}
+
+// suggestProfile generates a starter profile from the templates that fit the
+// entities registered in a project, keeping only rules whose rule types the
+// project defines. Nothing is created; the YAML is returned for review.
+func (t *Tools) suggestProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	templateName := req.GetString("template", "")
+	name := req.GetString("name", "")
+	if projectID == "" {
+		return mcp.NewToolResultError("project_id must be provided"), nil
+	}
+	templates, err := profileTemplates()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if templateName != "" && !slices.Contains(profileTemplateNames(), templateName) {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown template %q, want one of %s",
+			templateName, strings.Join(profileTemplateNames(), ", "))), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectCtx := &minderv1.Context{Project: &projectID}
+	ruleTypesResp, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{Context: projectCtx})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	ruleTypes := map[string]*minderv1.RuleType{}
+	for _, rt := range ruleTypesResp.GetRuleTypes() {
+		ruleTypes[rt.GetName()] = rt
+	}
+
+	registered, artifacts := t.registeredEntities(ctx, client, projectCtx)
+	chosen, note := chooseProfileTemplates(templates, templateName, registered)
+	if name == "" {
+		name = suggestedProfileName(chosen)
+	}
+
+	alert, remediate := "on", "off"
+	profile := &minderv1.Profile{
+		Version:   "v1",
+		Type:      "profile",
+		Name:      name,
+		Context:   projectCtx,
+		Alert:     &alert,
+		Remediate: &remediate,
+	}
+	templateNames := make([]string, 0, len(chosen))
+	for _, tmpl := range chosen {
+		templateNames = append(templateNames, tmpl.Name)
+	}
+	included, missing, err := addTemplateRules(profile, chosen, ruleTypes, artifacts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(included) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("project %s defines none of the rule types %s suggests (%s); "+
+			"create rule types first, e.g. from https://github.com/mindersec/minder-rules-and-profiles",
+			projectID, strings.Join(templateNames, " and "), strings.Join(missing, ", "))), nil
+	}
+
+	doc, err := protoYAML(profile)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := map[string]any{
+		"profile_yaml":       doc,
+		"templates":          templateNames,
+		"rules":              included,
+		"missing_rule_types": missing,
+		"registered_entities": map[string]int{
+			"repositories": registered["repository"],
+			"artifacts":    registered["artifact"],
+		},
+		"next_step": "Review the rule settings, save profile_yaml to a file and create it with " +
+			"\"minder profile create -f <file>\"",
+	}
+	if note != "" {
+		result["note"] = note
+	}
+	return marshalResult(ctx, result)
+}
+
+// suggestedProfileName names a profile after its only template, or
+// "baseline" when it combines several.
+func suggestedProfileName(templates []profileTemplate) string {
+	if len(templates) > 1 {
+		return "baseline"
+	}
+	return strings.ReplaceAll(templates[0].Name, "_", "-")
+}
+
+// registeredEntities counts the entities registered in a project by the
+// entity type templates target, and returns the names of its artifacts.
+// Entities that cannot be listed count as none registered.
+func (t *Tools) registeredEntities(
+	ctx context.Context, client MinderClient, projectCtx *minderv1.Context,
+) (map[string]int, []string) {
+	repos, err := client.Repositories().ListRepositories(ctx, &minderv1.ListRepositoriesRequest{Context: projectCtx})
+	if err != nil {
+		t.logger.DebugContext(ctx, "repository listing failed", "project", projectCtx.GetProject(), "error", err)
+	}
+	artifactsResp, err := client.Artifacts().ListArtifacts(ctx, &minderv1.ListArtifactsRequest{Context: projectCtx})
+	if err != nil {
+		t.logger.DebugContext(ctx, "artifact listing failed", "project", projectCtx.GetProject(), "error", err)
+	}
+	var artifacts []string
+	for _, a := range artifactsResp.GetResults() {
+		artifacts = append(artifacts, a.GetName())
+	}
+	return map[string]int{
+		"repository":   len(repos.GetResults()),
+		"pull_request": len(repos.GetResults()), // pull requests are evaluated on registered repositories
+		"artifact":     len(artifacts),
+	}, artifacts
+}
+
+// chooseProfileTemplates returns the named template, or every template for an
+// entity type with registered entities. Without any, it falls back to the
+// first template and explains why in the returned note.
+func chooseProfileTemplates(
+	templates []profileTemplate, name string, registered map[string]int,
+) ([]profileTemplate, string) {
+	var chosen []profileTemplate
+	for _, tmpl := range templates {
+		if tmpl.Name == name || (name == "" && registered[tmpl.Entity] > 0) {
+			chosen = append(chosen, tmpl)
+		}
+	}
+	if len(chosen) == 0 {
+		return templates[:1], "no repositories or artifacts are registered in this project yet; suggesting " + templates[0].Name
+	}
+	return chosen, ""
+}
+
+// addTemplateRules adds the rules of templates whose rule types are defined
+// to profile, in the section of the entity each rule type evaluates. It
+// returns the rules added and the rule types that are not defined.
+func addTemplateRules(
+	profile *minderv1.Profile, templates []profileTemplate, ruleTypes map[string]*minderv1.RuleType, artifacts []string,
+) ([]suggestedRule, []string, error) {
+	included := []suggestedRule{}
+	missing := []string{}
+	for _, tmpl := range templates {
+		for _, tr := range tmpl.Rules {
+			rt, ok := ruleTypes[tr.Type]
+			if !ok {
+				missing = append(missing, tr.Type)
+				continue
+			}
+			entity := cmp.Or(rt.GetDef().GetInEntity(), tmpl.Entity)
+			rules, err := templateRules(tr, artifacts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("template %s: %w", tmpl.Name, err)
+			}
+			for _, rule := range rules {
+				if addProfileRule(profile, entity, rule) {
+					included = append(included, suggestedRule{
+						Template: tmpl.Name, Entity: entity, Type: rule.GetType(), Name: rule.GetName(),
+					})
+				}
+			}
+		}
+	}
+	return included, missing, nil
+}
+
+// templateRules builds the profile rules for a template rule, one per
+// artifact for per-artifact rules.
+func templateRules(tr profileTemplateRule, artifacts []string) ([]*minderv1.Profile_Rule, error) {
+	build := func(name string, params map[string]any) (*minderv1.Profile_Rule, error) {
+		def, err := structpb.NewStruct(tr.Def)
+		if err != nil {
+			return nil, fmt.Errorf("invalid def for %s: %w", tr.Type, err)
+		}
+		rule := &minderv1.Profile_Rule{Type: tr.Type, Name: name, Def: def}
+		if len(params) > 0 {
+			if rule.Params, err = structpb.NewStruct(params); err != nil {
+				return nil, fmt.Errorf("invalid params for %s: %w", tr.Type, err)
+			}
+		}
+		return rule, nil
+	}
+
+	if !tr.PerArtifact || len(artifacts) == 0 {
+		rule, err := build("", tr.Params)
+		if err != nil {
+			return nil, err
+		}
+		return []*minderv1.Profile_Rule{rule}, nil
+	}
+	rules := make([]*minderv1.Profile_Rule, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		params := maps.Clone(tr.Params)
+		if params == nil {
+			params = map[string]any{}
+		}
+		params["name"] = artifact
+		rule, err := build(tr.Type+"-"+artifact, params)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// addProfileRule appends rule to the section of profile for entity. It
+// reports false for entities profiles have no section for.
+func addProfileRule(profile *minderv1.Profile, entity string, rule *minderv1.Profile_Rule) bool {
+	switch entity {
+	case "repository":
+		profile.Repository = append(profile.Repository, rule)
+	case "pull_request":
+		profile.PullRequest = append(profile.PullRequest, rule)
+	case "artifact":
+		profile.Artifact = append(profile.Artifact, rule)
+	case "build_environment":
+		profile.BuildEnvironment = append(profile.BuildEnvironment, rule)
+	case "release":
+		profile.Release = append(profile.Release, rule)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestProfileTemplates(t *testing.T) {
+	t.Parallel()
+
+	templates, err := profileTemplates()
+	if err != nil {
+		t.Fatalf("profileTemplates() error = %v", err)
+	}
+	for _, tmpl := range templates {
+		if tmpl.Name == "" || tmpl.Entity == "" || len(tmpl.Rules) == 0 {
+			t.Errorf("template %+v needs a name, an entity and rules", tmpl)
+		}
+		for _, tr := range tmpl.Rules {
+			if _, err := templateRules(tr, []string{"image"}); err != nil {
+				t.Errorf("template %s: %v", tmpl.Name, err)
+			}
+		}
+	}
+}
+
+func TestSuggestProfile(t *testing.T) {
+	t.Parallel()
+
+	ruleType := func(name, entity string) *minderv1.RuleType {
+		return &minderv1.RuleType{Name: name, Def: &minderv1.RuleType_Definition{InEntity: entity}}
+	}
+	mockClient := newMockClient()
+	mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{RuleTypes: []*minderv1.RuleType{
+		ruleType("secret_scanning", "repository"),
+		ruleType("branch_protection_allow_deletions", "repository"),
+		ruleType("artifact_signature", "artifact"),
+	}}
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{
+		Results: []*minderv1.Repository{{Owner: "acme", Name: "api"}},
+	}
+	mockClient.artifacts.listResp = &minderv1.ListArtifactsResponse{
+		Results: []*minderv1.Artifact{{Name: "api"}, {Name: "web"}},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.suggestProfile(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("suggestProfile() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		ProfileYAML string          `json:"profile_yaml"`
+		Templates   []string        `json:"templates"`
+		Rules       []suggestedRule `json:"rules"`
+		Missing     []string        `json:"missing_rule_types"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if want := []string{"repository_hygiene", "pull_request_security", "artifact_signing"}; !slices.Equal(got.Templates, want) {
+		t.Errorf("templates = %v, want %v", got.Templates, want)
+	}
+	if len(got.Rules) != 4 {
+		t.Errorf("rules = %+v, want 2 repository rules and a signature rule per artifact", got.Rules)
+	}
+	if !slices.Contains(got.Missing, "pr_vulnerability_check") || slices.Contains(got.Missing, "secret_scanning") {
+		t.Errorf("missing_rule_types = %v, want only rule types the project lacks", got.Missing)
+	}
+
+	var profile struct {
+		Version    string `json:"version"`
+		Type       string `json:"type"`
+		Name       string `json:"name"`
+		Alert      string `json:"alert"`
+		Repository []struct {
+			Type string         `json:"type"`
+			Def  map[string]any `json:"def"`
+		} `json:"repository"`
+		Artifact []struct {
+			Name   string         `json:"name"`
+			Params map[string]any `json:"params"`
+		} `json:"artifact"`
+	}
+	if err := yaml.Unmarshal([]byte(got.ProfileYAML), &profile); err != nil {
+		t.Fatalf("profile_yaml is not valid YAML: %v\n%s", err, got.ProfileYAML)
+	}
+	if profile.Version != "v1" || profile.Type != "profile" || profile.Name != "baseline" || profile.Alert != "on" {
+		t.Errorf("profile header = %+v, want a v1 profile named baseline", profile)
+	}
+	if len(profile.Repository) != 2 || profile.Repository[1].Def["allow_deletions"] != false {
+		t.Errorf("repository rules = %+v", profile.Repository)
+	}
+	if len(profile.Artifact) != 2 || profile.Artifact[1].Name != "artifact_signature-web" ||
+		profile.Artifact[1].Params["name"] != "web" {
+		t.Errorf("artifact rules = %+v, want one per artifact", profile.Artifact)
+	}
+}
+
+func TestSuggestProfile_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		ruleTypes []*minderv1.RuleType
+		wantMsg   string
+	}{
+		{
+			name:    "missing project",
+			args:    map[string]any{},
+			wantMsg: "project_id must be provided",
+		},
+		{
+			name:    "unknown template",
+			args:    map[string]any{"project_id": "proj-1", "template": "everything"},
+			wantMsg: `unknown template "everything"`,
+		},
+		{
+			name:      "no rule types defined",
+			args:      map[string]any{"project_id": "proj-1"},
+			ruleTypes: []*minderv1.RuleType{{Name: "unrelated"}},
+			wantMsg:   "defines none of the rule types repository_hygiene suggests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{RuleTypes: tt.ruleTypes}
+			tools := newTestTools(mockClient)
+			result, err := tools.suggestProfile(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("suggestProfile() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	"github.com/stacklok/minder-mcp/internal/paramschema"
+)
+
+// profileActionSettings are the values Minder accepts for a profile's alert and remediate settings.
+var profileActionSettings = []string{"on", "off", "dry_run"}
+
+// profileProblem is a part of a profile Minder would reject.
+type profileProblem struct {
+	EntityType string `json:"entity_type,omitempty"`
+	RuleType   string `json:"rule_type,omitempty"`
+	Rule       string `json:"rule,omitempty"`
+	// Index is the position of the rule in its entity type's list.
+	Index *int `json:"index,omitempty"`
+	// Field locates the value, e.g. "def.branches[0].name" or "alert".
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// parseProfile parses a profile from YAML or JSON, as written for minder profile create.
+func parseProfile(doc string) (*minderv1.Profile, error) {
+	data, err := yaml.YAMLToJSON([]byte(doc))
+	if err != nil {
+		return nil, err
+	}
+	profile := &minderv1.Profile{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// checkProfile returns the problems Minder would reject profile for: alert
+// and remediate settings it does not know, rules of rule types missing from
+// ruleTypes, keyed by name, or evaluating another entity type, and rule
+// definitions and parameters not matching their rule type's schemas.
+func checkProfile(profile *minderv1.Profile, ruleTypes map[string]*minderv1.RuleType) []profileProblem {
+	problems := []profileProblem{}
+	if profile.Alert != nil && !slices.Contains(profileActionSettings, profile.GetAlert()) {
+		problems = append(problems, profileProblem{Field: "alert", Message: "must be on, off or dry_run"})
+	}
+	if profile.Remediate != nil && !slices.Contains(profileActionSettings, profile.GetRemediate()) {
+		problems = append(problems, profileProblem{Field: "remediate", Message: "must be on, off or dry_run"})
+	}
+	for entityType, rules := range profileRules(profile) {
+		for i, rule := range rules {
+			problems = append(problems, checkProfileRule(entityType, i, rule, ruleTypes)...)
+		}
+	}
+	slices.SortStableFunc(problems, func(a, b profileProblem) int {
+		return cmp.Or(
+			cmp.Compare(a.EntityType, b.EntityType),
+			cmp.Compare(ptrValue(a.Index), ptrValue(b.Index)),
+			cmp.Compare(a.Field, b.Field),
+		)
+	})
+	return problems
+}
+
+// checkProfileRule returns the problems of the rule at index of entityType's rules.
+func checkProfileRule(
+	entityType string, index int, rule *minderv1.Profile_Rule, ruleTypes map[string]*minderv1.RuleType,
+) []profileProblem {
+	problem := func(field, message string) profileProblem {
+		return profileProblem{
+			EntityType: entityType,
+			RuleType:   rule.GetType(),
+			Rule:       rule.GetName(),
+			Index:      &index,
+			Field:      field,
+			Message:    message,
+		}
+	}
+	if rule.GetType() == "" {
+		return []profileProblem{problem("type", "is required")}
+	}
+	rt, ok := ruleTypes[rule.GetType()]
+	if !ok {
+		return []profileProblem{problem("type", "rule type "+rule.GetType()+" is not defined in the project")}
+	}
+	if in := rt.GetDef().GetInEntity(); in != "" && in != entityType {
+		return []profileProblem{problem("type", fmt.Sprintf(
+			"rule type %s evaluates %s entities; list the rule under %s", rule.GetType(), in, in))}
+	}
+
+	var problems []profileProblem
+	// Minder validates a missing def or params as an empty object
+	if schema := rt.GetDef().GetRuleSchema(); schema != nil {
+		for _, err := range paramschema.Validate(schema.AsMap(), structMap(rule.GetDef().AsMap())) {
+			problems = append(problems, problem(fieldPath("def", err.Path), err.Message))
+		}
+	}
+	if schema := rt.GetDef().GetParamSchema(); schema != nil {
+		for _, err := range paramschema.Validate(schema.AsMap(), structMap(rule.GetParams().AsMap())) {
+			problems = append(problems, problem(fieldPath("params", err.Path), err.Message))
+		}
+	}
+	return problems
+}
+
+// structMap returns m as a JSON object for validation, empty when m is nil.
+func structMap(m map[string]any) any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+// fieldPath returns the path of a value within a rule's def or params.
+func fieldPath(root, path string) string {
+	if path == "" {
+		return root
+	}
+	return root + "." + path
+}
+
+// ptrValue returns *p, or the zero value when p is nil.
+func ptrValue[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// validateProfile checks a profile against its project's rule types without
+// writing it, returning every problem with the field it is in rather than
+// the single InvalidArgument error Minder returns.
+func (t *Tools) validateProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	doc := req.GetString("profile", "")
+	projectID := req.GetString("project_id", "")
+	if doc == "" {
+		return mcp.NewToolResultError("profile must be provided"), nil
+	}
+	profile, err := parseProfile(doc)
+	if err != nil {
+		return mcp.NewToolResultError("profile is not a valid YAML or JSON profile: " + err.Error()), nil
+	}
+	if projectID == "" {
+		projectID = profile.GetContext().GetProject()
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	ruleTypes, err := forEachProject(ctx, client, projectID, func(ctx context.Context, projID string) ([]*minderv1.RuleType, error) {
+		resp, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{
+			Context: &minderv1.Context{Project: &projID},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.GetRuleTypes(), nil
+	})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	// Without a project, a rule type defined in several projects is checked
+	// against the first project's definition
+	byName := map[string]*minderv1.RuleType{}
+	for _, rt := range ruleTypes {
+		if _, ok := byName[rt.GetName()]; !ok {
+			byName[rt.GetName()] = rt
+		}
+	}
+
+	rules := 0
+	for _, entityRules := range profileRules(profile) {
+		rules += len(entityRules)
+	}
+	problems := checkProfile(profile, byName)
+	return marshalResult(ctx, map[string]any{
+		"valid":    len(problems) == 0,
+		"rules":    rules,
+		"problems": problems,
+	})
+}
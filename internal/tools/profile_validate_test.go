@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValidateProfile(t *testing.T) {
+	t.Parallel()
+
+	ruleSchema, err := structpb.NewStruct(map[string]any{
+		"type":     "object",
+		"required": []any{"branch"},
+		"properties": map[string]any{
+			"branch": map[string]any{"type": "string"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("invalid rule schema: %v", err)
+	}
+	paramSchema, err := structpb.NewStruct(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"name": map[string]any{"type": "string"}},
+	})
+	if err != nil {
+		t.Fatalf("invalid param schema: %v", err)
+	}
+	mockClient := newMockClient()
+	mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{
+		RuleTypes: []*minderv1.RuleType{
+			{Name: "branch_protection", Def: &minderv1.RuleType_Definition{InEntity: "repository", RuleSchema: ruleSchema}},
+			{Name: "artifact_signature", Def: &minderv1.RuleType_Definition{InEntity: "artifact", ParamSchema: paramSchema}},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	tests := []struct {
+		name    string
+		profile string
+		want    []profileProblem
+	}{
+		{
+			name: "valid",
+			profile: `
+name: baseline
+repository:
+  - type: branch_protection
+    def:
+      branch: main
+artifact:
+  - type: artifact_signature
+    params:
+      name: api
+`,
+			want: []profileProblem{},
+		},
+		{
+			name: "field-level problems",
+			profile: `
+name: baseline
+alert: loud
+repository:
+  - type: branch_protection
+    name: main-branch
+    def:
+      branch: 5
+  - type: branch_protection
+  - type: artifact_signature
+  - type: secret_scanning
+artifact:
+  - type: artifact_signature
+    params:
+      name: [api]
+`,
+			want: []profileProblem{
+				{Field: "alert", Message: "must be on, off or dry_run"},
+				{
+					EntityType: "artifact", RuleType: "artifact_signature", Index: ptr(0),
+					Field: "params.name", Message: "must be string, got array",
+				},
+				{
+					EntityType: "repository", RuleType: "branch_protection", Rule: "main-branch", Index: ptr(0),
+					Field: "def.branch", Message: "must be string, got integer",
+				},
+				{
+					EntityType: "repository", RuleType: "branch_protection", Index: ptr(1),
+					Field: "def.branch", Message: "is required",
+				},
+				{
+					EntityType: "repository", RuleType: "artifact_signature", Index: ptr(2),
+					Field: "type", Message: "rule type artifact_signature evaluates artifact entities; list the rule under artifact",
+				},
+				{
+					EntityType: "repository", RuleType: "secret_scanning", Index: ptr(3),
+					Field: "type", Message: "rule type secret_scanning is not defined in the project",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.validateProfile(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"profile": tt.profile, "project_id": "proj-1"}},
+			})
+			if err != nil {
+				t.Fatalf("validateProfile() returned Go error: %v", err)
+			}
+			text := getResultText(t, result)
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", text)
+			}
+			var got struct {
+				Valid    bool             `json:"valid"`
+				Problems []profileProblem `json:"problems"`
+			}
+			if err := json.Unmarshal([]byte(text), &got); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if got.Valid != (len(tt.want) == 0) {
+				t.Errorf("valid = %v with problems %+v", got.Valid, got.Problems)
+			}
+			gotJSON, _ := json.Marshal(got.Problems)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("problems = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestValidateProfile_InvalidDocument(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	for _, doc := range []string{"", "name: [unclosed", "repository: 5"} {
+		result, err := tools.validateProfile(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"profile": doc}},
+		})
+		if err != nil {
+			t.Fatalf("validateProfile(%q) returned Go error: %v", doc, err)
+		}
+		if !result.IsError {
+			t.Errorf("validateProfile(%q) = %s, want an error result", doc, getResultText(t, result))
+		}
+	}
+}
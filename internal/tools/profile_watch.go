@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// profileWatchPollInterval is how often Minder is asked for the profile status while watching.
+	profileWatchPollInterval = 5 * time.Second
+	// defaultProfileWatchWait and maxProfileWatchWait bound how long one call waits.
+	defaultProfileWatchWait = 60 * time.Second
+	maxProfileWatchWait     = 300 * time.Second
+)
+
+// profileStatusFetch returns the current status of the watched profile.
+type profileStatusFetch func(ctx context.Context) (profileStatusResponse, error)
+
+// ruleState is the outcome of one rule evaluation at one point in time.
+type ruleState struct {
+	Status      string `json:"status"`
+	Remediation string `json:"remediation_status,omitempty"`
+}
+
+// ruleStatusChange is a rule evaluation whose outcome changed while watching.
+// Before is nil for evaluations that appeared and After for those that went away.
+type ruleStatusChange struct {
+	RuleType string     `json:"rule_type"`
+	RuleName string     `json:"rule_name,omitempty"`
+	Entity   string     `json:"entity"`
+	EntityID string     `json:"entity_id,omitempty"`
+	Before   *ruleState `json:"before,omitempty"`
+	After    *ruleState `json:"after,omitempty"`
+}
+
+// watchProfileStatus polls a profile's status until it changes or the wait
+// runs out, so an agent can wait for a remediation or a new evaluation to
+// take effect within a single call.
+func (t *Tools) watchProfileStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profileID := req.GetString("profile_id", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	wait := time.Duration(req.GetInt("timeout_seconds", 0)) * time.Second
+	if errMsg := ValidateLookupParams(profileID, name, "profile_id", "name", map[string]string{
+		"project_id": projectID,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+	if wait <= 0 {
+		wait = defaultProfileWatchWait
+	}
+	wait = min(wait, maxProfileWatchWait)
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	initial, err := firstProfileStatus(ctx, client, profileID, name, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+	// Later polls go by ID so a name lookup does not scan projects again
+	fetch := func(ctx context.Context) (profileStatusResponse, error) {
+		return client.Profiles().GetProfileStatusById(ctx, &minderv1.GetProfileStatusByIdRequest{
+			Id:  initial.GetProfileStatus().GetProfileId(),
+			All: true,
+		})
+	}
+	progress := func(elapsed time.Duration) {
+		notifyProgress(ctx, req, elapsed.Seconds(), wait.Seconds(),
+			fmt.Sprintf("no change after %s", elapsed.Round(time.Second)))
+	}
+
+	start := time.Now()
+	curr, changes, err := pollProfileStatus(ctx, fetch, initial, profileWatchPollInterval, wait, progress)
+	if err != nil {
+		if ctx.Err() != nil {
+			return mcp.NewToolResultError("stopped waiting for the profile status: " + ctx.Err().Error()), nil
+		}
+		return grpcErrorResult(err), nil
+	}
+	return marshalResult(ctx, profileWatchResult(initial, curr, changes, wait, time.Since(start)))
+}
+
+// firstProfileStatus looks up the watched profile's status by ID, or by name
+// in projectID or across the accessible projects.
+func firstProfileStatus(
+	ctx context.Context, client MinderClient, profileID, name, projectID string,
+) (profileStatusResponse, error) {
+	if profileID != "" {
+		return client.Profiles().GetProfileStatusById(ctx, &minderv1.GetProfileStatusByIdRequest{
+			Id:  profileID,
+			All: true,
+		})
+	}
+	return findOneInProjects(
+		ctx, client, projectID, "profile "+name,
+		func(ctx context.Context, projID string) (*minderv1.GetProfileStatusByNameResponse, error) {
+			return client.Profiles().GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
+				Name:    name,
+				All:     true,
+				Context: &minderv1.Context{Project: &projID},
+			})
+		})
+}
+
+// pollProfileStatus calls fetch every interval until the status differs from
+// initial or wait runs out. It returns the last status fetched and its changes,
+// which are empty when the wait ran out. progress is called after every poll
+// that found no change.
+func pollProfileStatus(
+	ctx context.Context, fetch profileStatusFetch, initial profileStatusResponse,
+	interval, wait time.Duration, progress func(elapsed time.Duration),
+) (profileStatusResponse, []ruleStatusChange, error) {
+	start := time.Now()
+	deadline := start.Add(wait)
+	curr := initial
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return curr, nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
+
+		next, err := fetch(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		curr = next
+		changes := profileStatusChanges(initial, curr)
+		if len(changes) > 0 || initial.GetProfileStatus().GetProfileStatus() != curr.GetProfileStatus().GetProfileStatus() {
+			return curr, changes, nil
+		}
+		progress(time.Since(start))
+	}
+}
+
+// profileStatusChanges lists the rule evaluations whose status or
+// remediation status differ between prev and curr, sorted by rule type, rule
+// name and entity. Evaluations are matched by rule and entity ID.
+func profileStatusChanges(prev, curr profileStatusResponse) []ruleStatusChange {
+	type evalKey struct{ ruleType, ruleName, entity, entityID string }
+	keyOf := func(rule *minderv1.RuleEvaluationStatus) evalKey {
+		return evalKey{rule.GetRuleTypeName(), rule.GetRuleName(), rule.GetEntity(), rule.GetEntityInfo()["entity_id"]}
+	}
+	stateOf := func(rule *minderv1.RuleEvaluationStatus) *ruleState {
+		return &ruleState{Status: rule.GetStatus(), Remediation: rule.GetRemediationStatus()}
+	}
+
+	changes := map[evalKey]*ruleStatusChange{}
+	for _, rule := range prev.GetRuleEvaluationStatus() {
+		changes[keyOf(rule)] = &ruleStatusChange{
+			RuleType: rule.GetRuleTypeName(),
+			RuleName: rule.GetRuleName(),
+			Entity:   entityDisplayName(rule.GetEntityInfo()),
+			EntityID: rule.GetEntityInfo()["entity_id"],
+			Before:   stateOf(rule),
+		}
+	}
+	for _, rule := range curr.GetRuleEvaluationStatus() {
+		change, ok := changes[keyOf(rule)]
+		if !ok {
+			change = &ruleStatusChange{
+				RuleType: rule.GetRuleTypeName(),
+				RuleName: rule.GetRuleName(),
+				Entity:   entityDisplayName(rule.GetEntityInfo()),
+				EntityID: rule.GetEntityInfo()["entity_id"],
+			}
+			changes[keyOf(rule)] = change
+		}
+		change.After = stateOf(rule)
+	}
+
+	var result []ruleStatusChange
+	for _, change := range changes {
+		if change.Before == nil || change.After == nil || *change.Before != *change.After {
+			result = append(result, *change)
+		}
+	}
+	slices.SortFunc(result, func(a, b ruleStatusChange) int {
+		return cmp.Or(cmp.Compare(a.RuleType, b.RuleType), cmp.Compare(a.RuleName, b.RuleName),
+			cmp.Compare(a.Entity, b.Entity), cmp.Compare(a.EntityID, b.EntityID))
+	})
+	return result
+}
+
+// profileWatchResult reports the outcome of a watch: the changes found, or
+// that nothing changed within the wait.
+func profileWatchResult(
+	initial, curr profileStatusResponse, changes []ruleStatusChange, wait, waited time.Duration,
+) map[string]any {
+	status := curr.GetProfileStatus()
+	result := map[string]any{
+		"profile":    status.GetProfileName(),
+		"profile_id": status.GetProfileId(),
+		"waited":     waited.Round(time.Second).String(),
+	}
+	before := initial.GetProfileStatus().GetProfileStatus()
+	if len(changes) == 0 && before == status.GetProfileStatus() {
+		result["status"] = "unchanged"
+		result["profile_status"] = before
+		result["message"] = fmt.Sprintf("The profile status did not change within %s. Minder may not have "+
+			"re-evaluated the profile yet; call this tool again to keep waiting.", wait.Round(time.Second))
+		return result
+	}
+	result["status"] = "changed"
+	result["profile_status"] = map[string]string{"before": before, "after": status.GetProfileStatus()}
+	result["changes"] = append([]ruleStatusChange{}, changes...)
+	return result
+}
+
+// notifyProgress sends a progress notification for req to the client that
+// made it. It is a no-op when the client did not ask for progress or the
+// call is not running in an initialized session; delivery failures are ignored.
+func notifyProgress(ctx context.Context, req mcp.CallToolRequest, progress, total float64, message string) {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+	_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": req.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	})
+}
@@ -0,0 +1,259 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func watchedStatus(overall string, rules ...*minderv1.RuleEvaluationStatus) *minderv1.GetProfileStatusByIdResponse {
+	return &minderv1.GetProfileStatusByIdResponse{
+		ProfileStatus:        &minderv1.ProfileStatus{ProfileId: "profile-1", ProfileName: "baseline", ProfileStatus: overall},
+		RuleEvaluationStatus: rules,
+	}
+}
+
+func watchedRule(ruleType, entityID, status, remediation string) *minderv1.RuleEvaluationStatus {
+	return &minderv1.RuleEvaluationStatus{
+		RuleTypeName:      ruleType,
+		RuleName:          ruleType,
+		Entity:            "repository",
+		EntityInfo:        map[string]string{"entity_id": entityID, "repo_owner": "acme", "repo_name": entityID},
+		Status:            status,
+		RemediationStatus: remediation,
+	}
+}
+
+func TestProfileStatusChanges(t *testing.T) {
+	t.Parallel()
+
+	prev := watchedStatus("failure",
+		watchedRule("secret_scanning", "api", "failure", "pending"),
+		watchedRule("branch_protection", "api", "success", ""),
+		watchedRule("branch_protection", "web", "failure", ""),
+	)
+	curr := watchedStatus("failure",
+		watchedRule("secret_scanning", "api", "success", "success"),
+		watchedRule("branch_protection", "api", "success", ""),
+		watchedRule("dependabot", "api", "failure", ""),
+	)
+
+	changes := profileStatusChanges(prev, curr)
+	if len(changes) != 3 {
+		t.Fatalf("got %d changes, want 3: %+v", len(changes), changes)
+	}
+	removed, added, fixed := changes[0], changes[1], changes[2]
+	if removed.RuleType != "branch_protection" || removed.Entity != "acme/web" || removed.After != nil {
+		t.Errorf("changes[0] = %+v, want the removed branch_protection evaluation of acme/web", removed)
+	}
+	if added.RuleType != "dependabot" || added.Before != nil || added.After.Status != "failure" {
+		t.Errorf("changes[1] = %+v, want the new dependabot evaluation", added)
+	}
+	if fixed.RuleType != "secret_scanning" || fixed.Before.Status != "failure" ||
+		fixed.After.Status != "success" || fixed.After.Remediation != "success" {
+		t.Errorf("changes[2] = %+v, want secret_scanning fixed by remediation", fixed)
+	}
+
+	if changes := profileStatusChanges(prev, prev); len(changes) != 0 {
+		t.Errorf("profileStatusChanges() of an unchanged status = %+v, want none", changes)
+	}
+}
+
+func TestPollProfileStatus(t *testing.T) {
+	t.Parallel()
+
+	initial := watchedStatus("failure", watchedRule("secret_scanning", "api", "failure", "pending"))
+	tests := []struct {
+		name        string
+		polls       []profileStatusResponse
+		fetchErr    error
+		wantChanges int
+		wantStatus  string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name: "rule change",
+			polls: []profileStatusResponse{
+				initial,
+				watchedStatus("failure", watchedRule("secret_scanning", "api", "failure", "success")),
+			},
+			wantChanges: 1,
+			wantStatus:  "failure",
+			wantChanged: true,
+		},
+		{
+			name:        "overall status change",
+			polls:       []profileStatusResponse{watchedStatus("success", initial.RuleEvaluationStatus...)},
+			wantStatus:  "success",
+			wantChanged: true,
+		},
+		{
+			name:       "no change",
+			polls:      []profileStatusResponse{initial},
+			wantStatus: "failure",
+		},
+		{
+			name:     "fetch error",
+			fetchErr: errors.New("unavailable"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			fetch := func(context.Context) (profileStatusResponse, error) {
+				if tt.fetchErr != nil {
+					return nil, tt.fetchErr
+				}
+				resp := tt.polls[min(calls, len(tt.polls)-1)]
+				calls++
+				return resp, nil
+			}
+			progressed := 0
+			curr, changes, err := pollProfileStatus(context.Background(), fetch, initial,
+				10*time.Millisecond, 100*time.Millisecond, func(time.Duration) { progressed++ })
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pollProfileStatus() error = %v", err)
+			}
+			if len(changes) != tt.wantChanges || curr.GetProfileStatus().GetProfileStatus() != tt.wantStatus {
+				t.Errorf("got status %q with changes %+v, want %q with %d changes",
+					curr.GetProfileStatus().GetProfileStatus(), changes, tt.wantStatus, tt.wantChanges)
+			}
+			// Every poll but the one that found a change reports progress
+			wantProgressed := calls
+			if tt.wantChanged {
+				wantProgressed--
+			}
+			if progressed != wantProgressed {
+				t.Errorf("progress called %d times for %d polls, want %d", progressed, calls, wantProgressed)
+			}
+		})
+	}
+}
+
+func TestPollProfileStatus_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	initial := watchedStatus("failure")
+	fetch := func(context.Context) (profileStatusResponse, error) { return initial, nil }
+	if _, _, err := pollProfileStatus(ctx, fetch, initial, time.Second, time.Minute, func(time.Duration) {}); err == nil {
+		t.Error("expected an error for a canceled context")
+	}
+}
+
+func TestWatchProfileStatus_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileId: "profile-1", ProfileName: "baseline", ProfileStatus: "failure"},
+	}
+	mockClient.profiles.getStatusByIDResp = watchedStatus("failure")
+	result, err := newTestTools(mockClient).watchProfileStatus(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"name": "baseline", "project_id": "proj-1", "timeout_seconds": 1}},
+	})
+	if err != nil {
+		t.Fatalf("watchProfileStatus() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got["status"] != "unchanged" || got["profile_status"] != "failure" || got["message"] == nil {
+		t.Errorf("result = %v, want unchanged with a message", got)
+	}
+	if mockClient.profiles.getStatusByIDReq.GetId() != "profile-1" {
+		t.Errorf("polled profile %q, want profile-1 found by name", mockClient.profiles.getStatusByIDReq.GetId())
+	}
+}
+
+func TestWatchProfileStatus_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantMsg string
+	}{
+		{name: "no lookup", args: map[string]any{}, wantMsg: "profile_id or name"},
+		{name: "project with ID", args: map[string]any{"profile_id": "profile-1", "project_id": "proj-1"}, wantMsg: "project_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := newTestTools(newMockClient()).watchProfileStatus(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("watchProfileStatus() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("expected error result, got %s", getResultText(t, result))
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// progressSession is an initialized MCP session that keeps the notifications sent to it.
+type progressSession struct {
+	fakeSession
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *progressSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestNotifyProgress(t *testing.T) {
+	t.Parallel()
+
+	session := &progressSession{fakeSession: fakeSession{id: "session-1"}, notifications: make(chan mcp.JSONRPCNotification, 2)}
+	s := server.NewMCPServer("test", "0.0.0")
+	s.AddTool(mcp.NewTool("progress"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		notifyProgress(ctx, req, 5, 60, "waiting")
+		// Without a server in the context nothing is sent
+		notifyProgress(context.Background(), req, 10, 60, "waiting")
+		return mcp.NewToolResultText("ok"), nil
+	})
+	ctx := s.WithContext(context.Background(), session)
+
+	// Without a progress token nothing is sent
+	s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"progress"}}`))
+	if len(session.notifications) != 0 {
+		t.Fatalf("%d notifications were sent without a progress token", len(session.notifications))
+	}
+
+	s.HandleMessage(ctx, json.RawMessage(
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"progress","_meta":{"progressToken":"token-1"}}}`))
+	if len(session.notifications) != 1 {
+		t.Fatalf("%d notifications were sent, want 1", len(session.notifications))
+	}
+	n := <-session.notifications
+	if n.Method != "notifications/progress" || n.Params.AdditionalFields["progressToken"] != "token-1" ||
+		n.Params.AdditionalFields["progress"] != 5.0 || n.Params.AdditionalFields["total"] != 60.0 {
+		t.Errorf("notification = %+v, want progress 5 of 60 for token-1", n)
+	}
+}
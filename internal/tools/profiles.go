@@ -2,15 +2,56 @@ package tools
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
 
+// profileSummary is a profile without its rule configuration.
+type profileSummary struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	ProjectID string   `json:"project_id,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Rules     int      `json:"rules"`
+	// RulesByEntity counts the rules per entity type they apply to.
+	RulesByEntity map[string]int `json:"rules_by_entity,omitempty"`
+	// Alert and Remediate are the profile settings, with Minder's defaults
+	// for settings left unset.
+	Alert     string `json:"alert"`
+	Remediate string `json:"remediate"`
+}
+
+// summarizeProfile returns the summary of p.
+func summarizeProfile(p *minderv1.Profile) profileSummary {
+	summary := profileSummary{
+		ID:            p.GetId(),
+		Name:          p.GetName(),
+		ProjectID:     p.GetContext().GetProject(),
+		Labels:        p.GetLabels(),
+		RulesByEntity: map[string]int{},
+		Alert:         settingOrDefault(p.Alert, defaultProfileAlert),
+		Remediate:     settingOrDefault(p.Remediate, defaultProfileRemediate),
+	}
+	for entityType, rules := range profileRules(p) {
+		if len(rules) > 0 {
+			summary.Rules += len(rules)
+			summary.RulesByEntity[entityType] = len(rules)
+		}
+	}
+	return summary
+}
+
+// profileKey returns the project and name of a profile, to sort profiles with sortByProject.
+func profileKey(p *minderv1.Profile) (string, string) {
+	return p.GetContext().GetProject(), p.GetName()
+}
+
 func (t *Tools) listProfiles(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -38,10 +79,19 @@ func (t *Tools) listProfiles(ctx context.Context, req mcp.CallToolRequest) (*mcp
 		return resp.Profiles, nil
 	})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(profiles)
+	sortByProject(profiles, profileKey)
+	profiles, total := capResults(profiles, t.cfg.MCP.MaxResults)
+	if req.GetBool("summary", false) {
+		summaries := make([]profileSummary, len(profiles))
+		for i, p := range profiles {
+			summaries[i] = summarizeProfile(p)
+		}
+		return t.marshalCapped(ctx, summaries, len(profiles), total)
+	}
+	return t.marshalCapped(ctx, profiles, len(profiles), total)
 }
 
 func (t *Tools) getProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -58,7 +108,7 @@ func (t *Tools) getProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -74,35 +124,38 @@ func (t *Tools) getProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 			Id: profileID,
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 		profile = resp.Profile
 	} else {
 		// Lookup by name - search across projects if none specified
-		profile, err = findInProjects(ctx, client, projectID, func(ctx context.Context, projID string) (*minderv1.Profile, error) {
-			resp, err := client.Profiles().GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{
-				Name: name,
-				Context: &minderv1.Context{
-					Project: &projID,
-				},
+		profile, err = findOneInProjects(
+			ctx, client, projectID, "profile "+name,
+			func(ctx context.Context, projID string) (*minderv1.Profile, error) {
+				resp, err := client.Profiles().GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{
+					Name: name,
+					Context: &minderv1.Context{
+						Project: &projID,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return resp.Profile, nil
 			})
-			if err != nil {
-				return nil, err
-			}
-			return resp.Profile, nil
-		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 	}
 
-	return marshalResult(profile)
+	return marshalResult(ctx, profile)
 }
 
 func (t *Tools) getProfileStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	profileID := req.GetString("profile_id", "")
 	name := req.GetString("name", "")
 	projectID := req.GetString("project_id", "")
+	format := req.GetString("format", formatJSON)
 
 	// Validate parameters
 	if errMsg := ValidateLookupParams(profileID, name, "profile_id", "name", map[string]string{
@@ -110,10 +163,18 @@ func (t *Tools) getProfileStatus(ctx context.Context, req mcp.CallToolRequest) (
 	}); errMsg != "" {
 		return mcp.NewToolResultError(errMsg), nil
 	}
+	if format != formatJSON && format != formatGitHubAnnotations {
+		return mcp.NewToolResultError(fmt.Sprintf("format must be %s or %s, got %q",
+			formatJSON, formatGitHubAnnotations, format)), nil
+	}
+	threshold, errMsg := minSeverityParam(req)
+	if errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -128,14 +189,15 @@ func (t *Tools) getProfileStatus(ctx context.Context, req mcp.CallToolRequest) (
 			All: true, // Always request detailed per-rule evaluation results
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
-		return marshalResult(resp)
+		resp.RuleEvaluationStatus = filterRuleStatuses(resp.RuleEvaluationStatus, threshold)
+		return profileStatusResult(ctx, resp, format)
 	}
 
 	// Lookup by name - search across projects if none specified
-	resp, err := findInProjects(
-		ctx, client, projectID,
+	resp, err := findOneInProjects(
+		ctx, client, projectID, "profile "+name,
 		func(ctx context.Context, projID string) (*minderv1.GetProfileStatusByNameResponse, error) {
 			return client.Profiles().GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
 				Name: name,
@@ -146,8 +208,9 @@ func (t *Tools) getProfileStatus(ctx context.Context, req mcp.CallToolRequest) (
 			})
 		})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(resp)
+	resp.RuleEvaluationStatus = filterRuleStatuses(resp.RuleEvaluationStatus, threshold)
+	return profileStatusResult(ctx, resp, format)
 }
@@ -2,6 +2,8 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -99,6 +101,58 @@ func TestListProfiles(t *testing.T) {
 	}
 }
 
+func TestListProfiles_Summary(t *testing.T) {
+	t.Parallel()
+
+	on := "on"
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{
+			Name:      "baseline",
+			Id:        ptr("prof-123"),
+			Labels:    []string{"team:payments"},
+			Remediate: &on,
+			Repository: []*minderv1.Profile_Rule{
+				{Type: "secret_scanning", Name: "scan"},
+				{Type: "branch_protection"},
+			},
+			Artifact: []*minderv1.Profile_Rule{{Type: "artifact_signature"}},
+		}},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.listProfiles(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "summary": true}},
+	})
+	if err != nil {
+		t.Fatalf("listProfiles() returned Go error: %v", err)
+	}
+	text := getResultText(t, result)
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if strings.Contains(text, "secret_scanning") {
+		t.Errorf("summary %s contains rule configuration", text)
+	}
+
+	var got []profileSummary
+	if err := json.Unmarshal([]byte(text), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	want := profileSummary{
+		ID:            "prof-123",
+		Name:          "baseline",
+		Labels:        []string{"team:payments"},
+		Rules:         3,
+		RulesByEntity: map[string]int{"repository": 2, "artifact": 1},
+		Alert:         "on",
+		Remediate:     "on",
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("summaries = %+v, want [%+v]", got, want)
+	}
+}
+
 func TestGetProfile(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// projectsURI is the resource holding the accessible project hierarchy.
+	projectsURI = "minder://projects"
+	// maxProjectTreeNodes bounds the projects read into the tree.
+	maxProjectTreeNodes = 500
+)
+
+// projectNode is a project and the projects nested under it.
+type projectNode struct {
+	ProjectID   string         `json:"project_id"`
+	Name        string         `json:"name"`
+	DisplayName string         `json:"display_name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Children    []*projectNode `json:"children,omitempty"`
+}
+
+// buildProjectTree returns the accessible projects as trees, and whether
+// maxProjectTreeNodes cut the tree short. Projects that are also the child of
+// another accessible project appear only under their parent. Projects whose
+// children cannot be listed are kept as leaves.
+func buildProjectTree(ctx context.Context, client MinderClient) ([]*projectNode, bool, error) {
+	resp, err := client.Projects().ListProjects(ctx, &minderv1.ListProjectsRequest{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	nodes := make(map[string]*projectNode)
+	children := make(map[string][]string)
+	var order []string
+	truncated := false
+	queue := slices.Clone(resp.GetProjects())
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if nodes[p.GetProjectId()] != nil {
+			continue
+		}
+		if len(nodes) >= maxProjectTreeNodes {
+			truncated = true
+			break
+		}
+		nodes[p.GetProjectId()] = &projectNode{
+			ProjectID:   p.GetProjectId(),
+			Name:        p.GetName(),
+			DisplayName: p.GetDisplayName(),
+			Description: p.GetDescription(),
+		}
+		order = append(order, p.GetProjectId())
+
+		childResp, err := client.Projects().ListChildProjects(ctx, &minderv1.ListChildProjectsRequest{
+			Context: &minderv1.ContextV2{ProjectId: p.GetProjectId()},
+		})
+		if err != nil {
+			continue
+		}
+		for _, c := range childResp.GetProjects() {
+			children[p.GetProjectId()] = append(children[p.GetProjectId()], c.GetProjectId())
+			queue = append(queue, c)
+		}
+	}
+
+	nested := make(map[string]bool)
+	for _, id := range order {
+		for _, childID := range children[id] {
+			if child := nodes[childID]; child != nil && childID != id && !nested[childID] {
+				nodes[id].Children = append(nodes[id].Children, child)
+				nested[childID] = true
+			}
+		}
+	}
+	roots := make([]*projectNode, 0, len(order))
+	for _, id := range order {
+		if !nested[id] {
+			roots = append(roots, nodes[id])
+		}
+	}
+	return roots, truncated, nil
+}
+
+// countProjects returns the number of projects in the trees.
+func countProjects(nodes []*projectNode) int {
+	n := len(nodes)
+	for _, node := range nodes {
+		n += countProjects(node.Children)
+	}
+	return n
+}
+
+// registerProjectsResource lets agents load the project hierarchy once as
+// context instead of calling minder_list_projects repeatedly.
+func (t *Tools) registerProjectsResource(s *server.MCPServer) {
+	s.AddResource(
+		mcp.NewResource(projectsURI, "Projects",
+			mcp.WithResourceDescription("Hierarchy of the Minder projects the token can access, "+
+				"with the IDs other tools take as project_id"),
+			mcp.WithMIMEType("application/json"),
+		),
+		t.readProjects,
+	)
+}
+
+// readProjects serves the accessible project hierarchy.
+func (t *Tools) readProjects(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	client, err := t.resourceClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	roots, truncated, err := buildProjectTree(ctx, client)
+	if err != nil {
+		return nil, resourceError(err)
+	}
+	result := map[string]any{
+		"count":    countProjects(roots),
+		"projects": roots,
+	}
+	if truncated {
+		result["note"] = fmt.Sprintf("only the first %d projects were read; use minder_list_projects with project_id "+
+			"to list the children of a project", maxProjectTreeNodes)
+	}
+	data, err := marshalJSON(withCompactJSON(ctx, t.cfg.MCP.CompactJSON), result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", req.Params.URI, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: projectsURI, MIMEType: "application/json", Text: string(data)},
+	}, nil
+}
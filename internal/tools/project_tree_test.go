@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProjectTree(t *testing.T) {
+	t.Parallel()
+
+	project := func(id string) *minderv1.Project { return &minderv1.Project{ProjectId: id, Name: id + "-name"} }
+	children := func(ids ...string) *minderv1.ListChildProjectsResponse {
+		resp := &minderv1.ListChildProjectsResponse{}
+		for _, id := range ids {
+			resp.Projects = append(resp.Projects, project(id))
+		}
+		return resp
+	}
+
+	mockClient := newMockClient()
+	// The child "team" is listed before its parent and must end up nested.
+	mockClient.projects.listResp = &minderv1.ListProjectsResponse{Projects: []*minderv1.Project{
+		project("team"), project("org"), project("other"),
+	}}
+	mockClient.projects.childResps = map[string]*minderv1.ListChildProjectsResponse{
+		"org":  children("team", "infra"),
+		"team": children("team-a"),
+	}
+
+	roots, truncated, err := buildProjectTree(t.Context(), mockClient)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	require.Len(t, roots, 2)
+	assert.Equal(t, "org", roots[0].ProjectID)
+	assert.Equal(t, "other", roots[1].ProjectID)
+	require.Len(t, roots[0].Children, 2)
+	assert.Equal(t, "team", roots[0].Children[0].ProjectID)
+	assert.Equal(t, "infra", roots[0].Children[1].ProjectID)
+	require.Len(t, roots[0].Children[0].Children, 1)
+	assert.Equal(t, "team-a", roots[0].Children[0].Children[0].ProjectID)
+	assert.Equal(t, 5, countProjects(roots))
+}
+
+func TestReadProjects(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.projects.childResps = map[string]*minderv1.ListChildProjectsResponse{}
+	tools := newTestTools(mockClient)
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, false))
+	tools.Register(s)
+
+	result, rpcErr := readResource(t, s, projectsURI)
+	require.Nil(t, rpcErr)
+	require.Len(t, result.Contents, 1)
+	text, ok := result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "application/json", text.MIMEType)
+
+	var body struct {
+		Count    int            `json:"count"`
+		Projects []*projectNode `json:"projects"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &body))
+	assert.Equal(t, 1, body.Count)
+	require.Len(t, body.Projects, 1)
+	assert.NotEmpty(t, body.Projects[0].ProjectID)
+}
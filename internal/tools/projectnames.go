@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// withProjectNames lets a call name its project in project_id instead of
+// giving its UUID, which users rarely know. A name is replaced by the ID of
+// the accessible project of that name before handler runs. UUIDs are passed
+// through without asking Minder, and values matching no project are passed
+// through for Minder to reject.
+func (t *Tools) withProjectNames(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		value := req.GetString("project_id", "")
+		if value == "" {
+			return handler(ctx, req)
+		}
+		if _, err := uuid.Parse(value); err == nil {
+			return handler(ctx, req)
+		}
+
+		// When projects cannot be listed, the handler reports the failure
+		client, err := t.getClient(ctx)
+		if err != nil {
+			return handler(ctx, req)
+		}
+		projects, err := listAllProjects(ctx, client)
+		_ = client.Close()
+		if err != nil {
+			return handler(ctx, req)
+		}
+		projectID, errResult := matchProject(projects, value)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		args := maps.Clone(req.GetArguments())
+		args["project_id"] = projectID
+		req.Params.Arguments = args
+		return handler(ctx, req)
+	}
+}
+
+// matchProject returns the ID of the project whose ID or, failing that,
+// name is value, value itself when no project matches, or an error result
+// when several projects have that name.
+func matchProject(projects []*minderv1.Project, value string) (string, *mcp.CallToolResult) {
+	var ids []string
+	for _, p := range projects {
+		if p.GetProjectId() == value {
+			return value, nil
+		}
+		if p.GetName() == value {
+			ids = append(ids, p.GetProjectId())
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return value, nil
+	case 1:
+		return ids[0], nil
+	default:
+		return "", errorResult(fmt.Sprintf("project name %s is ambiguous: it matches projects %s",
+			value, strings.Join(ids, ", ")), ErrorDetail{
+			Code:            ErrCodeInvalidArgument,
+			SuggestedAction: "Pass the UUID of one of the matching projects as project_id.",
+		})
+	}
+}
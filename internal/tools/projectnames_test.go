@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	prodProjectID    = "6c4bd1b1-3b3c-4a43-9f0e-1d2a6f0d7e01"
+	stagingProjectID = "0f3e2d1c-4b5a-4c6d-8e7f-9a0b1c2d3e4f"
+	sandboxProjectID = "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+)
+
+func TestWithProjectNames(t *testing.T) {
+	t.Parallel()
+
+	projects := &minderv1.ListProjectsResponse{
+		Projects: []*minderv1.Project{
+			{ProjectId: prodProjectID, Name: "prod"},
+			{ProjectId: stagingProjectID, Name: "sandbox"},
+			{ProjectId: sandboxProjectID, Name: "sandbox"},
+			{ProjectId: "legacy-id", Name: "legacy"},
+		},
+	}
+	tests := []struct {
+		name         string
+		projectID    any
+		listErr      error
+		wantProject  any
+		wantContains string
+	}{
+		{name: "no project", projectID: nil, wantProject: nil},
+		{name: "UUID", projectID: stagingProjectID, wantProject: stagingProjectID},
+		{name: "name", projectID: "prod", wantProject: prodProjectID},
+		{name: "non-UUID ID", projectID: "legacy-id", wantProject: "legacy-id"},
+		{name: "unknown name passed through", projectID: "unknown", wantProject: "unknown"},
+		{
+			name:         "ambiguous name",
+			projectID:    "sandbox",
+			wantContains: "project name sandbox is ambiguous: it matches projects " + stagingProjectID + ", " + sandboxProjectID,
+		},
+		{
+			name:        "projects cannot be listed",
+			projectID:   "prod",
+			listErr:     status.Error(codes.Unavailable, "down"),
+			wantProject: "prod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.projects.listResp = projects
+			mockClient.projects.listErr = tt.listErr
+			tools := newTestTools(mockClient)
+
+			var got any
+			called := false
+			handler := tools.withProjectNames(func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = true
+				got = req.GetArguments()["project_id"]
+				return mcp.NewToolResultText("ok"), nil
+			})
+			args := map[string]any{"name": "baseline"}
+			if tt.projectID != nil {
+				args["project_id"] = tt.projectID
+			}
+			result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			if err != nil {
+				t.Fatalf("handler returned Go error: %v", err)
+			}
+
+			if tt.wantContains != "" {
+				if called || !result.IsError || !strings.Contains(getResultText(t, result), tt.wantContains) {
+					t.Errorf("result = %s, want an error containing %q", getResultText(t, result), tt.wantContains)
+				}
+				if detail, _ := errorDetailOf(result); detail.Code != ErrCodeInvalidArgument {
+					t.Errorf("error code = %q, want %q", detail.Code, ErrCodeInvalidArgument)
+				}
+				return
+			}
+			if !called || got != tt.wantProject {
+				t.Errorf("handler called = %v with project_id %v, want %v", called, got, tt.wantProject)
+			}
+			if tt.projectID != nil && args["project_id"] != tt.projectID {
+				t.Errorf("caller's arguments were modified: project_id = %v", args["project_id"])
+			}
+		})
+	}
+}
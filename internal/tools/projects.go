@@ -10,7 +10,7 @@ import (
 func (t *Tools) listProjects(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -20,7 +20,7 @@ func (t *Tools) listProjects(ctx context.Context, req mcp.CallToolRequest) (*mcp
 
 	projectID := req.GetString("project_id", "")
 
-	var projects any
+	var projects []*minderv1.Project
 	if projectID != "" {
 		// List child projects of the specified parent
 		resp, err := client.Projects().ListChildProjects(ctx, &minderv1.ListChildProjectsRequest{
@@ -29,17 +29,18 @@ func (t *Tools) listProjects(ctx context.Context, req mcp.CallToolRequest) (*mcp
 			},
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 		projects = resp.Projects
 	} else {
 		// List all accessible projects
 		resp, err := client.Projects().ListProjects(ctx, &minderv1.ListProjectsRequest{})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 		projects = resp.Projects
 	}
 
-	return marshalResult(projects)
+	projects, total := capResults(projects, t.cfg.MCP.MaxResults)
+	return t.marshalCapped(ctx, projects, len(projects), total)
 }
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// providerCapabilities is what one provider can do, derived from the traits
+// (provider types) it implements.
+type providerCapabilities struct {
+	Provider         string   `json:"provider"`
+	Project          string   `json:"project,omitempty"`
+	Class            string   `json:"class,omitempty"`
+	Traits           []string `json:"traits"`
+	CredentialsState string   `json:"credentials_state,omitempty"`
+	// EntityTypes lists the entity types profiles can evaluate through the provider.
+	EntityTypes map[string]bool `json:"entity_types"`
+	// Features lists actions the provider supports beyond evaluation.
+	Features map[string]bool `json:"features"`
+}
+
+// capabilitiesOf maps the traits a provider implements to the entity types and
+// features they enable in Minder:
+//   - github: repositories, pull requests and packages, with pull request
+//     remediation and security advisory alerts
+//   - git: cloning repositories for git-ingested rules
+//   - rest: REST-ingested rules and REST remediation
+//   - oci: container image artifacts, e.g. for signature verification
+//   - repo_lister and image_lister: enrolling repositories and listing images
+func capabilitiesOf(p *minderv1.Provider) providerCapabilities {
+	has := func(trait minderv1.ProviderType) bool { return slices.Contains(p.GetImplements(), trait) }
+	github := has(minderv1.ProviderType_PROVIDER_TYPE_GITHUB)
+	git := has(minderv1.ProviderType_PROVIDER_TYPE_GIT)
+	rest := has(minderv1.ProviderType_PROVIDER_TYPE_REST)
+	oci := has(minderv1.ProviderType_PROVIDER_TYPE_OCI)
+	repoLister := has(minderv1.ProviderType_PROVIDER_TYPE_REPO_LISTER)
+	imageLister := has(minderv1.ProviderType_PROVIDER_TYPE_IMAGE_LISTER)
+
+	traits := []string{}
+	for _, trait := range p.GetImplements() {
+		traits = append(traits, strings.ToLower(strings.TrimPrefix(trait.String(), "PROVIDER_TYPE_")))
+	}
+	return providerCapabilities{
+		Provider:         p.GetName(),
+		Project:          p.GetProject(),
+		Class:            p.GetClass(),
+		Traits:           traits,
+		CredentialsState: p.GetCredentialsState(),
+		EntityTypes: map[string]bool{
+			"repository":   github || repoLister || git,
+			"pull_request": github,
+			"artifact":     github || oci || imageLister,
+		},
+		Features: map[string]bool{
+			"register_repositories":    repoLister,
+			"list_images":              imageLister,
+			"git_ingest":               git,
+			"rest_ingest":              rest,
+			"auto_remediation":         rest || github,
+			"pull_request_remediation": github,
+			"security_advisory_alerts": github,
+		},
+	}
+}
+
+// getProviderCapabilities reports, per provider, the entity types and features
+// it supports so clients can avoid suggesting actions a provider cannot perform.
+func (t *Tools) getProviderCapabilities(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectID := req.GetString("project_id", "")
+	name := req.GetString("name", "")
+
+	providers, err := forEachProject(
+		ctx, client, projectID,
+		func(ctx context.Context, projID string) ([]*minderv1.Provider, error) {
+			resp, err := client.Providers().ListProviders(ctx, &minderv1.ListProvidersRequest{
+				Context: &minderv1.Context{
+					Project: &projID,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.Providers, nil
+		})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	sortByProject(providers, providerKey)
+	matrix := []providerCapabilities{}
+	for _, p := range providers {
+		if name == "" || p.GetName() == name {
+			matrix = append(matrix, capabilitiesOf(p))
+		}
+	}
+	if name != "" && len(matrix) == 0 {
+		return mcp.NewToolResultError("provider " + name + " not found"), nil
+	}
+	matrix, total := capResults(matrix, t.cfg.MCP.MaxResults)
+	return t.marshalCapped(ctx, map[string]any{"providers": matrix}, len(matrix), total)
+}
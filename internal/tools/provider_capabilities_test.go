@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestCapabilitiesOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		implements   []minderv1.ProviderType
+		wantEntities []string
+		wantFeatures []string
+	}{
+		{
+			name: "github app",
+			implements: []minderv1.ProviderType{
+				minderv1.ProviderType_PROVIDER_TYPE_GITHUB, minderv1.ProviderType_PROVIDER_TYPE_GIT,
+				minderv1.ProviderType_PROVIDER_TYPE_REST, minderv1.ProviderType_PROVIDER_TYPE_REPO_LISTER,
+			},
+			wantEntities: []string{"artifact", "pull_request", "repository"},
+			wantFeatures: []string{
+				"auto_remediation", "git_ingest", "pull_request_remediation", "register_repositories",
+				"rest_ingest", "security_advisory_alerts",
+			},
+		},
+		{
+			name: "container registry",
+			implements: []minderv1.ProviderType{
+				minderv1.ProviderType_PROVIDER_TYPE_OCI, minderv1.ProviderType_PROVIDER_TYPE_IMAGE_LISTER,
+			},
+			wantEntities: []string{"artifact"},
+			wantFeatures: []string{"list_images"},
+		},
+		{
+			name:         "no traits",
+			wantEntities: []string{},
+			wantFeatures: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := capabilitiesOf(&minderv1.Provider{Name: "p", Implements: tt.implements})
+			if len(got.Traits) != len(tt.implements) {
+				t.Errorf("traits = %v, want one per implemented provider type", got.Traits)
+			}
+			if entities := trueKeys(got.EntityTypes); !slices.Equal(entities, tt.wantEntities) {
+				t.Errorf("entity types = %v, want %v", entities, tt.wantEntities)
+			}
+			if features := trueKeys(got.Features); !slices.Equal(features, tt.wantFeatures) {
+				t.Errorf("features = %v, want %v", features, tt.wantFeatures)
+			}
+		})
+	}
+}
+
+// trueKeys returns the sorted keys set to true.
+func trueKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k, v := range m {
+		if v {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestGetProviderCapabilities(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.providers.listResp = &minderv1.ListProvidersResponse{
+		Providers: []*minderv1.Provider{
+			{
+				Name: "github-app-acme", Project: "proj-1", Class: "github-app", CredentialsState: "set",
+				Implements: []minderv1.ProviderType{minderv1.ProviderType_PROVIDER_TYPE_GITHUB},
+			},
+			{
+				Name: "dockerhub", Project: "proj-1", Class: "dockerhub",
+				Implements: []minderv1.ProviderType{minderv1.ProviderType_PROVIDER_TYPE_OCI},
+			},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getProviderCapabilities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "name": "dockerhub"}},
+	})
+	if err != nil {
+		t.Fatalf("getProviderCapabilities() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got struct {
+		Providers []providerCapabilities `json:"providers"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Providers) != 1 || got.Providers[0].Provider != "dockerhub" {
+		t.Fatalf("providers = %+v, want only dockerhub", got.Providers)
+	}
+	if p := got.Providers[0]; p.Features["auto_remediation"] || !p.EntityTypes["artifact"] || p.Traits[0] != "oci" {
+		t.Errorf("dockerhub capabilities = %+v, want artifacts without remediation", p)
+	}
+
+	result, err = tools.getProviderCapabilities(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "name": "gitlab"}},
+	})
+	if err != nil {
+		t.Fatalf("getProviderCapabilities() returned Go error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(getResultText(t, result), "provider gitlab not found") {
+		t.Errorf("result = %s, want a not found error", getResultText(t, result))
+	}
+}
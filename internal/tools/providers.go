@@ -7,10 +7,15 @@ import (
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
 
+// providerKey returns the project and name of a provider, to sort providers with sortByProject.
+func providerKey(p *minderv1.Provider) (string, string) {
+	return p.GetProject(), p.GetName()
+}
+
 func (t *Tools) listProviders(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -20,7 +25,7 @@ func (t *Tools) listProviders(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	projectID := req.GetString("project_id", "")
 	cursor := req.GetString("cursor", "")
-	limit := req.GetInt("limit", 0)
+	limit := t.pageSize(req.GetInt("limit", 0))
 
 	// Single project mode - preserves pagination
 	if projectID != "" {
@@ -32,17 +37,19 @@ func (t *Tools) listProviders(ctx context.Context, req mcp.CallToolRequest) (*mc
 		if cursor != "" {
 			reqProto.Cursor = cursor
 		}
-		if limit > 0 && limit <= 100 {
-			reqProto.Limit = int32(limit) //nolint:gosec // limit is bounded by schema validation (1-100)
+		if limit > 0 {
+			reqProto.Limit = int32(limit) //nolint:gosec // limit is bounded by t.pageSize (1-100)
 		}
 
 		resp, err := client.Providers().ListProviders(ctx, reqProto)
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 
+		// Minder may return more than the limit asked for
+		providers, total := capResults(resp.Providers, t.cfg.MCP.MaxResults)
 		result := map[string]any{
-			"results": resp.Providers,
+			"results": providers,
 		}
 		if resp.Cursor != "" {
 			result["next_cursor"] = resp.Cursor
@@ -50,7 +57,7 @@ func (t *Tools) listProviders(ctx context.Context, req mcp.CallToolRequest) (*mc
 		} else {
 			result["has_more"] = false
 		}
-		return marshalResult(result)
+		return t.marshalCapped(ctx, result, len(providers), total)
 	}
 
 	// Multi-project aggregation - pagination not supported
@@ -69,21 +76,23 @@ func (t *Tools) listProviders(ctx context.Context, req mcp.CallToolRequest) (*mc
 			return resp.Providers, nil
 		})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
+	sortByProject(providers, providerKey)
+	providers, total := capResults(providers, t.cfg.MCP.MaxResults)
 	result := map[string]any{
 		"results":  providers,
 		"has_more": false,
 	}
 
-	return marshalResult(result)
+	return t.marshalCapped(ctx, result, len(providers), total)
 }
 
 func (t *Tools) getProvider(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -99,21 +108,23 @@ func (t *Tools) getProvider(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	}
 
 	// Search across projects if none specified
-	provider, err := findInProjects(ctx, client, projectID, func(ctx context.Context, projID string) (*minderv1.Provider, error) {
-		resp, err := client.Providers().GetProvider(ctx, &minderv1.GetProviderRequest{
-			Name: name,
-			Context: &minderv1.Context{
-				Project: &projID,
-			},
+	provider, err := findOneInProjects(
+		ctx, client, projectID, "provider "+name,
+		func(ctx context.Context, projID string) (*minderv1.Provider, error) {
+			resp, err := client.Providers().GetProvider(ctx, &minderv1.GetProviderRequest{
+				Name: name,
+				Context: &minderv1.Context{
+					Project: &projID,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.Provider, nil
 		})
-		if err != nil {
-			return nil, err
-		}
-		return resp.Provider, nil
-	})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(provider)
+	return marshalResult(ctx, provider)
 }
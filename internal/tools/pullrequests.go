@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// pullRequestCheck is the latest evaluation of one profile rule on a pull request.
+type pullRequestCheck struct {
+	Profile           string `json:"profile"`
+	Rule              string `json:"rule"`
+	RuleType          string `json:"rule_type"`
+	Severity          string `json:"severity,omitempty"`
+	Status            string `json:"status"`
+	Details           string `json:"details,omitempty"`
+	AlertStatus       string `json:"alert_status,omitempty"`
+	RemediationStatus string `json:"remediation_status,omitempty"`
+	EvaluatedAt       string `json:"evaluated_at,omitempty"`
+	EvaluationID      string `json:"evaluation_id"`
+}
+
+// pullRequestEvaluations groups the checks Minder ran on one pull request.
+type pullRequestEvaluations struct {
+	Number        int64              `json:"number"`
+	EntityID      string             `json:"entity_id"`
+	Name          string             `json:"name"`
+	LastEvaluated string             `json:"last_evaluated,omitempty"`
+	Failing       int                `json:"failing"`
+	Checks        []pullRequestCheck `json:"checks"`
+
+	lastEvaluatedAt time.Time
+}
+
+// pullRequestEntityName returns the name Minder gives a pull request entity,
+// e.g. "stacklok/minder/42".
+func pullRequestEntityName(owner, repo string, number int64) string {
+	return fmt.Sprintf("%s/%s/%d", owner, repo, number)
+}
+
+// groupPullRequestEvaluations groups evaluation history rows by pull request,
+// keeping the latest evaluation of each profile rule. Pull requests are
+// ordered most recently evaluated first.
+func groupPullRequestEvaluations(rows []*minderv1.EvaluationHistory, prefix string) []*pullRequestEvaluations {
+	byEntity := map[string]*pullRequestEvaluations{}
+	latest := map[string]time.Time{}
+	var prs []*pullRequestEvaluations
+
+	for _, row := range rows {
+		entity := row.GetEntity()
+		if !strings.HasPrefix(entity.GetName(), prefix) {
+			continue
+		}
+		number, err := strconv.ParseInt(strings.TrimPrefix(entity.GetName(), prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		pr, ok := byEntity[entity.GetId()]
+		if !ok {
+			pr = &pullRequestEvaluations{Number: number, EntityID: entity.GetId(), Name: entity.GetName()}
+			byEntity[entity.GetId()] = pr
+			prs = append(prs, pr)
+		}
+
+		// History is not guaranteed to be ordered, so keep the newest row per rule
+		evaluatedAt := row.GetEvaluatedAt().AsTime()
+		key := entity.GetId() + "\x00" + row.GetRule().GetProfile() + "\x00" + row.GetRule().GetName()
+		if seen, ok := latest[key]; ok && !evaluatedAt.After(seen) {
+			continue
+		}
+		latest[key] = evaluatedAt
+		pr.Checks = slices.DeleteFunc(pr.Checks, func(c pullRequestCheck) bool {
+			return c.Profile == row.GetRule().GetProfile() && c.Rule == row.GetRule().GetName()
+		})
+		pr.Checks = append(pr.Checks, pullRequestCheckFrom(row))
+		if evaluatedAt.After(pr.lastEvaluatedAt) {
+			pr.lastEvaluatedAt = evaluatedAt
+			pr.LastEvaluated = evaluatedAt.Format(time.RFC3339)
+		}
+	}
+
+	for _, pr := range prs {
+		slices.SortFunc(pr.Checks, func(a, b pullRequestCheck) int {
+			return cmp.Or(cmp.Compare(a.Profile, b.Profile), cmp.Compare(a.Rule, b.Rule))
+		})
+		for _, c := range pr.Checks {
+			if watcher.IsFailing(c.Status) {
+				pr.Failing++
+			}
+		}
+	}
+	slices.SortStableFunc(prs, func(a, b *pullRequestEvaluations) int {
+		return cmp.Or(b.lastEvaluatedAt.Compare(a.lastEvaluatedAt), cmp.Compare(b.Number, a.Number))
+	})
+	return prs
+}
+
+// pullRequestCheckFrom converts an evaluation history row into a check.
+func pullRequestCheckFrom(row *minderv1.EvaluationHistory) pullRequestCheck {
+	check := pullRequestCheck{
+		Profile:           row.GetRule().GetProfile(),
+		Rule:              row.GetRule().GetName(),
+		RuleType:          row.GetRule().GetRuleType(),
+		Severity:          severityName(row.GetRule().GetSeverity()),
+		Status:            row.GetStatus().GetStatus(),
+		Details:           row.GetStatus().GetDetails(),
+		AlertStatus:       row.GetAlert().GetStatus(),
+		RemediationStatus: row.GetRemediation().GetStatus(),
+		EvaluationID:      row.GetId(),
+	}
+	if row.EvaluatedAt != nil {
+		check.EvaluatedAt = row.EvaluatedAt.AsTime().Format(time.RFC3339)
+	}
+	return check
+}
+
+// getPullRequestEvaluations returns the checks Minder ran on a repository's
+// pull requests, such as vulnerability and package reputation checks, grouped
+// per pull request rather than as evaluation history rows.
+func (t *Tools) getPullRequestEvaluations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	number := int64(req.GetInt("pull_number", 0))
+	projectID := req.GetString("project_id", "")
+
+	if owner == "" || name == "" {
+		return mcp.NewToolResultError("owner and name must be provided"), nil
+	}
+	from, err := parseTimeParam(req, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	rows, err := forEachProject(ctx, client, projectID,
+		func(ctx context.Context, projID string) ([]*minderv1.EvaluationHistory, error) {
+			reqProto := &minderv1.ListEvaluationHistoryRequest{
+				Context:     &minderv1.Context{Project: &projID},
+				EntityType:  []string{"pull_request"},
+				LabelFilter: []string{"*"},
+				Cursor:      &minderv1.Cursor{Size: maxPageSize},
+			}
+			if number > 0 {
+				reqProto.EntityName = []string{pullRequestEntityName(owner, name, number)}
+			}
+			if !from.IsZero() {
+				reqProto.From = timestamppb.New(from)
+			}
+			resp, err := client.EvalResults().ListEvaluationHistory(ctx, reqProto)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Data, nil
+		})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	prs := groupPullRequestEvaluations(rows, owner+"/"+name+"/")
+	prs, total := capResults(prs, t.cfg.MCP.MaxResults)
+	result := map[string]any{
+		"repository":    owner + "/" + name,
+		"pull_requests": prs,
+	}
+	if len(prs) == 0 {
+		result["note"] = "no pull request evaluations found; Minder only evaluates pull requests " +
+			"when a profile has pull_request rules"
+	}
+	return t.marshalCapped(ctx, result, len(prs), total)
+}
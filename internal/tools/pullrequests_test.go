@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// prEvaluation returns an evaluation history row for a pull request of stacklok/minder.
+func prEvaluation(id, name, rule, status string, at time.Time) *minderv1.EvaluationHistory {
+	return &minderv1.EvaluationHistory{
+		Id:          id,
+		Entity:      &minderv1.EvaluationHistoryEntity{Id: "pr-" + name, Name: "stacklok/minder/" + name},
+		Rule:        &minderv1.EvaluationHistoryRule{Name: rule, RuleType: rule, Profile: "pr-checks"},
+		Status:      &minderv1.EvaluationHistoryStatus{Status: status},
+		EvaluatedAt: timestamppb.New(at),
+	}
+}
+
+func TestGroupPullRequestEvaluations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []*minderv1.EvaluationHistory{
+		prEvaluation("e1", "41", "pr_vulnerability_check", "failure", now.Add(-2*time.Hour)),
+		prEvaluation("e2", "41", "pr_vulnerability_check", "success", now.Add(-time.Hour)),
+		prEvaluation("e3", "41", "pr_trusty_check", "failure", now.Add(-3*time.Hour)),
+		prEvaluation("e4", "42", "pr_vulnerability_check", "success", now),
+		{
+			Id:     "other",
+			Entity: &minderv1.EvaluationHistoryEntity{Id: "pr-x", Name: "stacklok/minder-mcp/7"},
+		},
+	}
+
+	prs := groupPullRequestEvaluations(rows, "stacklok/minder/")
+	if len(prs) != 2 {
+		t.Fatalf("got %d pull requests, want 2: %+v", len(prs), prs)
+	}
+	if prs[0].Number != 42 || prs[1].Number != 41 {
+		t.Errorf("order = %d, %d, want most recently evaluated first", prs[0].Number, prs[1].Number)
+	}
+
+	pr := prs[1]
+	if len(pr.Checks) != 2 {
+		t.Fatalf("got %d checks, want the latest evaluation of each rule: %+v", len(pr.Checks), pr.Checks)
+	}
+	if pr.Checks[1].Rule != "pr_vulnerability_check" || pr.Checks[1].EvaluationID != "e2" {
+		t.Errorf("vulnerability check = %+v, want the newer evaluation e2", pr.Checks[1])
+	}
+	if pr.Failing != 1 {
+		t.Errorf("failing = %d, want 1", pr.Failing)
+	}
+}
+
+func TestGetPullRequestEvaluations(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{
+		Data: []*minderv1.EvaluationHistory{
+			prEvaluation("e1", "42", "pr_vulnerability_check", "failure", time.Now()),
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getPullRequestEvaluations(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"owner": "stacklok", "name": "minder", "pull_number": float64(42), "project_id": "proj-1",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("getPullRequestEvaluations() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		PullRequests []pullRequestEvaluations `json:"pull_requests"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.PullRequests) != 1 || got.PullRequests[0].Failing != 1 {
+		t.Errorf("pull_requests = %+v, want PR 42 with one failing check", got.PullRequests)
+	}
+
+	req := mockClient.evalResults.listReq
+	if len(req.EntityName) != 1 || req.EntityName[0] != "stacklok/minder/42" {
+		t.Errorf("entity_name filter = %v, want stacklok/minder/42", req.EntityName)
+	}
+	if len(req.EntityType) != 1 || req.EntityType[0] != "pull_request" {
+		t.Errorf("entity_type filter = %v, want pull_request", req.EntityType)
+	}
+}
+
+func TestGetPullRequestEvaluations_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{name: "missing name", args: map[string]any{"owner": "stacklok"}},
+		{name: "invalid from", args: map[string]any{"owner": "stacklok", "name": "minder", "from": "yesterday"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tools := newTestTools(newMockClient())
+			result, err := tools.getPullRequestEvaluations(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("getPullRequestEvaluations() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Error("expected error result")
+			}
+		})
+	}
+}
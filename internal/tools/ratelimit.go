@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"google.golang.org/grpc"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// maxRateBuckets is the number of callers tracked. Past it, buckets that have
+// refilled completely, and so carry no state, are dropped, and then the
+// longest unused ones.
+const maxRateBuckets = 4096
+
+// rateLimiter throttles tool calls per caller with a token bucket, so one
+// runaway agent cannot flood the Minder backend shared with everyone else.
+// Each caller may make burst calls at once and then perMinute calls a minute.
+//
+// A caller is identified by its Authorization header token once Minder has
+// accepted that token, and by its network address until then, so made-up
+// tokens cannot each claim a fresh bucket.
+type rateLimiter struct {
+	perSecond float64
+	burst     float64
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets map[[sha256.Size]byte]*rateBucket
+}
+
+// rateBucket is the state of one caller's token bucket.
+type rateBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newRateLimiter returns a limiter admitting perMinute calls a minute per
+// caller after an initial burst. With perMinute zero it returns nil, which
+// admits every call.
+func newRateLimiter(perMinute, burst int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		perSecond: float64(perMinute) / 60,
+		burst:     float64(max(burst, 1)),
+		now:       time.Now,
+		buckets:   make(map[[sha256.Size]byte]*rateBucket),
+	}
+}
+
+// allow takes a call from the bucket of the caller in ctx. When the bucket is
+// empty it returns false and how long until a call is admitted.
+func (l *rateLimiter) allow(ctx context.Context) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var b *rateBucket
+	if middleware.TokenFromHeader(ctx) {
+		b = l.buckets[tokenBucketKey(ctx)]
+	}
+	if b == nil {
+		key := addrBucketKey(ctx)
+		if b = l.buckets[key]; b == nil {
+			b = l.add(key, now)
+		}
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.perSecond * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// accept gives the Authorization header token in ctx a bucket of its own,
+// once Minder has accepted the token.
+func (l *rateLimiter) accept(ctx context.Context) {
+	if l == nil || !middleware.TokenFromHeader(ctx) {
+		return
+	}
+	key := tokenBucketKey(ctx)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.buckets[key]; !ok {
+		l.add(key, l.now())
+	}
+}
+
+// Interceptor returns a gRPC interceptor that calls accept after each Minder
+// call that succeeds.
+func (l *rateLimiter) Interceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			l.accept(ctx)
+		}
+		return err
+	}
+}
+
+// add returns a full bucket stored under key, first making room for it when
+// maxRateBuckets are tracked. The caller holds l.mu.
+func (l *rateLimiter) add(key [sha256.Size]byte, now time.Time) *rateBucket {
+	if len(l.buckets) >= maxRateBuckets {
+		l.dropFullBuckets(now)
+	}
+	for len(l.buckets) >= maxRateBuckets {
+		l.dropOldestBucket()
+	}
+	b := &rateBucket{tokens: l.burst, updated: now}
+	l.buckets[key] = b
+	return b
+}
+
+// tokenBucketKey keys the bucket of the token in ctx. It is a hash, so tokens
+// are not kept in memory.
+func tokenBucketKey(ctx context.Context) [sha256.Size]byte {
+	return sha256.Sum256([]byte("token\x00" + middleware.TokenFromContext(ctx)))
+}
+
+// addrBucketKey keys the bucket of the client address in ctx. Calls without
+// one, such as those over stdio, share a bucket.
+func addrBucketKey(ctx context.Context) [sha256.Size]byte {
+	addr, _ := middleware.ClientAddrFromContext(ctx)
+	return sha256.Sum256([]byte("addr\x00" + addr.String()))
+}
+
+// refill returns the tokens in b at now.
+func (l *rateLimiter) refill(b *rateBucket, now time.Time) float64 {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed <= 0 {
+		return b.tokens
+	}
+	return math.Min(l.burst, b.tokens+elapsed*l.perSecond)
+}
+
+// dropFullBuckets forgets callers whose buckets have refilled, since a new
+// bucket starts full anyway. The caller holds l.mu.
+func (l *rateLimiter) dropFullBuckets(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// dropOldestBucket forgets the caller whose bucket was used longest ago. The
+// caller holds l.mu.
+func (l *rateLimiter) dropOldestBucket() {
+	var oldest [sha256.Size]byte
+	var oldestUpdated time.Time
+	found := false
+	for key, b := range l.buckets {
+		if !found || b.updated.Before(oldestUpdated) {
+			oldest, oldestUpdated, found = key, b.updated, true
+		}
+	}
+	delete(l.buckets, oldest)
+}
+
+// rateLimitResult reports a call refused because its subject is over the rate limit.
+func rateLimitResult(tool string, wait time.Duration) *mcp.CallToolResult {
+	seconds := math.Ceil(wait.Seconds()*10) / 10
+	return errorResult(fmt.Sprintf("Tool call rate limit exceeded: %s was not started; retry in %.1fs", tool, seconds),
+		ErrorDetail{
+			Code:              ErrCodeRateLimited,
+			Retryable:         true,
+			SuggestedAction:   "Wait before retrying and make fewer tool calls per minute.",
+			RetryAfterSeconds: seconds,
+		})
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(60, 2) // one call a second after a burst of two
+	l.now = func() time.Time { return now }
+
+	alice := middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("192.0.2.1"))
+	bob := middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("192.0.2.2"))
+
+	for i := range 2 {
+		if ok, _ := l.allow(alice); !ok {
+			t.Fatalf("burst call %d refused", i+1)
+		}
+	}
+	ok, wait := l.allow(alice)
+	if ok {
+		t.Fatal("call over the burst admitted")
+	}
+	if wait != time.Second {
+		t.Errorf("wait = %v, want 1s", wait)
+	}
+	if ok, _ := l.allow(bob); !ok {
+		t.Error("another client's call refused")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, wait := l.allow(alice); ok || wait != 500*time.Millisecond {
+		t.Errorf("allow() after 500ms = %v, %v, want false, 500ms", ok, wait)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.allow(alice); !ok {
+		t.Error("call refused after the bucket refilled")
+	}
+}
+
+func TestRateLimiter_HeaderTokens(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(60, 1)
+	l.now = func() time.Time { return now }
+	client := middleware.ContextWithClientAddr(context.Background(), netip.MustParseAddr("192.0.2.1"))
+	alice := middleware.ContextWithHeaderToken(client, "alice")
+
+	// Tokens Minder has not accepted share the bucket of their client address
+	if ok, _ := l.allow(alice); !ok {
+		t.Fatal("first call refused")
+	}
+	if ok, _ := l.allow(middleware.ContextWithHeaderToken(client, "made-up")); ok {
+		t.Error("call with another unverified token admitted from the same address")
+	}
+
+	// An accepted token gets a bucket of its own
+	l.accept(alice)
+	if ok, _ := l.allow(alice); !ok {
+		t.Error("call with an accepted token refused")
+	}
+	if ok, _ := l.allow(alice); ok {
+		t.Error("call over the accepted token's burst admitted")
+	}
+
+	// The configured token is never given a bucket of its own
+	l.accept(middleware.ContextWithToken(client, "configured"))
+	if len(l.buckets) != 2 {
+		t.Errorf("buckets = %d, want 2", len(l.buckets))
+	}
+}
+
+func TestRateLimiter_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(60, 1)
+	ctx := middleware.ContextWithHeaderToken(context.Background(), "alice")
+	interceptor := l.Interceptor()
+	invoke := func(err error) grpc.UnaryInvoker {
+		return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return err }
+	}
+
+	const method = "/minder.v1.ProjectsService/ListProjects"
+	_ = interceptor(ctx, method, nil, nil, nil, invoke(status.Error(codes.Unauthenticated, "bad token")))
+	if len(l.buckets) != 0 {
+		t.Fatalf("buckets = %d after Minder rejected the token, want 0", len(l.buckets))
+	}
+	if err := interceptor(ctx, method, nil, nil, nil, invoke(nil)); err != nil {
+		t.Fatalf("interceptor() = %v", err)
+	}
+	if _, ok := l.buckets[tokenBucketKey(ctx)]; !ok {
+		t.Error("token Minder accepted has no bucket")
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(0, 10)
+	for range 100 {
+		if ok, _ := l.allow(context.Background()); !ok {
+			t.Fatal("disabled limiter refused a call")
+		}
+	}
+}
+
+func TestRateLimiter_DropsFullBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(60, 1)
+	l.now = func() time.Time { return now }
+	for i := range maxRateBuckets {
+		l.allow(clientAddrContext(i))
+	}
+
+	now = now.Add(time.Minute)
+	l.allow(clientAddrContext(maxRateBuckets))
+	if len(l.buckets) != 1 {
+		t.Errorf("buckets = %d, want 1 after refilled buckets are dropped", len(l.buckets))
+	}
+}
+
+func TestRateLimiter_DropsOldestBucket(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(1, 1)
+	l.now = func() time.Time { return now }
+	for i := range maxRateBuckets {
+		l.allow(clientAddrContext(i))
+		now = now.Add(time.Millisecond)
+	}
+
+	// No bucket has refilled, so the oldest is dropped to stay within the cap
+	if ok, _ := l.allow(clientAddrContext(maxRateBuckets)); !ok {
+		t.Error("new client's call refused")
+	}
+	if len(l.buckets) != maxRateBuckets {
+		t.Errorf("buckets = %d, want %d", len(l.buckets), maxRateBuckets)
+	}
+	if _, ok := l.buckets[addrBucketKey(clientAddrContext(0))]; ok {
+		t.Error("oldest bucket kept")
+	}
+	if _, ok := l.buckets[addrBucketKey(clientAddrContext(1))]; !ok {
+		t.Error("second oldest bucket dropped")
+	}
+}
+
+// clientAddrContext returns a context with a client address distinct for each i.
+func clientAddrContext(i int) context.Context {
+	return middleware.ContextWithClientAddr(context.Background(), netip.AddrFrom4([4]byte{10, 0, byte(i >> 8), byte(i)}))
+}
+
+func TestWrapHandler_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	tools := newTestTools(newMockClient())
+	tools.rateLimiter = newRateLimiter(1, 1)
+	handler := tools.wrapHandler("test_tool", func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	ctx := middleware.ContextWithToken(context.Background(), "token")
+	if result, _ := handler(ctx, mcp.CallToolRequest{}); result.IsError {
+		t.Fatalf("first call failed: %s", getResultText(t, result))
+	}
+	result, err := handler(ctx, mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok || detail.Code != ErrCodeRateLimited || !detail.Retryable || detail.RetryAfterSeconds <= 0 {
+		t.Errorf("error detail = %#v, want a retryable %q error with a retry hint", detail, ErrCodeRateLimited)
+	}
+}
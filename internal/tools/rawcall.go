@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// minderPackage is the protobuf package raw calls are restricted to.
+const minderPackage = "minder.v1"
+
+// resolveRawMethod looks up a unary Minder RPC named "minder.v1.Service/Method".
+func resolveRawMethod(name string) (protoreflect.MethodDescriptor, error) {
+	service, method, _ := strings.Cut(name, "/")
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok || sd.ParentFile().Package() != minderPackage {
+		return nil, fmt.Errorf("%s is not a %s service", service, minderPackage)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", service, method)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("%s is a streaming method", name)
+	}
+	return md, nil
+}
+
+// registerRawCall registers minder_raw_call for the RPCs allowed by
+// MCP_RAW_CALL_METHODS. Entries that do not name a unary Minder RPC are
+// logged and ignored; without any valid entry the tool is not registered.
+func (t *Tools) registerRawCall(s *server.MCPServer) {
+	methods := make(map[string]protoreflect.MethodDescriptor)
+	for _, entry := range t.cfg.MCP.RawCallMethods {
+		name := strings.TrimPrefix(entry, "/")
+		md, err := resolveRawMethod(name)
+		if err != nil {
+			t.logger.Warn("ignoring MCP_RAW_CALL_METHODS entry", "method", entry, "error", err)
+			continue
+		}
+		methods[name] = md
+	}
+	if len(methods) == 0 {
+		return
+	}
+	names := slices.Sorted(maps.Keys(methods))
+
+	t.addTool(s, mcp.NewTool("minder_raw_call",
+		mcp.WithDescription("Invoke a Minder RPC that has no dedicated tool, for advanced use. "+
+			"The request is the RPC's request message in protobuf JSON form and the response is returned the same way. "+
+			"Prefer a dedicated tool when one covers the task; calls may modify Minder."),
+		mcp.WithTitleAnnotation("Raw Minder Call"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("method",
+			mcp.Required(),
+			mcp.Title("Method"),
+			mcp.Description("RPC to invoke, as Service/Method"),
+			mcp.Enum(names...),
+		),
+		mcp.WithObject("request",
+			mcp.Title("Request"),
+			mcp.Description("Request message in protobuf JSON form, using the proto field names or their camelCase. "+
+				"Omit for an empty request"),
+		),
+	), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return t.rawCall(ctx, req, methods)
+	})
+}
+
+// rawCall invokes one of the allowed methods with a JSON request body.
+func (t *Tools) rawCall(
+	ctx context.Context, req mcp.CallToolRequest, methods map[string]protoreflect.MethodDescriptor,
+) (*mcp.CallToolResult, error) {
+	name := strings.TrimPrefix(req.GetString("method", ""), "/")
+	md, ok := methods[name]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("method %q is not allowed on this server; allowed methods: %s",
+			name, strings.Join(slices.Sorted(maps.Keys(methods)), ", "))), nil
+	}
+
+	body := []byte("{}")
+	if raw, ok := req.GetArguments()["request"]; ok && raw != nil {
+		var err error
+		if body, err = json.Marshal(raw); err != nil {
+			return mcp.NewToolResultError("request must be a JSON object: " + err.Error()), nil
+		}
+	}
+	in := dynamicpb.NewMessage(md.Input())
+	if err := protojson.Unmarshal(body, in); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid request for %s: %v", name, err)), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	t.logger.InfoContext(ctx, "invoking raw Minder call", "method", name)
+	out := dynamicpb.NewMessage(md.Output())
+	if err := client.Conn().Invoke(ctx, "/"+name, in, out); err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	data, err := protojson.Marshal(out)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal response: " + err.Error()), nil
+	}
+	return marshalResult(ctx, json.RawMessage(data))
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/pkg/mindertest"
+)
+
+// newRawCallServer registers tools allowing methods against a fake Minder.
+func newRawCallServer(t *testing.T, methods ...string) *server.MCPServer {
+	t.Helper()
+	fake := mindertest.Start(mindertest.Fixtures{
+		Projects: []*minderv1.Project{{ProjectId: "p1", Name: "acme"}},
+	})
+	t.Cleanup(fake.Close)
+	conn, err := fake.Dial(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	mockClient := newMockClient()
+	mockClient.conn = conn
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{RawCallMethods: methods}}
+	tools := NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return mockClient, nil
+	})
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+	return s
+}
+
+func TestRegisterRawCall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		methods []string
+		want    bool
+	}{
+		{name: "not configured"},
+		{
+			name:    "only invalid methods",
+			methods: []string{"minder.v1.ProjectsService/NoSuchMethod", "grpc.health.v1.Health/Check"},
+		},
+		{
+			name:    "valid method",
+			methods: []string{"/minder.v1.ProjectsService/ListProjects", "minder.v1.NoSuchService/Get"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newRawCallServer(t, tt.methods...)
+			tool := s.GetTool("minder_raw_call")
+			require.Equal(t, tt.want, tool != nil)
+			if tt.want {
+				method, ok := tool.Tool.InputSchema.Properties["method"].(map[string]any)
+				require.True(t, ok)
+				assert.Equal(t, []string{"minder.v1.ProjectsService/ListProjects"}, method["enum"])
+			}
+		})
+	}
+}
+
+func TestRawCall(t *testing.T) {
+	t.Parallel()
+
+	s := newRawCallServer(t, "minder.v1.ProjectsService/ListProjects")
+	ctx := context.Background()
+
+	result := callTool(ctx, t, s, "minder_raw_call", map[string]any{
+		"method":  "minder.v1.ProjectsService/ListProjects",
+		"request": map[string]any{},
+	})
+	require.False(t, result.IsError, getResultText(t, result))
+	var resp struct {
+		Projects []struct {
+			ProjectID string `json:"projectId"`
+			Name      string `json:"name"`
+		} `json:"projects"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getResultText(t, result)), &resp))
+	require.Len(t, resp.Projects, 1)
+	assert.Equal(t, "acme", resp.Projects[0].Name)
+}
+
+func TestRawCall_Errors(t *testing.T) {
+	t.Parallel()
+
+	s := newRawCallServer(t, "minder.v1.ProjectsService/ListProjects")
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "method not allowed",
+			args:    map[string]any{"method": "minder.v1.ProjectsService/DeleteProject"},
+			wantMsg: "not allowed on this server; allowed methods: minder.v1.ProjectsService/ListProjects",
+		},
+		{
+			name: "unknown request field",
+			args: map[string]any{
+				"method":  "minder.v1.ProjectsService/ListProjects",
+				"request": map[string]any{"no_such_field": true},
+			},
+			wantMsg: "invalid request for minder.v1.ProjectsService/ListProjects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := callTool(context.Background(), t, s, "minder_raw_call", tt.args)
+			require.True(t, result.IsError)
+			assert.Contains(t, getResultText(t, result), tt.wantMsg)
+		})
+	}
+}
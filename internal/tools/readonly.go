@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// addTool registers a tool with the server. In read-only mode, tools without
+// the read-only hint are not registered at all; their handlers also refuse to
+// run in read-only mode in case they are reached some other way. Handlers
+// receive the session's pinned project and provider as argument defaults,
+// and take an auth_profile argument when auth profiles are configured.
+// Tools are registered under their configured prefix, with the configured
+// overrides of their prose applied. The read-only and auth_profile checks run
+// within wrapHandler, so their refusals are counted, limited and carry a
+// request ID like any other result.
+func (t *Tools) addTool(s *server.MCPServer, tool mcp.Tool, handler server.ToolHandlerFunc) {
+	name := tool.Name
+	tool, handler = t.withAuthProfile(tool, handler)
+	tool = t.withOverride(tool)
+	if !isReadOnlyTool(tool) {
+		if t.cfg.MCP.ReadOnly {
+			t.logger.Debug("skipping write tool in read-only mode", "tool", t.toolName(name))
+			return
+		}
+		handler = t.rejectInReadOnly(name, handler)
+	}
+	handler = t.withSessionContext(tool, t.wrapHandler(name, handler))
+	s.AddTool(t.withToolPrefix(tool), handler)
+}
+
+// rejectInReadOnly wraps a write tool's handler so it fails when read-only mode is enabled.
+func (t *Tools) rejectInReadOnly(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	name = t.toolName(name)
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if t.cfg.MCP.ReadOnly {
+			t.logger.WarnContext(ctx, "rejected write tool in read-only mode", "tool", name)
+			return errorResult(fmt.Sprintf("tool %s modifies Minder and the server is in read-only mode", name), ErrorDetail{
+				Code:            ErrCodeFailedPrecondition,
+				SuggestedAction: "Use a read-only tool, or ask an administrator to disable MINDER_MCP_READ_ONLY.",
+			}), nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// isReadOnlyTool reports whether the tool is annotated as read-only.
+func isReadOnlyTool(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+func newReadOnlyTestTools(readOnly bool) *Tools {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{ReadOnly: readOnly}}
+	return NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return newMockClient(), nil
+	})
+}
+
+func writeTool() mcp.Tool {
+	return mcp.NewTool("minder_test_write",
+		mcp.WithReadOnlyHintAnnotation(false),
+	)
+}
+
+func okHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return mcp.NewToolResultText("ok"), nil
+}
+
+func TestAddTool_ReadOnlySkipsWriteTools(t *testing.T) {
+	t.Parallel()
+
+	tools := newReadOnlyTestTools(true)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.addTool(s, writeTool(), okHandler)
+	tools.addTool(s, mcp.NewTool("minder_test_read", mcp.WithReadOnlyHintAnnotation(true)), okHandler)
+
+	if s.GetTool("minder_test_write") != nil {
+		t.Error("write tool should not be registered in read-only mode")
+	}
+	if s.GetTool("minder_test_read") == nil {
+		t.Error("read-only tool should be registered in read-only mode")
+	}
+}
+
+func TestAddTool_WriteToolsRegisteredByDefault(t *testing.T) {
+	t.Parallel()
+
+	tools := newReadOnlyTestTools(false)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.addTool(s, writeTool(), okHandler)
+
+	if s.GetTool("minder_test_write") == nil {
+		t.Error("write tool should be registered when read-only mode is off")
+	}
+}
+
+func TestRejectInReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tools := newReadOnlyTestTools(true)
+	handler := tools.rejectInReadOnly("minder_test_write", okHandler)
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned Go error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result in read-only mode")
+	}
+	if text := getResultText(t, result); !strings.Contains(text, "read-only") {
+		t.Errorf("error %q should mention read-only mode", text)
+	}
+}
+
+func TestAddTool_ReadOnlyRefusal(t *testing.T) {
+	t.Parallel()
+
+	// Registered before read-only mode was turned on, then reached anyway
+	tools := newReadOnlyTestTools(false)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.addTool(s, writeTool(), okHandler)
+	handler := s.GetTool("minder_test_write").Handler
+	tools.cfg.MCP.ReadOnly = true
+
+	ctx := middleware.ContextWithRequestID(context.Background(), "req-7")
+	result, err := handler(ctx, mcp.CallToolRequest{})
+	if err != nil || result == nil || !result.IsError {
+		t.Fatalf("handler() = %v, %v, want an error result", result, err)
+	}
+	detail, ok := errorDetailOf(result)
+	if !ok || detail.Code != ErrCodeFailedPrecondition || detail.RequestID != "req-7" {
+		t.Errorf("error detail = %+v, want failed_precondition with the request ID", detail)
+	}
+
+	tools.SetEnabledTools([]string{"minder_list_profiles"})
+	result, err = handler(ctx, mcp.CallToolRequest{})
+	if err != nil || !result.IsError || !strings.Contains(getResultText(t, result), "disabled") {
+		t.Errorf("handler() = %v, %v, want the disabled tool error", result, err)
+	}
+}
+
+func TestRegister_AllToolsAreReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tools := newReadOnlyTestTools(true)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+
+	for name, tool := range s.ListTools() {
+		if !isReadOnlyTool(tool.Tool) {
+			t.Errorf("tool %s is registered in read-only mode without a read-only hint", name)
+		}
+	}
+	if len(s.ListTools()) == 0 {
+		t.Error("expected read-only tools to be registered")
+	}
+}
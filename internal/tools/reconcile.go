@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// reevaluateRepository looks up a repository by owner/name or ID and asks
+// Minder to reconcile it, which re-evaluates every profile that selects it.
+// The evaluation runs asynchronously in Minder after the call returns.
+func (t *Tools) reevaluateRepository(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	repoID := req.GetString("repository_id", "")
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	provider := req.GetString("provider", "")
+
+	if errMsg := ValidateRepositoryLookupParams(repoID, owner, name, map[string]string{
+		"project_id": projectID,
+		"provider":   provider,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	repo, err := lookupRepository(ctx, client, repoID, owner, name, projectID, provider)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	repoCtx := &minderv1.Context{}
+	if c := repo.GetContext(); c != nil {
+		repoCtx.Project, repoCtx.Provider = c.Project, c.Provider
+	}
+	if _, err := client.Projects().CreateEntityReconciliationTask(ctx, &minderv1.CreateEntityReconciliationTaskRequest{
+		Entity:  &minderv1.EntityTypedId{Type: minderv1.Entity_ENTITY_REPOSITORIES, Id: repo.GetId()},
+		Context: repoCtx,
+	}); err != nil {
+		return grpcErrorResult(err), nil
+	}
+	fullName := repo.GetOwner() + "/" + repo.GetName()
+	t.logger.InfoContext(ctx, "requested repository re-evaluation", "repository", fullName, "repository_id", repo.GetId())
+
+	return marshalResult(ctx, map[string]any{
+		"status":        "queued",
+		"repository":    fullName,
+		"repository_id": repo.GetId(),
+		"project":       repo.GetContext().GetProject(),
+		"message": "Minder re-evaluates the repository against its profiles in the background. " +
+			"Use minder_watch_profile_status to wait for the results.",
+	})
+}
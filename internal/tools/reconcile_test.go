@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReevaluateRepository(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.getByNameResp = &minderv1.GetRepositoryByNameResponse{Repository: &minderv1.Repository{
+		Id:      ptr("repo-1"),
+		Context: &minderv1.Context{Project: ptr("proj-1"), Provider: ptr("github-app")},
+		Owner:   "stacklok",
+		Name:    "minder",
+	}}
+
+	result, err := newTestTools(mockClient).reevaluateRepository(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"owner": "stacklok", "name": "minder"}},
+	})
+	if err != nil {
+		t.Fatalf("reevaluateRepository() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	if text := getResultText(t, result); !strings.Contains(text, `"status": "queued"`) ||
+		!strings.Contains(text, `"repository": "stacklok/minder"`) {
+		t.Errorf("result = %s, want stacklok/minder queued", text)
+	}
+
+	reconcile := mockClient.projects.reconcileReq
+	if reconcile.GetEntity().GetId() != "repo-1" || reconcile.GetEntity().GetType() != minderv1.Entity_ENTITY_REPOSITORIES {
+		t.Errorf("reconciled %v, want repository repo-1", reconcile.GetEntity())
+	}
+	if reconcile.GetContext().GetProject() != "proj-1" || reconcile.GetContext().GetProvider() != "github-app" {
+		t.Errorf("reconcile context = %v, want the repository's project and provider", reconcile.GetContext())
+	}
+}
+
+func TestReevaluateRepository_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		reconcileErr error
+		wantMsg      string
+	}{
+		{
+			name:    "no lookup",
+			args:    map[string]any{},
+			wantMsg: "repository_id",
+		},
+		{
+			name:    "owner without name",
+			args:    map[string]any{"owner": "stacklok"},
+			wantMsg: "name",
+		},
+		{
+			name:         "reconcile denied",
+			args:         map[string]any{"repository_id": "repo-1"},
+			reconcileErr: status.Error(codes.PermissionDenied, "denied"),
+			wantMsg:      "ermission",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{
+				Repository: &minderv1.Repository{Id: ptr("repo-1"), Owner: "stacklok", Name: "minder"},
+			}
+			mockClient.projects.reconcileErr = tt.reconcileErr
+			result, err := newTestTools(mockClient).reevaluateRepository(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("reevaluateRepository() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("expected error result, got %s", getResultText(t, result))
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
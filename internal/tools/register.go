@@ -4,15 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net"
+	"runtime/debug"
+	"strconv"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"google.golang.org/grpc"
 
+	"github.com/stacklok/minder-mcp/internal/callmeta"
 	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/history"
+	"github.com/stacklok/minder-mcp/internal/metrics"
 	"github.com/stacklok/minder-mcp/internal/middleware"
 	"github.com/stacklok/minder-mcp/internal/minder"
+	"github.com/stacklok/minder-mcp/internal/plugin"
 	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/stats"
+	"github.com/stacklok/minder-mcp/internal/store"
+	"github.com/stacklok/minder-mcp/internal/timing"
 )
 
 // Tools holds the tool handlers and configuration.
@@ -21,6 +33,19 @@ type Tools struct {
 	clientFactory  ClientFactory
 	logger         *slog.Logger
 	tokenRefresher *minder.TokenRefresher
+	pool           *minder.Pool
+	calls          *minder.CallTracker
+	stats          *stats.Collector
+	limiter        *callLimiter
+	rateLimiter    *rateLimiter
+	enabled        enabledTools
+	sessions       sessionStore
+	history        *history.Store
+	interceptors   []grpc.UnaryClientInterceptor
+	userAgent      string
+	overrides      ToolOverrides
+	plugins        []*plugin.Plugin
+	results        resultStore
 }
 
 // New creates a new Tools instance with the default client factory.
@@ -29,20 +54,71 @@ func New(cfg *config.Config, logger *slog.Logger) *Tools {
 		cfg:            cfg,
 		logger:         logger,
 		tokenRefresher: minder.NewTokenRefresher(),
+		pool:           minder.NewPool(),
+		calls:          minder.NewCallTracker(),
+		stats:          stats.NewCollector(),
+		limiter:        newCallLimiter(cfg.MCP.MaxConcurrentCalls, cfg.MCP.MaxConcurrentCallsPerTool),
+		rateLimiter:    newRateLimiter(cfg.MCP.RateLimitPerMinute, cfg.MCP.RateLimitBurst),
 	}
 	t.clientFactory = t.defaultClientFactory
+	t.AddClientInterceptor(t.calls.Interceptor())
+	if t.rateLimiter != nil {
+		t.AddClientInterceptor(t.rateLimiter.Interceptor())
+	}
+	if path := cfg.Minder.RealmCachePath; path != "" {
+		if err := t.tokenRefresher.EnableRealmCache(path, logger); err != nil {
+			// The cache only saves a discovery round trip; start without its entries
+			logger.Warn("ignoring realm cache", "path", path, "error", err)
+		}
+	}
+	t.SetEnabledTools(cfg.MCP.EnabledTools)
 	return t
 }
 
+// PersistRealms keeps discovered realms in the embedded store st, in place
+// of the realm cache file, so they survive restarts.
+func (t *Tools) PersistRealms(st *store.Store) error {
+	if t.tokenRefresher == nil {
+		return nil
+	}
+	return t.tokenRefresher.EnableRealmStore(st, t.logger)
+}
+
 // NewWithClientFactory creates a new Tools instance with a custom client factory.
 // This is useful for testing with mock clients.
 func NewWithClientFactory(cfg *config.Config, logger *slog.Logger, factory ClientFactory) *Tools {
-	return &Tools{
+	t := &Tools{
 		cfg:           cfg,
 		clientFactory: factory,
 		logger:        logger,
+		stats:         stats.NewCollector(),
+		limiter:       newCallLimiter(cfg.MCP.MaxConcurrentCalls, cfg.MCP.MaxConcurrentCallsPerTool),
+		rateLimiter:   newRateLimiter(cfg.MCP.RateLimitPerMinute, cfg.MCP.RateLimitBurst),
 		// tokenRefresher not needed when using custom factory (e.g., for tests)
 	}
+	t.SetEnabledTools(cfg.MCP.EnabledTools)
+	return t
+}
+
+// AddClientInterceptor adds an interceptor to every Minder client created by the
+// default client factory, e.g. to record responses. Call it before serving.
+func (t *Tools) AddClientInterceptor(interceptor grpc.UnaryClientInterceptor) {
+	t.interceptors = append(t.interceptors, interceptor)
+}
+
+// SetUserAgent sets the user-agent, e.g. "minder-mcp/v1.2.0", sent to Minder
+// by clients of the default client factory so Minder can tell this server's
+// traffic from the CLI's. Call it before serving.
+func (t *Tools) SetUserAgent(userAgent string) {
+	t.userAgent = userAgent
+	if t.tokenRefresher != nil {
+		t.tokenRefresher.SetUserAgent(userAgent)
+	}
+}
+
+// Stats returns the collector tracking tool usage for this Tools instance.
+func (t *Tools) Stats() *stats.Collector {
+	return t.stats
 }
 
 // Close releases resources held by the Tools instance.
@@ -50,24 +126,167 @@ func (t *Tools) Close() {
 	if t.tokenRefresher != nil {
 		t.tokenRefresher.Close()
 	}
+	if t.pool != nil {
+		_ = t.pool.Close()
+	}
 }
 
-// wrapHandler wraps a tool handler with debug logging.
+// wrapHandler wraps a tool handler with request ID propagation, debug logging and metrics.
+// Logs, metrics and the enabled-tools check use the tool's prefixed name.
 func (t *Tools) wrapHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	name = t.toolName(name)
+	handler = t.withProjectNames(handler)
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !t.toolEnabled(name) {
+			return disabledToolResult(name), nil
+		}
+
+		requestID := middleware.RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = middleware.NewRequestID()
+			ctx = middleware.ContextWithRequestID(ctx, requestID)
+		}
+		if id := sessionID(ctx); id != "" {
+			ctx = middleware.ContextWithSessionID(ctx, id)
+		}
+
+		ctx = withCompactJSON(ctx, t.cfg.MCP.CompactJSON)
+		ctx = withHealthCheckTimeout(ctx, t.cfg.MCP.HealthCheckTimeout)
+		ctx, rec := timing.NewContext(ctx)
+		ctx, info := callmeta.NewContext(ctx)
 		start := time.Now()
+		// Sensitive arguments are masked by the logging handler.
 		t.logger.DebugContext(ctx, "tool invoked", "tool", name, "params", req.Params.Arguments)
-		result, err := handler(ctx, req)
+		var result *mcp.CallToolResult
+		var err error
+		if allowed, wait := t.rateLimiter.allow(ctx); !allowed {
+			t.logger.WarnContext(ctx, "tool call rate limited", "tool", name, "retry_after", wait)
+			result = rateLimitResult(name, wait)
+		} else {
+			var release func()
+			release, err = t.limiter.acquire(ctx, name)
+			if t.limiter != nil {
+				timing.Record(ctx, "queue", start)
+			}
+			if err != nil {
+				t.logger.WarnContext(ctx, "tool call gave up waiting for a concurrency slot", "tool", name, "error", err)
+				result, err = concurrencyLimitResult(name, err), nil
+			} else {
+				result, err = t.callHandler(ctx, name, handler, req, release)
+			}
+		}
+		duration := time.Since(start)
 		hasError := err != nil
-		t.logger.DebugContext(ctx, "tool completed", "tool", name, "duration", time.Since(start), "error", hasError)
+		t.logger.DebugContext(ctx, "tool completed", "tool", name, "duration", duration, "error", hasError)
+		if threshold := t.cfg.MCP.SlowCallThreshold; threshold > 0 && duration >= threshold {
+			t.logger.WarnContext(ctx, "slow tool call",
+				"tool", name,
+				"duration", duration,
+				"threshold", threshold,
+				"breakdown", rec.Breakdown(duration),
+			)
+		}
+		addCallMeta(result, info, duration)
+		result = t.linkOversizedResult(ctx, name, result)
+		errorCode := ""
+		if result != nil && result.IsError {
+			addErrorDetail(result, requestID)
+			appendRequestID(result, requestID)
+			detail, _ := errorDetailOf(result)
+			errorCode = detail.Code
+		}
+		outcome := toolOutcome(result, err)
+		metrics.ObserveTool(name, outcome, errorCode, duration)
+		t.stats.Record(name, outcome != metrics.OutcomeSuccess, duration)
 		return result, err
 	}
 }
 
-// Register registers all MCP tools with the server.
+// callHandler runs handler and then release. A panicking handler is recovered
+// and reported as an internal error, so it neither takes the server down nor
+// keeps its concurrency slot.
+func (t *Tools) callHandler(
+	ctx context.Context, name string, handler server.ToolHandlerFunc, req mcp.CallToolRequest, release func(),
+) (result *mcp.CallToolResult, err error) {
+	defer release()
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger.ErrorContext(ctx, "tool handler panicked", "tool", name, "panic", r, "stack", string(debug.Stack()))
+			result, err = errorResult(fmt.Sprintf("Tool %s failed with an internal error", name), ErrorDetail{
+				Code:            ErrCodeInternal,
+				SuggestedAction: "Report the error to the server administrator with its request ID.",
+			}), nil
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// addCallMeta adds what the call did to the _meta of its result, next to any
+// fields the tool set, to help explain slow or surprising answers.
+func addCallMeta(result *mcp.CallToolResult, info *callmeta.Info, elapsed time.Duration) {
+	if result == nil {
+		return
+	}
+	if result.Meta == nil {
+		result.Meta = mcp.NewMetaFromMap(map[string]any{})
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = map[string]any{}
+	}
+	maps.Copy(result.Meta.AdditionalFields, info.Fields(elapsed))
+}
+
+// addErrorDetail stamps the request ID on an error result's structured
+// detail, adding an ErrCodeToolError detail to results that have none.
+func addErrorDetail(result *mcp.CallToolResult, requestID string) {
+	detail, ok := errorDetailOf(result)
+	if !ok {
+		detail = ErrorDetail{Code: ErrCodeToolError}
+		for _, content := range result.Content {
+			if text, ok := mcp.AsTextContent(content); ok {
+				detail.Message = text.Text
+				break
+			}
+		}
+	}
+	detail.RequestID = requestID
+	result.StructuredContent = map[string]any{"error": detail}
+}
+
+// appendRequestID adds the request ID to the text of an error result so users
+// can quote it when reporting problems.
+func appendRequestID(result *mcp.CallToolResult, requestID string) {
+	for i, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			text.Text += " (request_id: " + requestID + ")"
+			result.Content[i] = *text
+			return
+		}
+	}
+}
+
+// toolOutcome classifies a tool handler's return values for metrics.
+func toolOutcome(result *mcp.CallToolResult, err error) string {
+	switch {
+	case err != nil:
+		return metrics.OutcomeFailure
+	case result != nil && result.IsError:
+		return metrics.OutcomeToolError
+	default:
+		return metrics.OutcomeSuccess
+	}
+}
+
+// Register registers all MCP tools with the server, and the resources serving
+// oversized tool results.
 func (t *Tools) Register(s *server.MCPServer) {
+	t.registerResultResources(s)
+	t.registerPolicyResources(s)
+	t.registerEvaluationResource(s)
+	t.registerProjectsResource(s)
+
 	// Projects
-	s.AddTool(mcp.NewTool("minder_list_projects",
+	t.addTool(s, mcp.NewTool("minder_list_projects",
 		mcp.WithDescription("List projects accessible to the current user. "+
 			"If project_id is provided, lists child projects of that project. "+
 			"Otherwise, lists all top-level accessible projects."),
@@ -75,12 +294,76 @@ func (t *Tools) Register(s *server.MCPServer) {
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("UUID of a parent project to list children for. Omit to list all accessible projects"),
+			mcp.Description("UUID or name of a parent project to list children for. Omit to list all accessible projects"),
+		),
+	), t.listProjects)
+
+	t.addTool(s, mcp.NewTool("minder_resolve",
+		mcp.WithDescription("Resolve a name to the ID of a project, repository, profile, rule type, artifact "+
+			"or data source, with its project ID and name, instead of searching list results. "+
+			"Returns every match, since the same name may exist in several projects."),
+		mcp.WithTitleAnnotation("Resolve Name to ID"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Title("Kind"),
+			mcp.Description("Kind of entity the name belongs to"),
+			mcp.Enum(resolveKinds...),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Title("Name"),
+			mcp.Description("Name of the entity; owner/name for repositories"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only search this project. Omit to search all accessible projects. Not used for projects"),
+		),
+	), t.resolveName)
+
+	t.addTool(s, mcp.NewTool("minder_set_context",
+		mcp.WithDescription("Pin a default project and provider for the rest of this session. "+
+			"Later tool calls that omit project_id or provider use these defaults. "+
+			"Call without arguments to show the current context, or with clear to remove it."),
+		mcp.WithTitleAnnotation("Set Session Context"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name to use when a tool call omits project_id"),
+		),
+		mcp.WithString("provider",
+			mcp.Title("Provider"),
+			mcp.Description("Provider name to use when a tool call omits provider (e.g., 'github-app-acme')"),
 		),
-	), t.wrapHandler("minder_list_projects", t.listProjects))
+		mcp.WithBoolean("clear",
+			mcp.Title("Clear"),
+			mcp.Description("Remove the pinned project and provider"),
+		),
+	), t.setContext)
+
+	t.addTool(s, mcp.NewTool("minder_save_credentials",
+		mcp.WithDescription("Save a Minder token for the rest of this session, used in place of the configured "+
+			"or Authorization header token for the selected server. "+
+			"Prefer an offline token from `minder auth offline-token get`, which is refreshed as needed. "+
+			"The token is checked before it is saved."),
+		mcp.WithTitleAnnotation("Save Credentials"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("token",
+			mcp.Required(),
+			mcp.Title("Token"),
+			mcp.Description("Offline or access token for the selected Minder server"),
+		),
+	), t.saveCredentials)
+
+	t.addTool(s, mcp.NewTool("minder_logout",
+		mcp.WithDescription("Forget the tokens saved with minder_save_credentials in this session and the "+
+			"access tokens cached for them and for the token in use, so the next call must authenticate again."),
+		mcp.WithTitleAnnotation("Log Out"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	), t.logout)
 
 	// Repositories
-	s.AddTool(mcp.NewTool("minder_list_repositories",
+	t.addTool(s, mcp.NewTool("minder_list_repositories",
 		mcp.WithDescription("List repositories registered with Minder. "+
 			"Returns repository details including ID, name, owner, provider, and registration status. "+
 			"Supports cursor-based pagination."),
@@ -88,7 +371,7 @@ func (t *Tools) Register(s *server.MCPServer) {
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter repositories by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter repositories by project UUID or name. Omit to list from all accessible projects"),
 		),
 		mcp.WithString("provider",
 			mcp.Title("Provider"),
@@ -104,9 +387,9 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Min(1),
 			mcp.Max(100),
 		),
-	), t.wrapHandler("minder_list_repositories", t.listRepositories))
+	), t.listRepositories)
 
-	s.AddTool(mcp.NewTool("minder_get_repository",
+	t.addTool(s, mcp.NewTool("minder_get_repository",
 		mcp.WithDescription("Get a repository by ID or owner/name. "+
 			"Use repository_id for UUID lookup, or provide both owner and name for name lookup."),
 		mcp.WithTitleAnnotation("Get Repository"),
@@ -131,25 +414,121 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Provider"),
 			mcp.Description("Provider filter. Only valid with owner/name lookup"),
 		),
-	), t.wrapHandler("minder_get_repository", t.getRepository))
+	), t.getRepository)
+
+	t.addTool(s, mcp.NewTool("minder_get_repository_webhook",
+		mcp.WithDescription("Check the webhook Minder registered for a repository: whether it is recorded, "+
+			"its ID, URL and type, and when the repository was last evaluated. "+
+			"Use this to debug Minder no longer reacting to pushes or pull requests."),
+		mcp.WithTitleAnnotation("Get Repository Webhook"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("repository_id",
+			mcp.Title("Repository ID"),
+			mcp.Description("UUID of the repository. Mutually exclusive with owner/name"),
+		),
+		mcp.WithString("owner",
+			mcp.Title("Owner"),
+			mcp.Description("Repository owner or organization. Required with name for name lookup"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Name"),
+			mcp.Description("Repository name without owner prefix. Required with owner for name lookup"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with owner/name lookup"),
+		),
+		mcp.WithString("provider",
+			mcp.Title("Provider"),
+			mcp.Description("Provider filter. Only valid with owner/name lookup"),
+		),
+	), t.getRepositoryWebhook)
+
+	t.addTool(s, mcp.NewTool("minder_reregister_repository",
+		mcp.WithDescription("Re-create a repository's Minder webhook by deleting the repository from Minder "+
+			"and registering it again. This discards the repository's evaluation history. "+
+			"Use only when minder_get_repository_webhook shows a missing or broken webhook."),
+		mcp.WithTitleAnnotation("Re-register Repository"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("repository_id",
+			mcp.Title("Repository ID"),
+			mcp.Description("UUID of the repository. Mutually exclusive with owner/name"),
+		),
+		mcp.WithString("owner",
+			mcp.Title("Owner"),
+			mcp.Description("Repository owner or organization. Required with name for name lookup"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Name"),
+			mcp.Description("Repository name without owner prefix. Required with owner for name lookup"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with owner/name lookup"),
+		),
+		mcp.WithString("provider",
+			mcp.Title("Provider"),
+			mcp.Description("Provider filter. Only valid with owner/name lookup"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Title("Confirm"),
+			mcp.Description("Must be true to acknowledge that evaluation history is discarded"),
+		),
+	), t.reregisterRepository)
+
+	t.addTool(s, mcp.NewTool("minder_reevaluate_repository",
+		mcp.WithDescription("Ask Minder to re-evaluate a repository against every profile that selects it, "+
+			"looking the repository up by owner/name so no ID is needed. Evaluation runs in the background; "+
+			"use minder_watch_profile_status to wait for the new results."),
+		mcp.WithTitleAnnotation("Re-evaluate Repository"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString("owner",
+			mcp.Title("Owner"),
+			mcp.Description("Repository owner or organization. Required with name for name lookup"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Name"),
+			mcp.Description("Repository name without owner prefix. Required with owner for name lookup"),
+		),
+		mcp.WithString("repository_id",
+			mcp.Title("Repository ID"),
+			mcp.Description("UUID of the repository. Mutually exclusive with owner/name"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with owner/name lookup"),
+		),
+		mcp.WithString("provider",
+			mcp.Title("Provider"),
+			mcp.Description("Provider filter. Only valid with owner/name lookup"),
+		),
+	), t.reevaluateRepository)
 
 	// Profiles
-	s.AddTool(mcp.NewTool("minder_list_profiles",
+	t.addTool(s, mcp.NewTool("minder_list_profiles",
 		mcp.WithDescription("List security profiles configured in Minder. "+
-			"Returns profile names, IDs, and associated rule configurations."),
+			"Returns profile names, IDs, and associated rule configurations. Set summary to return only "+
+			"each profile's name, ID, labels, rule counts and alert and remediation settings."),
 		mcp.WithTitleAnnotation("List Profiles"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter profiles by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter profiles by project UUID or name. Omit to list from all accessible projects"),
 		),
 		mcp.WithString("label_filter",
 			mcp.Title("Label Filter"),
 			mcp.Description("Filter profiles by label selector expression"),
 		),
-	), t.wrapHandler("minder_list_profiles", t.listProfiles))
+		mcp.WithBoolean("summary",
+			mcp.Title("Summary"),
+			mcp.Description("Return a summary of each profile instead of its full rule configuration (default false)"),
+		),
+	), t.listProfiles)
 
-	s.AddTool(mcp.NewTool("minder_get_profile",
+	t.addTool(s, mcp.NewTool("minder_get_profile",
 		mcp.WithDescription("Get a security profile by ID or name. "+
 			"Use profile_id for UUID lookup, or name for name lookup."),
 		mcp.WithTitleAnnotation("Get Profile"),
@@ -166,9 +545,9 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Project ID"),
 			mcp.Description("Project scope. Only valid with name lookup"),
 		),
-	), t.wrapHandler("minder_get_profile", t.getProfile))
+	), t.getProfile)
 
-	s.AddTool(mcp.NewTool("minder_get_profile_status",
+	t.addTool(s, mcp.NewTool("minder_get_profile_status",
 		mcp.WithDescription("Get the current evaluation status of a profile by ID or name. "+
 			"Use profile_id for UUID lookup, or name for name lookup. "+
 			"Returns compliance status and detailed per-rule evaluation results for all entities."),
@@ -186,21 +565,167 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Project ID"),
 			mcp.Description("Project scope. Only valid with name lookup"),
 		),
-	), t.wrapHandler("minder_get_profile_status", t.getProfileStatus))
+		mcp.WithString("format",
+			mcp.Title("Output Format"),
+			mcp.Description("json (default) returns the full status. github_annotations returns failing evaluations "+
+				"as GitHub Actions workflow commands (::error/::warning lines) for CI jobs"),
+			mcp.Enum(formatJSON, formatGitHubAnnotations),
+		),
+		mcp.WithString("min_severity",
+			mcp.Title("Minimum Severity"),
+			mcp.Description("Only include rules whose rule type has at least this severity; "+
+				"rules without a declared severity count as medium"),
+			mcp.Enum("info", "low", "medium", "high", "critical"),
+		),
+	), t.getProfileStatus)
+
+	t.addTool(s, mcp.NewTool("minder_get_all_profile_statuses",
+		mcp.WithDescription("Get the status of every profile in a project in one call, instead of listing "+
+			"profiles and calling minder_get_profile_status for each. Returns a summary of profiles by status "+
+			"and, per profile, its rule evaluation counts by status and the failing rules, with failing profiles "+
+			"first. Profiles whose status cannot be read are listed under errors. With group_by=label, also "+
+			"summarizes the profiles of each label, e.g. team:payments, for team-sliced compliance views."),
+		mcp.WithTitleAnnotation("Get All Profile Statuses"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name. Omit to cover all accessible projects"),
+		),
+		mcp.WithString("label_filter",
+			mcp.Title("Label Filter"),
+			mcp.Description("Filter profiles by labels. '*' includes all (default), "+
+				"empty for unlabeled only. Prefix with '!' to exclude (e.g., '!system')."),
+		),
+		mcp.WithString("group_by",
+			mcp.Title("Group By"),
+			mcp.Description("Add a summary per profile label under groups; unlabeled profiles are grouped as (unlabeled)"),
+			mcp.Enum("label"),
+		),
+		mcp.WithString("min_severity",
+			mcp.Title("Minimum Severity"),
+			mcp.Description("Only include rules whose rule type has at least this severity; "+
+				"rules without a declared severity count as medium"),
+			mcp.Enum("info", "low", "medium", "high", "critical"),
+		),
+	), t.getAllProfileStatuses)
+
+	t.addTool(s, mcp.NewTool("minder_watch_profile_status",
+		mcp.WithDescription("Wait for a profile's evaluation status to change, for example for a remediation "+
+			"to take effect. Polls Minder every few seconds for at most timeout_seconds and returns as soon as "+
+			"the overall status or any rule evaluation's status or remediation status changes, listing the "+
+			"changes. Returns status unchanged when the wait runs out; call again to keep waiting. Sends "+
+			"progress notifications when the request carries a progress token."),
+		mcp.WithTitleAnnotation("Watch Profile Status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("profile_id",
+			mcp.Title("Profile ID"),
+			mcp.Description("UUID of the profile. Mutually exclusive with name"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Profile Name"),
+			mcp.Description("Name of the profile. Mutually exclusive with profile_id"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with name lookup"),
+		),
+		mcp.WithNumber("timeout_seconds",
+			mcp.Title("Timeout Seconds"),
+			mcp.Description("How long to wait for a change (default 60, at most 300)"),
+			mcp.Min(1),
+			mcp.Max(300),
+		),
+	), t.watchProfileStatus)
+
+	t.addTool(s, mcp.NewTool("minder_get_entity_status",
+		mcp.WithDescription("Get how every profile that selects a repository or artifact evaluated it, "+
+			"answering \"is this repository compliant?\". The inverse of minder_get_profile_status: "+
+			"returns whether the entity is compliant, the number of failing rules and, per profile, "+
+			"the status of each rule. Identify a repository by repository_id or owner and name, "+
+			"or an artifact by artifact_id or artifact_name."),
+		mcp.WithTitleAnnotation("Get Entity Status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("repository_id",
+			mcp.Title("Repository ID"),
+			mcp.Description("UUID of the repository. Mutually exclusive with owner/name"),
+		),
+		mcp.WithString("owner",
+			mcp.Title("Owner"),
+			mcp.Description("Repository owner or organization. Required with name for name lookup"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Name"),
+			mcp.Description("Repository name without owner prefix. Required with owner for name lookup"),
+		),
+		mcp.WithString("artifact_id",
+			mcp.Title("Artifact ID"),
+			mcp.Description("UUID of the artifact. Mutually exclusive with artifact_name and the repository parameters"),
+		),
+		mcp.WithString("artifact_name",
+			mcp.Title("Artifact Name"),
+			mcp.Description("Full artifact name including registry path. Mutually exclusive with artifact_id"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with name lookups"),
+		),
+		mcp.WithString("provider",
+			mcp.Title("Provider"),
+			mcp.Description("Provider filter. Only valid with name lookups"),
+		),
+	), t.getEntityStatus)
+
+	t.addTool(s, mcp.NewTool("minder_get_profile_actions",
+		mcp.WithDescription("Report, per profile and rule, whether Minder alerts on and remediates failures: "+
+			"the profile's alert and remediate settings and, per rule, the effective actions given what "+
+			"its rule type supports and a mode of remediate, alert_only, dry_run or evaluate_only. "+
+			"Lists the rules running in alert only mode and counts rules per mode."),
+		mcp.WithTitleAnnotation("Get Profile Alert and Remediation Settings"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("name",
+			mcp.Title("Profile Name"),
+			mcp.Description("Only report this profile. Omit to report every profile"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only report this project. Omit to report all accessible projects"),
+		),
+	), t.getProfileActions)
 
 	// Rule Types
-	s.AddTool(mcp.NewTool("minder_list_rule_types",
-		mcp.WithDescription("List available rule types that can be used in profiles. "+
-			"Returns rule type names, descriptions, and parameter schemas."),
+	t.addTool(s, mcp.NewTool("minder_list_rule_types",
+		mcp.WithDescription("List available rule types that can be used in profiles, ordered by name. "+
+			"The full view returns complete definitions, including evaluation code such as Rego; use "+
+			"view=summary for names, descriptions, entity types, severities and actions, or view=names "+
+			"for names only. Supports cursor-based pagination, also across all projects."),
 		mcp.WithTitleAnnotation("List Rule Types"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter rule types by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter rule types by project UUID or name. Omit to list from all accessible projects"),
+		),
+		mcp.WithString("entity_type",
+			mcp.Title("Entity Type"),
+			mcp.Description("Only list rule types evaluating this entity type (e.g., 'repository', 'artifact')"),
+		),
+		mcp.WithString("view",
+			mcp.Title("View"),
+			mcp.Description("How much of each rule type to return (default full)"),
+			mcp.Enum(ruleTypeViewFull, ruleTypeViewSummary, ruleTypeViewNames),
+		),
+		mcp.WithString("cursor",
+			mcp.Title("Pagination Cursor"),
+			mcp.Description("next_cursor from the previous response, with the same filters. Omit for first page"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Title("Page Size"),
+			mcp.Description("Maximum number of results per page (1-100)"),
+			mcp.Min(1),
+			mcp.Max(100),
 		),
-	), t.wrapHandler("minder_list_rule_types", t.listRuleTypes))
+	), t.listRuleTypes)
 
-	s.AddTool(mcp.NewTool("minder_get_rule_type",
+	t.addTool(s, mcp.NewTool("minder_get_rule_type",
 		mcp.WithDescription("Get a rule type by ID or name. "+
 			"Use rule_type_id for UUID lookup, or name for name lookup."),
 		mcp.WithTitleAnnotation("Get Rule Type"),
@@ -217,21 +742,139 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Project ID"),
 			mcp.Description("Project scope. Only valid with name lookup"),
 		),
-	), t.wrapHandler("minder_get_rule_type", t.getRuleType))
+	), t.getRuleType)
+
+	t.addTool(s, mcp.NewTool("minder_list_rule_type_profiles",
+		mcp.WithDescription("List every profile that includes a rule type, across accessible projects, "+
+			"with the entity type, rule name, parameters and definition values each profile configures. "+
+			"Use before editing or deleting a shared rule type to see what depends on it."),
+		mcp.WithTitleAnnotation("List Rule Type Profiles"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("rule_type",
+			mcp.Required(),
+			mcp.Title("Rule Type Name"),
+			mcp.Description("Name of the rule type, as referenced by profiles"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only search profiles in this project. Omit to search all accessible projects"),
+		),
+	), t.listRuleTypeProfiles)
+
+	t.addTool(s, mcp.NewTool("minder_get_rule_type_stats",
+		mcp.WithDescription("Summarize how each rule type is used: how many profiles and rules use it and how "+
+			"many of its current evaluations pass, fail or neither, with the pass rate. Lists unused rule "+
+			"types and rule types that fail everywhere they are evaluated, to help prune or fix rules. "+
+			"Rule types with the same name in several projects are combined."),
+		mcp.WithTitleAnnotation("Get Rule Type Statistics"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only summarize this project. Omit to summarize all accessible projects"),
+		),
+	), t.getRuleTypeStats)
+
+	t.addTool(s, mcp.NewTool("minder_list_failing_entities",
+		mcp.WithDescription("List every repository, artifact or other entity currently failing a rule type, "+
+			"answering \"which repositories fail branch protection?\". Covers every profile using the rule "+
+			"type; returns each failing evaluation with its entity, profile, rule, status, severity and details, "+
+			"and the number of distinct failing entities."),
+		mcp.WithTitleAnnotation("List Entities Failing a Rule Type"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("rule_type",
+			mcp.Required(),
+			mcp.Title("Rule Type"),
+			mcp.Description("Name of the rule type (e.g., 'secret_scanning')"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only search this project. Omit to search all accessible projects"),
+		),
+		mcp.WithString("min_severity",
+			mcp.Title("Minimum Severity"),
+			mcp.Description("Only include rules whose rule type has at least this severity; "+
+				"rules without a declared severity count as medium"),
+			mcp.Enum("info", "low", "medium", "high", "critical"),
+		),
+	), t.listFailingEntities)
+
+	t.addTool(s, mcp.NewTool("minder_get_coverage_matrix",
+		mcp.WithDescription("Show which profiles apply to which repositories as a matrix with a row per "+
+			"repository and a column per profile. Each cell is the profile's current status for that "+
+			"repository: failure if any rule failed or errored, otherwise success if any rule passed. "+
+			"Lists repositories no profile applies to and profiles that apply to no repository, "+
+			"for coverage reviews. Profiles with the same name in several projects share a column."),
+		mcp.WithTitleAnnotation("Get Coverage Matrix"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only include this project. Omit to include all accessible projects"),
+		),
+		mcp.WithString("format",
+			mcp.Title("Output Format"),
+			mcp.Description("json (default) returns the matrix as data. markdown returns it as a table, "+
+				"with a dash where a profile does not apply"),
+			mcp.Enum(formatJSON, formatMarkdown),
+		),
+	), t.getCoverageMatrix)
+
+	t.addTool(s, mcp.NewTool("minder_suggest_profile",
+		mcp.WithDescription("Generate a starter profile as YAML from built-in templates, such as baseline "+
+			"repository hygiene, based on the entities registered in a project and the rule types it defines. "+
+			"Templates are chosen by the registered entity types unless one is named, and only rules whose "+
+			"rule types exist in the project are included. Nothing is created: review the YAML, then create "+
+			"the profile with \"minder profile create -f\"."),
+		mcp.WithTitleAnnotation("Suggest Profile"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project to inspect and create the profile in"),
+			mcp.Required(),
+		),
+		mcp.WithString("template",
+			mcp.Title("Template"),
+			mcp.Description("Use only this template. Omit to pick templates by the registered entities"),
+			mcp.Enum(profileTemplateNames()...),
+		),
+		mcp.WithString("name",
+			mcp.Title("Profile Name"),
+			mcp.Description("Name of the profile. Defaults to the template name, or baseline for several templates"),
+		),
+	), t.suggestProfile)
+
+	t.addTool(s, mcp.NewTool("minder_validate_profile",
+		mcp.WithDescription("Check a profile, as YAML or JSON, against its project's rule types without "+
+			"creating it: each rule's rule type must exist and evaluate the entity type the rule is listed "+
+			"under, its def must match the rule type's rule schema and its params the parameter schema. "+
+			"Returns every problem with its rule and field, e.g. def.branches[0].name, instead of the single "+
+			"InvalidArgument error Minder returns. Use before minder profile create or apply."),
+		mcp.WithTitleAnnotation("Validate Profile"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("profile",
+			mcp.Required(),
+			mcp.Title("Profile"),
+			mcp.Description("The profile document, as written for minder profile create"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project whose rule types to check against. Defaults to the profile's context "+
+				"project, or all accessible projects"),
+		),
+	), t.validateProfile)
 
 	// Data Sources
-	s.AddTool(mcp.NewTool("minder_list_data_sources",
+	t.addTool(s, mcp.NewTool("minder_list_data_sources",
 		mcp.WithDescription("List data sources available for rule evaluations. "+
 			"Returns data source names, types, and configuration details."),
 		mcp.WithTitleAnnotation("List Data Sources"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter data sources by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter data sources by project UUID or name. Omit to list from all accessible projects"),
 		),
-	), t.wrapHandler("minder_list_data_sources", t.listDataSources))
+	), t.listDataSources)
 
-	s.AddTool(mcp.NewTool("minder_get_data_source",
+	t.addTool(s, mcp.NewTool("minder_get_data_source",
 		mcp.WithDescription("Get a data source by ID or name. "+
 			"Use data_source_id for UUID lookup, or name for name lookup."),
 		mcp.WithTitleAnnotation("Get Data Source"),
@@ -248,17 +891,17 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Project ID"),
 			mcp.Description("Project scope. Only valid with name lookup"),
 		),
-	), t.wrapHandler("minder_get_data_source", t.getDataSource))
+	), t.getDataSource)
 
 	// Providers
-	s.AddTool(mcp.NewTool("minder_list_providers",
+	t.addTool(s, mcp.NewTool("minder_list_providers",
 		mcp.WithDescription("List configured providers (e.g., GitHub, GitLab). "+
 			"Returns provider names, types, and connection status. Supports cursor-based pagination."),
 		mcp.WithTitleAnnotation("List Providers"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter providers by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter providers by project UUID or name. Omit to list from all accessible projects"),
 		),
 		mcp.WithString("cursor",
 			mcp.Title("Pagination Cursor"),
@@ -270,9 +913,9 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Min(1),
 			mcp.Max(100),
 		),
-	), t.wrapHandler("minder_list_providers", t.listProviders))
+	), t.listProviders)
 
-	s.AddTool(mcp.NewTool("minder_get_provider",
+	t.addTool(s, mcp.NewTool("minder_get_provider",
 		mcp.WithDescription("Get detailed information about a provider by its name. Returns provider configuration and capabilities."),
 		mcp.WithTitleAnnotation("Get Provider"),
 		mcp.WithReadOnlyHintAnnotation(true),
@@ -283,27 +926,69 @@ func (t *Tools) Register(s *server.MCPServer) {
 		),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Project UUID to scope the lookup. Omit to search all accessible projects"),
+			mcp.Description("Project UUID or name to scope the lookup. Omit to search all accessible projects"),
+		),
+	), t.getProvider)
+
+	t.addTool(s, mcp.NewTool("minder_get_provider_capabilities",
+		mcp.WithDescription("Report, per configured provider, which entity types profiles can evaluate through it "+
+			"(repositories, pull requests, artifacts) and which features it supports, such as enrolling "+
+			"repositories, auto-remediation, pull request remediation and security advisory alerts. "+
+			"Capabilities are derived from the traits the provider implements. Check this before suggesting "+
+			"actions a provider may not be able to perform."),
+		mcp.WithTitleAnnotation("Get Provider Capabilities"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Only include providers of this project. Omit to include all accessible projects"),
 		),
-	), t.wrapHandler("minder_get_provider", t.getProvider))
+		mcp.WithString("name",
+			mcp.Title("Provider Name"),
+			mcp.Description("Only include the provider with this name (e.g., 'github-app-acme')"),
+		),
+	), t.getProviderCapabilities)
+
+	t.addTool(s, mcp.NewTool("minder_wait_for_enrollment",
+		mcp.WithDescription("Wait for a provider enrollment to complete after the user was given an authorization "+
+			"URL, then report the connected provider and its capabilities. Polls Minder every few seconds for "+
+			"at most timeout_seconds. If the user has not finished authorizing yet, returns status pending; "+
+			"call again with the same enrollment_nonce to keep waiting."),
+		mcp.WithTitleAnnotation("Wait for Provider Enrollment"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("enrollment_nonce",
+			mcp.Required(),
+			mcp.Title("Enrollment Nonce"),
+			mcp.Description("The state returned with the authorization URL when the enrollment was started"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project the provider is enrolled in. Omit for the default project"),
+		),
+		mcp.WithNumber("timeout_seconds",
+			mcp.Title("Timeout Seconds"),
+			mcp.Description("How long to wait for the enrollment (default 60, at most 120)"),
+			mcp.Min(1),
+			mcp.Max(120),
+		),
+	), t.waitForEnrollment)
 
 	// Artifacts
-	s.AddTool(mcp.NewTool("minder_list_artifacts",
+	t.addTool(s, mcp.NewTool("minder_list_artifacts",
 		mcp.WithDescription("List artifacts (container images, packages) tracked by Minder. "+
 			"Returns artifact names, versions, and associated repositories."),
 		mcp.WithTitleAnnotation("List Artifacts"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter artifacts by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter artifacts by project UUID or name. Omit to list from all accessible projects"),
 		),
 		mcp.WithString("provider",
 			mcp.Title("Provider"),
 			mcp.Description("Filter artifacts by provider name (e.g., 'github')"),
 		),
-	), t.wrapHandler("minder_list_artifacts", t.listArtifacts))
+	), t.listArtifacts)
 
-	s.AddTool(mcp.NewTool("minder_get_artifact",
+	t.addTool(s, mcp.NewTool("minder_get_artifact",
 		mcp.WithDescription("Get an artifact by ID or name. "+
 			"Use artifact_id for UUID lookup, or name for name lookup."),
 		mcp.WithTitleAnnotation("Get Artifact"),
@@ -324,18 +1009,103 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Title("Provider"),
 			mcp.Description("Provider filter. Only valid with name lookup"),
 		),
-	), t.wrapHandler("minder_get_artifact", t.getArtifact))
+	), t.getArtifact)
+
+	t.addTool(s, mcp.NewTool("minder_get_artifact_vulnerabilities",
+		mcp.WithDescription("Get the vulnerability findings Minder has evaluated for an artifact by ID or name. "+
+			"Returns the status, severity and details of each evaluation by a rule whose type or name refers to "+
+			"vulnerability scanning (vuln, cve, osv, trivy, grype). Minder evaluates an artifact as a whole; "+
+			"version narrows the reported artifact versions to the given tag or digest."),
+		mcp.WithTitleAnnotation("Get Artifact Vulnerabilities"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("artifact_id",
+			mcp.Title("Artifact ID"),
+			mcp.Description("UUID of the artifact. Mutually exclusive with name"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Artifact Name"),
+			mcp.Description("Full artifact name including registry path. Mutually exclusive with artifact_id"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with name lookup"),
+		),
+		mcp.WithString("version",
+			mcp.Title("Version"),
+			mcp.Description("Tag or sha256 digest of the artifact version. Omit to report all tracked versions"),
+		),
+	), t.getArtifactVulnerabilities)
+
+	t.addTool(s, mcp.NewTool("minder_get_artifact_provenance",
+		mcp.WithDescription("Get the signature and provenance verification status of an artifact by ID or name. "+
+			"Consolidates the evaluations of rules that verify signatures, attestations or provenance "+
+			"(e.g. artifact_signature) into one status: verified, failing, incomplete or not_evaluated, "+
+			"with the status and details of each rule."),
+		mcp.WithTitleAnnotation("Get Artifact Provenance"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("artifact_id",
+			mcp.Title("Artifact ID"),
+			mcp.Description("UUID of the artifact. Mutually exclusive with name"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Artifact Name"),
+			mcp.Description("Full artifact name including registry path. Mutually exclusive with artifact_id"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Only valid with name lookup"),
+		),
+		mcp.WithString("version",
+			mcp.Title("Version"),
+			mcp.Description("Tag or sha256 digest of the artifact version. Omit to report all tracked versions"),
+		),
+	), t.getArtifactProvenance)
+
+	t.addTool(s, mcp.NewTool("minder_get_artifact_links",
+		mcp.WithDescription("Map artifacts to the repositories they were built from, answering \"which repository "+
+			"built this image?\" and \"which images come from this repository?\". Give an artifact to get its "+
+			"source repository, a repository to get its artifacts, or neither to map every artifact. "+
+			"A source repository that is not registered in Minder is returned with registered false."),
+		mcp.WithTitleAnnotation("Get Artifact Links"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("artifact_id",
+			mcp.Title("Artifact ID"),
+			mcp.Description("UUID of the artifact to find the source repository of"),
+		),
+		mcp.WithString("artifact_name",
+			mcp.Title("Artifact Name"),
+			mcp.Description("Name of the artifact to find the source repository of"),
+		),
+		mcp.WithString("repository_id",
+			mcp.Title("Repository ID"),
+			mcp.Description("UUID of the repository to list artifacts of"),
+		),
+		mcp.WithString("owner",
+			mcp.Title("Repository Owner"),
+			mcp.Description("Owner of the repository to list artifacts of. Use with name"),
+		),
+		mcp.WithString("name",
+			mcp.Title("Repository Name"),
+			mcp.Description("Name of the repository to list artifacts of. Use with owner"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project scope. Omit to search all accessible projects"),
+		),
+	), t.getArtifactLinks)
 
 	// Evaluation Results
-	s.AddTool(mcp.NewTool("minder_list_evaluation_history",
+	t.addTool(s, mcp.NewTool("minder_list_evaluation_history",
 		mcp.WithDescription("List historical evaluation results for profile rules. "+
 			"Returns evaluation timestamps, statuses, and entity details with filtering support. "+
-			"Supports cursor-based pagination with total record count."),
+			"Supports cursor-based pagination, also across all projects when project_id is omitted. "+
+			"total_records counts every matching evaluation, per project when several are listed; "+
+			"when where has clauses Minder cannot apply, the counts are reported as total_records_before_where instead."),
 		mcp.WithTitleAnnotation("List Evaluation History"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithString("project_id",
 			mcp.Title("Project ID"),
-			mcp.Description("Filter by project UUID. Omit to list from all accessible projects"),
+			mcp.Description("Filter by project UUID or name. Omit to list from all accessible projects"),
 		),
 		mcp.WithString("profile_name",
 			mcp.Title("Profile Name"),
@@ -375,7 +1145,7 @@ func (t *Tools) Register(s *server.MCPServer) {
 		),
 		mcp.WithString("cursor",
 			mcp.Title("Pagination Cursor"),
-			mcp.Description("Cursor from previous response for pagination. Omit for first page"),
+			mcp.Description("next_cursor from the previous response, with the same filters. Omit for first page"),
 		),
 		mcp.WithNumber("page_size",
 			mcp.Title("Page Size"),
@@ -388,7 +1158,138 @@ func (t *Tools) Register(s *server.MCPServer) {
 			mcp.Description("Filter by profile labels. '*' includes all (default), "+
 				"empty for unlabeled only. Prefix with '!' to exclude (e.g., '!system')."),
 		),
-	), t.wrapHandler("minder_list_evaluation_history", t.listEvaluationHistory))
+		mcp.WithString("where",
+			mcp.Title("Where"),
+			mcp.Description("Filter expression of comparisons joined by AND, e.g. "+
+				"'status = failure AND rule ~ \"branch\" AND age < 7d'. Fields: status, remediation, alert, "+
+				"profile, entity, entity_type, rule (name or rule type), rule_type, age. "+
+				"Operators: = and != for all but age; ~ and !~ (case-insensitive substring) for profile, entity, "+
+				"rule and rule_type; < <= > >= for age (e.g. 12h, 7d, 2w). "+
+				"Clauses Minder cannot filter on are applied to each returned page, so pages may be short"),
+		),
+	), t.listEvaluationHistory)
+
+	t.addTool(s, mcp.NewTool("minder_get_evaluation",
+		mcp.WithDescription("Get one evaluation by ID with full details: the evaluated entity, "+
+			"the rule and profile, the evaluation status and failure output, alert and remediation details, "+
+			"and the rule type's description and guidance. Use IDs from minder_list_evaluation_history."),
+		mcp.WithTitleAnnotation("Get Evaluation"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("evaluation_id",
+			mcp.Required(),
+			mcp.Title("Evaluation ID"),
+			mcp.Description("UUID of the evaluation"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name the evaluation belongs to. Omit to search all accessible projects"),
+		),
+	), t.getEvaluation)
+
+	t.addTool(s, mcp.NewTool("minder_explain_evaluation",
+		mcp.WithDescription("Explain an evaluation and how to fix it in one call: combines the evaluation's "+
+			"status and failure details with the rule type's description and guidance, the remediation "+
+			"and alert state, whether the rule type can remediate and whether the profile enables it, "+
+			"and a suggested next step. Use IDs from minder_list_evaluation_history."),
+		mcp.WithTitleAnnotation("Explain Evaluation"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("evaluation_id",
+			mcp.Required(),
+			mcp.Title("Evaluation ID"),
+			mcp.Description("UUID of the evaluation"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name the evaluation belongs to. Omit to search all accessible projects"),
+		),
+	), t.explainEvaluation)
+
+	t.addTool(s, mcp.NewTool("minder_get_evaluation_timeline",
+		mcp.WithDescription("Get the chronological timeline of one rule on one entity: the evaluations "+
+			"where its status, remediation or alert state changed, and per profile the current status and "+
+			"since when it has been failing. Answers \"when did this repository start failing branch "+
+			"protection and what happened since?\""),
+		mcp.WithTitleAnnotation("Get Evaluation Timeline"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("entity_name",
+			mcp.Required(),
+			mcp.Title("Entity Name"),
+			mcp.Description("Name of the entity, e.g. owner/name for a repository"),
+		),
+		mcp.WithString("entity_type",
+			mcp.Title("Entity Type"),
+			mcp.Description("Type of the entity. Defaults to repository"),
+			mcp.Enum("repository", "artifact", "pull_request"),
+		),
+		mcp.WithString("rule",
+			mcp.Required(),
+			mcp.Title("Rule"),
+			mcp.Description("Name of the rule as used in the profile, as reported by minder_get_entity_status"),
+		),
+		mcp.WithString("profile_name",
+			mcp.Title("Profile Name"),
+			mcp.Description("Only include evaluations for this profile"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name. Omit to search all accessible projects"),
+		),
+		mcp.WithString("from",
+			mcp.Title("From Time"),
+			mcp.Description("Only include evaluations after this RFC3339 time (e.g., 2024-01-15T09:00:00Z)"),
+		),
+	), t.getEvaluationTimeline)
+
+	t.addTool(s, mcp.NewTool("minder_get_pull_request_evaluations",
+		mcp.WithDescription("Get the checks Minder ran on a repository's pull requests, such as vulnerability "+
+			"and package reputation checks. Returns one entry per pull request with the latest status of each "+
+			"profile rule, most recently evaluated first."),
+		mcp.WithTitleAnnotation("Get Pull Request Evaluations"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Title("Owner"),
+			mcp.Description("Repository owner or organization"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Title("Name"),
+			mcp.Description("Repository name without owner prefix"),
+		),
+		mcp.WithNumber("pull_number",
+			mcp.Title("Pull Request Number"),
+			mcp.Description("Only return evaluations of this pull request. Omit for recent pull requests"),
+			mcp.Min(1),
+		),
+		mcp.WithString("from",
+			mcp.Title("From Time"),
+			mcp.Description("Only include evaluations after this RFC3339 time (e.g., 2024-01-15T09:00:00Z)"),
+		),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name. Omit to search all accessible projects"),
+		),
+	), t.getPullRequestEvaluations)
+
+	// Reports
+	t.addTool(s, mcp.NewTool("minder_get_slack_summary",
+		mcp.WithDescription("Summarize current compliance as a Slack message payload. "+
+			"Returns Block Kit blocks with the compliance score, failing profile and rule counts, and the "+
+			"repositories with failing rules, plus a plain-text fallback, ready to post with chat.postMessage "+
+			"or an incoming webhook."),
+		mcp.WithTitleAnnotation("Get Slack Compliance Summary"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name to summarize. Omit to summarize all accessible projects"),
+		),
+		mcp.WithString("min_severity",
+			mcp.Title("Minimum Severity"),
+			mcp.Description("Only include rules whose rule type has at least this severity; "+
+				"rules without a declared severity count as medium"),
+			mcp.Enum("info", "low", "medium", "high", "critical"),
+		),
+	), t.slackComplianceSummary)
 
 	// Dashboard - includes _meta.ui.resourceUri for MCP Apps support
 	dashboardTool := mcp.NewTool("minder_show_dashboard",
@@ -402,21 +1303,189 @@ func (t *Tools) Register(s *server.MCPServer) {
 		"ui": map[string]any{
 			"resourceUri": resources.DashboardURI,
 		},
+		"toolPrefix": t.toolPrefix(),
 	})
-	s.AddTool(dashboardTool, t.wrapHandler("minder_show_dashboard", t.showComplianceDashboard))
+	t.addTool(s, dashboardTool, t.showComplianceDashboard)
+
+	// Compliance History
+	if t.history != nil {
+		t.addTool(s, mcp.NewTool("minder_get_compliance_history",
+			mcp.WithDescription("Get compliance summaries recorded by this server over time. "+
+				"Each summary holds the compliance score, profile and rule counts, and failing rules per repository. "+
+				"Set period to aggregate scores per day or week."),
+			mcp.WithTitleAnnotation("Get Compliance History"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("from",
+				mcp.Title("From Time"),
+				mcp.Description("Start of time range in RFC3339 format (e.g., 2024-01-15T09:00:00Z). Omit for the oldest summary"),
+			),
+			mcp.WithString("to",
+				mcp.Title("To Time"),
+				mcp.Description("End of time range in RFC3339 format (e.g., 2024-01-15T17:00:00Z). Omit for the newest summary"),
+			),
+			mcp.WithString("period",
+				mcp.Title("Period"),
+				mcp.Description("Aggregate summaries per UTC day or ISO week. Omit to return every summary"),
+				mcp.Enum(string(history.PeriodDay), string(history.PeriodWeek)),
+			),
+		), t.getComplianceHistory)
+
+		t.addTool(s, mcp.NewTool("minder_compare_compliance_history",
+			mcp.WithDescription("Compare the compliance summaries recorded by this server at two points in time. "+
+				"Returns the score delta, newly failing and recovered rules per repository, and repositories "+
+				"that became fully compliant. Defaults to now versus 7 days ago."),
+			mcp.WithTitleAnnotation("Compare Compliance History"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("from",
+				mcp.Title("From Time"),
+				mcp.Description("Earlier point in RFC3339 format. Mutually exclusive with days_ago"),
+			),
+			mcp.WithString("to",
+				mcp.Title("To Time"),
+				mcp.Description("Later point in RFC3339 format. Omit for now"),
+			),
+			mcp.WithNumber("days_ago",
+				mcp.Title("Days Ago"),
+				mcp.Description("Compare against the summary this many days before to (default 7). Mutually exclusive with from"),
+				mcp.Min(1),
+			),
+		), t.compareComplianceHistory)
+
+		t.addTool(s, mcp.NewTool("minder_render_compliance_chart",
+			mcp.WithDescription("Render the compliance history recorded by this server as a chart image, "+
+				"with a text description of the same data. Draws the compliance score over time, or the number "+
+				"of repositories failing each rule in the latest summary. PNG images are unlabelled; prefer SVG."),
+			mcp.WithTitleAnnotation("Render Compliance Chart"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("chart",
+				mcp.Title("Chart"),
+				mcp.Description("What to draw: the score over time (default) or failing repositories per rule"),
+				mcp.Enum(chartScore, chartFailuresByRule),
+			),
+			mcp.WithString("from",
+				mcp.Title("From Time"),
+				mcp.Description("Start of time range in RFC3339 format (e.g., 2024-01-15T09:00:00Z). Omit for the oldest summary"),
+			),
+			mcp.WithString("to",
+				mcp.Title("To Time"),
+				mcp.Description("End of time range in RFC3339 format. Omit for the newest summary"),
+			),
+			mcp.WithString("period",
+				mcp.Title("Period"),
+				mcp.Description("For the score chart, plot the average score per UTC day or ISO week instead of every summary"),
+				mcp.Enum(string(history.PeriodDay), string(history.PeriodWeek)),
+			),
+			mcp.WithString("format",
+				mcp.Title("Format"),
+				mcp.Description("Image format (default svg)"),
+				mcp.Enum(chartFormatSVG, chartFormatPNG),
+			),
+		), t.renderComplianceChart)
+	}
+
+	// Server
+	t.addTool(s, mcp.NewTool("minder_server_stats",
+		mcp.WithDescription("Show usage statistics for this MCP server since it started. "+
+			"Returns per-tool invocation counts, error rates, and p95 latency."),
+		mcp.WithTitleAnnotation("Server Statistics"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	), t.serverStats)
+
+	t.addTool(s, mcp.NewTool("minder_server_config",
+		mcp.WithDescription("Show the effective configuration of this MCP server with secrets left out: "+
+			"Minder servers and their transport, limits, cache settings, the enabled tools and background jobs. "+
+			"Tokens and webhook secrets are reported only as whether they are set."),
+		mcp.WithTitleAnnotation("Server Configuration"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	), t.serverConfig)
+
+	t.addTool(s, mcp.NewTool("minder_diagnose",
+		mcp.WithDescription("Check why Minder tools are failing. Runs realm discovery, token validity and expiry, "+
+			"a health check and a cheap list call per Minder service against the selected server, "+
+			"and returns a PASS, FAIL or SKIP result with details for each check."),
+		mcp.WithTitleAnnotation("Diagnose Minder Connection"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("project_id",
+			mcp.Title("Project ID"),
+			mcp.Description("Project UUID or name to run the per-service checks in. Omit to use the first accessible project"),
+		),
+	), t.diagnose)
+
+	if len(t.cfg.Minder.Servers) > 0 {
+		t.addTool(s, mcp.NewTool("minder_select_server",
+			mcp.WithDescription("Select which Minder server later tool calls in this session are sent to. "+
+				"Omit name to show the current selection and the available servers."),
+			mcp.WithTitleAnnotation("Select Minder Server"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("name",
+				mcp.Title("Server Name"),
+				mcp.Description("Name of the server to use"),
+				mcp.Enum(t.cfg.Minder.ServerNames()...),
+			),
+		), t.selectServer)
+	}
+
+	t.registerRawCall(s)
+	t.registerPlugins(s)
+	t.warnUnusedOverrides(s)
+}
+
+// tokenFor returns the token a request in ctx authenticates to srv with. The
+// auth profile the call selected takes precedence, then a token saved for
+// the session. A caller's Authorization header token is only sent to the
+// default server, to the host the request routed to with X-Minder-Host, or
+// to servers configured to accept header tokens; otherwise srv's configured
+// token is used.
+func (t *Tools) tokenFor(ctx context.Context, srv config.NamedServer) string {
+	if profile, ok := authProfileFromContext(ctx); ok {
+		// A profile's token is never sent to another server
+		if profile.Server != srv.Name {
+			return ""
+		}
+		return profile.Token
+	}
+	if token := t.sessionToken(ctx, srv); token != "" {
+		return token
+	}
+	if middleware.TokenFromHeader(ctx) &&
+		(srv.Name == config.DefaultServerName || srv.HeaderTokens || middleware.MinderHostFromContext(ctx) != "") {
+		return middleware.TokenFromContext(ctx)
+	}
+	return srv.AuthToken
 }
 
 // getClient returns a MinderClient using the configured factory.
 func (t *Tools) getClient(ctx context.Context) (MinderClient, error) {
+	defer timing.Record(ctx, "client_create", time.Now())
 	return t.clientFactory(ctx)
 }
 
 // defaultClientFactory creates a real Minder client using the token from context.
 // If the token is an offline/refresh token or expired, it will be refreshed automatically.
 func (t *Tools) defaultClientFactory(ctx context.Context) (MinderClient, error) {
-	token := middleware.TokenFromContext(ctx)
+	srv, err := t.resolveServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.clientFor(ctx, srv)
+}
+
+// clientFor returns a client for srv over its pooled connection, authenticated
+// with the token a request in ctx uses for srv.
+func (t *Tools) clientFor(ctx context.Context, srv config.NamedServer) (MinderClient, error) {
+	callmeta.SetServer(ctx, net.JoinHostPort(srv.Host, strconv.Itoa(srv.Port)))
+	if profile, ok := authProfileFromContext(ctx); ok && profile.Server != srv.Name {
+		return nil, fmt.Errorf("auth profile %s holds a token for server %s, not the selected server %s",
+			profile.Name, profile.Server, srv.Name)
+	}
+	token := t.tokenFor(ctx, srv)
 
 	// Log token status for debugging
+	if token == "" && srv.Name != config.DefaultServerName && middleware.MinderHostFromContext(ctx) == "" {
+		t.logger.WarnContext(ctx, "no authentication token for server", "server", srv.Name)
+		return nil, fmt.Errorf("no authentication token for server %s: set MINDER_SERVER_<NAME>_AUTH_TOKEN, "+
+			"or MINDER_SERVER_<NAME>_HEADER_TOKENS=true to send the Authorization header token there", srv.Name)
+	}
 	if token == "" {
 		t.logger.WarnContext(ctx, "no authentication token provided",
 			"hint", "set MINDER_AUTH_TOKEN or pass Authorization header")
@@ -424,9 +1493,10 @@ func (t *Tools) defaultClientFactory(ctx context.Context) (MinderClient, error)
 	}
 
 	serverCfg := minder.ServerConfig{
-		Host:     t.cfg.Minder.Host,
-		Port:     t.cfg.Minder.Port,
-		Insecure: t.cfg.Minder.Insecure,
+		Host:     srv.Host,
+		Port:     srv.Port,
+		Insecure: srv.Insecure,
+		CertPins: srv.CertPins,
 	}
 
 	// Validate and potentially refresh the token
@@ -434,19 +1504,26 @@ func (t *Tools) defaultClientFactory(ctx context.Context) (MinderClient, error)
 	if err != nil {
 		t.logger.ErrorContext(ctx, "token validation failed",
 			"error", err,
-			"server_host", t.cfg.Minder.Host,
-			"server_port", t.cfg.Minder.Port,
-			"insecure", t.cfg.Minder.Insecure,
+			"server", srv.Name,
+			"server_host", srv.Host,
+			"server_port", srv.Port,
+			"insecure", srv.Insecure,
 		)
-		return nil, fmt.Errorf("token validation failed: %w", err)
+		return nil, t.tokenError(ctx, srv, serverCfg, err)
 	}
 
 	t.logger.DebugContext(ctx, "token validated successfully")
 
-	return minder.NewClient(minder.ClientConfig{
-		Host:     t.cfg.Minder.Host,
-		Port:     t.cfg.Minder.Port,
-		Insecure: t.cfg.Minder.Insecure,
-		Token:    validToken,
+	return t.pool.Client(minder.ClientConfig{
+		Host:             srv.Host,
+		Port:             srv.Port,
+		Insecure:         srv.Insecure,
+		CertPins:         srv.CertPins,
+		Token:            validToken,
+		Logger:           t.logger,
+		Interceptors:     t.interceptors,
+		RateLimitRetries: t.cfg.Minder.RateLimitRetries,
+		RateLimitMaxWait: t.cfg.Minder.RateLimitMaxWait,
+		UserAgent:        t.userAgent,
 	})
 }
@@ -0,0 +1,242 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/metrics"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+func TestWrapHandler_RequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		ctxRequestID  string
+		result        *mcp.CallToolResult
+		wantInText    string
+		wantGenerated bool
+	}{
+		{
+			name:         "appends existing request ID to error result",
+			ctxRequestID: "req-123",
+			result:       mcp.NewToolResultError("Not found: profile"),
+			wantInText:   "Not found: profile (request_id: req-123)",
+		},
+		{
+			name:          "generates request ID when missing",
+			result:        mcp.NewToolResultError("Service unavailable"),
+			wantInText:    "(request_id: ",
+			wantGenerated: true,
+		},
+		{
+			name:         "leaves successful result untouched",
+			ctxRequestID: "req-456",
+			result:       mcp.NewToolResultText("ok"),
+			wantInText:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tools := newTestTools(newMockClient())
+			var seenID string
+			handler := tools.wrapHandler("test_tool", func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				seenID = middleware.RequestIDFromContext(ctx)
+				return tt.result, nil
+			})
+
+			ctx := context.Background()
+			if tt.ctxRequestID != "" {
+				ctx = middleware.ContextWithRequestID(ctx, tt.ctxRequestID)
+			}
+
+			result, err := handler(ctx, mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("handler returned Go error: %v", err)
+			}
+
+			if tt.wantGenerated && seenID == "" {
+				t.Error("expected handler context to carry a generated request ID")
+			}
+			if !tt.wantGenerated && seenID != tt.ctxRequestID {
+				t.Errorf("handler saw request ID %q, want %q", seenID, tt.ctxRequestID)
+			}
+
+			text := getResultText(t, result)
+			if !strings.Contains(text, tt.wantInText) {
+				t.Errorf("result %q does not contain %q", text, tt.wantInText)
+			}
+			if !result.IsError && strings.Contains(text, "request_id") {
+				t.Errorf("successful result %q should not include request ID", text)
+			}
+		})
+	}
+}
+
+func TestWrapHandler_ErrorDetail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		wantCode string
+	}{
+		{
+			name:     "keeps Minder error detail",
+			result:   grpcErrorResult(status.Error(codes.NotFound, "profile")),
+			wantCode: ErrCodeNotFound,
+		},
+		{
+			name:     "adds detail to tool errors",
+			result:   mcp.NewToolResultError("project_id is required"),
+			wantCode: ErrCodeToolError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tools := newTestTools(newMockClient())
+			name := "error_detail_" + tt.wantCode
+			handler := tools.wrapHandler(name, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, nil
+			})
+			ctx := middleware.ContextWithRequestID(context.Background(), "req-789")
+			invocations := metrics.ToolInvocations.WithLabelValues(name, metrics.OutcomeToolError, tt.wantCode)
+			before := testutil.ToFloat64(invocations)
+
+			result, err := handler(ctx, mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("handler returned Go error: %v", err)
+			}
+			detail, ok := errorDetailOf(result)
+			if !ok {
+				t.Fatalf("structured content = %#v, want an error detail", result.StructuredContent)
+			}
+			if detail.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", detail.Code, tt.wantCode)
+			}
+			if detail.RequestID != "req-789" {
+				t.Errorf("RequestID = %q, want %q", detail.RequestID, "req-789")
+			}
+			if detail.Message == "" || strings.Contains(detail.Message, "request_id") {
+				t.Errorf("Message = %q, want the error message without the request ID", detail.Message)
+			}
+			if got := testutil.ToFloat64(invocations); got != before+1 {
+				t.Errorf("tool_invocations_total with error_code %q = %v, want %v", tt.wantCode, got, before+1)
+			}
+		})
+	}
+}
+
+func TestWrapHandler_CallMeta(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.projects.listResp = &minderv1.ListProjectsResponse{
+		Projects: []*minderv1.Project{{ProjectId: "proj-1"}, {ProjectId: "proj-2"}},
+	}
+	tools := newTestTools(mockClient)
+	handler := tools.wrapHandler("test_tool", func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, err := projectIDsOrAll(ctx, mockClient, ""); err != nil {
+			return nil, err
+		}
+		result := mcp.NewToolResultText("ok")
+		result.Meta = mcp.NewMetaFromMap(map[string]any{"toolPrefix": "minder_"})
+		return result, nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if result.Meta == nil {
+		t.Fatal("result has no _meta")
+	}
+	fields := result.Meta.AdditionalFields
+	if fields["toolPrefix"] != "minder_" {
+		t.Errorf("toolPrefix = %v, want the tool's own _meta field kept", fields["toolPrefix"])
+	}
+	if fields["projects_scanned"] != 2 {
+		t.Errorf("projects_scanned = %v, want 2", fields["projects_scanned"])
+	}
+	if _, ok := fields["elapsed_ms"]; !ok {
+		t.Error("_meta has no elapsed_ms")
+	}
+	if _, ok := fields["server"]; ok {
+		t.Errorf("server = %v, want none for a call that did not resolve a server", fields["server"])
+	}
+}
+
+func TestWrapHandler_SlowCallLogging(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		wantLog   bool
+	}{
+		{
+			name:      "logs breakdown above threshold",
+			threshold: time.Nanosecond,
+			wantLog:   true,
+		},
+		{
+			name:      "skips fast calls",
+			threshold: time.Hour,
+			wantLog:   false,
+		},
+		{
+			name:      "disabled with zero threshold",
+			threshold: 0,
+			wantLog:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			cfg := &config.Config{MCP: config.MCPConfig{SlowCallThreshold: tt.threshold}}
+			mockClient := newMockClient()
+			tools := NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+				return mockClient, nil
+			})
+
+			handler := tools.wrapHandler("minder_list_projects", tools.listProjects)
+			if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+				t.Fatalf("handler returned Go error: %v", err)
+			}
+
+			logged := strings.Contains(buf.String(), "slow tool call")
+			if logged != tt.wantLog {
+				t.Errorf("slow call logged = %v, want %v (output: %s)", logged, tt.wantLog, buf.String())
+			}
+			if tt.wantLog {
+				for _, phase := range []string{"client_create=", "marshal="} {
+					if !strings.Contains(buf.String(), phase) {
+						t.Errorf("breakdown missing phase %q: %s", phase, buf.String())
+					}
+				}
+			}
+		})
+	}
+}
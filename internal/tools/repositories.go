@@ -10,7 +10,7 @@ import (
 func (t *Tools) listRepositories(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -21,7 +21,7 @@ func (t *Tools) listRepositories(ctx context.Context, req mcp.CallToolRequest) (
 	projectID := req.GetString("project_id", "")
 	provider := req.GetString("provider", "")
 	cursor := req.GetString("cursor", "")
-	limit := req.GetInt("limit", 0)
+	limit := t.pageSize(req.GetInt("limit", 0))
 
 	// Single project mode - preserves pagination
 	if projectID != "" {
@@ -36,17 +36,19 @@ func (t *Tools) listRepositories(ctx context.Context, req mcp.CallToolRequest) (
 		if cursor != "" {
 			reqProto.Cursor = cursor
 		}
-		if limit > 0 && limit <= 100 {
-			reqProto.Limit = int64(limit) //nolint:gosec // limit is bounded by schema validation (1-100)
+		if limit > 0 {
+			reqProto.Limit = int64(limit) //nolint:gosec // limit is bounded by t.pageSize (1-100)
 		}
 
 		resp, err := client.Repositories().ListRepositories(ctx, reqProto)
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 
+		// Minder may return more than the limit asked for
+		repos, total := capResults(resp.Results, t.cfg.MCP.MaxResults)
 		result := map[string]any{
-			"results": resp.Results,
+			"results": repos,
 		}
 		if resp.Cursor != "" {
 			result["next_cursor"] = resp.Cursor
@@ -54,7 +56,7 @@ func (t *Tools) listRepositories(ctx context.Context, req mcp.CallToolRequest) (
 		} else {
 			result["has_more"] = false
 		}
-		return marshalResult(result)
+		return t.marshalCapped(ctx, result, len(repos), total)
 	}
 
 	// Multi-project aggregation - pagination not supported
@@ -76,15 +78,19 @@ func (t *Tools) listRepositories(ctx context.Context, req mcp.CallToolRequest) (
 			return resp.Results, nil
 		})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
 	}
 
+	sortByProject(repos, func(r *minderv1.Repository) (string, string) {
+		return r.GetContext().GetProject(), r.GetOwner() + "/" + r.GetName()
+	})
+	repos, total := capResults(repos, t.cfg.MCP.MaxResults)
 	result := map[string]any{
 		"results":  repos,
 		"has_more": false,
 	}
 
-	return marshalResult(result)
+	return t.marshalCapped(ctx, result, len(repos), total)
 }
 
 func (t *Tools) getRepository(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -104,7 +110,7 @@ func (t *Tools) getRepository(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -112,42 +118,48 @@ func (t *Tools) getRepository(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return errResult, nil
 	}
 
-	var repository *minderv1.Repository
+	repository, err := lookupRepository(ctx, client, repoID, owner, name, projectID, provider)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
 
+	return marshalResult(ctx, repository)
+}
+
+// lookupRepository gets a repository by ID, or by owner/name searching across
+// projects when projectID is empty.
+func lookupRepository(
+	ctx context.Context, client MinderClient, repoID, owner, name, projectID, provider string,
+) (*minderv1.Repository, error) {
 	if repoID != "" {
 		// Lookup by ID - no project context needed
 		resp, err := client.Repositories().GetRepositoryById(ctx, &minderv1.GetRepositoryByIdRequest{
 			RepositoryId: repoID,
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
-		}
-		repository = resp.Repository
-	} else {
-		// Lookup by owner/name - search across projects if none specified
-		fullName := owner + "/" + name
-		repository, err = findInProjects(
-			ctx, client, projectID,
-			func(ctx context.Context, projID string) (*minderv1.Repository, error) {
-				reqProto := &minderv1.GetRepositoryByNameRequest{
-					Name: fullName,
-					Context: &minderv1.Context{
-						Project: &projID,
-					},
-				}
-				if provider != "" {
-					reqProto.Context.Provider = &provider
-				}
-				resp, err := client.Repositories().GetRepositoryByName(ctx, reqProto)
-				if err != nil {
-					return nil, err
-				}
-				return resp.Repository, nil
-			})
-		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return nil, err
 		}
+		return resp.Repository, nil
 	}
 
-	return marshalResult(repository)
+	// Lookup by owner/name - search across projects if none specified
+	fullName := owner + "/" + name
+	return findOneInProjects(
+		ctx, client, projectID, "repository "+fullName,
+		func(ctx context.Context, projID string) (*minderv1.Repository, error) {
+			reqProto := &minderv1.GetRepositoryByNameRequest{
+				Name: fullName,
+				Context: &minderv1.Context{
+					Project: &projID,
+				},
+			}
+			if provider != "" {
+				reqProto.Context.Provider = &provider
+			}
+			resp, err := client.Repositories().GetRepositoryByName(ctx, reqProto)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Repository, nil
+		})
 }
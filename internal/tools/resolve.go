@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/callmeta"
+)
+
+// resolvedEntity is an entity matching a name, with the context follow-up calls need.
+type resolvedEntity struct {
+	Kind        string `json:"kind"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+}
+
+// resolveFunc looks up the entity called name in a project, returning a
+// NotFound error when there is none.
+type resolveFunc func(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error)
+
+// resolvers look up each kind of entity minder_resolve resolves, besides projects.
+var resolvers = map[string]resolveFunc{
+	"repository":  resolveRepository,
+	"profile":     resolveProfile,
+	"rule_type":   resolveRuleType,
+	"artifact":    resolveArtifact,
+	"data_source": resolveDataSource,
+}
+
+// resolveKinds are the kinds minder_resolve accepts.
+var resolveKinds = []string{"project", "repository", "profile", "rule_type", "artifact", "data_source"}
+
+func resolveRepository(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error) {
+	resp, err := client.Repositories().GetRepositoryByName(ctx, &minderv1.GetRepositoryByNameRequest{
+		Name:    name,
+		Context: &minderv1.Context{Project: &projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo := resp.GetRepository()
+	return &resolvedEntity{
+		ID:       repo.GetId(),
+		Name:     repo.GetOwner() + "/" + repo.GetName(),
+		Provider: repo.GetContext().GetProvider(),
+	}, nil
+}
+
+func resolveProfile(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error) {
+	resp, err := client.Profiles().GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{
+		Name:    name,
+		Context: &minderv1.Context{Project: &projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resolvedEntity{ID: resp.GetProfile().GetId(), Name: resp.GetProfile().GetName()}, nil
+}
+
+func resolveRuleType(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error) {
+	resp, err := client.RuleTypes().GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{
+		Name:    name,
+		Context: &minderv1.Context{Project: &projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resolvedEntity{ID: resp.GetRuleType().GetId(), Name: resp.GetRuleType().GetName()}, nil
+}
+
+func resolveArtifact(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error) {
+	resp, err := client.Artifacts().GetArtifactByName(ctx, &minderv1.GetArtifactByNameRequest{
+		Name:    name,
+		Context: &minderv1.Context{Project: &projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	artifact := resp.GetArtifact()
+	return &resolvedEntity{
+		ID:       artifact.GetArtifactPk(),
+		Name:     artifact.GetName(),
+		Provider: artifact.GetContext().GetProvider(),
+	}, nil
+}
+
+func resolveDataSource(ctx context.Context, client MinderClient, projectID, name string) (*resolvedEntity, error) {
+	resp, err := client.DataSources().GetDataSourceByName(ctx, &minderv1.GetDataSourceByNameRequest{
+		Name:    name,
+		Context: &minderv1.ContextV2{ProjectId: projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resolvedEntity{ID: resp.GetDataSource().GetId(), Name: resp.GetDataSource().GetName()}, nil
+}
+
+// resolveProjects returns the accessible projects called name.
+func resolveProjects(projects []*minderv1.Project, name string) []resolvedEntity {
+	matches := []resolvedEntity{}
+	for _, p := range projects {
+		if p.GetName() == name {
+			matches = append(matches, resolvedEntity{
+				Kind:        "project",
+				ID:          p.GetProjectId(),
+				Name:        p.GetName(),
+				ProjectID:   p.GetProjectId(),
+				ProjectName: p.GetName(),
+			})
+		}
+	}
+	return matches
+}
+
+// resolveName returns the ID of each entity of a kind called a name, with
+// its project, so follow-up calls can use IDs without listing every entity.
+// The same name may match in several projects.
+func (t *Tools) resolveName(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind := req.GetString("kind", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	if !slices.Contains(resolveKinds, kind) {
+		return mcp.NewToolResultError("kind must be one of " + strings.Join(resolveKinds, ", ")), nil
+	}
+	if name == "" {
+		return mcp.NewToolResultError("name must be provided"), nil
+	}
+	if kind == "project" {
+		// Projects are matched among all accessible ones, even with a project pinned for the session
+		projectID = ""
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projects := []*minderv1.Project{{ProjectId: projectID}}
+	if projectID == "" {
+		if projects, err = listAllProjects(ctx, client); err != nil {
+			return grpcErrorResult(err), nil
+		}
+	}
+	callmeta.AddProjects(ctx, len(projects))
+
+	var matches []resolvedEntity
+	if kind == "project" {
+		matches = resolveProjects(projects, name)
+	} else if matches, err = resolveInProjects(ctx, client, projects, kind, name); err != nil {
+		return grpcErrorResult(err), nil
+	}
+	if len(matches) == 0 {
+		scope := "any accessible project"
+		if projectID != "" {
+			scope = "project " + projectID
+		}
+		return errorResult(fmt.Sprintf("no %s named %s found in %s", kind, name, scope), ErrorDetail{
+			Code:            ErrCodeNotFound,
+			SuggestedAction: "Check the name with the matching list tool; repositories are named owner/name.",
+		}), nil
+	}
+	return marshalResult(ctx, map[string]any{"matches": matches})
+}
+
+// resolveInProjects looks up the entity of kind called name in each project.
+// When searching several projects, one that cannot be read is skipped.
+func resolveInProjects(
+	ctx context.Context, client MinderClient, projects []*minderv1.Project, kind, name string,
+) ([]resolvedEntity, error) {
+	matches := []resolvedEntity{}
+	for _, p := range projects {
+		entity, err := resolvers[kind](ctx, client, p.GetProjectId(), name)
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			if len(projects) == 1 {
+				return nil, err
+			}
+			continue
+		}
+		entity.Kind = kind
+		entity.ProjectID = p.GetProjectId()
+		entity.ProjectName = p.GetName()
+		matches = append(matches, *entity)
+	}
+	return matches, nil
+}
@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveName(t *testing.T) {
+	t.Parallel()
+
+	github := "github-app-acme"
+	tests := []struct {
+		name      string
+		mockSetup func(*mockMinderClient)
+		params    map[string]any
+		want      []resolvedEntity
+	}{
+		{
+			name: "project",
+			mockSetup: func(m *mockMinderClient) {
+				m.projects.listResp = &minderv1.ListProjectsResponse{
+					Projects: []*minderv1.Project{{ProjectId: "proj-2", Name: "staging"}, {ProjectId: "proj-1", Name: "prod"}},
+				}
+			},
+			params: map[string]any{"kind": "project", "name": "prod", "project_id": "proj-2"},
+			want:   []resolvedEntity{{Kind: "project", ID: "proj-1", Name: "prod", ProjectID: "proj-1", ProjectName: "prod"}},
+		},
+		{
+			name: "repository in every project",
+			mockSetup: func(m *mockMinderClient) {
+				m.projects.listResp = &minderv1.ListProjectsResponse{
+					Projects: []*minderv1.Project{{ProjectId: "proj-1", Name: "prod"}, {ProjectId: "proj-2", Name: "staging"}},
+				}
+				m.repositories.getByNameResp = &minderv1.GetRepositoryByNameResponse{
+					Repository: &minderv1.Repository{
+						Id: ptr("repo-1"), Owner: "acme", Name: "api", Context: &minderv1.Context{Provider: &github},
+					},
+				}
+			},
+			params: map[string]any{"kind": "repository", "name": "acme/api"},
+			want: []resolvedEntity{
+				{Kind: "repository", ID: "repo-1", Name: "acme/api", ProjectID: "proj-1", ProjectName: "prod", Provider: github},
+				{Kind: "repository", ID: "repo-1", Name: "acme/api", ProjectID: "proj-2", ProjectName: "staging", Provider: github},
+			},
+		},
+		{
+			name: "profile in a project",
+			mockSetup: func(m *mockMinderClient) {
+				m.profiles.getByNameResp = &minderv1.GetProfileByNameResponse{
+					Profile: &minderv1.Profile{Id: ptr("prof-1"), Name: "baseline"},
+				}
+			},
+			params: map[string]any{"kind": "profile", "name": "baseline", "project_id": "proj-1"},
+			want:   []resolvedEntity{{Kind: "profile", ID: "prof-1", Name: "baseline", ProjectID: "proj-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			tt.mockSetup(mockClient)
+			tools := newTestTools(mockClient)
+
+			result, err := tools.resolveName(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.params},
+			})
+			if err != nil {
+				t.Fatalf("resolveName() returned Go error: %v", err)
+			}
+			text := getResultText(t, result)
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", text)
+			}
+			var got struct {
+				Matches []resolvedEntity `json:"matches"`
+			}
+			if err := json.Unmarshal([]byte(text), &got); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			gotJSON, _ := json.Marshal(got.Matches)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("matches = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestResolveName_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*mockMinderClient)
+		params       map[string]any
+		wantContains string
+		wantCode     string
+	}{
+		{
+			name:         "unknown kind",
+			params:       map[string]any{"kind": "webhook", "name": "x"},
+			wantContains: "kind must be one of",
+		},
+		{
+			name:         "missing name",
+			params:       map[string]any{"kind": "profile"},
+			wantContains: "name must be provided",
+		},
+		{
+			name: "not found anywhere",
+			mockSetup: func(m *mockMinderClient) {
+				m.ruleTypes.getByNameErr = status.Error(codes.NotFound, "rule type not found")
+			},
+			params:       map[string]any{"kind": "rule_type", "name": "missing"},
+			wantContains: "no rule_type named missing found in any accessible project",
+			wantCode:     ErrCodeNotFound,
+		},
+		{
+			name: "project cannot be read",
+			mockSetup: func(m *mockMinderClient) {
+				m.dataSources.getByNameErr = status.Error(codes.PermissionDenied, "no access")
+			},
+			params:       map[string]any{"kind": "data_source", "name": "osv", "project_id": "proj-1"},
+			wantContains: "Permission denied",
+			wantCode:     ErrCodePermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockClient)
+			}
+			tools := newTestTools(mockClient)
+
+			result, err := tools.resolveName(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.params},
+			})
+			if err != nil {
+				t.Fatalf("resolveName() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("expected error result, got %s", getResultText(t, result))
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantContains) {
+				t.Errorf("error %q does not contain %q", text, tt.wantContains)
+			}
+			if tt.wantCode != "" {
+				if detail, _ := errorDetailOf(result); detail.Code != tt.wantCode {
+					t.Errorf("error code = %q, want %q", detail.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// resultURIPrefix starts the URI of every stored oversized result.
+	resultURIPrefix = "minder://results/"
+	// maxStoredResults bounds the oversized results kept in memory; the one
+	// closest to expiry is dropped to make room.
+	maxStoredResults = 100
+	// maxSummaryFields bounds the top-level fields described in a summary.
+	maxSummaryFields = 20
+)
+
+// storedResult is an oversized tool result readable as a resource.
+type storedResult struct {
+	session string
+	text    string
+	expires time.Time
+}
+
+// resultStore keeps oversized tool results in memory until they expire.
+type resultStore struct {
+	mu      sync.Mutex
+	results map[string]storedResult
+}
+
+// put stores text for the session until ttl passes and returns its ID.
+func (s *resultStore) put(session, text string, ttl time.Duration, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.results == nil {
+		s.results = make(map[string]storedResult)
+	}
+	for id, r := range s.results {
+		if !now.Before(r.expires) {
+			delete(s.results, id)
+		}
+	}
+	for len(s.results) >= maxStoredResults {
+		oldest := ""
+		for id, r := range s.results {
+			if oldest == "" || r.expires.Before(s.results[oldest].expires) {
+				oldest = id
+			}
+		}
+		delete(s.results, oldest)
+	}
+
+	id := uuid.NewString()
+	s.results[id] = storedResult{session: session, text: text, expires: now.Add(ttl)}
+	return id
+}
+
+// get returns the stored result if it has not expired and belongs to the session.
+func (s *resultStore) get(session, id string, now time.Time) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.results[id]
+	if !ok || r.session != session || !now.Before(r.expires) {
+		return "", false
+	}
+	return r.text, true
+}
+
+// forget drops the results stored for the session.
+func (s *resultStore) forget(session string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, r := range s.results {
+		if r.session == session {
+			delete(s.results, id)
+		}
+	}
+}
+
+// registerResultResources lets clients read oversized results stored by
+// linkOversizedResult. It registers nothing unless MCP_MAX_RESULT_BYTES is set.
+func (t *Tools) registerResultResources(s *server.MCPServer) {
+	if t.cfg.MCP.MaxResultBytes <= 0 {
+		return
+	}
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(resultURIPrefix+"{id}", "Tool Result",
+			mcp.WithTemplateDescription("Full JSON of a tool result too large to return inline. "+
+				"Links to these resources are returned in place of the result and expire after a while"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		t.readStoredResult,
+	)
+}
+
+// readStoredResult serves an oversized result to the session that produced it.
+func (t *Tools) readStoredResult(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
+	text, ok := t.results.get(sessionID(ctx), strings.TrimPrefix(uri, resultURIPrefix), time.Now())
+	if !ok {
+		return nil, fmt.Errorf("result %s has expired or was not produced in this session; call the tool again", uri)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: text},
+	}, nil
+}
+
+// linkOversizedResult replaces the payload of a result larger than
+// MCP_MAX_RESULT_BYTES with a summary and a link to a resource holding it, so
+// the full payload is only read into the client's context on demand. Other
+// content, such as truncation notes, is kept.
+func (t *Tools) linkOversizedResult(ctx context.Context, tool string, result *mcp.CallToolResult) *mcp.CallToolResult {
+	limit := t.cfg.MCP.MaxResultBytes
+	if limit <= 0 || result == nil || result.IsError || len(result.Content) == 0 {
+		return result
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok || len(text.Text) <= limit {
+		return result
+	}
+
+	ttl := t.cfg.MCP.ResultResourceTTL
+	uri := resultURIPrefix + t.results.put(sessionID(ctx), text.Text, ttl, time.Now())
+	summary := fmt.Sprintf("The %s result is %d bytes, more than the %d bytes this server returns inline. "+
+		"Read resource %s within %s for the full JSON. %s",
+		tool, len(text.Text), limit, uri, ttl, summarizeJSON(text.Text))
+	t.logger.DebugContext(ctx, "oversized tool result stored as resource",
+		"tool", tool, "bytes", len(text.Text), "uri", uri)
+
+	content := []mcp.Content{
+		mcp.NewTextContent(summary),
+		mcp.NewResourceLink(uri, tool+" result", "Full JSON result of "+tool, "application/json"),
+	}
+	result.Content = append(content, result.Content[1:]...)
+	return result
+}
+
+// summarizeJSON describes the top-level shape of a JSON document: the length
+// of arrays and the values of short scalars.
+func summarizeJSON(text string) string {
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return "The result is not JSON."
+	}
+	switch v := v.(type) {
+	case []any:
+		return fmt.Sprintf("It is a list of %d items.", len(v))
+	case map[string]any:
+		keys := slices.Sorted(maps.Keys(v))
+		var fields []string
+		for _, k := range keys {
+			if len(fields) == maxSummaryFields {
+				fields = append(fields, fmt.Sprintf("and %d more", len(keys)-maxSummaryFields))
+				break
+			}
+			fields = append(fields, k+": "+describeJSONValue(v[k]))
+		}
+		return "Top-level fields: " + strings.Join(fields, "; ") + "."
+	default:
+		return ""
+	}
+}
+
+// describeJSONValue summarizes one decoded JSON value.
+func describeJSONValue(v any) string {
+	switch v := v.(type) {
+	case []any:
+		return fmt.Sprintf("%d items", len(v))
+	case map[string]any:
+		return fmt.Sprintf("object with %d fields", len(v))
+	case string:
+		if len(v) > 80 {
+			return fmt.Sprintf("text of %d bytes", len(v))
+		}
+		return fmt.Sprintf("%q", v)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+)
+
+func TestResultStore(t *testing.T) {
+	t.Parallel()
+
+	var store resultStore
+	now := time.Now()
+	id := store.put("s1", `{"a":1}`, time.Minute, now)
+
+	text, ok := store.get("s1", id, now)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"a":1}`, text)
+
+	_, ok = store.get("s2", id, now)
+	assert.False(t, ok, "result readable from another session")
+	_, ok = store.get("s1", id, now.Add(time.Minute))
+	assert.False(t, ok, "result readable after expiry")
+
+	store.forget("s1")
+	_, ok = store.get("s1", id, now)
+	assert.False(t, ok, "result readable after the session ended")
+
+	first := store.put("s1", "first", time.Minute, now)
+	for i := range maxStoredResults {
+		store.put("s1", "later", time.Hour+time.Duration(i), now)
+	}
+	_, ok = store.get("s1", first, now)
+	assert.False(t, ok, "result closest to expiry not evicted")
+	assert.Len(t, store.results, maxStoredResults)
+}
+
+func TestLinkOversizedResult(t *testing.T) {
+	t.Parallel()
+
+	repos := make([]*minderv1.Repository, 50)
+	for i := range repos {
+		repos[i] = &minderv1.Repository{Owner: "acme", Name: fmt.Sprintf("repo-%d", i)}
+	}
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{Results: repos}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{MaxResultBytes: 1024, ResultResourceTTL: time.Minute}}
+	tools := NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return mockClient, nil
+	})
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+	ctx := contextWithSession("session-1")
+
+	result := callTool(ctx, t, s, "minder_list_repositories", map[string]any{"project_id": "p1"})
+	require.False(t, result.IsError, getResultText(t, result))
+	require.Len(t, result.Content, 2)
+	summary := getResultText(t, result)
+	assert.Contains(t, summary, "results: 50 items")
+	assert.Contains(t, summary, "has_more: false")
+
+	link, ok := result.Content[1].(mcp.ResourceLink)
+	require.True(t, ok, "expected a resource link, got %T", result.Content[1])
+	assert.Contains(t, link.URI, resultURIPrefix)
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = link.URI
+	contents, err := tools.readStoredResult(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	full, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Contains(t, full.Text, "repo-49")
+
+	_, err = tools.readStoredResult(contextWithSession("session-2"), req)
+	assert.Error(t, err, "another session read the result")
+}
+
+func TestLinkOversizedResult_Small(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{MCP: config.MCPConfig{MaxResultBytes: 1024, ResultResourceTTL: time.Minute}}
+	tools := NewWithClientFactory(cfg, logger, nil)
+
+	result := mcp.NewToolResultText(`{"results": []}`)
+	assert.Same(t, result, tools.linkOversizedResult(context.Background(), "minder_list_repositories", result))
+	assert.Len(t, result.Content, 1)
+}
+
+func TestSummarizeJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "list", text: `[1, 2, 3]`, want: "It is a list of 3 items."},
+		{
+			name: "object",
+			text: `{"results": [{}, {}], "has_more": true, "next_cursor": "abc", "profile": {"id": "x"}, "note": null}`,
+			want: `Top-level fields: has_more: true; next_cursor: "abc"; note: null; profile: object with 1 fields; results: 2 items.`,
+		},
+		{name: "not JSON", text: `plain text`, want: "The result is not JSON."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, summarizeJSON(tt.text))
+		})
+	}
+}
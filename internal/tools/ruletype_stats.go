@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// ruleTypeStat summarizes how one rule type is used and how it evaluates.
+// Rule types with the same name in several projects are combined.
+type ruleTypeStat struct {
+	RuleType string   `json:"rule_type"`
+	Projects []string `json:"projects,omitempty"`
+	Profiles int      `json:"profiles"`
+	Rules    int      `json:"rules"`
+	Passing  int      `json:"passing"`
+	Failing  int      `json:"failing"`
+	// Other counts evaluations that neither passed nor failed, e.g. skipped or pending.
+	Other int `json:"other"`
+	// PassRate is Passing over Passing plus Failing, or nil without such evaluations.
+	PassRate *float64 `json:"pass_rate,omitempty"`
+}
+
+// ruleTypeStats accumulates ruleTypeStat entries by rule type name.
+type ruleTypeStats map[string]*ruleTypeStat
+
+// get returns the entry of ruleType, creating it if needed.
+func (s ruleTypeStats) get(ruleType string) *ruleTypeStat {
+	stat, ok := s[ruleType]
+	if !ok {
+		stat = &ruleTypeStat{RuleType: ruleType}
+		s[ruleType] = stat
+	}
+	return stat
+}
+
+// addRuleTypes records rule types defined in a project.
+func (s ruleTypeStats) addRuleTypes(projectID string, ruleTypes []*minderv1.RuleType) {
+	for _, rt := range ruleTypes {
+		stat := s.get(rt.GetName())
+		if !slices.Contains(stat.Projects, projectID) {
+			stat.Projects = append(stat.Projects, projectID)
+		}
+	}
+}
+
+// addProfile records the rule types a profile uses and how its rules evaluated.
+func (s ruleTypeStats) addProfile(profile *minderv1.Profile, evaluations []*minderv1.RuleEvaluationStatus) {
+	used := map[string]bool{}
+	for _, rules := range profileRules(profile) {
+		for _, rule := range rules {
+			s.get(rule.GetType()).Rules++
+			used[rule.GetType()] = true
+		}
+	}
+	for ruleType := range used {
+		s.get(ruleType).Profiles++
+	}
+	for _, eval := range evaluations {
+		if eval.GetRuleTypeName() == "" {
+			continue
+		}
+		stat := s.get(eval.GetRuleTypeName())
+		switch {
+		case eval.GetStatus() == "success":
+			stat.Passing++
+		case watcher.IsFailing(eval.GetStatus()):
+			stat.Failing++
+		default:
+			stat.Other++
+		}
+	}
+}
+
+// sorted returns the entries ordered by rule type, with pass rates filled in.
+func (s ruleTypeStats) sorted() []ruleTypeStat {
+	stats := make([]ruleTypeStat, 0, len(s))
+	for _, stat := range s {
+		if decided := stat.Passing + stat.Failing; decided > 0 {
+			rate := float64(stat.Passing) / float64(decided)
+			stat.PassRate = &rate
+		}
+		slices.Sort(stat.Projects)
+		stats = append(stats, *stat)
+	}
+	slices.SortFunc(stats, func(a, b ruleTypeStat) int { return cmp.Compare(a.RuleType, b.RuleType) })
+	return stats
+}
+
+// getRuleTypeStats summarizes, per rule type, how many profiles use it and how
+// its rules evaluate across entities, to find unused and always failing rule types.
+func (t *Tools) getRuleTypeStats(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	projectIDs, err := projectIDsOrAll(ctx, client, projectID)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	stats := ruleTypeStats{}
+	var skipped []skippedRead
+	for _, projID := range projectIDs {
+		ruleTypes, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{
+			Context: &minderv1.Context{Project: &projID},
+		})
+		if err != nil {
+			skipped = append(skipped, skippedRead{Project: projID, Error: "listing rule types: " + MapGRPCError(err)})
+		} else {
+			stats.addRuleTypes(projID, ruleTypes.GetRuleTypes())
+		}
+		statuses, projSkipped := readProfileStatuses(ctx, client, projID)
+		skipped = append(skipped, projSkipped...)
+		for _, status := range statuses {
+			stats.addProfile(status.Profile, status.Evaluations)
+		}
+	}
+
+	// The output guides pruning, so rule types of projects that were not read
+	// completely are never reported unused or always failing
+	incomplete := map[string]bool{}
+	for _, s := range skipped {
+		incomplete[s.Project] = true
+	}
+	all := stats.sorted()
+	unused, alwaysFailing := []string{}, []string{}
+	for _, stat := range all {
+		if slices.ContainsFunc(stat.Projects, func(p string) bool { return incomplete[p] }) {
+			continue
+		}
+		if stat.Profiles == 0 {
+			unused = append(unused, stat.RuleType)
+		}
+		if stat.Failing > 0 && stat.Passing == 0 {
+			alwaysFailing = append(alwaysFailing, stat.RuleType)
+		}
+	}
+	result := map[string]any{
+		"rule_types":     all,
+		"unused":         unused,
+		"always_failing": alwaysFailing,
+	}
+	if len(skipped) > 0 {
+		result["skipped"] = skipped
+		result["note"] = "rule types of the skipped projects are left out of unused and always_failing"
+	}
+	return marshalResult(ctx, result)
+}
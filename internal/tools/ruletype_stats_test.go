@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRuleTypeStats(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{
+		RuleTypes: []*minderv1.RuleType{{Name: "branch_protection"}, {Name: "license"}, {Name: "secret_scanning"}},
+	}
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{
+			Name: "baseline",
+			Repository: []*minderv1.Profile_Rule{
+				{Type: "branch_protection", Name: "main"},
+				{Type: "branch_protection", Name: "release"},
+				{Type: "license"},
+			},
+		}},
+	}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{RuleTypeName: "branch_protection", Status: "success"},
+			{RuleTypeName: "branch_protection", Status: "failure"},
+			{RuleTypeName: "branch_protection", Status: "success"},
+			{RuleTypeName: "license", Status: "error"},
+			{RuleTypeName: "license", Status: "skipped"},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getRuleTypeStats(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getRuleTypeStats() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		RuleTypes     []ruleTypeStat `json:"rule_types"`
+		Unused        []string       `json:"unused"`
+		AlwaysFailing []string       `json:"always_failing"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.RuleTypes) != 3 {
+		t.Fatalf("rule_types = %+v, want 3 entries", got.RuleTypes)
+	}
+	bp := got.RuleTypes[0]
+	if bp.RuleType != "branch_protection" || bp.Profiles != 1 || bp.Rules != 2 || bp.Passing != 2 || bp.Failing != 1 {
+		t.Errorf("branch_protection = %+v, want 1 profile, 2 rules, 2 passing, 1 failing", bp)
+	}
+	if bp.PassRate == nil || *bp.PassRate < 0.66 || *bp.PassRate > 0.67 {
+		t.Errorf("branch_protection pass_rate = %v, want 2/3", bp.PassRate)
+	}
+	if lic := got.RuleTypes[1]; lic.Other != 1 || *lic.PassRate != 0 {
+		t.Errorf("license = %+v, want 1 other evaluation and a pass rate of 0", lic)
+	}
+	if !slices.Equal(got.Unused, []string{"secret_scanning"}) {
+		t.Errorf("unused = %v, want [secret_scanning]", got.Unused)
+	}
+	if !slices.Equal(got.AlwaysFailing, []string{"license"}) {
+		t.Errorf("always_failing = %v, want [license]", got.AlwaysFailing)
+	}
+}
+
+func TestGetRuleTypeStats_IncompleteProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		listErr   error
+		statusErr error
+	}{
+		{name: "profiles cannot be listed", listErr: status.Error(codes.Unavailable, "down")},
+		{name: "profile status cannot be read", statusErr: status.Error(codes.PermissionDenied, "denied")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{
+				RuleTypes: []*minderv1.RuleType{{Name: "branch_protection"}, {Name: "secret_scanning"}},
+			}
+			mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+				Profiles: []*minderv1.Profile{{Name: "baseline", Repository: []*minderv1.Profile_Rule{{Type: "branch_protection"}}}},
+			}
+			mockClient.profiles.listErr = tt.listErr
+			mockClient.profiles.getStatusByNameErr = tt.statusErr
+
+			result, err := newTestTools(mockClient).getRuleTypeStats(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+			})
+			if err != nil {
+				t.Fatalf("getRuleTypeStats() returned Go error: %v", err)
+			}
+			var got struct {
+				RuleTypes []ruleTypeStat `json:"rule_types"`
+				Unused    []string       `json:"unused"`
+				Skipped   []skippedRead  `json:"skipped"`
+			}
+			if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if len(got.Unused) != 0 {
+				t.Errorf("unused = %v, want none while the project was not read completely", got.Unused)
+			}
+			if len(got.Skipped) != 1 || got.Skipped[0].Project != "proj-1" {
+				t.Errorf("skipped = %+v, want proj-1", got.Skipped)
+			}
+			for _, stat := range got.RuleTypes {
+				if stat.Profiles != 0 {
+					t.Errorf("%s counted %d profiles from a profile that could not be read", stat.RuleType, stat.Profiles)
+				}
+			}
+		})
+	}
+}
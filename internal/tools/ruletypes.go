@@ -1,16 +1,127 @@
 package tools
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// Views of minder_list_rule_types, from the most to the least detailed.
+const (
+	ruleTypeViewFull    = "full"
+	ruleTypeViewSummary = "summary"
+	ruleTypeViewNames   = "names"
+)
+
+// ruleTypeSummary is a rule type without its definition, guidance and
+// parameter schema, which hold most of its size.
+type ruleTypeSummary struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+	EntityType  string `json:"entity_type,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Description string `json:"description,omitempty"`
+	// AlertMethod and RemediateMethod are empty when the rule type cannot alert or remediate.
+	AlertMethod     string `json:"alert_method,omitempty"`
+	RemediateMethod string `json:"remediate_method,omitempty"`
+}
+
+// ruleTypeCursor is the position after the last rule type of a page. Rule
+// types are listed by name, then project.
+type ruleTypeCursor struct {
+	Name    string `json:"n"`
+	Project string `json:"p,omitempty"`
+}
+
+// errInvalidRuleTypeCursor is returned for a cursor not produced by minder_list_rule_types.
+var errInvalidRuleTypeCursor = errors.New("invalid cursor: pass next_cursor from a previous response unchanged")
+
+// ruleTypeKey returns the position of rt in the listing.
+func ruleTypeKey(rt *minderv1.RuleType) ruleTypeCursor {
+	return ruleTypeCursor{Name: rt.GetName(), Project: rt.GetContext().GetProject()}
+}
+
+// compareRuleTypeKeys orders rule types by name, then project.
+func compareRuleTypeKeys(a, b ruleTypeCursor) int {
+	return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Project, b.Project))
+}
+
+// pageRuleTypes sorts ruleTypes and returns the page of up to size rule
+// types after cursor, and the cursor of the next page or "" after the last.
+// A size of zero or less returns every rule type after cursor.
+func pageRuleTypes(ruleTypes []*minderv1.RuleType, cursor string, size int) ([]*minderv1.RuleType, string, error) {
+	slices.SortFunc(ruleTypes, func(a, b *minderv1.RuleType) int {
+		return compareRuleTypeKeys(ruleTypeKey(a), ruleTypeKey(b))
+	})
+	if cursor != "" {
+		var after ruleTypeCursor
+		if !decodeCursor(cursor, &after) || after.Name == "" {
+			return nil, "", errInvalidRuleTypeCursor
+		}
+		start, _ := slices.BinarySearchFunc(ruleTypes, after, func(rt *minderv1.RuleType, c ruleTypeCursor) int {
+			// Start after the cursor's rule type, which may have since been deleted
+			if compareRuleTypeKeys(ruleTypeKey(rt), c) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		ruleTypes = ruleTypes[start:]
+	}
+	if size <= 0 || len(ruleTypes) <= size {
+		return ruleTypes, "", nil
+	}
+	page := ruleTypes[:size]
+	return page, encodeCursor(ruleTypeKey(page[size-1])), nil
+}
+
+// ruleTypeView returns ruleTypes as listed in view.
+func ruleTypeView(ruleTypes []*minderv1.RuleType, view string) any {
+	switch view {
+	case ruleTypeViewNames:
+		names := make([]string, len(ruleTypes))
+		for i, rt := range ruleTypes {
+			names[i] = rt.GetName()
+		}
+		return names
+	case ruleTypeViewSummary:
+		summaries := make([]ruleTypeSummary, len(ruleTypes))
+		for i, rt := range ruleTypes {
+			summaries[i] = ruleTypeSummary{
+				Name:            rt.GetName(),
+				DisplayName:     rt.GetDisplayName(),
+				ProjectID:       rt.GetContext().GetProject(),
+				EntityType:      rt.GetDef().GetInEntity(),
+				Severity:        severityName(rt.GetSeverity()),
+				Description:     rt.GetDescription(),
+				AlertMethod:     rt.GetDef().GetAlert().GetType(),
+				RemediateMethod: rt.GetDef().GetRemediate().GetType(),
+			}
+		}
+		return summaries
+	default:
+		return ruleTypes
+	}
+}
+
 func (t *Tools) listRuleTypes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	entityType := req.GetString("entity_type", "")
+	view := req.GetString("view", ruleTypeViewFull)
+	cursor := req.GetString("cursor", "")
+	limit := t.pageSize(req.GetInt("limit", 0))
+	if !slices.Contains([]string{ruleTypeViewFull, ruleTypeViewSummary, ruleTypeViewNames}, view) {
+		return mcp.NewToolResultError("view must be full, summary or names"), nil
+	}
+
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -18,8 +129,6 @@ func (t *Tools) listRuleTypes(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return errResult, nil
 	}
 
-	projectID := req.GetString("project_id", "")
-
 	// Use multi-project aggregation when no project_id specified
 	ruleTypes, err := forEachProject(ctx, client, projectID, func(ctx context.Context, projID string) ([]*minderv1.RuleType, error) {
 		resp, err := client.RuleTypes().ListRuleTypes(ctx, &minderv1.ListRuleTypesRequest{
@@ -33,10 +142,28 @@ func (t *Tools) listRuleTypes(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return resp.RuleTypes, nil
 	})
 	if err != nil {
-		return mcp.NewToolResultError(MapGRPCError(err)), nil
+		return grpcErrorResult(err), nil
+	}
+	if entityType != "" {
+		ruleTypes = slices.DeleteFunc(ruleTypes, func(rt *minderv1.RuleType) bool {
+			return rt.GetDef().GetInEntity() != entityType
+		})
 	}
 
-	return marshalResult(ruleTypes)
+	// Minder lists every rule type at once, so pages are cut here
+	page, next, err := pageRuleTypes(ruleTypes, cursor, limit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	page, total := capResults(page, t.cfg.MCP.MaxResults)
+	result := map[string]any{
+		"results":  ruleTypeView(page, view),
+		"has_more": next != "",
+	}
+	if next != "" {
+		result["next_cursor"] = next
+	}
+	return t.marshalCapped(ctx, result, len(page), total)
 }
 
 func (t *Tools) getRuleType(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -53,7 +180,7 @@ func (t *Tools) getRuleType(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 
 	client, err := t.getClient(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return clientErrorResult(err), nil
 	}
 	defer func() { _ = client.Close() }()
 
@@ -69,27 +196,139 @@ func (t *Tools) getRuleType(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 			Id: ruleTypeID,
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return grpcErrorResult(err), nil
 		}
 		ruleType = resp.RuleType
 	} else {
 		// Lookup by name - search across projects if none specified
-		ruleType, err = findInProjects(ctx, client, projectID, func(ctx context.Context, projID string) (*minderv1.RuleType, error) {
-			resp, err := client.RuleTypes().GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{
-				Name: name,
-				Context: &minderv1.Context{
-					Project: &projID,
-				},
+		ruleType, err = findOneInProjects(
+			ctx, client, projectID, "rule type "+name,
+			func(ctx context.Context, projID string) (*minderv1.RuleType, error) {
+				resp, err := client.RuleTypes().GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{
+					Name: name,
+					Context: &minderv1.Context{
+						Project: &projID,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return resp.RuleType, nil
 			})
-			if err != nil {
-				return nil, err
+		if err != nil {
+			return grpcErrorResult(err), nil
+		}
+	}
+
+	return marshalResult(ctx, ruleType)
+}
+
+// ruleTypeUsage is a profile that includes a rule type.
+type ruleTypeUsage struct {
+	Profile   string                  `json:"profile"`
+	ProfileID string                  `json:"profile_id,omitempty"`
+	ProjectID string                  `json:"project_id,omitempty"`
+	Rules     []ruleTypeUsageInstance `json:"rules"`
+}
+
+// ruleTypeUsageInstance is one rule of a profile using the rule type, with
+// the parameters and definition values the profile configures.
+type ruleTypeUsageInstance struct {
+	EntityType string           `json:"entity_type"`
+	Name       string           `json:"name,omitempty"`
+	Params     *structpb.Struct `json:"params,omitempty"`
+	Def        *structpb.Struct `json:"def,omitempty"`
+}
+
+// profileRules returns the rules of a profile keyed by the entity type they apply to.
+func profileRules(p *minderv1.Profile) map[string][]*minderv1.Profile_Rule {
+	return map[string][]*minderv1.Profile_Rule{
+		"repository":        p.GetRepository(),
+		"build_environment": p.GetBuildEnvironment(),
+		"artifact":          p.GetArtifact(),
+		"pull_request":      p.GetPullRequest(),
+		"release":           p.GetRelease(),
+		"pipeline_run":      p.GetPipelineRun(),
+		"task_run":          p.GetTaskRun(),
+		"build":             p.GetBuild(),
+	}
+}
+
+// ruleTypeUsages returns the profiles that include ruleType, with their rules of that type.
+func ruleTypeUsages(profiles []*minderv1.Profile, ruleType string) []ruleTypeUsage {
+	usages := []ruleTypeUsage{}
+	for _, p := range profiles {
+		var rules []ruleTypeUsageInstance
+		for entityType, entityRules := range profileRules(p) {
+			for _, rule := range entityRules {
+				if rule.GetType() == ruleType {
+					rules = append(rules, ruleTypeUsageInstance{
+						EntityType: entityType,
+						Name:       rule.GetName(),
+						Params:     rule.GetParams(),
+						Def:        rule.GetDef(),
+					})
+				}
 			}
-			return resp.RuleType, nil
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		slices.SortFunc(rules, func(a, b ruleTypeUsageInstance) int {
+			return cmp.Or(cmp.Compare(a.EntityType, b.EntityType), cmp.Compare(a.Name, b.Name))
+		})
+		usages = append(usages, ruleTypeUsage{
+			Profile:   p.GetName(),
+			ProfileID: p.GetId(),
+			ProjectID: p.GetContext().GetProject(),
+			Rules:     rules,
+		})
+	}
+	return usages
+}
+
+// listRuleTypeProfiles lists the profiles that include a rule type and how
+// they configure it, to check the impact of editing or deleting the rule type.
+func (t *Tools) listRuleTypeProfiles(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ruleType := req.GetString("rule_type", "")
+	projectID := req.GetString("project_id", "")
+	if ruleType == "" {
+		return mcp.NewToolResultError("rule_type must be provided"), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	profiles, err := forEachProject(ctx, client, projectID, func(ctx context.Context, projID string) ([]*minderv1.Profile, error) {
+		resp, err := client.Profiles().ListProfiles(ctx, &minderv1.ListProfilesRequest{
+			Context:     &minderv1.Context{Project: &projID},
+			LabelFilter: "*",
 		})
 		if err != nil {
-			return mcp.NewToolResultError(MapGRPCError(err)), nil
+			return nil, err
 		}
+		return resp.Profiles, nil
+	})
+	if err != nil {
+		return grpcErrorResult(err), nil
 	}
 
-	return marshalResult(ruleType)
+	sortByProject(profiles, profileKey)
+	usages := ruleTypeUsages(profiles, ruleType)
+	result := map[string]any{
+		"rule_type": ruleType,
+		"profiles":  usages,
+	}
+	if len(usages) == 0 {
+		result["note"] = "no accessible profile includes this rule type; profiles in projects the token " +
+			"cannot read are not searched"
+	}
+	return marshalResult(ctx, result)
 }
@@ -2,6 +2,9 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestListRuleTypes(t *testing.T) {
@@ -83,6 +87,122 @@ func TestListRuleTypes(t *testing.T) {
 	}
 }
 
+func TestPageRuleTypes(t *testing.T) {
+	t.Parallel()
+
+	proj1, proj2 := "proj-1", "proj-2"
+	all := []*minderv1.RuleType{
+		{Name: "secret_scanning", Context: &minderv1.Context{Project: &proj1}},
+		{Name: "branch_protection", Context: &minderv1.Context{Project: &proj2}},
+		{Name: "license", Context: &minderv1.Context{Project: &proj1}},
+		{Name: "branch_protection", Context: &minderv1.Context{Project: &proj1}},
+	}
+
+	var names []string
+	cursor := ""
+	for range len(all) {
+		page, next, err := pageRuleTypes(slices.Clone(all), cursor, 3)
+		if err != nil {
+			t.Fatalf("pageRuleTypes() error = %v", err)
+		}
+		for _, rt := range page {
+			names = append(names, rt.GetName()+"@"+rt.GetContext().GetProject())
+		}
+		if cursor = next; cursor == "" {
+			break
+		}
+	}
+	want := []string{"branch_protection@proj-1", "branch_protection@proj-2", "license@proj-1", "secret_scanning@proj-1"}
+	if !slices.Equal(names, want) {
+		t.Errorf("paged rule types = %v, want %v", names, want)
+	}
+
+	// A cursor stays valid when its rule type is deleted
+	cursor = encodeCursor(ruleTypeCursor{Name: "c"})
+	page, next, err := pageRuleTypes(slices.Clone(all), cursor, 0)
+	if err != nil || len(page) != 2 || page[0].GetName() != "license" || next != "" {
+		t.Errorf("pageRuleTypes(after c) = %v, %q, %v, want license and secret_scanning", page, next, err)
+	}
+
+	if _, _, err := pageRuleTypes(all, "not-a-cursor", 3); !errors.Is(err, errInvalidRuleTypeCursor) {
+		t.Errorf("pageRuleTypes(invalid cursor) error = %v, want errInvalidRuleTypeCursor", err)
+	}
+}
+
+func TestListRuleTypes_Views(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.ruleTypes.listResp = &minderv1.ListRuleTypesResponse{
+		RuleTypes: []*minderv1.RuleType{
+			{
+				Name:        "secret_scanning",
+				Description: "Secret scanning is enabled",
+				Severity:    &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH},
+				Def: &minderv1.RuleType_Definition{
+					InEntity:  "repository",
+					Remediate: &minderv1.RuleType_Definition_Remediate{Type: "rest"},
+					Eval:      &minderv1.RuleType_Definition_Eval{Type: "rego"},
+				},
+			},
+			{Name: "artifact_signature", Def: &minderv1.RuleType_Definition{InEntity: "artifact"}},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	tests := []struct {
+		name    string
+		params  map[string]any
+		want    string
+		notWant string
+	}{
+		{
+			name:    "summary drops definitions",
+			params:  map[string]any{"view": "summary", "entity_type": "repository"},
+			want:    `"remediate_method": "rest"`,
+			notWant: "rego",
+		},
+		{
+			name:    "names only",
+			params:  map[string]any{"view": "names"},
+			want:    `"artifact_signature",`,
+			notWant: "description",
+		},
+		{
+			name:    "entity type filter",
+			params:  map[string]any{"entity_type": "artifact"},
+			want:    "artifact_signature",
+			notWant: "secret_scanning",
+		},
+		{
+			name:   "page",
+			params: map[string]any{"view": "names", "limit": float64(1)},
+			want:   `"has_more": true`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.listRuleTypes(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.params},
+			})
+			if err != nil {
+				t.Fatalf("listRuleTypes() returned Go error: %v", err)
+			}
+			text := getResultText(t, result)
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", text)
+			}
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("response %s does not contain %q", text, tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(text, tt.notWant) {
+				t.Errorf("response %s contains %q", text, tt.notWant)
+			}
+		})
+	}
+}
+
 func TestGetRuleType(t *testing.T) {
 	t.Parallel()
 
@@ -170,3 +290,62 @@ func TestGetRuleType(t *testing.T) {
 		})
 	}
 }
+
+func TestListRuleTypeProfiles(t *testing.T) {
+	t.Parallel()
+
+	params, err := structpb.NewStruct(map[string]any{"branch": "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{
+			{
+				Id:      ptr("prof-1"),
+				Name:    "baseline",
+				Context: &minderv1.Context{Project: ptr("proj-1")},
+				Repository: []*minderv1.Profile_Rule{
+					{Type: "branch_protection", Name: "main_protected", Params: params},
+					{Type: "license", Name: "license"},
+				},
+			},
+			{
+				Name:       "artifacts",
+				Repository: []*minderv1.Profile_Rule{{Type: "license"}},
+			},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.listRuleTypeProfiles(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"rule_type": "branch_protection", "project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("listRuleTypeProfiles() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Profiles []struct {
+			Profile string `json:"profile"`
+			Rules   []struct {
+				EntityType string         `json:"entity_type"`
+				Name       string         `json:"name"`
+				Params     map[string]any `json:"params"`
+			} `json:"rules"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Profiles) != 1 || got.Profiles[0].Profile != "baseline" || len(got.Profiles[0].Rules) != 1 {
+		t.Fatalf("profiles = %+v, want only baseline with one rule", got.Profiles)
+	}
+	rule := got.Profiles[0].Rules[0]
+	if rule.EntityType != "repository" || rule.Name != "main_protected" || rule.Params["branch"] != "main" {
+		t.Errorf("rule = %+v, want the repository rule main_protected with its params", rule)
+	}
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Definitions returns the tools Register adds for the current configuration,
+// sorted by name, with their input schemas and annotations. It lets the tool
+// contract be exported or compared without serving it.
+func (t *Tools) Definitions() []mcp.Tool {
+	s := server.NewMCPServer("definitions", "")
+	t.Register(s)
+
+	defs := make([]mcp.Tool, 0, len(s.ListTools()))
+	for _, tool := range s.ListTools() {
+		defs = append(defs, tool.Tool)
+	}
+	slices.SortFunc(defs, func(a, b mcp.Tool) int { return strings.Compare(a.Name, b.Name) })
+	return defs
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+// updateGolden rewrites the golden files instead of comparing against them:
+//
+//	go test ./internal/tools -run TestToolDefinitionsGolden -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// TestToolDefinitionsGolden guards the tool contract: any change to a tool's
+// name, description, parameters or annotations must be made deliberately by
+// regenerating testdata/tools.golden.json and reviewing the diff.
+func TestToolDefinitionsGolden(t *testing.T) {
+	t.Parallel()
+
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	// Enable the optional tools so the golden file covers every tool
+	cfg := &config.Config{Minder: config.MinderConfig{Servers: []config.NamedServer{{Name: "staging"}, {Name: "prod"}}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tools := NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return newMockClient(), nil
+	})
+	tools.SetHistory(store)
+
+	got, err := json.MarshalIndent(tools.Definitions(), "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "tools.golden.json")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, got, 0o600))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run with -update to create the golden file")
+	assert.JSONEq(t, string(want), string(got),
+		"tool definitions changed; if intended, run with -update and review the diff")
+}
+
+func TestDefinitionsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tools := NewWithClientFactory(&config.Config{MCP: config.MCPConfig{ReadOnly: true}}, logger,
+		func(_ context.Context) (MinderClient, error) { return newMockClient(), nil })
+
+	for _, def := range tools.Definitions() {
+		assert.True(t, isReadOnlyTool(def), "write tool %s listed in read-only mode", def.Name)
+	}
+}
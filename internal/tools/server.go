@@ -0,0 +1,14 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// serverStats returns in-memory usage statistics for every tool invoked since startup.
+func (t *Tools) serverStats(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return marshalResult(ctx, map[string]any{
+		"tools": t.stats.Snapshot(),
+	})
+}
@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+)
+
+// Transports reported for a Minder server.
+const (
+	transportTLS       = "tls"
+	transportPlaintext = "plaintext"
+)
+
+// serverConfigServer is a configured Minder server. Only whether it has a
+// token and how many certificate pins it has are reported, never their values.
+type serverConfigServer struct {
+	Name               string `json:"name"`
+	Host               string `json:"host"`
+	Port               int    `json:"port"`
+	Transport          string `json:"transport"`
+	CertPins           int    `json:"cert_pins"`
+	HasConfiguredToken bool   `json:"has_configured_token"`
+}
+
+// serverConfigAuthProfile is a configured auth profile, without its token.
+type serverConfigAuthProfile struct {
+	Name         string   `json:"name"`
+	Server       string   `json:"server,omitempty"`
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+}
+
+// serverConfig returns the effective configuration of this server. It is
+// built from an explicit list of settings rather than the config struct, so
+// secrets added to the configuration later are not exposed by default.
+func (t *Tools) serverConfig(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cfg := t.cfg
+	servers := make([]serverConfigServer, 0, len(cfg.Minder.ServerNames()))
+	for _, name := range cfg.Minder.ServerNames() {
+		srv, _ := cfg.Minder.Server(name)
+		transport := transportTLS
+		if srv.Insecure {
+			transport = transportPlaintext
+		}
+		servers = append(servers, serverConfigServer{
+			Name:               srv.Name,
+			Host:               srv.Host,
+			Port:               srv.Port,
+			Transport:          transport,
+			CertPins:           len(srv.CertPins),
+			HasConfiguredToken: srv.AuthToken != "",
+		})
+	}
+	profiles := make([]serverConfigAuthProfile, 0, len(cfg.Minder.AuthProfiles))
+	for _, p := range cfg.Minder.AuthProfiles {
+		profiles = append(profiles, serverConfigAuthProfile{Name: p.Name, Server: p.Server, AllowedCIDRs: p.AllowedCIDRs})
+	}
+
+	return marshalResult(ctx, map[string]any{
+		"mode":          cfg.MCP.Mode,
+		"recording_dir": cfg.MCP.RecordingDir,
+		"read_only":     cfg.MCP.ReadOnly,
+		"minder": map[string]any{
+			"servers":             servers,
+			"insecure_confirmed":  cfg.Minder.InsecureConfirmed,
+			"auth_profiles":       profiles,
+			"allowed_hosts":       cfg.Minder.AllowedHosts,
+			"rate_limit_retries":  cfg.Minder.RateLimitRetries,
+			"rate_limit_max_wait": cfg.Minder.RateLimitMaxWait.String(),
+		},
+		"mcp": map[string]any{
+			"port":                    cfg.MCP.Port,
+			"endpoint_path":           cfg.MCP.EndpointPath,
+			"metrics_enabled":         cfg.MCP.MetricsEnabled,
+			"stats_enabled":           cfg.MCP.StatsEnabled,
+			"pprof_port":              cfg.MCP.PprofPort,
+			"cors_allowed_origins":    cfg.MCP.CORSAllowedOrigins,
+			"trusted_origins":         cfg.MCP.TrustedOrigins,
+			"client_networks_limited": len(cfg.MCP.AllowedCIDRs) > 0,
+			"warmup":                  cfg.MCP.Warmup,
+			"health_check_timeout":    cfg.MCP.HealthCheckTimeout.String(),
+			"compact_json":            cfg.MCP.CompactJSON,
+		},
+		"limits": map[string]any{
+			"default_page_size":             cfg.MCP.DefaultPageSize,
+			"max_results":                   cfg.MCP.MaxResults,
+			"max_result_bytes":              cfg.MCP.MaxResultBytes,
+			"max_concurrent_calls":          cfg.MCP.MaxConcurrentCalls,
+			"max_concurrent_calls_per_tool": cfg.MCP.MaxConcurrentCallsPerTool,
+			"rate_limit_per_minute":         cfg.MCP.RateLimitPerMinute,
+			"rate_limit_burst":              cfg.MCP.RateLimitBurst,
+			"slow_call_threshold":           cfg.MCP.SlowCallThreshold.String(),
+		},
+		"cache": map[string]any{
+			"realm_cache_persisted": cfg.Minder.RealmCachePath != "" || cfg.Store.Path != "",
+			"result_resource_ttl":   cfg.MCP.ResultResourceTTL.String(),
+		},
+		"store": map[string]any{
+			"enabled":              cfg.Store.Path != "",
+			"max_size_mb":          cfg.Store.MaxSizeMB,
+			"maintenance_interval": cfg.Store.MaintenanceInterval.String(),
+		},
+		"tools": map[string]any{
+			"prefix":           cfg.MCP.ToolPrefix,
+			"overrides":        len(t.overrides),
+			"allowlist":        t.allowlist(),
+			"enabled":          t.enabledToolNames(ctx),
+			"raw_call_methods": cfg.MCP.RawCallMethods,
+			"plugins":          cfg.MCP.Plugins,
+			"plugin_timeout":   cfg.MCP.PluginTimeout.String(),
+			"plugin_env":       cfg.MCP.PluginEnv,
+		},
+		"watch": map[string]any{
+			"interval":       cfg.Watch.Interval.String(),
+			"projects":       cfg.Watch.Projects,
+			"notify":         cfg.Watch.Notify,
+			"webhook_hosts":  webhookHosts(cfg.Watch.WebhookURLs),
+			"webhook_signed": cfg.Watch.WebhookSecret != "",
+			"alert_sinks":    alertSinks(cfg.Watch.AlertSinks),
+		},
+		"history": map[string]any{
+			"interval":  cfg.History.Interval.String(),
+			"retention": cfg.History.Retention.String(),
+		},
+		"report": map[string]any{
+			"schedule": cfg.Report.Schedule,
+			"dir":      cfg.Report.Dir,
+			"keep":     cfg.Report.Keep,
+		},
+	})
+}
+
+// allowlist returns the current tool allowlist, which SIGHUP may have
+// changed since startup, or nil when every tool is enabled.
+func (t *Tools) allowlist() []string {
+	set := t.enabled.set.Load()
+	if set == nil {
+		return nil
+	}
+	names := make([]string, 0, len(*set))
+	for name := range *set {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// enabledToolNames returns the names of the tools clients are currently
+// offered, or nil when the calling server is unknown.
+func (t *Tools) enabledToolNames(ctx context.Context) []string {
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return nil
+	}
+	tools := make([]mcp.Tool, 0, len(s.ListTools()))
+	for _, tool := range s.ListTools() {
+		tools = append(tools, tool.Tool)
+	}
+	names := make([]string, 0, len(tools))
+	for _, tool := range t.FilterTools(ctx, tools) {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// alertSinks describes the alert sinks, reducing their URLs to hosts.
+func alertSinks(sinks []config.AlertSink) []map[string]any {
+	out := make([]map[string]any, 0, len(sinks))
+	for _, sink := range sinks {
+		out = append(out, map[string]any{
+			"name":         sink.Name,
+			"type":         sink.Type,
+			"host":         strings.Join(webhookHosts([]string{sink.URL}), ""),
+			"projects":     sink.Projects,
+			"min_severity": sink.MinSeverity,
+			"recoveries":   sink.Recoveries,
+			"signed":       sink.Secret != "",
+		})
+	}
+	return out
+}
+
+// webhookHosts returns the hosts of webhook URLs, leaving out paths, queries
+// and credentials, which often carry the webhook's secret.
+func webhookHosts(urls []string) []string {
+	hosts := make([]string, 0, len(urls))
+	for _, raw := range urls {
+		if u, err := url.Parse(raw); err == nil {
+			hosts = append(hosts, u.Host)
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// serverFor returns the Minder server selected for the session in ctx,
+// falling back to the default server.
+func (t *Tools) serverFor(ctx context.Context) config.NamedServer {
+	name := ""
+	if id := sessionID(ctx); id != "" {
+		name = t.sessions.get(id).server
+	}
+	srv, ok := t.cfg.Minder.Server(name)
+	if !ok {
+		// The selected server was removed from the configuration.
+		srv, _ = t.cfg.Minder.Server(config.DefaultServerName)
+	}
+	return srv
+}
+
+// resolveServer returns the Minder server a request in ctx is sent to: the
+// host named by the X-Minder-Host header when it is allowed, otherwise the
+// session's selected server.
+func (t *Tools) resolveServer(ctx context.Context) (config.NamedServer, error) {
+	host := middleware.MinderHostFromContext(ctx)
+	if host == "" {
+		return t.serverFor(ctx), nil
+	}
+	srv, ok := t.cfg.Minder.ServerForHost(host)
+	if !ok {
+		return config.NamedServer{}, fmt.Errorf("minder host %q from the %s header is not allowed: "+
+			"use a configured server's host or one listed in MINDER_ALLOWED_HOSTS", host, middleware.MinderHostHeader)
+	}
+	return srv, nil
+}
+
+// selectServer chooses the Minder server used by later tool calls in this session.
+// Without a name it reports the current selection and the available servers.
+func (t *Tools) selectServer(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	names := t.cfg.Minder.ServerNames()
+	name := strings.ToLower(req.GetString("name", ""))
+
+	if name != "" {
+		if _, ok := t.cfg.Minder.Server(name); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("unknown server %q: available servers are %s",
+				name, strings.Join(names, ", "))), nil
+		}
+		id := sessionID(ctx)
+		if id == "" {
+			return mcp.NewToolResultError("selecting a server requires an MCP session"), nil
+		}
+		// Project IDs and providers differ between servers, so drop the pinned context
+		t.sessions.update(id, func(s *sessionState) { s.server, s.projectID, s.provider = name, "", "" })
+		t.logger.InfoContext(ctx, "session selected minder server", "server", name)
+	}
+
+	srv := t.serverFor(ctx)
+	return marshalResult(ctx, map[string]any{
+		"server":    srv.Name,
+		"host":      srv.Host,
+		"available": names,
+	})
+}
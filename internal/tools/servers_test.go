@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// fakeSession is a minimal server.ClientSession for tests that need a session ID.
+type fakeSession struct {
+	id string
+}
+
+func (*fakeSession) Initialize()       {}
+func (*fakeSession) Initialized() bool { return true }
+func (s *fakeSession) SessionID() string {
+	return s.id
+}
+func (*fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification, 1)
+}
+
+// contextWithSession returns a context carrying a fake MCP session with the given ID.
+func contextWithSession(id string) context.Context {
+	s := server.NewMCPServer("test", "0.0.0")
+	return s.WithContext(context.Background(), &fakeSession{id: id})
+}
+
+func newMultiServerTools() *Tools {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Minder: config.MinderConfig{
+			Host: "api.example.com",
+			Port: 443,
+			Servers: []config.NamedServer{
+				{Name: "staging", Host: "staging.example.com", Port: 443},
+			},
+			AllowedHosts: []string{"customer.example.com"},
+		},
+	}
+	return NewWithClientFactory(cfg, logger, func(_ context.Context) (MinderClient, error) {
+		return newMockClient(), nil
+	})
+}
+
+func TestSelectServer(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	ctx := contextWithSession("session-1")
+
+	if got := tools.serverFor(ctx); got.Name != config.DefaultServerName {
+		t.Errorf("serverFor() before selection = %q, want %q", got.Name, config.DefaultServerName)
+	}
+
+	result, err := tools.selectServer(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"name": "staging"}},
+	})
+	if err != nil {
+		t.Fatalf("selectServer() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", getResultText(t, result))
+	}
+	if text := getResultText(t, result); !strings.Contains(text, `"host": "staging.example.com"`) {
+		t.Errorf("response %q does not report the selected host", text)
+	}
+
+	if got := tools.serverFor(ctx); got.Host != "staging.example.com" {
+		t.Errorf("serverFor() after selection = %q, want staging.example.com", got.Host)
+	}
+	if got := tools.serverFor(contextWithSession("session-2")); got.Name != config.DefaultServerName {
+		t.Errorf("selection leaked into another session: got %q", got.Name)
+	}
+
+	tools.ForgetSession("session-1")
+	if got := tools.serverFor(ctx); got.Name != config.DefaultServerName {
+		t.Errorf("serverFor() after ForgetSession = %q, want %q", got.Name, config.DefaultServerName)
+	}
+}
+
+func TestSelectServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		server  string
+		wantMsg string
+	}{
+		{
+			name:    "unknown server",
+			ctx:     contextWithSession("session-1"),
+			server:  "production",
+			wantMsg: "available servers are default, staging",
+		},
+		{
+			name:    "no session",
+			ctx:     context.Background(),
+			server:  "staging",
+			wantMsg: "requires an MCP session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tools.selectServer(tt.ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"name": tt.server}},
+			})
+			if err != nil {
+				t.Fatalf("selectServer() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestResolveServer(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	session := contextWithSession("session-1")
+	tools.sessions.update("session-1", func(s *sessionState) { s.server = "staging" })
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantHost string
+		wantErr  string
+	}{
+		{name: "no header uses session selection", ctx: session, wantHost: "staging.example.com"},
+		{
+			name:     "header overrides session selection",
+			ctx:      middleware.ContextWithMinderHost(session, "api.example.com"),
+			wantHost: "api.example.com",
+		},
+		{
+			name:     "allowlisted host",
+			ctx:      middleware.ContextWithMinderHost(context.Background(), "customer.example.com:443"),
+			wantHost: "customer.example.com",
+		},
+		{
+			name:    "host not allowed",
+			ctx:     middleware.ContextWithMinderHost(session, "evil.example.com"),
+			wantErr: "not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv, err := tools.resolveServer(tt.ctx)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveServer() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveServer() returned error: %v", err)
+			}
+			if srv.Host != tt.wantHost {
+				t.Errorf("resolveServer() host = %q, want %q", srv.Host, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestTokenFor_HeaderTokenScope(t *testing.T) {
+	t.Parallel()
+
+	tools := newMultiServerTools()
+	tools.cfg.Minder.AuthToken = "prod-configured"
+	tools.cfg.Minder.Servers = []config.NamedServer{
+		{Name: "staging", Host: "staging.example.com", Port: 443, AuthToken: "staging-configured"},
+		{Name: "dev", Host: "dev.example.com", Port: 443},
+		{Name: "shared", Host: "shared.example.com", Port: 443, HeaderTokens: true},
+	}
+	header := middleware.ContextWithHeaderToken(context.Background(), "prod-user")
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		server string
+		want   string
+	}{
+		{name: "header token to the default server", ctx: header, server: config.DefaultServerName, want: "prod-user"},
+		{name: "header token kept from another server", ctx: header, server: "staging", want: "staging-configured"},
+		{name: "header token kept from a server without a token", ctx: header, server: "dev", want: ""},
+		{name: "server accepting header tokens", ctx: header, server: "shared", want: "prod-user"},
+		{
+			name:   "host the request routed to",
+			ctx:    middleware.ContextWithMinderHost(header, "dev.example.com"),
+			server: "dev",
+			want:   "prod-user",
+		},
+		{name: "configured token", ctx: context.Background(), server: config.DefaultServerName, want: "prod-configured"},
+		{name: "configured token kept from another server", ctx: context.Background(), server: "dev", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv, _ := tools.cfg.Minder.Server(tt.server)
+			if got := tools.tokenFor(tt.ctx, srv); got != tt.want {
+				t.Errorf("tokenFor(%s) = %q, want %q", tt.server, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister_SelectServerOnlyWithNamedServers(t *testing.T) {
+	t.Parallel()
+
+	single := server.NewMCPServer("test", "0.0.0")
+	newTestTools(newMockClient()).Register(single)
+	if single.GetTool("minder_select_server") != nil {
+		t.Error("minder_select_server should not be registered without named servers")
+	}
+
+	multi := server.NewMCPServer("test", "0.0.0")
+	newMultiServerTools().Register(multi)
+	if multi.GetTool("minder_select_server") == nil {
+		t.Error("minder_select_server should be registered with named servers")
+	}
+}
@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// sessionState holds selections a client has made for its MCP session.
+type sessionState struct {
+	server string
+	// projectID and provider are defaults pinned with minder_set_context.
+	projectID string
+	provider  string
+	// tokens are saved with minder_save_credentials, keyed by server name.
+	// The map is replaced, never modified, so copies from get stay valid.
+	tokens map[string]savedToken
+}
+
+// savedToken is a token saved with minder_save_credentials. It is only used
+// for calls from the caller that saved it, identified by the hash of the
+// Authorization header token it sent, so the session ID alone does not
+// unlock it.
+type savedToken struct {
+	token  string
+	caller [sha256.Size]byte
+}
+
+// callerKey identifies the caller of the request in ctx for saved tokens: the
+// hash of the token in its Authorization header, or of "" without one.
+func callerKey(ctx context.Context) [sha256.Size]byte {
+	var token string
+	if middleware.TokenFromHeader(ctx) {
+		token = middleware.TokenFromContext(ctx)
+	}
+	return sha256.Sum256([]byte(token))
+}
+
+// sessionStore tracks per-session state keyed by MCP session ID.
+type sessionStore struct {
+	mu     sync.RWMutex
+	states map[string]sessionState
+}
+
+// get returns the state for the session, or the zero state if none was stored.
+func (s *sessionStore) get(id string) sessionState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.states[id]
+}
+
+// update applies fn to the session's state.
+func (s *sessionStore) update(id string, fn func(*sessionState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.states == nil {
+		s.states = make(map[string]sessionState)
+	}
+	state := s.states[id]
+	fn(&state)
+	s.states[id] = state
+}
+
+// delete removes the session's state.
+func (s *sessionStore) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, id)
+}
+
+// ForgetSession drops the state and oversized results stored for an MCP session. Call it when the
+// session is unregistered so the store does not grow without bound.
+func (t *Tools) ForgetSession(id string) {
+	t.sessions.delete(id)
+	t.results.forget(id)
+}
+
+// sessionID returns the MCP session ID from the context, or "" outside a session.
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// idLookupParams select a resource by ID. Tools reject project_id and provider
+// alongside them, so pinned defaults are not applied to such calls.
+var idLookupParams = []string{"artifact_id", "data_source_id", "profile_id", "repository_id", "rule_type_id"}
+
+// sessionContextExempt lists tools whose project_id is not a default scope:
+// minder_list_projects treats it as a parent whose children are listed.
+var sessionContextExempt = map[string]bool{
+	"minder_list_projects": true,
+	"minder_set_context":   true,
+}
+
+// withSessionContext fills the tool's project_id and provider arguments from
+// the defaults pinned for the session when a call omits them.
+func (t *Tools) withSessionContext(tool mcp.Tool, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	_, hasProject := tool.InputSchema.Properties["project_id"]
+	_, hasProvider := tool.InputSchema.Properties["provider"]
+	if (!hasProject && !hasProvider) || sessionContextExempt[tool.Name] {
+		return handler
+	}
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := sessionID(ctx)
+		if id == "" {
+			return handler(ctx, req)
+		}
+		state := t.sessions.get(id)
+		if state.projectID == "" && state.provider == "" {
+			return handler(ctx, req)
+		}
+
+		for _, param := range idLookupParams {
+			if req.GetString(param, "") != "" {
+				return handler(ctx, req)
+			}
+		}
+
+		args := maps.Clone(req.GetArguments())
+		if args == nil {
+			args = map[string]any{}
+		}
+		if _, ok := args["project_id"]; hasProject && !ok && state.projectID != "" {
+			args["project_id"] = state.projectID
+		}
+		if _, ok := args["provider"]; hasProvider && !ok && state.provider != "" {
+			args["provider"] = state.provider
+		}
+		req.Params.Arguments = args
+		return handler(ctx, req)
+	}
+}
+
+// setContext pins a default project and provider for the rest of the session,
+// after checking they exist. Without arguments it reports the current context.
+func (t *Tools) setContext(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id := sessionID(ctx)
+	if id == "" {
+		return mcp.NewToolResultError("session context requires an MCP session"), nil
+	}
+
+	projectID := req.GetString("project_id", "")
+	provider := req.GetString("provider", "")
+	switch {
+	case req.GetBool("clear", false):
+		t.sessions.update(id, func(s *sessionState) { s.projectID, s.provider = "", "" })
+	case projectID != "" || provider != "":
+		state := t.sessions.get(id)
+		if projectID == "" {
+			projectID = state.projectID
+		}
+		if provider == "" {
+			provider = state.provider
+		}
+		if errResult := t.checkSessionContext(ctx, projectID, provider); errResult != nil {
+			return errResult, nil
+		}
+		t.sessions.update(id, func(s *sessionState) { s.projectID, s.provider = projectID, provider })
+		t.logger.InfoContext(ctx, "session context set", "project_id", projectID, "provider", provider)
+	}
+
+	state := t.sessions.get(id)
+	return marshalResult(ctx, map[string]any{
+		"project_id": state.projectID,
+		"provider":   state.provider,
+	})
+}
+
+// checkSessionContext verifies that the project is accessible and the provider
+// exists in it, so mistakes surface when the context is set rather than on
+// every later call.
+func (t *Tools) checkSessionContext(ctx context.Context, projectID, provider string) *mcp.CallToolResult {
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult
+	}
+
+	if projectID != "" {
+		_, err := client.Projects().ListChildProjects(ctx, &minderv1.ListChildProjectsRequest{
+			Context: &minderv1.ContextV2{ProjectId: projectID},
+		})
+		if err != nil {
+			return errorResult(fmt.Sprintf("project %s: %s", projectID, MapGRPCError(err)), NewErrorDetail(err))
+		}
+	}
+	if provider != "" {
+		providerCtx := &minderv1.Context{}
+		if projectID != "" {
+			providerCtx.Project = &projectID
+		}
+		_, err := client.Providers().GetProvider(ctx, &minderv1.GetProviderRequest{Context: providerCtx, Name: provider})
+		if err != nil {
+			return errorResult(fmt.Sprintf("provider %s: %s", provider, MapGRPCError(err)), NewErrorDetail(err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callTool calls a tool through the handler registered on s, as the MCP server would.
+func callTool(ctx context.Context, t *testing.T, s *server.MCPServer, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	tool := s.GetTool(name)
+	require.NotNil(t, tool, "tool %s not registered", name)
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := tool.Handler(ctx, req)
+	require.NoError(t, err)
+	return result
+}
+
+func TestSetContext(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.listResp = &minderv1.ListRepositoriesResponse{}
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{Repository: &minderv1.Repository{Name: "api"}}
+	tools := newTestTools(mockClient)
+	s := server.NewMCPServer("test", "0.0.0")
+	tools.Register(s)
+	ctx := contextWithSession("session-1")
+
+	result := callTool(ctx, t, s, "minder_set_context", map[string]any{"project_id": "pinned-project", "provider": "github-app"})
+	require.False(t, result.IsError, getResultText(t, result))
+	assert.Contains(t, getResultText(t, result), `"project_id": "pinned-project"`)
+
+	// Omitted arguments take the pinned defaults
+	result = callTool(ctx, t, s, "minder_list_repositories", nil)
+	require.False(t, result.IsError, getResultText(t, result))
+	assert.Equal(t, "pinned-project", mockClient.repositories.listReq.GetContext().GetProject())
+	assert.Equal(t, "github-app", mockClient.repositories.listReq.GetContext().GetProvider())
+
+	// Explicit arguments win
+	callTool(ctx, t, s, "minder_list_repositories", map[string]any{"project_id": "other-project"})
+	assert.Equal(t, "other-project", mockClient.repositories.listReq.GetContext().GetProject())
+
+	// ID lookups reject project_id, so defaults are not applied
+	result = callTool(ctx, t, s, "minder_get_repository", map[string]any{"repository_id": "repo-1"})
+	assert.False(t, result.IsError, getResultText(t, result))
+
+	// Other sessions are unaffected
+	callTool(contextWithSession("session-2"), t, s, "minder_list_repositories", map[string]any{"project_id": "p2"})
+	assert.Equal(t, "p2", mockClient.repositories.listReq.GetContext().GetProject())
+
+	result = callTool(ctx, t, s, "minder_set_context", map[string]any{"clear": true})
+	require.False(t, result.IsError)
+	assert.Contains(t, getResultText(t, result), `"project_id": ""`)
+}
+
+func TestSetContext_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		setup   func(*mockMinderClient)
+		args    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "no session",
+			ctx:     context.Background(),
+			args:    map[string]any{"project_id": "p1"},
+			wantMsg: "requires an MCP session",
+		},
+		{
+			name: "inaccessible project",
+			ctx:  contextWithSession("session-1"),
+			setup: func(m *mockMinderClient) {
+				m.projects.listChildErr = status.Error(codes.NotFound, "project not found")
+			},
+			args:    map[string]any{"project_id": "missing"},
+			wantMsg: "project missing",
+		},
+		{
+			name: "unknown provider",
+			ctx:  contextWithSession("session-1"),
+			setup: func(m *mockMinderClient) {
+				m.providers.getErr = status.Error(codes.NotFound, "provider not found")
+			},
+			args:    map[string]any{"provider": "gitlab"},
+			wantMsg: "provider gitlab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := newMockClient()
+			if tt.setup != nil {
+				tt.setup(mockClient)
+			}
+			tools := newTestTools(mockClient)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+			result, err := tools.setContext(tt.ctx, req)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.True(t, strings.Contains(getResultText(t, result), tt.wantMsg), getResultText(t, result))
+			if id := sessionID(tt.ctx); id != "" {
+				assert.Empty(t, tools.sessions.get(id).projectID, "context must not be set after a failed check")
+			}
+		})
+	}
+}
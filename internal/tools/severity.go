@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/alert"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// minSeverityParam reads the min_severity argument. It returns an error
+// message for a severity Minder does not assign.
+func minSeverityParam(req mcp.CallToolRequest) (alert.Severity, string) {
+	threshold := alert.Severity(strings.ToLower(req.GetString("min_severity", "")))
+	if threshold != "" && !threshold.Known() {
+		return "", fmt.Sprintf("min_severity must be info, low, medium, high or critical, got %q", threshold)
+	}
+	return threshold, ""
+}
+
+// meetsSeverity reports whether a rule of the named severity is kept by the
+// min_severity threshold. Every rule meets an empty threshold; rules whose
+// type declares no severity rank as medium, as in alert routing.
+func meetsSeverity(severity string, threshold alert.Severity) bool {
+	return threshold == "" || alert.Severity(severity).AtLeast(threshold)
+}
+
+// filterRuleStatuses returns the rule evaluations meeting threshold.
+func filterRuleStatuses(rules []*minderv1.RuleEvaluationStatus, threshold alert.Severity) []*minderv1.RuleEvaluationStatus {
+	if threshold == "" {
+		return rules
+	}
+	kept := make([]*minderv1.RuleEvaluationStatus, 0, len(rules))
+	for _, rule := range rules {
+		if meetsSeverity(severityName(rule.GetSeverity()), threshold) {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
+
+// filterSnapshotSeverity drops the rule evaluations of s below threshold.
+func filterSnapshotSeverity(s watcher.Snapshot, threshold alert.Severity) watcher.Snapshot {
+	if threshold == "" {
+		return s
+	}
+	for key := range s.Rules {
+		if !meetsSeverity(s.Severities[key], threshold) {
+			delete(s.Rules, key)
+			delete(s.Severities, key)
+		}
+	}
+	return s
+}
+
+// bySeverity orders severity names most severe first, for slices.SortFunc.
+func bySeverity(a, b string) int {
+	switch sa, sb := alert.Severity(a), alert.Severity(b); {
+	case !sb.AtLeast(sa):
+		return -1
+	case !sa.AtLeast(sb):
+		return 1
+	}
+	return 0
+}
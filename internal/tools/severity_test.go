@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/alert"
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+func TestMinSeverityParam(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    alert.Severity
+		wantErr bool
+	}{
+		{name: "unset", args: map[string]any{}},
+		{name: "high", args: map[string]any{"min_severity": "high"}, want: alert.SeverityHigh},
+		{name: "upper case", args: map[string]any{"min_severity": "CRITICAL"}, want: alert.SeverityCritical},
+		{name: "unknown", args: map[string]any{"min_severity": "urgent"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, errMsg := minSeverityParam(mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tt.args}})
+			if (errMsg != "") != tt.wantErr || got != tt.want {
+				t.Errorf("minSeverityParam() = %q, %q, want %q with error %v", got, errMsg, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeetsSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		severity  string
+		threshold alert.Severity
+		want      bool
+	}{
+		{severity: "low", threshold: "", want: true},
+		{severity: "critical", threshold: alert.SeverityHigh, want: true},
+		{severity: "high", threshold: alert.SeverityHigh, want: true},
+		{severity: "medium", threshold: alert.SeverityHigh, want: false},
+		{severity: "", threshold: alert.SeverityHigh, want: false},
+		{severity: "", threshold: alert.SeverityLow, want: true},
+	}
+	for _, tt := range tests {
+		if got := meetsSeverity(tt.severity, tt.threshold); got != tt.want {
+			t.Errorf("meetsSeverity(%q, %q) = %v, want %v", tt.severity, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestFilterSnapshotSeverity(t *testing.T) {
+	t.Parallel()
+
+	s := watcher.NewSnapshot()
+	s.Rules["p/baseline/secrets/e1"] = "failure"
+	s.Rules["p/baseline/branch/e1"] = "failure"
+	s.Rules["p/baseline/license/e1"] = "failure"
+	s.Severities["p/baseline/secrets/e1"] = "critical"
+	s.Severities["p/baseline/branch/e1"] = "low"
+
+	s = filterSnapshotSeverity(s, alert.SeverityHigh)
+	if len(s.Rules) != 1 || s.Rules["p/baseline/secrets/e1"] == "" {
+		t.Errorf("rules = %v, want only the critical one", s.Rules)
+	}
+}
+
+func TestBySeverity(t *testing.T) {
+	t.Parallel()
+
+	got := []string{"low", "", "critical", "info", "high"}
+	slices.SortStableFunc(got, bySeverity)
+	if want := []string{"critical", "high", "", "low", "info"}; !slices.Equal(got, want) {
+		t.Errorf("sorted = %q, want %q", got, want)
+	}
+}
+
+func TestGetAllProfileStatuses_MinSeverity(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{Profiles: []*minderv1.Profile{{Name: "baseline"}}}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileStatus: "failure"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{RuleTypeName: "license", Status: "failure", Severity: &minderv1.Severity{Value: minderv1.Severity_VALUE_LOW}},
+			{RuleTypeName: "secrets", Status: "failure", Severity: &minderv1.Severity{Value: minderv1.Severity_VALUE_CRITICAL}},
+			{RuleTypeName: "branch", Status: "success", Severity: &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH}},
+		},
+	}
+
+	result, err := newTestTools(mockClient).getAllProfileStatuses(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1", "min_severity": "high"}},
+	})
+	if err != nil {
+		t.Fatalf("getAllProfileStatuses() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+	var got allProfileStatusesResult
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(got.Profiles) != 1 {
+		t.Fatalf("got %d profiles, want 1", len(got.Profiles))
+	}
+	p := got.Profiles[0]
+	if p.Rules["failure"] != 1 || p.Rules["success"] != 1 || len(p.Failing) != 1 || p.Failing[0].RuleType != "secrets" {
+		t.Errorf("profile = %+v, want only the critical failure and the high success counted", p)
+	}
+}
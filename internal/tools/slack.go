@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+// slackMaxEntities caps the repositories listed in a Slack summary, keeping
+// the section text well under Slack's 3000 character limit.
+const slackMaxEntities = 10
+
+// slackComplianceSummary returns a Slack Block Kit message summarizing the
+// current compliance of a project, or of every accessible project. A project
+// or profile that cannot be read fails the call rather than being left out of
+// the score.
+func (t *Tools) slackComplianceSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID := req.GetString("project_id", "")
+	threshold, errMsg := minSeverityParam(req)
+	if errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	var projectIDs []string
+	if projectID != "" {
+		projectIDs = append(projectIDs, projectID)
+	}
+	snapshot, err := collectSnapshot(ctx, client, "", projectIDs...)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	scope := "all accessible projects"
+	if projectID != "" {
+		scope = "project " + projectID
+	}
+	if threshold != "" {
+		snapshot = filterSnapshotSeverity(snapshot, threshold)
+		scope += ", " + string(threshold) + " severity and above"
+	}
+	return marshalResult(ctx, slackMessage(history.Summarize(time.Now(), snapshot), scope))
+}
+
+// slackMessage renders a compliance summary as a Slack message payload with
+// Block Kit blocks and a plain-text fallback.
+func slackMessage(sum history.Summary, scope string) map[string]any {
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": "Minder compliance summary"},
+		},
+		{
+			"type": "section",
+			"fields": []map[string]any{
+				slackMrkdwn(fmt.Sprintf("*Score*\n%.1f%%", sum.Score)),
+				slackMrkdwn(fmt.Sprintf("*Failing profiles*\n%d of %d", sum.FailingProfiles, sum.Profiles)),
+				slackMrkdwn(fmt.Sprintf("*Failing rules*\n%d of %d", sum.FailingRules, sum.Rules)),
+				slackMrkdwn(fmt.Sprintf("*Failing repositories*\n%d", len(sum.FailingByEntity))),
+			},
+		},
+	}
+
+	if len(sum.FailingByEntity) > 0 {
+		blocks = append(blocks,
+			map[string]any{"type": "divider"},
+			map[string]any{"type": "section", "text": slackMrkdwn(slackFailingList(sum.FailingByEntity))},
+		)
+	}
+
+	blocks = append(blocks, map[string]any{
+		"type": "context",
+		"elements": []map[string]any{
+			slackMrkdwn(slackEscape(scope) + " · " + sum.Time.Format(time.RFC3339)),
+		},
+	})
+
+	return map[string]any{
+		"text": fmt.Sprintf("Minder compliance: %.1f%% (%d of %d rules failing)",
+			sum.Score, sum.FailingRules, sum.Rules),
+		"blocks": blocks,
+	}
+}
+
+// slackFailingList lists the entities with the most failing rules first.
+func slackFailingList(failing map[string][]string) string {
+	entities := slices.Sorted(maps.Keys(failing))
+	slices.SortStableFunc(entities, func(a, b string) int {
+		return len(failing[b]) - len(failing[a])
+	})
+
+	var b strings.Builder
+	b.WriteString("*Failing repositories*")
+	for _, entity := range entities[:min(len(entities), slackMaxEntities)] {
+		fmt.Fprintf(&b, "\n• `%s`: %s", slackEscape(entity), slackEscape(strings.Join(failing[entity], ", ")))
+	}
+	if extra := len(entities) - slackMaxEntities; extra > 0 {
+		fmt.Fprintf(&b, "\n…and %d more", extra)
+	}
+	return b.String()
+}
+
+// slackMrkdwn returns a Block Kit mrkdwn text object.
+func slackMrkdwn(text string) map[string]any {
+	return map[string]any{"type": "mrkdwn", "text": text}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/internal/history"
+)
+
+func TestSlackComplianceSummary(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+		Profiles: []*minderv1.Profile{{Name: "baseline"}},
+	}
+	mockClient.profiles.getStatusByNameResp = &minderv1.GetProfileStatusByNameResponse{
+		ProfileStatus: &minderv1.ProfileStatus{ProfileName: "baseline", ProfileStatus: "failure"},
+		RuleEvaluationStatus: []*minderv1.RuleEvaluationStatus{
+			{
+				RuleDescriptionName: "branch_protection",
+				Status:              "failure",
+				EntityInfo:          map[string]string{"entity_id": "repo-1", "repo_owner": "stacklok", "repo_name": "minder"},
+			},
+			{
+				RuleDescriptionName: "dependabot",
+				Status:              "success",
+				EntityInfo:          map[string]string{"entity_id": "repo-1", "repo_owner": "stacklok", "repo_name": "minder"},
+			},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.slackComplianceSummary(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+	})
+	if err != nil {
+		t.Fatalf("slackComplianceSummary() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var msg struct {
+		Text   string           `json:"text"`
+		Blocks []map[string]any `json:"blocks"`
+	}
+	text := getResultText(t, result)
+	if err := json.Unmarshal([]byte(text), &msg); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if msg.Text != "Minder compliance: 50.0% (1 of 2 rules failing)" {
+		t.Errorf("fallback text = %q", msg.Text)
+	}
+	if len(msg.Blocks) != 5 {
+		t.Errorf("got %d blocks, want header, fields, divider, failing list and context", len(msg.Blocks))
+	}
+	if !strings.Contains(text, "• `stacklok/minder`: baseline/branch_protection") {
+		t.Errorf("message does not list the failing repository: %s", text)
+	}
+	if !strings.Contains(text, "project proj-1") {
+		t.Errorf("message does not name the project: %s", text)
+	}
+}
+
+func TestSlackComplianceSummary_UnreadableProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		listErr   error
+		statusErr error
+	}{
+		{name: "project cannot be read", listErr: status.Error(codes.PermissionDenied, "not authorized")},
+		{name: "profile status cannot be read", statusErr: status.Error(codes.Unavailable, "down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.profiles.listResp = &minderv1.ListProfilesResponse{
+				Profiles: []*minderv1.Profile{{Name: "baseline"}},
+			}
+			mockClient.profiles.listErr = tt.listErr
+			mockClient.profiles.getStatusByNameErr = tt.statusErr
+
+			result, err := newTestTools(mockClient).slackComplianceSummary(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"project_id": "proj-1"}},
+			})
+			if err != nil {
+				t.Fatalf("slackComplianceSummary() returned Go error: %v", err)
+			}
+			// An unreadable project has no failing rules, which would score 100%
+			if !result.IsError {
+				t.Errorf("expected error result, got %s", getResultText(t, result))
+			}
+		})
+	}
+}
+
+func TestSlackMessage_AllPassing(t *testing.T) {
+	t.Parallel()
+
+	msg := slackMessage(history.Summary{Time: time.Now(), Score: 100, Rules: 3}, "all accessible projects")
+	blocks, _ := msg["blocks"].([]map[string]any)
+	if len(blocks) != 3 {
+		t.Errorf("got %d blocks, want header, fields and context without a failing list", len(blocks))
+	}
+}
+
+func TestSlackFailingList(t *testing.T) {
+	t.Parallel()
+
+	failing := map[string][]string{"a<b>": {"p/one"}, "busy": {"p/one", "p/two"}}
+	for i := range slackMaxEntities {
+		failing[fmt.Sprintf("repo-%02d", i)] = []string{"p/one"}
+	}
+
+	got := slackFailingList(failing)
+	lines := strings.Split(got, "\n")
+	if lines[1] != "• `busy`: p/one, p/two" {
+		t.Errorf("first entry = %q, want the entity with the most failures", lines[1])
+	}
+	if !strings.Contains(got, "`a&lt;b&gt;`") {
+		t.Errorf("entity names are not escaped: %s", got)
+	}
+	if lines[len(lines)-1] != "…and 2 more" {
+		t.Errorf("last line = %q, want the overflow count", lines[len(lines)-1])
+	}
+}
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// maxTimelinePages bounds the evaluation history pages read for a timeline.
+const maxTimelinePages = 5
+
+// timelineEvent is an evaluation that changed the status, remediation or
+// alert state of a rule on an entity, or the first evaluation seen.
+type timelineEvent struct {
+	EvaluatedAt       string   `json:"evaluated_at"`
+	EvaluationID      string   `json:"evaluation_id"`
+	Profile           string   `json:"profile"`
+	Changes           []string `json:"changes"`
+	Status            string   `json:"status"`
+	PreviousStatus    string   `json:"previous_status,omitempty"`
+	Details           string   `json:"details,omitempty"`
+	RemediationStatus string   `json:"remediation_status,omitempty"`
+	RemediationDetail string   `json:"remediation_details,omitempty"`
+	AlertStatus       string   `json:"alert_status,omitempty"`
+	AlertDetails      string   `json:"alert_details,omitempty"`
+}
+
+// timelineProfile is the current state of the rule under one profile.
+type timelineProfile struct {
+	Profile       string `json:"profile"`
+	Status        string `json:"status"`
+	LastEvaluated string `json:"last_evaluated"`
+	// FailingSince is when the current run of failing evaluations started.
+	FailingSince string `json:"failing_since,omitempty"`
+}
+
+// buildTimeline orders evaluations of one rule on one entity by time and keeps
+// those that changed the status, remediation or alert state, per profile.
+func buildTimeline(rows []*minderv1.EvaluationHistory) ([]timelineEvent, []timelineProfile) {
+	rows = slices.Clone(rows)
+	slices.SortStableFunc(rows, func(a, b *minderv1.EvaluationHistory) int {
+		return a.GetEvaluatedAt().AsTime().Compare(b.GetEvaluatedAt().AsTime())
+	})
+
+	events := []timelineEvent{}
+	last := map[string]*minderv1.EvaluationHistory{}
+	current := map[string]*timelineProfile{}
+	var profiles []string
+	for _, row := range rows {
+		profile := row.GetRule().GetProfile()
+		evaluatedAt := row.GetEvaluatedAt().AsTime().Format(time.RFC3339)
+		status := row.GetStatus().GetStatus()
+
+		state, ok := current[profile]
+		if !ok {
+			state = &timelineProfile{Profile: profile}
+			current[profile] = state
+			profiles = append(profiles, profile)
+		}
+		if !watcher.IsFailing(status) {
+			state.FailingSince = ""
+		} else if state.FailingSince == "" {
+			state.FailingSince = evaluatedAt
+		}
+		state.Status, state.LastEvaluated = status, evaluatedAt
+
+		prev := last[profile]
+		last[profile] = row
+		changes := timelineChanges(prev, row)
+		if len(changes) == 0 {
+			continue
+		}
+		event := timelineEvent{
+			EvaluatedAt:       evaluatedAt,
+			EvaluationID:      row.GetId(),
+			Profile:           profile,
+			Changes:           changes,
+			Status:            status,
+			Details:           row.GetStatus().GetDetails(),
+			RemediationStatus: row.GetRemediation().GetStatus(),
+			RemediationDetail: row.GetRemediation().GetDetails(),
+			AlertStatus:       row.GetAlert().GetStatus(),
+			AlertDetails:      row.GetAlert().GetDetails(),
+		}
+		if prev != nil {
+			event.PreviousStatus = prev.GetStatus().GetStatus()
+		}
+		events = append(events, event)
+	}
+
+	states := make([]timelineProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		states = append(states, *current[profile])
+	}
+	slices.SortFunc(states, func(a, b timelineProfile) int { return cmp.Compare(a.Profile, b.Profile) })
+	return events, states
+}
+
+// timelineChanges names what row changed compared to prev, the previous
+// evaluation under the same profile: "first_seen", "status", "remediation" or
+// "alert".
+func timelineChanges(prev, row *minderv1.EvaluationHistory) []string {
+	if prev == nil {
+		return []string{"first_seen"}
+	}
+	var changes []string
+	if prev.GetStatus().GetStatus() != row.GetStatus().GetStatus() {
+		changes = append(changes, "status")
+	}
+	if prev.GetRemediation().GetStatus() != row.GetRemediation().GetStatus() {
+		changes = append(changes, "remediation")
+	}
+	if prev.GetAlert().GetStatus() != row.GetAlert().GetStatus() {
+		changes = append(changes, "alert")
+	}
+	return changes
+}
+
+// getEvaluationTimeline returns the chronological history of one rule on one
+// entity, reduced to the evaluations where something changed, to answer when
+// the entity started failing the rule and what happened since.
+func (t *Tools) getEvaluationTimeline(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	entityName := req.GetString("entity_name", "")
+	entityType := req.GetString("entity_type", "repository")
+	rule := req.GetString("rule", "")
+	profileName := req.GetString("profile_name", "")
+	projectID := req.GetString("project_id", "")
+
+	if entityName == "" || rule == "" {
+		return mcp.NewToolResultError("entity_name and rule must be provided"), nil
+	}
+	from, err := parseTimeParam(req, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	truncated := false
+	rows, err := forEachProject(ctx, client, projectID,
+		func(ctx context.Context, projID string) ([]*minderv1.EvaluationHistory, error) {
+			reqProto := &minderv1.ListEvaluationHistoryRequest{
+				Context:     &minderv1.Context{Project: &projID},
+				EntityType:  []string{entityType},
+				EntityName:  []string{entityName},
+				LabelFilter: []string{"*"},
+				Cursor:      &minderv1.Cursor{Size: maxPageSize},
+			}
+			if profileName != "" {
+				reqProto.ProfileName = []string{profileName}
+			}
+			if !from.IsZero() {
+				reqProto.From = timestamppb.New(from)
+			}
+			var rows []*minderv1.EvaluationHistory
+			for page := 0; ; page++ {
+				resp, err := client.EvalResults().ListEvaluationHistory(ctx, reqProto)
+				if err != nil {
+					return nil, err
+				}
+				for _, row := range resp.Data {
+					if row.GetRule().GetName() == rule {
+						rows = append(rows, row)
+					}
+				}
+				next := resp.GetPage().GetNext().GetCursor()
+				if next == "" || len(resp.Data) == 0 {
+					return rows, nil
+				}
+				if page+1 == maxTimelinePages {
+					truncated = true
+					return rows, nil
+				}
+				reqProto.Cursor = &minderv1.Cursor{Cursor: next, Size: maxPageSize}
+			}
+		})
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	events, profiles := buildTimeline(rows)
+	result := map[string]any{
+		"entity":      entityName,
+		"entity_type": entityType,
+		"rule":        rule,
+		"evaluations": len(rows),
+		"current":     profiles,
+		"events":      events,
+	}
+	switch {
+	case len(rows) == 0:
+		result["note"] = "no evaluations of this rule on this entity were found; check the entity name " +
+			"(owner/name for repositories) and the rule name with minder_get_entity_status"
+	case truncated:
+		result["note"] = "only the most recent evaluations were read; set from to look further back " +
+			"than the first event"
+	}
+	return marshalResult(ctx, result)
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timelineRow returns an evaluation of rule under profile, day days after 2026-03-02.
+func timelineRow(id, profile, rule, status, remediation string, day int) *minderv1.EvaluationHistory {
+	return &minderv1.EvaluationHistory{
+		Id:          id,
+		Rule:        &minderv1.EvaluationHistoryRule{Name: rule, Profile: profile},
+		Status:      &minderv1.EvaluationHistoryStatus{Status: status},
+		Remediation: &minderv1.EvaluationHistoryRemediation{Status: remediation},
+		EvaluatedAt: timestamppb.New(time.Date(2026, 3, 2+day, 12, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	t.Parallel()
+
+	// Newest first, as Minder returns history
+	rows := []*minderv1.EvaluationHistory{
+		timelineRow("e5", "baseline", "bp", "failure", "pending", 4),
+		timelineRow("e4", "baseline", "bp", "failure", "skipped", 3),
+		timelineRow("e3", "baseline", "bp", "failure", "skipped", 2),
+		timelineRow("e2", "baseline", "bp", "success", "skipped", 1),
+		timelineRow("e1", "baseline", "bp", "success", "skipped", 0),
+		timelineRow("s1", "strict", "bp", "success", "", 1),
+	}
+
+	events, profiles := buildTimeline(rows)
+
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.EvaluationID)
+	}
+	if want := []string{"e1", "s1", "e3", "e5"}; !slices.Equal(ids, want) {
+		t.Errorf("events = %v, want %v", ids, want)
+	}
+	if got := events[2]; !slices.Equal(got.Changes, []string{"status"}) || got.PreviousStatus != "success" {
+		t.Errorf("event e3 = %+v, want a status change from success", got)
+	}
+	if got := events[3]; !slices.Equal(got.Changes, []string{"remediation"}) {
+		t.Errorf("event e5 changes = %v, want [remediation]", got.Changes)
+	}
+
+	want := []timelineProfile{
+		{Profile: "baseline", Status: "failure", LastEvaluated: "2026-03-06T12:00:00Z", FailingSince: "2026-03-04T12:00:00Z"},
+		{Profile: "strict", Status: "success", LastEvaluated: "2026-03-03T12:00:00Z"},
+	}
+	if !slices.Equal(profiles, want) {
+		t.Errorf("profiles = %+v, want %+v", profiles, want)
+	}
+}
+
+func TestGetEvaluationTimeline(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{
+		Data: []*minderv1.EvaluationHistory{
+			timelineRow("e2", "baseline", "bp", "failure", "", 1),
+			timelineRow("x1", "baseline", "license", "failure", "", 1),
+			timelineRow("e1", "baseline", "bp", "success", "", 0),
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getEvaluationTimeline(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"entity_name": "acme/api", "rule": "bp", "project_id": "proj-1",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("getEvaluationTimeline() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got struct {
+		Evaluations int             `json:"evaluations"`
+		Events      []timelineEvent `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Evaluations != 2 || len(got.Events) != 2 {
+		t.Errorf("evaluations, events = %d, %d, want 2, 2 without other rules", got.Evaluations, len(got.Events))
+	}
+	req := mockClient.evalResults.listReq
+	if !slices.Equal(req.EntityName, []string{"acme/api"}) || !slices.Equal(req.EntityType, []string{"repository"}) {
+		t.Errorf("request entity filter = %v %v, want repository acme/api", req.EntityType, req.EntityName)
+	}
+}
+
+func TestGetEvaluationTimeline_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{name: "missing rule", args: map[string]any{"entity_name": "acme/api"}},
+		{name: "missing entity", args: map[string]any{"rule": "bp"}},
+		{name: "invalid from", args: map[string]any{"entity_name": "acme/api", "rule": "bp", "from": "yesterday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tools := newTestTools(newMockClient())
+			result, err := tools.getEvaluationTimeline(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("getEvaluationTimeline() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Errorf("expected error result, got %s", getResultText(t, result))
+			}
+		})
+	}
+}
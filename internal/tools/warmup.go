@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+)
+
+// warmupTimeout bounds the warmup of each Minder server.
+const warmupTimeout = 30 * time.Second
+
+// Warmup prepares every configured Minder server that has a token for the
+// first tool call: it validates or refreshes the token, which discovers the
+// identity provider realm, opens the pooled connection and lists the
+// accessible projects. Failures are logged; tool calls retry each step.
+func (t *Tools) Warmup(ctx context.Context) {
+	ctx = middleware.ContextWithToken(ctx, t.cfg.Minder.AuthToken)
+	for _, name := range t.cfg.Minder.ServerNames() {
+		srv, _ := t.cfg.Minder.Server(name)
+		if t.tokenFor(ctx, srv) == "" {
+			t.logger.InfoContext(ctx, "minder server warmup skipped: no configured token", "server", name)
+			continue
+		}
+		start := time.Now()
+		projects, err := t.warmServer(ctx, srv)
+		if err != nil {
+			t.logger.WarnContext(ctx, "minder server warmup failed", "server", name, "error", err)
+			continue
+		}
+		t.logger.InfoContext(ctx, "minder server warmed up",
+			"server", name, "projects", projects, "duration", time.Since(start))
+	}
+}
+
+// warmServer runs the warmup steps against srv and returns the number of
+// projects the token can access.
+func (t *Tools) warmServer(ctx context.Context, srv config.NamedServer) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, warmupTimeout)
+	defer cancel()
+
+	client, err := t.clientFor(ctx, srv)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = client.Close() }()
+
+	resp, err := client.Projects().ListProjects(ctx, &minderv1.ListProjectsRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return len(resp.Projects), nil
+}
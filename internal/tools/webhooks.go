@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// Repository webhook states.
+const (
+	webhookRegistered = "registered"
+	webhookIncomplete = "incomplete"
+	webhookMissing    = "missing"
+)
+
+// webhookDeliveryNote explains why webhook health is inferred rather than observed.
+const webhookDeliveryNote = "Minder does not expose webhook deliveries. last_evaluated is the latest rule " +
+	"evaluation of the repository, which follows each event Minder receives; if it is stale while the " +
+	"repository changes, check the webhook's recent deliveries in the provider (on GitHub: Settings > Webhooks)."
+
+// webhookHealth describes the webhook Minder registered for a repository.
+type webhookHealth struct {
+	Repository    string   `json:"repository"`
+	RepositoryID  string   `json:"repository_id"`
+	ProjectID     string   `json:"project_id,omitempty"`
+	Provider      string   `json:"provider,omitempty"`
+	Status        string   `json:"status"`
+	HookID        int64    `json:"hook_id,omitempty"`
+	HookName      string   `json:"hook_name,omitempty"`
+	HookType      string   `json:"hook_type,omitempty"`
+	HookURL       string   `json:"hook_url,omitempty"`
+	HookUUID      string   `json:"hook_uuid,omitempty"`
+	Issues        []string `json:"issues,omitempty"`
+	UpdatedAt     string   `json:"updated_at,omitempty"`
+	LastEvaluated string   `json:"last_evaluated,omitempty"`
+	Note          string   `json:"note"`
+}
+
+// repositoryWebhookHealth checks the webhook fields Minder records for a repository.
+func repositoryWebhookHealth(repo *minderv1.Repository) webhookHealth {
+	health := webhookHealth{
+		Repository:   repo.GetOwner() + "/" + repo.GetName(),
+		RepositoryID: repo.GetId(),
+		ProjectID:    repo.GetContext().GetProject(),
+		Provider:     repo.GetContext().GetProvider(),
+		HookID:       repo.GetHookId(),
+		HookName:     repo.GetHookName(),
+		HookType:     repo.GetHookType(),
+		HookURL:      repo.GetHookUrl(),
+		HookUUID:     repo.GetHookUuid(),
+		Note:         webhookDeliveryNote,
+	}
+	if repo.GetUpdatedAt() != nil {
+		health.UpdatedAt = repo.GetUpdatedAt().AsTime().Format(time.RFC3339)
+	}
+
+	if health.HookID == 0 {
+		health.Status = webhookMissing
+		health.Issues = append(health.Issues, "no webhook ID is recorded; Minder will not receive events")
+		return health
+	}
+	if health.HookURL == "" {
+		health.Issues = append(health.Issues, "no webhook URL is recorded")
+	}
+	if health.HookUUID == "" {
+		health.Issues = append(health.Issues, "no webhook UUID is recorded; deliveries cannot be matched to the repository")
+	}
+	health.Status = webhookRegistered
+	if len(health.Issues) > 0 {
+		health.Status = webhookIncomplete
+	}
+	return health
+}
+
+// lastRepositoryEvaluation returns when a repository was last evaluated, or
+// the zero time when it has no evaluations.
+func lastRepositoryEvaluation(ctx context.Context, client MinderClient, repo *minderv1.Repository) (time.Time, error) {
+	projectID := repo.GetContext().GetProject()
+	resp, err := client.EvalResults().ListEvaluationHistory(ctx, &minderv1.ListEvaluationHistoryRequest{
+		Context:     &minderv1.Context{Project: &projectID},
+		EntityType:  []string{"repository"},
+		EntityName:  []string{repo.GetOwner() + "/" + repo.GetName()},
+		LabelFilter: []string{"*"},
+		Cursor:      &minderv1.Cursor{Size: 1},
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	var last time.Time
+	for _, row := range resp.GetData() {
+		if at := row.GetEvaluatedAt().AsTime(); row.EvaluatedAt != nil && at.After(last) {
+			last = at
+		}
+	}
+	return last, nil
+}
+
+// webhookHealthResult builds the webhook health of a repository, including
+// when it was last evaluated. A failed evaluation lookup is not fatal.
+func (t *Tools) webhookHealthResult(ctx context.Context, client MinderClient, repo *minderv1.Repository) webhookHealth {
+	health := repositoryWebhookHealth(repo)
+	last, err := lastRepositoryEvaluation(ctx, client, repo)
+	if err != nil {
+		t.logger.DebugContext(ctx, "evaluation history lookup failed", "repository", health.Repository, "error", err)
+	} else if !last.IsZero() {
+		health.LastEvaluated = last.Format(time.RFC3339)
+	}
+	return health
+}
+
+// getRepositoryWebhook reports the state of the webhook Minder registered for
+// a repository, to debug Minder no longer reacting to repository events.
+func (t *Tools) getRepositoryWebhook(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	repoID := req.GetString("repository_id", "")
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	provider := req.GetString("provider", "")
+
+	if errMsg := ValidateRepositoryLookupParams(repoID, owner, name, map[string]string{
+		"project_id": projectID,
+		"provider":   provider,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	repo, err := lookupRepository(ctx, client, repoID, owner, name, projectID, provider)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	return marshalResult(ctx, t.webhookHealthResult(ctx, client, repo))
+}
+
+// reregisterRepository deletes a repository from Minder and registers it
+// again, which makes Minder create a fresh webhook.
+func (t *Tools) reregisterRepository(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	repoID := req.GetString("repository_id", "")
+	owner := req.GetString("owner", "")
+	name := req.GetString("name", "")
+	projectID := req.GetString("project_id", "")
+	provider := req.GetString("provider", "")
+
+	if errMsg := ValidateRepositoryLookupParams(repoID, owner, name, map[string]string{
+		"project_id": projectID,
+		"provider":   provider,
+	}); errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+	if !req.GetBool("confirm", false) {
+		return mcp.NewToolResultError("re-registering deletes the repository's evaluation history in Minder; " +
+			"set confirm to true to proceed"), nil
+	}
+
+	client, err := t.getClient(ctx)
+	if err != nil {
+		return clientErrorResult(err), nil
+	}
+	defer func() { _ = client.Close() }()
+
+	if errResult := checkHealth(ctx, client); errResult != nil {
+		return errResult, nil
+	}
+
+	repo, err := lookupRepository(ctx, client, repoID, owner, name, projectID, provider)
+	if err != nil {
+		return grpcErrorResult(err), nil
+	}
+
+	repoCtx := &minderv1.Context{
+		Project:  repo.GetContext().Project,
+		Provider: repo.GetContext().Provider,
+	}
+	if _, err := client.Repositories().DeleteRepositoryById(ctx, &minderv1.DeleteRepositoryByIdRequest{
+		RepositoryId: repo.GetId(),
+		Context:      repoCtx,
+	}); err != nil {
+		return grpcErrorResult(err), nil
+	}
+	t.logger.InfoContext(ctx, "deleted repository for re-registration",
+		"repository", repo.GetOwner()+"/"+repo.GetName(), "repository_id", repo.GetId())
+
+	resp, err := client.Repositories().RegisterRepository(ctx, &minderv1.RegisterRepositoryRequest{
+		Context:    repoCtx,
+		Repository: &minderv1.UpstreamRepositoryRef{Owner: repo.GetOwner(), Name: repo.GetName()},
+	})
+	if err == nil && !resp.GetResult().GetStatus().GetSuccess() {
+		err = fmt.Errorf("registration failed: %s", resp.GetResult().GetStatus().GetError())
+	}
+	if err != nil {
+		return errorResult(fmt.Sprintf("repository %s/%s was deleted but could not be registered again, "+
+			"register it manually: %s", repo.GetOwner(), repo.GetName(), MapGRPCError(err)), NewErrorDetail(err)), nil
+	}
+
+	return marshalResult(ctx, repositoryWebhookHealth(resp.GetResult().GetRepository()))
+}
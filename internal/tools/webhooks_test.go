@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// webhookRepo returns a registered repository with a complete webhook.
+func webhookRepo() *minderv1.Repository {
+	return &minderv1.Repository{
+		Id:       ptr("repo-1"),
+		Context:  &minderv1.Context{Project: ptr("proj-1"), Provider: ptr("github-app")},
+		Owner:    "stacklok",
+		Name:     "minder",
+		HookId:   123,
+		HookUrl:  "https://api.github.com/repos/stacklok/minder/hooks/123",
+		HookUuid: "hook-uuid",
+	}
+}
+
+func TestRepositoryWebhookHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		modify     func(*minderv1.Repository)
+		wantStatus string
+		wantIssues int
+	}{
+		{name: "registered", modify: func(*minderv1.Repository) {}, wantStatus: webhookRegistered},
+		{name: "missing", modify: func(r *minderv1.Repository) { r.HookId = 0 }, wantStatus: webhookMissing, wantIssues: 1},
+		{
+			name:       "incomplete",
+			modify:     func(r *minderv1.Repository) { r.HookUrl, r.HookUuid = "", "" },
+			wantStatus: webhookIncomplete,
+			wantIssues: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo := webhookRepo()
+			tt.modify(repo)
+			got := repositoryWebhookHealth(repo)
+			if got.Status != tt.wantStatus || len(got.Issues) != tt.wantIssues {
+				t.Errorf("status = %q with issues %v, want %q with %d issues", got.Status, got.Issues, tt.wantStatus, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestGetRepositoryWebhook(t *testing.T) {
+	t.Parallel()
+
+	evaluatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockClient := newMockClient()
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{Repository: webhookRepo()}
+	mockClient.evalResults.listResp = &minderv1.ListEvaluationHistoryResponse{
+		Data: []*minderv1.EvaluationHistory{{Id: "eval-1", EvaluatedAt: timestamppb.New(evaluatedAt)}},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.getRepositoryWebhook(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"repository_id": "repo-1"}},
+	})
+	if err != nil {
+		t.Fatalf("getRepositoryWebhook() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got webhookHealth
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.Status != webhookRegistered || got.LastEvaluated != "2026-03-01T12:00:00Z" {
+		t.Errorf("got %+v, want a registered webhook last evaluated 2026-03-01T12:00:00Z", got)
+	}
+	req := mockClient.evalResults.listReq
+	if req.GetContext().GetProject() != "proj-1" || req.EntityName[0] != "stacklok/minder" {
+		t.Errorf("ListEvaluationHistory request = %v, want stacklok/minder in proj-1", req)
+	}
+}
+
+func TestReregisterRepository(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockClient()
+	mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{Repository: &minderv1.Repository{
+		Id:      ptr("repo-1"),
+		Context: &minderv1.Context{Project: ptr("proj-1"), Provider: ptr("github-app")},
+		Owner:   "stacklok",
+		Name:    "minder",
+	}}
+	newRepo := webhookRepo()
+	newRepo.Id = ptr("repo-2")
+	mockClient.repositories.registerResp = &minderv1.RegisterRepositoryResponse{
+		Result: &minderv1.RegisterRepoResult{
+			Repository: newRepo,
+			Status:     &minderv1.RegisterRepoResult_Status{Success: true},
+		},
+	}
+	tools := newTestTools(mockClient)
+
+	result, err := tools.reregisterRepository(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"repository_id": "repo-1", "confirm": true}},
+	})
+	if err != nil {
+		t.Fatalf("reregisterRepository() returned Go error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", getResultText(t, result))
+	}
+
+	var got webhookHealth
+	if err := json.Unmarshal([]byte(getResultText(t, result)), &got); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if got.RepositoryID != "repo-2" || got.Status != webhookRegistered {
+		t.Errorf("got %+v, want the re-registered repository with a webhook", got)
+	}
+	if mockClient.repositories.deleteReq.GetRepositoryId() != "repo-1" {
+		t.Errorf("deleted %v, want repo-1", mockClient.repositories.deleteReq)
+	}
+	reg := mockClient.repositories.registerReq
+	if reg.GetContext().GetProvider() != "github-app" || reg.GetRepository().GetName() != "minder" {
+		t.Errorf("register request = %v, want stacklok/minder with provider github-app", reg)
+	}
+}
+
+func TestReregisterRepository_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		registerErr error
+		wantMsg     string
+		wantDeleted bool
+	}{
+		{
+			name:    "not confirmed",
+			args:    map[string]any{"repository_id": "repo-1"},
+			wantMsg: "set confirm to true",
+		},
+		{
+			name:        "registration fails",
+			args:        map[string]any{"repository_id": "repo-1", "confirm": true},
+			registerErr: errors.New("boom"),
+			wantMsg:     "was deleted but could not be registered again",
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := newMockClient()
+			mockClient.repositories.getByIDResp = &minderv1.GetRepositoryByIdResponse{Repository: webhookRepo()}
+			mockClient.repositories.registerErr = tt.registerErr
+			tools := newTestTools(mockClient)
+
+			result, err := tools.reregisterRepository(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("reregisterRepository() returned Go error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+			if text := getResultText(t, result); !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("error %q does not contain %q", text, tt.wantMsg)
+			}
+			if deleted := mockClient.repositories.deleteReq != nil; deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
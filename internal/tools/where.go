@@ -0,0 +1,337 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// whereFields lists the fields a where expression can test and the
+// operators each accepts.
+var whereFields = map[string][]string{
+	"status":      {"=", "!="},
+	"remediation": {"=", "!="},
+	"alert":       {"=", "!="},
+	"profile":     {"=", "!=", "~", "!~"},
+	"entity":      {"=", "!=", "~", "!~"},
+	"entity_type": {"=", "!="},
+	"rule":        {"=", "!=", "~", "!~"},
+	"rule_type":   {"=", "!=", "~", "!~"},
+	"age":         {"<", "<=", ">", ">="},
+}
+
+// whereClause is one comparison in a where expression, e.g. status = failure.
+type whereClause struct {
+	field string
+	op    string
+	value string
+	age   time.Duration // parsed value of an age clause
+}
+
+// whereQuery is a parsed where expression: clauses that must all hold.
+type whereQuery []whereClause
+
+// parseWhere parses a where expression of comparisons joined by AND, e.g.
+// status = failure AND rule ~ "branch" AND age < 7d. Values are bare words
+// or double-quoted strings; ~ matches a case-insensitive substring.
+func parseWhere(expr string) (whereQuery, error) {
+	tokens, err := tokenizeWhere(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+
+	var q whereQuery
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete comparison %q, want field, operator and value", strings.Join(tokens, " "))
+		}
+		c, err := newWhereClause(strings.ToLower(tokens[0]), tokens[1], tokens[2])
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, c)
+		tokens = tokens[3:]
+		if len(tokens) == 0 {
+			break
+		}
+		if !strings.EqualFold(tokens[0], "AND") {
+			return nil, fmt.Errorf("expected AND after %s %s %s, got %q", c.field, c.op, c.value, tokens[0])
+		}
+		if len(tokens) == 1 {
+			return nil, errors.New("expression ends with AND")
+		}
+		tokens = tokens[1:]
+	}
+	return q, nil
+}
+
+func newWhereClause(field, op, value string) (whereClause, error) {
+	ops, ok := whereFields[field]
+	if !ok {
+		return whereClause{}, fmt.Errorf("unknown field %q, want one of %s", field, whereFieldNames())
+	}
+	if !slices.Contains(ops, op) {
+		return whereClause{}, fmt.Errorf("%s does not support %q, use %s", field, op, strings.Join(ops, " "))
+	}
+	c := whereClause{field: field, op: op, value: value}
+	if field == "age" {
+		age, err := parseAge(value)
+		if err != nil {
+			return whereClause{}, err
+		}
+		c.age = age
+	}
+	return c, nil
+}
+
+func whereFieldNames() string {
+	return "status, remediation, alert, profile, entity, entity_type, rule, rule_type, age"
+}
+
+// tokenizeWhere splits a where expression into words, quoted strings
+// (returned unquoted) and operators.
+func tokenizeWhere(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '"':
+			s, end, err := scanWhereString(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, s)
+			i = end
+		case strings.IndexByte("=!~<>", ch) >= 0:
+			op, err := scanWhereOperator(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, op)
+			i += len(op)
+		default:
+			end := i
+			for end < len(expr) && isWhereWordByte(expr[end]) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %q", expr[i])
+			}
+			tokens = append(tokens, expr[i:end])
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// scanWhereString unquotes the double-quoted string starting at expr[start]
+// and returns the index after its closing quote.
+func scanWhereString(expr string, start int) (string, int, error) {
+	end := start + 1
+	for end < len(expr) && expr[end] != '"' {
+		if expr[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(expr) {
+		return "", 0, fmt.Errorf("unterminated string starting at %q", expr[start:])
+	}
+	s, err := strconv.Unquote(expr[start : end+1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid string %s", expr[start:end+1])
+	}
+	return s, end + 1, nil
+}
+
+// scanWhereOperator returns the one or two character operator at expr[start].
+func scanWhereOperator(expr string, start int) (string, error) {
+	ch := expr[start]
+	if start+1 < len(expr) && (expr[start+1] == '=' || (ch == '!' && expr[start+1] == '~')) {
+		return expr[start : start+2], nil
+	}
+	if ch == '!' {
+		return "", errors.New("unexpected ! (use != or !~)")
+	}
+	return string(ch), nil
+}
+
+func isWhereWordByte(ch byte) bool {
+	r := rune(ch)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:*@", r)
+}
+
+// parseAge parses an age such as 7d, 2w, 12h or 90m. Go durations like
+// 1h30m are accepted too.
+func parseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, errors.New("age is empty, want e.g. 7d, 12h or 30m")
+	}
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q, want e.g. 7d, 12h or 30m", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q, want e.g. 7d, 12h or 30m", s)
+	}
+	return d, nil
+}
+
+// compile narrows req with the clauses Minder can filter on: equality on
+// statuses, profile, entity and entity type, and age bounds. Filters already
+// set on req are kept; match still applies every clause to the results.
+func (q whereQuery) compile(req *minderv1.ListEvaluationHistoryRequest, now time.Time) {
+	for _, c := range q {
+		if c.field == "age" {
+			bound := timestamppb.New(now.Add(-c.age))
+			switch c.op {
+			case "<", "<=":
+				if req.From == nil || req.From.AsTime().Before(bound.AsTime()) {
+					req.From = bound
+				}
+			case ">", ">=":
+				if req.To == nil || req.To.AsTime().After(bound.AsTime()) {
+					req.To = bound
+				}
+			}
+			continue
+		}
+		if c.op != "=" {
+			continue
+		}
+		filter := whereFilter(req, c.field)
+		if filter == nil {
+			continue
+		}
+		if len(*filter) == 0 {
+			*filter = []string{c.value}
+		}
+	}
+}
+
+// minderApplies reports whether Minder applies every clause of q to req, once
+// compiled into it, so the counts it returns already reflect the query.
+func (q whereQuery) minderApplies(req *minderv1.ListEvaluationHistoryRequest) bool {
+	for _, c := range q {
+		if c.field == "age" {
+			continue // compile always bounds the time range by an age
+		}
+		filter := whereFilter(req, c.field)
+		if c.op != "=" || filter == nil || !slices.Equal(*filter, []string{c.value}) {
+			return false
+		}
+	}
+	return true
+}
+
+// whereFilter returns the request filter for equality on field, or nil if
+// Minder cannot filter on it.
+func whereFilter(req *minderv1.ListEvaluationHistoryRequest, field string) *[]string {
+	switch field {
+	case "status":
+		return &req.Status
+	case "remediation":
+		return &req.Remediation
+	case "alert":
+		return &req.Alert
+	case "profile":
+		return &req.ProfileName
+	case "entity":
+		return &req.EntityName
+	case "entity_type":
+		return &req.EntityType
+	}
+	return nil
+}
+
+// match reports whether e satisfies every clause.
+func (q whereQuery) match(e *minderv1.EvaluationHistory, now time.Time) bool {
+	for _, c := range q {
+		if !c.match(e, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c whereClause) match(e *minderv1.EvaluationHistory, now time.Time) bool {
+	switch c.field {
+	case "age":
+		if e.GetEvaluatedAt() == nil {
+			return false
+		}
+		age := now.Sub(e.GetEvaluatedAt().AsTime())
+		switch c.op {
+		case "<":
+			return age < c.age
+		case "<=":
+			return age <= c.age
+		case ">":
+			return age > c.age
+		default:
+			return age >= c.age
+		}
+	case "rule":
+		// A rule matches on its instance name or its rule type, so a negated
+		// clause must hold for both
+		name, ruleType := c.compare(e.GetRule().GetName()), c.compare(e.GetRule().GetRuleType())
+		if strings.HasPrefix(c.op, "!") {
+			return name && ruleType
+		}
+		return name || ruleType
+	}
+	return c.compare(c.fieldValue(e))
+}
+
+func (c whereClause) fieldValue(e *minderv1.EvaluationHistory) string {
+	switch c.field {
+	case "status":
+		return e.GetStatus().GetStatus()
+	case "remediation":
+		return e.GetRemediation().GetStatus()
+	case "alert":
+		return e.GetAlert().GetStatus()
+	case "profile":
+		return e.GetRule().GetProfile()
+	case "entity":
+		return e.GetEntity().GetName()
+	case "entity_type":
+		return e.GetEntity().GetType().ToString()
+	case "rule_type":
+		return e.GetRule().GetRuleType()
+	}
+	return ""
+}
+
+// compare applies c's operator to a field value. Equality ignores case, as
+// Minder's statuses and entity types are lower case.
+func (c whereClause) compare(v string) bool {
+	switch c.op {
+	case "=":
+		return strings.EqualFold(v, c.value)
+	case "!=":
+		return !strings.EqualFold(v, c.value)
+	case "~":
+		return strings.Contains(strings.ToLower(v), strings.ToLower(c.value))
+	case "!~":
+		return !strings.Contains(strings.ToLower(v), strings.ToLower(c.value))
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseWhere_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "empty", expr: "  ", wantErr: "expression is empty"},
+		{name: "unknown field", expr: "severity = high", wantErr: `unknown field "severity"`},
+		{name: "unsupported operator", expr: "status ~ fail", wantErr: `status does not support "~"`},
+		{name: "incomplete", expr: "status =", wantErr: "incomplete comparison"},
+		{name: "missing AND", expr: "status = failure profile = baseline", wantErr: `expected AND after status = failure, got "profile"`},
+		{name: "OR", expr: "status = failure OR status = error", wantErr: `got "OR"`},
+		{name: "trailing AND", expr: "status = failure AND", wantErr: "expression ends with AND"},
+		{name: "unterminated string", expr: `rule ~ "branch`, wantErr: "unterminated string"},
+		{name: "bare bang", expr: "status ! failure", wantErr: "unexpected !"},
+		{name: "bad character", expr: "status = (failure)", wantErr: `unexpected character '('`},
+		{name: "bad age", expr: "age < soon", wantErr: `invalid age "soon"`},
+		{name: "empty age", expr: `age < ""`, wantErr: "age is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseWhere(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseWhere(%q) error = %v, want %q", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWhereCompile(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	q, err := parseWhere(`status = failure and PROFILE = baseline AND rule ~ "branch" AND ` +
+		`entity_type = repository AND alert != on AND age < 7d AND age >= 12h`)
+	if err != nil {
+		t.Fatalf("parseWhere() error = %v", err)
+	}
+	req := &minderv1.ListEvaluationHistoryRequest{ProfileName: []string{"explicit"}}
+	q.compile(req, now)
+
+	if len(req.Status) != 1 || req.Status[0] != "failure" {
+		t.Errorf("Status = %v, want [failure]", req.Status)
+	}
+	if len(req.ProfileName) != 1 || req.ProfileName[0] != "explicit" {
+		t.Errorf("ProfileName = %v, want the filter already set kept", req.ProfileName)
+	}
+	if len(req.EntityType) != 1 || req.EntityType[0] != "repository" {
+		t.Errorf("EntityType = %v, want [repository]", req.EntityType)
+	}
+	if len(req.Alert) != 0 {
+		t.Errorf("Alert = %v, want != left to client-side filtering", req.Alert)
+	}
+	if got := req.GetFrom().AsTime(); !got.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("From = %v, want 7 days ago", got)
+	}
+	if got := req.GetTo().AsTime(); !got.Equal(now.Add(-12 * time.Hour)) {
+		t.Errorf("To = %v, want 12 hours ago", got)
+	}
+}
+
+func TestWhereMinderApplies(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr string
+		req  *minderv1.ListEvaluationHistoryRequest
+		want bool
+	}{
+		{name: "equality and age", expr: "status = failure AND entity_type = repository AND age < 7d", want: true},
+		{name: "inequality", expr: "status = failure AND alert != on", want: false},
+		{name: "pattern", expr: `rule ~ "branch"`, want: false},
+		{
+			name: "filter already set to another value",
+			expr: "profile = baseline",
+			req:  &minderv1.ListEvaluationHistoryRequest{ProfileName: []string{"explicit"}},
+			want: false,
+		},
+		{
+			name: "filter already set to the same value",
+			expr: "profile = baseline",
+			req:  &minderv1.ListEvaluationHistoryRequest{ProfileName: []string{"baseline"}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			q, err := parseWhere(tt.expr)
+			if err != nil {
+				t.Fatalf("parseWhere() error = %v", err)
+			}
+			req := tt.req
+			if req == nil {
+				req = &minderv1.ListEvaluationHistoryRequest{}
+			}
+			q.compile(req, now)
+			if got := q.minderApplies(req); got != tt.want {
+				t.Errorf("minderApplies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereMatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	evaluation := &minderv1.EvaluationHistory{
+		Entity: &minderv1.EvaluationHistoryEntity{Name: "acme/api", Type: minderv1.Entity_ENTITY_REPOSITORIES},
+		Rule: &minderv1.EvaluationHistoryRule{
+			Name:     "protect-main",
+			RuleType: "branch_protection_enabled",
+			Profile:  "baseline",
+		},
+		Status:      &minderv1.EvaluationHistoryStatus{Status: "failure"},
+		Alert:       &minderv1.EvaluationHistoryAlert{Status: "on"},
+		EvaluatedAt: timestamppb.New(now.Add(-48 * time.Hour)),
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "status = failure", want: true},
+		{expr: "status = FAILURE", want: true},
+		{expr: "status != failure", want: false},
+		{expr: "remediation = success", want: false},
+		{expr: "alert = on", want: true},
+		{expr: `rule ~ "Branch"`, want: true},
+		{expr: "rule = protect-main", want: true},
+		{expr: "rule !~ branch", want: false},
+		{expr: "rule !~ secret", want: true},
+		{expr: "rule_type ~ secret", want: false},
+		{expr: "entity ~ acme/ AND entity_type = repository", want: true},
+		{expr: "entity_type != artifact", want: true},
+		{expr: "profile !~ base", want: false},
+		{expr: "age < 7d", want: true},
+		{expr: "age < 1d", want: false},
+		{expr: "age > 1d AND age <= 2d", want: true},
+		{expr: "age >= 1w", want: false},
+		{expr: "status = failure AND age < 90m", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			q, err := parseWhere(tt.expr)
+			if err != nil {
+				t.Fatalf("parseWhere() error = %v", err)
+			}
+			if got := q.match(evaluation, now); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package watcher
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// TransitionKind classifies a change in compliance status.
+type TransitionKind string
+
+const (
+	// ProfileRegressed means a profile started failing.
+	ProfileRegressed TransitionKind = "profile_regressed"
+	// ProfileRecovered means a failing profile is no longer failing.
+	ProfileRecovered TransitionKind = "profile_recovered"
+	// RuleFailing means a rule evaluation started failing, including a newly evaluated rule.
+	RuleFailing TransitionKind = "rule_failing"
+	// RuleRecovered means a failing rule evaluation is no longer failing.
+	RuleRecovered TransitionKind = "rule_recovered"
+)
+
+// Transition describes one profile or rule whose status crossed between
+// passing and failing between two snapshots.
+type Transition struct {
+	Kind TransitionKind `json:"kind"`
+	// Key is the snapshot key of the profile or rule evaluation.
+	Key string `json:"key"`
+	// From is the previous status, empty if the profile or rule is new.
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Regression reports whether the transition is a move into a failing state.
+func (t Transition) Regression() bool {
+	return t.Kind == ProfileRegressed || t.Kind == RuleFailing
+}
+
+// IsFailing reports whether a Minder evaluation status counts as failing.
+func IsFailing(status string) bool {
+	return status == "failure" || status == "error"
+}
+
+// Diff returns the transitions between prev and curr, sorted with profiles
+// before rules and by key within each. Status changes that stay on the same
+// side of passing/failing (e.g. success to skipped) are not transitions, and
+// entries that disappeared are ignored.
+func Diff(prev, curr Snapshot) []Transition {
+	transitions := diffStatuses(prev.Profiles, curr.Profiles, ProfileRegressed, ProfileRecovered)
+	return append(transitions, diffStatuses(prev.Rules, curr.Rules, RuleFailing, RuleRecovered)...)
+}
+
+// diffStatuses compares two status maps, returning transitions sorted by key.
+func diffStatuses(prev, curr map[string]string, regressed, recovered TransitionKind) []Transition {
+	var transitions []Transition
+	for key, to := range curr {
+		from := prev[key]
+		switch {
+		case IsFailing(to) && !IsFailing(from):
+			transitions = append(transitions, Transition{Kind: regressed, Key: key, From: from, To: to})
+		case IsFailing(from) && !IsFailing(to):
+			transitions = append(transitions, Transition{Kind: recovered, Key: key, From: from, To: to})
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].Key < transitions[j].Key })
+	return transitions
+}
+
+// LogTransitions returns a ChangeFunc that writes one log event per transition:
+// warnings for regressions and info for recoveries.
+func LogTransitions(logger *slog.Logger) ChangeFunc {
+	return func(ctx context.Context, prev, curr Snapshot) {
+		for _, t := range Diff(prev, curr) {
+			level := slog.LevelInfo
+			if t.Regression() {
+				level = slog.LevelWarn
+			}
+			logger.Log(ctx, level, "compliance transition",
+				"kind", t.Kind,
+				"key", t.Key,
+				"from", t.From,
+				"to", t.To,
+			)
+		}
+	}
+}
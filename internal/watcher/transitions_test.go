@@ -0,0 +1,79 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	prev := NewSnapshot()
+	prev.Profiles["p/one"] = "success"
+	prev.Profiles["p/two"] = "failure"
+	prev.Profiles["p/three"] = "success"
+	prev.Profiles["p/gone"] = "failure"
+	prev.Rules["p/one/rule-a/e1"] = "success"
+	prev.Rules["p/two/rule-b/e1"] = "error"
+
+	curr := NewSnapshot()
+	curr.Profiles["p/one"] = "failure"
+	curr.Profiles["p/two"] = "success"
+	curr.Profiles["p/three"] = "skipped"
+	curr.Profiles["p/new"] = "error"
+	curr.Rules["p/one/rule-a/e1"] = "failure"
+	curr.Rules["p/one/rule-c/e2"] = "failure"
+	curr.Rules["p/two/rule-b/e1"] = "success"
+
+	got := Diff(prev, curr)
+	want := []Transition{
+		{Kind: ProfileRegressed, Key: "p/new", From: "", To: "error"},
+		{Kind: ProfileRegressed, Key: "p/one", From: "success", To: "failure"},
+		{Kind: ProfileRecovered, Key: "p/two", From: "failure", To: "success"},
+		{Kind: RuleFailing, Key: "p/one/rule-a/e1", From: "success", To: "failure"},
+		{Kind: RuleFailing, Key: "p/one/rule-c/e2", From: "", To: "failure"},
+		{Kind: RuleRecovered, Key: "p/two/rule-b/e1", From: "error", To: "success"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Diff() returned %d transitions, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDiff_NoTransitions(t *testing.T) {
+	t.Parallel()
+
+	a := snapshotWith(map[string]string{"p/one": "success"})
+	b := snapshotWith(map[string]string{"p/one": "pending"})
+
+	if got := Diff(a, b); len(got) != 0 {
+		t.Errorf("Diff() = %+v, want no transitions", got)
+	}
+}
+
+func TestLogTransitions(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	prev := snapshotWith(map[string]string{"p/one": "success", "p/two": "failure"})
+	curr := snapshotWith(map[string]string{"p/one": "failure", "p/two": "success"})
+	LogTransitions(logger)(context.Background(), prev, curr)
+
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "kind=profile_regressed key=p/one") {
+		t.Errorf("expected a warning for the regression, got %q", out)
+	}
+	if !strings.Contains(out, "level=INFO") || !strings.Contains(out, "kind=profile_recovered key=p/two") {
+		t.Errorf("expected an info event for the recovery, got %q", out)
+	}
+}
@@ -0,0 +1,147 @@
+// Package watcher provides background polling of Minder compliance status.
+package watcher
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Snapshot captures the evaluation state of every profile visible to the watcher.
+type Snapshot struct {
+	// Profiles maps "<project_id>/<profile_name>" to the overall profile status.
+	Profiles map[string]string
+	// Rules maps "<project_id>/<profile_name>/<rule_name>/<entity_id>" to the rule evaluation status.
+	Rules map[string]string
+	// Entities maps entity IDs seen in Rules to a display name, e.g. "owner/repo".
+	// It is informational and not compared by Equal.
+	Entities map[string]string
+	// Severities maps keys in Rules to the severity of the rule's type, e.g.
+	// "high", when Minder reports one. It is informational and not compared by Equal.
+	Severities map[string]string
+}
+
+// NewSnapshot returns an empty Snapshot ready to be populated.
+func NewSnapshot() Snapshot {
+	return Snapshot{
+		Profiles:   make(map[string]string),
+		Rules:      make(map[string]string),
+		Entities:   make(map[string]string),
+		Severities: make(map[string]string),
+	}
+}
+
+// Equal reports whether two snapshots hold the same profile and rule statuses.
+func (s Snapshot) Equal(other Snapshot) bool {
+	return maps.Equal(s.Profiles, other.Profiles) && maps.Equal(s.Rules, other.Rules)
+}
+
+// FetchFunc collects the current compliance snapshot.
+type FetchFunc func(ctx context.Context) (Snapshot, error)
+
+// ChangeFunc is invoked when a poll observes a snapshot that differs from the previous one.
+type ChangeFunc func(ctx context.Context, prev, curr Snapshot)
+
+// SnapshotFunc receives a newly observed snapshot.
+type SnapshotFunc func(ctx context.Context, s Snapshot)
+
+// Watcher periodically polls compliance status and notifies listeners on change.
+// Watcher is safe for concurrent use by multiple goroutines.
+type Watcher struct {
+	interval time.Duration
+	fetch    FetchFunc
+	logger   *slog.Logger
+
+	// mu protects listeners and the last observed snapshot
+	mu        sync.Mutex
+	listeners []ChangeFunc
+	observers []SnapshotFunc
+	last      *Snapshot
+}
+
+// New creates a new Watcher that polls using fetch every interval.
+func New(interval time.Duration, fetch FetchFunc, logger *slog.Logger) *Watcher {
+	return &Watcher{
+		interval: interval,
+		fetch:    fetch,
+		logger:   logger,
+	}
+}
+
+// OnChange registers a listener invoked whenever compliance status changes.
+func (w *Watcher) OnChange(fn ChangeFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, fn)
+}
+
+// OnSnapshot registers fn to receive the snapshot of the first poll and of
+// every poll that observes a change, e.g. to persist the baseline.
+func (w *Watcher) OnSnapshot(fn SnapshotFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.observers = append(w.observers, fn)
+}
+
+// Restore makes s the baseline the first poll is compared against, such as a
+// snapshot persisted by an earlier run, so changes made while the server was
+// down are reported.
+func (w *Watcher) Restore(s Snapshot) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = &s
+}
+
+// Run polls until ctx is canceled. Unless a baseline was restored, the first
+// poll establishes one and does not notify listeners.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll fetches a snapshot, compares it to the previous one, and notifies listeners on change.
+func (w *Watcher) poll(ctx context.Context) {
+	curr, err := w.fetch(ctx)
+	if err != nil {
+		// Keep the previous snapshot so a transient failure is not reported as a change
+		w.logger.WarnContext(ctx, "compliance poll failed", "error", err)
+		return
+	}
+
+	w.mu.Lock()
+	prev := w.last
+	w.last = &curr
+	listeners := append([]ChangeFunc(nil), w.listeners...)
+	observers := append([]SnapshotFunc(nil), w.observers...)
+	w.mu.Unlock()
+
+	if prev != nil && prev.Equal(curr) {
+		return
+	}
+	for _, fn := range observers {
+		fn(ctx, curr)
+	}
+	if prev == nil {
+		return
+	}
+
+	w.logger.DebugContext(ctx, "compliance status changed",
+		"profiles", len(curr.Profiles),
+		"rules", len(curr.Rules),
+	)
+	for _, fn := range listeners {
+		fn(ctx, *prev, curr)
+	}
+}
@@ -0,0 +1,163 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// sequenceFetcher returns each snapshot (or error) in order, repeating the last one.
+func sequenceFetcher(snapshots []Snapshot, errs []error) FetchFunc {
+	i := 0
+	return func(_ context.Context) (Snapshot, error) {
+		idx := min(i, len(snapshots)-1)
+		i++
+		if idx < len(errs) && errs[idx] != nil {
+			return Snapshot{}, errs[idx]
+		}
+		return snapshots[idx], nil
+	}
+}
+
+func snapshotWith(profiles map[string]string) Snapshot {
+	s := NewSnapshot()
+	for k, v := range profiles {
+		s.Profiles[k] = v
+	}
+	return s
+}
+
+func TestSnapshotEqual(t *testing.T) {
+	t.Parallel()
+
+	a := snapshotWith(map[string]string{"p/one": "success"})
+	b := snapshotWith(map[string]string{"p/one": "success"})
+	c := snapshotWith(map[string]string{"p/one": "failure"})
+
+	if !a.Equal(b) {
+		t.Error("expected identical snapshots to be equal")
+	}
+	if a.Equal(c) {
+		t.Error("expected snapshots with different statuses to differ")
+	}
+
+	b.Rules["p/one/rule/entity"] = "failure"
+	if a.Equal(b) {
+		t.Error("expected snapshots with different rules to differ")
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	t.Parallel()
+
+	passing := snapshotWith(map[string]string{"p/one": "success"})
+	failing := snapshotWith(map[string]string{"p/one": "failure"})
+
+	tests := []struct {
+		name          string
+		restore       *Snapshot
+		snapshots     []Snapshot
+		errs          []error
+		polls         int
+		wantCalls     int
+		wantSnapshots int
+	}{
+		{
+			name:          "baseline poll does not notify",
+			snapshots:     []Snapshot{passing},
+			polls:         1,
+			wantCalls:     0,
+			wantSnapshots: 1,
+		},
+		{
+			name:          "unchanged status does not notify",
+			snapshots:     []Snapshot{passing, passing},
+			polls:         2,
+			wantCalls:     0,
+			wantSnapshots: 1,
+		},
+		{
+			name:          "changed status notifies",
+			snapshots:     []Snapshot{passing, failing},
+			polls:         2,
+			wantCalls:     1,
+			wantSnapshots: 2,
+		},
+		{
+			name:          "failed poll keeps previous snapshot",
+			snapshots:     []Snapshot{passing, {}, passing},
+			errs:          []error{nil, errors.New("unavailable"), nil},
+			polls:         3,
+			wantCalls:     0,
+			wantSnapshots: 1,
+		},
+		{
+			name:          "first poll compares against restored baseline",
+			restore:       &passing,
+			snapshots:     []Snapshot{failing},
+			polls:         1,
+			wantCalls:     1,
+			wantSnapshots: 1,
+		},
+		{
+			name:          "unchanged restored baseline does not notify",
+			restore:       &passing,
+			snapshots:     []Snapshot{passing},
+			polls:         1,
+			wantCalls:     0,
+			wantSnapshots: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := New(time.Minute, sequenceFetcher(tt.snapshots, tt.errs), newTestLogger())
+			if tt.restore != nil {
+				w.Restore(*tt.restore)
+			}
+			calls, snapshots := 0, 0
+			w.OnChange(func(_ context.Context, _, _ Snapshot) { calls++ })
+			w.OnSnapshot(func(_ context.Context, _ Snapshot) { snapshots++ })
+
+			for range tt.polls {
+				w.poll(context.Background())
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("listener called %d times, want %d", calls, tt.wantCalls)
+			}
+			if snapshots != tt.wantSnapshots {
+				t.Errorf("snapshot observer called %d times, want %d", snapshots, tt.wantSnapshots)
+			}
+		})
+	}
+}
+
+func TestWatcherRunStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := New(time.Millisecond, sequenceFetcher([]Snapshot{NewSnapshot()}, nil), newTestLogger())
+
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+}
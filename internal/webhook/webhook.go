@@ -0,0 +1,155 @@
+// Package webhook posts compliance transition events to operator-configured
+// HTTP endpoints, optionally signed with HMAC-SHA256.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+const (
+	// EventHeader carries the event type so receivers can route without parsing the body.
+	EventHeader = "X-Minder-MCP-Event"
+	// SignatureHeader carries "sha256=<hex HMAC of the body>" when a secret is configured.
+	SignatureHeader = "X-Minder-MCP-Signature-256"
+
+	// deliveryTimeout bounds each POST so a slow receiver cannot stall the watcher.
+	deliveryTimeout = 10 * time.Second
+)
+
+// EventType classifies a webhook event.
+type EventType string
+
+const (
+	// EventRegressed reports profiles or rule evaluations that started failing.
+	EventRegressed EventType = "compliance.regressed"
+	// EventRecovered reports profiles or rule evaluations that stopped failing.
+	EventRecovered EventType = "compliance.recovered"
+)
+
+// Event is the JSON body posted to each webhook URL.
+type Event struct {
+	Type        EventType            `json:"type"`
+	Time        time.Time            `json:"time"`
+	Transitions []watcher.Transition `json:"transitions"`
+}
+
+// Sender posts events to a fixed set of URLs.
+type Sender struct {
+	urls   []string
+	secret []byte
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewSender returns a Sender that posts to urls, signing each body with secret
+// when it is non-empty.
+func NewSender(urls []string, secret string, logger *slog.Logger) *Sender {
+	s := &Sender{
+		urls:   urls,
+		client: &http.Client{Timeout: deliveryTimeout},
+		logger: logger,
+		now:    time.Now,
+	}
+	if secret != "" {
+		s.secret = []byte(secret)
+	}
+	return s
+}
+
+// Sign returns the SignatureHeader value for body: "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of body keyed with secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the event to every URL. Delivery continues past failures;
+// the returned error joins every failed delivery.
+func (s *Sender) Send(ctx context.Context, event Event) error {
+	return s.SendPayload(ctx, event.Type, event)
+}
+
+// SendPayload posts payload, encoded as JSON, to every URL as an event of
+// eventType, for receivers that expect a body other than Event. It signs and
+// reports failures like Send.
+func (s *Sender) SendPayload(ctx context.Context, eventType EventType, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode webhook event: %w", err)
+	}
+
+	var errs []error
+	for _, url := range s.urls {
+		if err := s.post(ctx, url, eventType, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post delivers one event body to url.
+func (s *Sender) post(ctx context.Context, url string, eventType EventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "minder-mcp")
+	req.Header.Set(EventHeader, string(eventType))
+	if s.secret != nil {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", url, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: unexpected status %s", url, resp.Status)
+	}
+	return nil
+}
+
+// Notify returns a ChangeFunc that sends one EventRegressed event with the
+// regressions and one EventRecovered event with the recoveries between two
+// snapshots, skipping either when it would be empty.
+func (s *Sender) Notify() watcher.ChangeFunc {
+	return func(ctx context.Context, prev, curr watcher.Snapshot) {
+		var regressed, recovered []watcher.Transition
+		for _, t := range watcher.Diff(prev, curr) {
+			if t.Regression() {
+				regressed = append(regressed, t)
+			} else {
+				recovered = append(recovered, t)
+			}
+		}
+
+		now := s.now().UTC()
+		for _, event := range []Event{
+			{Type: EventRegressed, Time: now, Transitions: regressed},
+			{Type: EventRecovered, Time: now, Transitions: recovered},
+		} {
+			if len(event.Transitions) == 0 {
+				continue
+			}
+			if err := s.Send(ctx, event); err != nil {
+				s.logger.WarnContext(ctx, "webhook delivery failed", "event", event.Type, "error", err)
+			}
+		}
+	}
+}
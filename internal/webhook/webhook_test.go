@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stacklok/minder-mcp/internal/watcher"
+)
+
+// received is a request captured by a test webhook receiver.
+type received struct {
+	event     Event
+	eventType string
+	signature string
+	body      []byte
+}
+
+// newReceiver starts a server recording every delivery and answering with status.
+func newReceiver(t *testing.T, status int) (*httptest.Server, func() []received) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("receiver could not decode body: %v", err)
+		}
+		mu.Lock()
+		got = append(got, received{
+			event:     event,
+			eventType: r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      body,
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), got...)
+	}
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestSend_Signed(t *testing.T) {
+	t.Parallel()
+
+	srv, deliveries := newReceiver(t, http.StatusNoContent)
+	s := NewSender([]string{srv.URL}, "s3cret", discardLogger())
+
+	event := Event{Type: EventRegressed, Transitions: []watcher.Transition{
+		{Kind: watcher.RuleFailing, Key: "p/baseline/rule/repo-1", From: "success", To: "failure"},
+	}}
+	if err := s.Send(context.Background(), event); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+
+	got := deliveries()
+	if len(got) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(got))
+	}
+	if got[0].eventType != string(EventRegressed) {
+		t.Errorf("event header = %q, want %q", got[0].eventType, EventRegressed)
+	}
+	if want := Sign([]byte("s3cret"), got[0].body); got[0].signature != want {
+		t.Errorf("signature = %q, want %q", got[0].signature, want)
+	}
+	if len(got[0].event.Transitions) != 1 {
+		t.Errorf("delivered transitions = %+v, want one", got[0].event.Transitions)
+	}
+}
+
+func TestSend_Unsigned(t *testing.T) {
+	t.Parallel()
+
+	srv, deliveries := newReceiver(t, http.StatusOK)
+	s := NewSender([]string{srv.URL}, "", discardLogger())
+
+	if err := s.Send(context.Background(), Event{Type: EventRecovered}); err != nil {
+		t.Fatalf("Send() returned error: %v", err)
+	}
+	if got := deliveries(); len(got) != 1 || got[0].signature != "" {
+		t.Errorf("deliveries = %+v, want one without a signature", got)
+	}
+}
+
+func TestSend_ContinuesPastFailures(t *testing.T) {
+	t.Parallel()
+
+	failing, _ := newReceiver(t, http.StatusInternalServerError)
+	ok, deliveries := newReceiver(t, http.StatusOK)
+	s := NewSender([]string{failing.URL, ok.URL}, "", discardLogger())
+
+	if err := s.Send(context.Background(), Event{Type: EventRegressed}); err == nil {
+		t.Error("Send() returned nil, want error for the failing receiver")
+	}
+	if got := deliveries(); len(got) != 1 {
+		t.Errorf("healthy receiver got %d deliveries, want 1", len(got))
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n 'hello' | openssl dgst -sha256 -hmac key
+	want := "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"
+	if got := Sign([]byte("key"), []byte("hello")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	srv, deliveries := newReceiver(t, http.StatusOK)
+	s := NewSender([]string{srv.URL}, "", discardLogger())
+
+	prev := watcher.NewSnapshot()
+	prev.Profiles["p/one"] = "success"
+	prev.Profiles["p/two"] = "failure"
+	curr := watcher.NewSnapshot()
+	curr.Profiles["p/one"] = "failure"
+	curr.Profiles["p/two"] = "success"
+	curr.Rules["p/one/rule/repo-1"] = "error"
+
+	s.Notify()(context.Background(), prev, curr)
+
+	got := deliveries()
+	if len(got) != 2 {
+		t.Fatalf("got %d deliveries, want a regressed and a recovered event", len(got))
+	}
+	if got[0].event.Type != EventRegressed || len(got[0].event.Transitions) != 2 {
+		t.Errorf("first event = %+v, want two regressions", got[0].event)
+	}
+	if got[1].event.Type != EventRecovered || len(got[1].event.Transitions) != 1 {
+		t.Errorf("second event = %+v, want one recovery", got[1].event)
+	}
+}
+
+func TestNotify_NoTransitions(t *testing.T) {
+	t.Parallel()
+
+	srv, deliveries := newReceiver(t, http.StatusOK)
+	s := NewSender([]string{srv.URL}, "", discardLogger())
+
+	prev := watcher.NewSnapshot()
+	prev.Profiles["p/one"] = "success"
+	curr := watcher.NewSnapshot()
+	curr.Profiles["p/one"] = "skipped"
+
+	s.Notify()(context.Background(), prev, curr)
+
+	if got := deliveries(); len(got) != 0 {
+		t.Errorf("got %d deliveries, want none", len(got))
+	}
+}
@@ -0,0 +1,156 @@
+// Package mindermcp embeds the Minder MCP server in another Go program, so it
+// can be mounted on the program's own mux and share its listener and
+// lifecycle instead of running the minder-mcp binary alongside it:
+//
+//	cfg := mindermcp.LoadConfig()
+//	h, err := mindermcp.New(cfg, mindermcp.WithLogger(logger))
+//	if err != nil {
+//		return err
+//	}
+//	defer h.(io.Closer).Close()
+//	mux.Handle(cfg.MCP.EndpointPath, h)
+//
+// The handler serves the MCP endpoint with the tools, resources, CORS, origin
+// and client network checks of the binary. Process-wide features stay with
+// the binary: the health, readiness and metrics endpoints, the compliance
+// watcher, history and reports, the persistent store and SIGHUP reloading.
+package mindermcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/internal/mcpserver"
+	"github.com/stacklok/minder-mcp/internal/middleware"
+	"github.com/stacklok/minder-mcp/internal/resources"
+	"github.com/stacklok/minder-mcp/internal/tools"
+)
+
+// modulePath is the module this package is built from, used to report its version.
+const modulePath = "github.com/stacklok/minder-mcp"
+
+// Config is the server configuration, with the fields and defaults of the
+// binary's environment variables and flags.
+type Config = config.Config
+
+// LoadConfig reads the configuration from the environment variables the
+// binary reads, applying their defaults.
+func LoadConfig() *Config {
+	return config.Load()
+}
+
+// Option customizes the handler returned by New.
+type Option func(*options)
+
+type options struct {
+	logger  *slog.Logger
+	name    string
+	version string
+	server  []mcpserver.Option
+}
+
+// WithLogger sets the logger of tool calls and Minder connections. The
+// default is slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithServerInfo sets the server name and version reported to MCP clients
+// and, as name/version, in the user agent of Minder requests. The default is
+// minder-mcp and the version of this module.
+func WithServerInfo(name, version string) Option {
+	return func(o *options) {
+		o.name = name
+		o.version = version
+	}
+}
+
+// WithToolMiddleware wraps every tool call in mw, such as an authorization
+// check, a quota or custom logging. The first middleware is outermost, and
+// all of them run before the tools' own logging, metrics and limits.
+func WithToolMiddleware(mw ...server.ToolHandlerMiddleware) Option {
+	return func(o *options) { o.server = append(o.server, mcpserver.WithToolMiddleware(mw...)) }
+}
+
+// WithoutResources leaves out the compliance dashboard and server info
+// resources, for hosts that cannot render MCP Apps.
+func WithoutResources() Option {
+	return func(o *options) { o.server = append(o.server, mcpserver.WithoutResources()) }
+}
+
+// handler is the embedded MCP endpoint and the Minder connections it holds.
+type handler struct {
+	http.Handler
+	close func()
+}
+
+// Close releases the handler's pooled Minder connections and token refresher.
+func (h *handler) Close() error {
+	h.close()
+	return nil
+}
+
+// New validates cfg and returns the handler serving the MCP endpoint at
+// cfg.MCP.EndpointPath. The handler also implements io.Closer; close it once
+// it no longer serves requests to release its Minder connections.
+func New(cfg *Config, opts ...Option) (http.Handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	o := options{logger: slog.Default(), name: "minder-mcp", version: moduleVersion()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	t, closeTools, err := tools.NewForMode(cfg, o.logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.LoadExtensions(context.Background()); err != nil {
+		closeTools()
+		return nil, err
+	}
+	t.SetUserAgent(o.name + "/" + o.version)
+
+	info := resources.BuildInfo{
+		Name:      o.name,
+		Version:   o.version,
+		Commit:    "unknown",
+		BuildTime: "unknown",
+		GoVersion: runtime.Version(),
+	}
+	srv, err := mcpserver.New(info, t, o.logger, o.server...)
+	if err != nil {
+		closeTools()
+		return nil, err
+	}
+	mcpHandler := srv.HTTPHandler(cfg)
+	guard := &middleware.OriginGuard{
+		Trusted:           middleware.NewOriginAllowlist(cfg.MCP.TrustedOrigins),
+		StoredCredentials: cfg.Minder.HasAuthToken(),
+	}
+	prefixes, _ := cfg.MCP.AllowedPrefixes() // validated above
+	clients := middleware.NewIPAllowlist(prefixes)
+	cors := middleware.CORS(middleware.NewOriginAllowlist(cfg.MCP.CORSAllowedOrigins), mcpHandler)
+	return &handler{Handler: clients.Handler(guard.Handler(cors)), close: closeTools}, nil
+}
+
+// moduleVersion returns the version of this module in the embedding
+// program's build, or "dev" when it is not known.
+func moduleVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath && dep.Version != "" {
+				return dep.Version
+			}
+		}
+	}
+	return "dev"
+}
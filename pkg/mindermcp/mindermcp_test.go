@@ -0,0 +1,80 @@
+package mindermcp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stacklok/minder-mcp/internal/config"
+	"github.com/stacklok/minder-mcp/pkg/mindermcp"
+)
+
+func demoConfig() *mindermcp.Config {
+	cfg := config.LoadWithReader(func(string) string { return "" })
+	cfg.MCP.Mode = config.ModeDemo
+	return cfg
+}
+
+func post(t *testing.T, srv *httptest.Server, sessionID, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/mcp", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestNew_ServesTools(t *testing.T) {
+	t.Parallel()
+
+	h, err := mindermcp.New(demoConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = h.(io.Closer).Close() }()
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", h)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp := post(t, srv, "", `{"jsonrpc":"2.0","id":1,"method":"initialize",`+
+		`"params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("initialize status = %d, want 200", resp.StatusCode)
+	}
+	session := resp.Header.Get("Mcp-Session-Id")
+	if session == "" {
+		t.Fatal("initialize returned no Mcp-Session-Id")
+	}
+
+	resp = post(t, srv, session, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"minder_list_repositories"`) {
+		t.Errorf("tools/list = %s, want minder_list_repositories", body)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := demoConfig()
+	cfg.MCP.Mode = "bogus"
+	if _, err := mindermcp.New(cfg); err == nil {
+		t.Error("New() with an invalid mode succeeded, want an error")
+	}
+}
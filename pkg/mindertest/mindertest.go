@@ -0,0 +1,141 @@
+// Package mindertest provides an in-process fake Minder gRPC server for
+// integration tests of code built on this MCP server.
+//
+// The fake serves the read RPCs the tools use from Fixtures; every other RPC
+// returns Unimplemented. Errors can be injected per method with SetError:
+//
+//	srv := mindertest.Start(mindertest.Fixtures{
+//		Projects:     []*minderv1.Project{{ProjectId: projectID, Name: "test"}},
+//		Repositories: []*minderv1.Repository{repo},
+//	})
+//	defer srv.Close()
+//	conn, err := srv.Dial(ctx)
+package mindertest
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// listenerBufferSize is the in-memory connection buffer size.
+const listenerBufferSize = 1 << 20
+
+// Fixtures is the state served by a fake Minder. Resources carrying a project
+// in their context are only listed for that project; resources without one
+// are listed for every project.
+type Fixtures struct {
+	Projects     []*minderv1.Project
+	Providers    []*minderv1.Provider
+	Repositories []*minderv1.Repository
+	Artifacts    []*minderv1.Artifact
+	RuleTypes    []*minderv1.RuleType
+	Profiles     []*minderv1.Profile
+	DataSources  []*minderv1.DataSource
+	// Statuses are the current rule evaluations, per profile ID.
+	Statuses map[string][]*minderv1.RuleEvaluationStatus
+	// History holds evaluation history rows, newest first.
+	History []*minderv1.EvaluationHistory
+}
+
+// Server is an in-process fake Minder.
+type Server struct {
+	listener *bufconn.Listener
+	grpc     *grpc.Server
+
+	mu     sync.Mutex
+	errors map[string]error
+	calls  map[string]int
+}
+
+// Start starts a fake Minder serving f. Fixtures must not be modified while
+// the server runs.
+func Start(f Fixtures) *Server {
+	s := &Server{
+		listener: bufconn.Listen(listenerBufferSize),
+		errors:   map[string]error{},
+		calls:    map[string]int{},
+	}
+	s.grpc = grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+
+	fx := &f
+	minderv1.RegisterHealthServiceServer(s.grpc, healthService{})
+	minderv1.RegisterProjectsServiceServer(s.grpc, projectsService{fx: fx})
+	minderv1.RegisterProvidersServiceServer(s.grpc, providersService{fx: fx})
+	minderv1.RegisterRepositoryServiceServer(s.grpc, repositoryService{fx: fx})
+	minderv1.RegisterArtifactServiceServer(s.grpc, artifactService{fx: fx})
+	minderv1.RegisterRuleTypeServiceServer(s.grpc, ruleTypeService{fx: fx})
+	minderv1.RegisterDataSourceServiceServer(s.grpc, dataSourceService{fx: fx})
+	minderv1.RegisterProfileServiceServer(s.grpc, profileService{fx: fx})
+	minderv1.RegisterEvalResultsServiceServer(s.grpc, evalResultsService{fx: fx})
+	go func() { _ = s.grpc.Serve(s.listener) }()
+	return s
+}
+
+// Dial returns a connection to the server. Each call opens a new connection,
+// which the caller closes.
+func (s *Server) Dial(_ context.Context) (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///mindertest",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+// SetError makes every call to the full gRPC method name, such as
+// minderv1.RepositoryService_ListRepositories_FullMethodName, fail with err.
+// A nil err restores normal responses.
+func (s *Server) SetError(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.errors, method)
+		return
+	}
+	s.errors[method] = err
+}
+
+// Calls returns how many times the full gRPC method name has been called.
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.grpc.Stop()
+}
+
+// intercept counts calls and fails those with an injected error.
+func (s *Server) intercept(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	s.mu.Lock()
+	s.calls[info.FullMethod]++
+	err := s.errors[info.FullMethod]
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// EntityTypeName returns the name Minder uses for an entity type in filters
+// and entity info, e.g. "repository".
+func EntityTypeName(kind minderv1.Entity) string {
+	switch kind {
+	case minderv1.Entity_ENTITY_ARTIFACTS:
+		return "artifact"
+	case minderv1.Entity_ENTITY_PULL_REQUESTS:
+		return "pull_request"
+	default:
+		return "repository"
+	}
+}
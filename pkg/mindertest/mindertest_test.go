@@ -0,0 +1,105 @@
+package mindertest_test
+
+import (
+	"context"
+	"testing"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stacklok/minder-mcp/pkg/mindertest"
+)
+
+const (
+	projectA = "11111111-1111-4111-8111-111111111111"
+	projectB = "22222222-2222-4222-8222-222222222222"
+)
+
+func repository(id, project, name string) *minderv1.Repository {
+	return &minderv1.Repository{Id: &id, Context: &minderv1.Context{Project: &project}, Owner: "acme", Name: name}
+}
+
+func startServer(t *testing.T) (*mindertest.Server, minderv1.RepositoryServiceClient) {
+	t.Helper()
+	srv := mindertest.Start(mindertest.Fixtures{
+		Projects: []*minderv1.Project{{ProjectId: projectA, Name: "a"}, {ProjectId: projectB, Name: "b"}},
+		Repositories: []*minderv1.Repository{
+			repository("r1", projectA, "api"),
+			repository("r2", projectA, "web"),
+			repository("r3", projectB, "infra"),
+		},
+	})
+	t.Cleanup(srv.Close)
+
+	conn, err := srv.Dial(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return srv, minderv1.NewRepositoryServiceClient(conn)
+}
+
+func TestListRepositoriesByProject(t *testing.T) {
+	t.Parallel()
+
+	_, repos := startServer(t)
+
+	tests := []struct {
+		name     string
+		project  string
+		want     []string
+		wantCode codes.Code
+	}{
+		{name: "all projects", want: []string{"api", "web", "infra"}},
+		{name: "project a", project: projectA, want: []string{"api", "web"}},
+		{name: "project b", project: projectB, want: []string{"infra"}},
+		{name: "unknown project", project: "33333333-3333-4333-8333-333333333333", wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &minderv1.ListRepositoriesRequest{}
+			if tt.project != "" {
+				req.Context = &minderv1.Context{Project: &tt.project}
+			}
+			resp, err := repos.ListRepositories(context.Background(), req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			var names []string
+			for _, repo := range resp.GetResults() {
+				names = append(names, repo.GetName())
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestSetError(t *testing.T) {
+	t.Parallel()
+
+	srv, repos := startServer(t)
+	method := minderv1.RepositoryService_GetRepositoryById_FullMethodName
+	ctx := context.Background()
+
+	srv.SetError(method, status.Error(codes.PermissionDenied, "denied"))
+	_, err := repos.GetRepositoryById(ctx, &minderv1.GetRepositoryByIdRequest{RepositoryId: "r1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	srv.SetError(method, nil)
+	resp, err := repos.GetRepositoryById(ctx, &minderv1.GetRepositoryByIdRequest{RepositoryId: "r1"})
+	require.NoError(t, err)
+	assert.Equal(t, "api", resp.GetRepository().GetName())
+
+	assert.Equal(t, 2, srv.Calls(method))
+	assert.Zero(t, srv.Calls(minderv1.RepositoryService_ListRepositories_FullMethodName))
+}
+
+func TestUnimplemented(t *testing.T) {
+	t.Parallel()
+
+	_, repos := startServer(t)
+	_, err := repos.DeleteRepositoryById(context.Background(), &minderv1.DeleteRepositoryByIdRequest{RepositoryId: "r1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,504 @@
+package mindertest
+
+import (
+	"context"
+	"slices"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkProject rejects requests scoped to a project that is not in the fixtures.
+func (f *Fixtures) checkProject(projectID string) error {
+	if projectID == "" || slices.ContainsFunc(f.Projects, func(p *minderv1.Project) bool {
+		return p.GetProjectId() == projectID
+	}) {
+		return nil
+	}
+	return status.Errorf(codes.NotFound, "project %s not found", projectID)
+}
+
+// visible returns the items in the requested project; an empty project on
+// either the request or an item matches everything.
+func visible[T any](items []T, project string, projectOf func(T) string) []T {
+	var out []T
+	for _, item := range items {
+		if p := projectOf(item); project == "" || p == "" || p == project {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func providerProject(p *minderv1.Provider) string     { return p.GetProject() }
+func repositoryProject(r *minderv1.Repository) string { return r.GetContext().GetProject() }
+func artifactProject(a *minderv1.Artifact) string     { return a.GetContext().GetProject() }
+func ruleTypeProject(rt *minderv1.RuleType) string    { return rt.GetContext().GetProject() }
+func dataSourceProject(d *minderv1.DataSource) string { return d.GetContext().GetProjectId() }
+func profileProject(p *minderv1.Profile) string       { return p.GetContext().GetProject() }
+
+type healthService struct {
+	minderv1.UnimplementedHealthServiceServer
+}
+
+func (healthService) CheckHealth(context.Context, *minderv1.CheckHealthRequest) (*minderv1.CheckHealthResponse, error) {
+	return &minderv1.CheckHealthResponse{Status: "OK"}, nil
+}
+
+type projectsService struct {
+	minderv1.UnimplementedProjectsServiceServer
+	fx *Fixtures
+}
+
+func (s projectsService) ListProjects(context.Context, *minderv1.ListProjectsRequest) (*minderv1.ListProjectsResponse, error) {
+	return &minderv1.ListProjectsResponse{Projects: s.fx.Projects}, nil
+}
+
+func (s projectsService) ListChildProjects(
+	_ context.Context, req *minderv1.ListChildProjectsRequest,
+) (*minderv1.ListChildProjectsResponse, error) {
+	if err := s.fx.checkProject(req.GetContext().GetProjectId()); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListChildProjectsResponse{}, nil
+}
+
+type providersService struct {
+	minderv1.UnimplementedProvidersServiceServer
+	fx *Fixtures
+}
+
+func (s providersService) ListProviders(
+	_ context.Context, req *minderv1.ListProvidersRequest,
+) (*minderv1.ListProvidersResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListProvidersResponse{Providers: visible(s.fx.Providers, project, providerProject)}, nil
+}
+
+func (s providersService) GetProvider(
+	_ context.Context, req *minderv1.GetProviderRequest,
+) (*minderv1.GetProviderResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	for _, provider := range visible(s.fx.Providers, project, providerProject) {
+		if provider.GetName() == req.GetName() {
+			return &minderv1.GetProviderResponse{Provider: provider}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "provider %s not found", req.GetName())
+}
+
+type repositoryService struct {
+	minderv1.UnimplementedRepositoryServiceServer
+	fx *Fixtures
+}
+
+func (s repositoryService) ListRepositories(
+	_ context.Context, req *minderv1.ListRepositoriesRequest,
+) (*minderv1.ListRepositoriesResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	var repos []*minderv1.Repository
+	for _, repo := range visible(s.fx.Repositories, project, repositoryProject) {
+		if provider := req.GetContext().GetProvider(); provider == "" || provider == repo.GetContext().GetProvider() {
+			repos = append(repos, repo)
+		}
+	}
+	if limit := int(req.GetLimit()); limit > 0 && limit < len(repos) {
+		repos = repos[:limit]
+	}
+	return &minderv1.ListRepositoriesResponse{Results: repos}, nil
+}
+
+func (s repositoryService) GetRepositoryById(
+	_ context.Context, req *minderv1.GetRepositoryByIdRequest,
+) (*minderv1.GetRepositoryByIdResponse, error) {
+	for _, repo := range s.fx.Repositories {
+		if repo.GetId() == req.GetRepositoryId() {
+			return &minderv1.GetRepositoryByIdResponse{Repository: repo}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "repository %s not found", req.GetRepositoryId())
+}
+
+func (s repositoryService) GetRepositoryByName(
+	_ context.Context, req *minderv1.GetRepositoryByNameRequest,
+) (*minderv1.GetRepositoryByNameResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	for _, repo := range visible(s.fx.Repositories, project, repositoryProject) {
+		if repo.Owner+"/"+repo.Name == req.GetName() {
+			return &minderv1.GetRepositoryByNameResponse{Repository: repo}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "repository %s not found", req.GetName())
+}
+
+type artifactService struct {
+	minderv1.UnimplementedArtifactServiceServer
+	fx *Fixtures
+}
+
+func (s artifactService) ListArtifacts(
+	_ context.Context, req *minderv1.ListArtifactsRequest,
+) (*minderv1.ListArtifactsResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListArtifactsResponse{Results: visible(s.fx.Artifacts, project, artifactProject)}, nil
+}
+
+func (s artifactService) GetArtifactById(
+	_ context.Context, req *minderv1.GetArtifactByIdRequest,
+) (*minderv1.GetArtifactByIdResponse, error) {
+	for _, artifact := range s.fx.Artifacts {
+		if artifact.ArtifactPk == req.GetId() {
+			return &minderv1.GetArtifactByIdResponse{Artifact: artifact, Versions: artifact.Versions}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "artifact %s not found", req.GetId())
+}
+
+func (s artifactService) GetArtifactByName(
+	_ context.Context, req *minderv1.GetArtifactByNameRequest,
+) (*minderv1.GetArtifactByNameResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	for _, artifact := range visible(s.fx.Artifacts, project, artifactProject) {
+		if artifact.Name == req.GetName() || artifact.Owner+"/"+artifact.Name == req.GetName() {
+			return &minderv1.GetArtifactByNameResponse{Artifact: artifact, Versions: artifact.Versions}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "artifact %s not found", req.GetName())
+}
+
+type ruleTypeService struct {
+	minderv1.UnimplementedRuleTypeServiceServer
+	fx *Fixtures
+}
+
+func (s ruleTypeService) ListRuleTypes(
+	_ context.Context, req *minderv1.ListRuleTypesRequest,
+) (*minderv1.ListRuleTypesResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListRuleTypesResponse{RuleTypes: visible(s.fx.RuleTypes, project, ruleTypeProject)}, nil
+}
+
+func (s ruleTypeService) GetRuleTypeById(
+	_ context.Context, req *minderv1.GetRuleTypeByIdRequest,
+) (*minderv1.GetRuleTypeByIdResponse, error) {
+	for _, rt := range s.fx.RuleTypes {
+		if rt.GetId() == req.GetId() {
+			return &minderv1.GetRuleTypeByIdResponse{RuleType: rt}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "rule type %s not found", req.GetId())
+}
+
+func (s ruleTypeService) GetRuleTypeByName(
+	_ context.Context, req *minderv1.GetRuleTypeByNameRequest,
+) (*minderv1.GetRuleTypeByNameResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	for _, rt := range visible(s.fx.RuleTypes, project, ruleTypeProject) {
+		if rt.Name == req.GetName() {
+			return &minderv1.GetRuleTypeByNameResponse{RuleType: rt}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "rule type %s not found", req.GetName())
+}
+
+type dataSourceService struct {
+	minderv1.UnimplementedDataSourceServiceServer
+	fx *Fixtures
+}
+
+func (s dataSourceService) ListDataSources(
+	_ context.Context, req *minderv1.ListDataSourcesRequest,
+) (*minderv1.ListDataSourcesResponse, error) {
+	project := req.GetContext().GetProjectId()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListDataSourcesResponse{DataSources: visible(s.fx.DataSources, project, dataSourceProject)}, nil
+}
+
+func (s dataSourceService) GetDataSourceById(
+	_ context.Context, req *minderv1.GetDataSourceByIdRequest,
+) (*minderv1.GetDataSourceByIdResponse, error) {
+	for _, ds := range s.fx.DataSources {
+		if ds.Id == req.GetId() {
+			return &minderv1.GetDataSourceByIdResponse{DataSource: ds}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "data source %s not found", req.GetId())
+}
+
+func (s dataSourceService) GetDataSourceByName(
+	_ context.Context, req *minderv1.GetDataSourceByNameRequest,
+) (*minderv1.GetDataSourceByNameResponse, error) {
+	project := req.GetContext().GetProjectId()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	for _, ds := range visible(s.fx.DataSources, project, dataSourceProject) {
+		if ds.Name == req.GetName() {
+			return &minderv1.GetDataSourceByNameResponse{DataSource: ds}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "data source %s not found", req.GetName())
+}
+
+type profileService struct {
+	minderv1.UnimplementedProfileServiceServer
+	fx *Fixtures
+}
+
+func (s profileService) ListProfiles(
+	_ context.Context, req *minderv1.ListProfilesRequest,
+) (*minderv1.ListProfilesResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+	return &minderv1.ListProfilesResponse{Profiles: visible(s.fx.Profiles, project, profileProject)}, nil
+}
+
+func (s profileService) GetProfileById(
+	_ context.Context, req *minderv1.GetProfileByIdRequest,
+) (*minderv1.GetProfileByIdResponse, error) {
+	profile, err := s.byID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &minderv1.GetProfileByIdResponse{Profile: profile}, nil
+}
+
+func (s profileService) GetProfileByName(
+	_ context.Context, req *minderv1.GetProfileByNameRequest,
+) (*minderv1.GetProfileByNameResponse, error) {
+	profile, err := s.byName(req.GetContext().GetProject(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &minderv1.GetProfileByNameResponse{Profile: profile}, nil
+}
+
+func (s profileService) GetProfileStatusById(
+	_ context.Context, req *minderv1.GetProfileStatusByIdRequest,
+) (*minderv1.GetProfileStatusByIdResponse, error) {
+	profile, err := s.byID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	profileStatus, rules := s.status(profile, req.GetEntity(), req.GetRuleType(), req.GetRuleName())
+	return &minderv1.GetProfileStatusByIdResponse{ProfileStatus: profileStatus, RuleEvaluationStatus: rules}, nil
+}
+
+func (s profileService) GetProfileStatusByName(
+	_ context.Context, req *minderv1.GetProfileStatusByNameRequest,
+) (*minderv1.GetProfileStatusByNameResponse, error) {
+	profile, err := s.byName(req.GetContext().GetProject(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	profileStatus, rules := s.status(profile, req.GetEntity(), req.GetRuleType(), req.GetRuleName())
+	return &minderv1.GetProfileStatusByNameResponse{ProfileStatus: profileStatus, RuleEvaluationStatus: rules}, nil
+}
+
+func (s profileService) byID(id string) (*minderv1.Profile, error) {
+	for _, profile := range s.fx.Profiles {
+		if profile.GetId() == id {
+			return profile, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "profile %s not found", id)
+}
+
+func (s profileService) byName(projectID, name string) (*minderv1.Profile, error) {
+	if err := s.fx.checkProject(projectID); err != nil {
+		return nil, err
+	}
+	for _, profile := range visible(s.fx.Profiles, projectID, profileProject) {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "profile %s not found", name)
+}
+
+// status returns a profile's aggregate status and its rule evaluations
+// matching the optional entity, rule type and rule name filters.
+func (s profileService) status(
+	profile *minderv1.Profile, entity *minderv1.EntityTypedId, ruleType, ruleName string,
+) (*minderv1.ProfileStatus, []*minderv1.RuleEvaluationStatus) {
+	var rules []*minderv1.RuleEvaluationStatus
+	for _, rule := range s.fx.Statuses[profile.GetId()] {
+		if entity.GetId() != "" && rule.EntityInfo["entity_id"] != entity.GetId() {
+			continue
+		}
+		if (ruleType != "" && rule.RuleTypeName != ruleType) || (ruleName != "" && rule.RuleName != ruleName) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return profileStatus(profile, s.fx.Statuses[profile.GetId()]), rules
+}
+
+// profileStatus aggregates rule evaluations the way Minder does: any failure
+// fails the profile, then any error, and it passes when every rule passed.
+func profileStatus(profile *minderv1.Profile, rules []*minderv1.RuleEvaluationStatus) *minderv1.ProfileStatus {
+	ps := &minderv1.ProfileStatus{
+		ProfileId:          profile.GetId(),
+		ProfileName:        profile.Name,
+		ProfileDisplayName: profile.DisplayName,
+		ProfileStatus:      "pending",
+	}
+	statuses := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		statuses = append(statuses, rule.Status)
+		if ps.LastUpdated == nil || rule.LastUpdated.AsTime().After(ps.LastUpdated.AsTime()) {
+			ps.LastUpdated = rule.LastUpdated
+		}
+	}
+	switch {
+	case slices.Contains(statuses, "failure"):
+		ps.ProfileStatus = "failure"
+	case slices.Contains(statuses, "error"):
+		ps.ProfileStatus = "error"
+	case len(statuses) > 0:
+		ps.ProfileStatus = "success"
+	}
+	return ps
+}
+
+type evalResultsService struct {
+	minderv1.UnimplementedEvalResultsServiceServer
+	fx *Fixtures
+}
+
+func (s evalResultsService) ListEvaluationResults(
+	_ context.Context, req *minderv1.ListEvaluationResultsRequest,
+) (*minderv1.ListEvaluationResultsResponse, error) {
+	project := req.GetContext().GetProject()
+	if err := s.fx.checkProject(project); err != nil {
+		return nil, err
+	}
+
+	resp := &minderv1.ListEvaluationResultsResponse{}
+	byEntity := map[string]*minderv1.ListEvaluationResultsResponse_EntityEvaluationResults{}
+	for _, profile := range visible(s.fx.Profiles, project, profileProject) {
+		if name := req.GetProfile(); name != "" && profile.Name != name {
+			continue
+		}
+		for _, rule := range s.fx.Statuses[profile.GetId()] {
+			entityID := rule.EntityInfo["entity_id"]
+			if !matchesEntity(req.GetEntity(), entityID) ||
+				(len(req.GetRuleName()) > 0 && !slices.Contains(req.GetRuleName(), rule.RuleName)) {
+				continue
+			}
+			results, ok := byEntity[entityID]
+			if !ok {
+				results = &minderv1.ListEvaluationResultsResponse_EntityEvaluationResults{
+					Entity: &minderv1.EntityTypedId{Id: entityID, Type: entityType(rule.Entity)},
+				}
+				byEntity[entityID] = results
+				resp.Entities = append(resp.Entities, results)
+			}
+			n := len(results.Profiles)
+			if n == 0 || results.Profiles[n-1].ProfileStatus.ProfileId != profile.GetId() {
+				results.Profiles = append(results.Profiles, &minderv1.ListEvaluationResultsResponse_EntityProfileEvaluationResults{
+					ProfileStatus: profileStatus(profile, s.fx.Statuses[profile.GetId()]),
+				})
+				n++
+			}
+			results.Profiles[n-1].Results = append(results.Profiles[n-1].Results, rule)
+		}
+	}
+	return resp, nil
+}
+
+// matchesEntity reports whether entityID is selected by the filter; an empty filter selects every entity.
+func matchesEntity(filter []*minderv1.EntityTypedId, entityID string) bool {
+	return len(filter) == 0 || slices.ContainsFunc(filter, func(e *minderv1.EntityTypedId) bool {
+		return e.GetId() == entityID
+	})
+}
+
+// entityType parses an entity type name as used in entity info.
+func entityType(name string) minderv1.Entity {
+	switch name {
+	case "artifact":
+		return minderv1.Entity_ENTITY_ARTIFACTS
+	case "pull_request":
+		return minderv1.Entity_ENTITY_PULL_REQUESTS
+	default:
+		return minderv1.Entity_ENTITY_REPOSITORIES
+	}
+}
+
+func (s evalResultsService) ListEvaluationHistory(
+	_ context.Context, req *minderv1.ListEvaluationHistoryRequest,
+) (*minderv1.ListEvaluationHistoryResponse, error) {
+	if err := s.fx.checkProject(req.GetContext().GetProject()); err != nil {
+		return nil, err
+	}
+
+	resp := &minderv1.ListEvaluationHistoryResponse{Page: &minderv1.CursorPage{}}
+	for _, row := range s.fx.History {
+		evaluatedAt := row.EvaluatedAt.AsTime()
+		switch {
+		case !matchesAny(req.GetEntityType(), EntityTypeName(row.Entity.Type)),
+			!matchesAny(req.GetEntityName(), row.Entity.Name),
+			!matchesAny(req.GetProfileName(), row.Rule.Profile),
+			!matchesAny(req.GetStatus(), row.Status.Status),
+			!matchesAny(req.GetRemediation(), row.Remediation.Status),
+			!matchesAny(req.GetAlert(), row.Alert.Status),
+			req.GetFrom() != nil && evaluatedAt.Before(req.GetFrom().AsTime()),
+			req.GetTo() != nil && evaluatedAt.After(req.GetTo().AsTime()):
+			continue
+		}
+		resp.Page.TotalRecords++
+		if size := int(req.GetCursor().GetSize()); size == 0 || len(resp.Data) < size {
+			resp.Data = append(resp.Data, row)
+		}
+	}
+	return resp, nil
+}
+
+// matchesAny reports whether value is in filter; an empty filter matches everything.
+func matchesAny(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
+
+func (s evalResultsService) GetEvaluationHistory(
+	_ context.Context, req *minderv1.GetEvaluationHistoryRequest,
+) (*minderv1.GetEvaluationHistoryResponse, error) {
+	if err := s.fx.checkProject(req.GetContext().GetProject()); err != nil {
+		return nil, err
+	}
+	for _, row := range s.fx.History {
+		if row.Id == req.GetId() {
+			return &minderv1.GetEvaluationHistoryResponse{Evaluation: row}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "evaluation %s not found", req.GetId())
+}
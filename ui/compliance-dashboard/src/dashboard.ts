@@ -1,4 +1,5 @@
 import {
+  DEFAULT_TOOL_PREFIX,
   MCPAppsClient,
   Profile,
   ProfileStatusResult,
@@ -397,6 +398,14 @@ async function loadDashboard(): Promise<void> {
   }
 }
 
+/**
+ * Returns the server's name for a tool, using the default prefix until the
+ * client has learned the configured one.
+ */
+function toolName(name: string): string {
+  return mcpClient ? mcpClient.toolName(name) : DEFAULT_TOOL_PREFIX + name;
+}
+
 /**
  * Show a state indicating we're waiting for data via notifications.
  */
@@ -409,9 +418,9 @@ function showWaitingForData(): void {
         Ask your AI assistant to fetch Minder data using these tools:
       </p>
       <ul style="text-align: left; max-width: 400px; margin: 0 auto 16px;">
-        <li><code>minder_list_profiles</code> - List all profiles</li>
-        <li><code>minder_get_profile_status</code> - Get compliance status</li>
-        <li><code>minder_list_repositories</code> - List repositories</li>
+        <li><code>${toolName('list_profiles')}</code> - List all profiles</li>
+        <li><code>${toolName('get_profile_status')}</code> - Get compliance status</li>
+        <li><code>${toolName('list_repositories')}</code> - List repositories</li>
       </ul>
       <p style="color: var(--text-muted); font-size: 13px;">
         Data will appear automatically when received.
@@ -438,10 +447,10 @@ function showInfoMessage(): void {
         To view compliance data, ask your AI assistant to:
       </p>
       <ul style="text-align: left; max-width: 400px; margin: 0 auto 16px;">
-        <li>List profiles: <code>minder_list_profiles</code></li>
-        <li>Get profile status: <code>minder_get_profile_status</code></li>
-        <li>List repositories: <code>minder_list_repositories</code></li>
-        <li>View evaluation history: <code>minder_list_evaluation_history</code></li>
+        <li>List profiles: <code>${toolName('list_profiles')}</code></li>
+        <li>Get profile status: <code>${toolName('get_profile_status')}</code></li>
+        <li>List repositories: <code>${toolName('list_repositories')}</code></li>
+        <li>View evaluation history: <code>${toolName('list_evaluation_history')}</code></li>
       </ul>
       <p style="color: var(--text-muted); font-size: 13px;">
         The AI will aggregate the data and provide compliance insights.
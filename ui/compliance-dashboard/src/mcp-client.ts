@@ -40,6 +40,11 @@ export interface ContainerDimensions {
  */
 export type DimensionsCallback = (dimensions: ContainerDimensions) => void;
 
+/**
+ * Prefix of the server's tool names unless the server reports another one.
+ */
+export const DEFAULT_TOOL_PREFIX = 'minder_';
+
 /**
  * MCP Apps client for communicating with the MCP server from a UI iframe.
  * Uses the official @modelcontextprotocol/ext-apps SDK for secure communication.
@@ -47,6 +52,7 @@ export type DimensionsCallback = (dimensions: ContainerDimensions) => void;
 export class MCPAppsClient {
   private app: App;
   private connected = false;
+  private toolPrefix = DEFAULT_TOOL_PREFIX;
   private onToolResultCallback: ToolResultCallback | null = null;
   private onToolInputCallback: ToolInputCallback | null = null;
   private onDimensionsCallback: DimensionsCallback | null = null;
@@ -71,6 +77,11 @@ export class MCPAppsClient {
         console.warn('[MCP] Received null/undefined tool result');
         return;
       }
+      // The dashboard tool reports the server's configured tool prefix
+      const prefix = result._meta?.toolPrefix;
+      if (typeof prefix === 'string' && prefix !== '') {
+        this.toolPrefix = prefix;
+      }
       if (this.onToolResultCallback) {
         this.onToolResultCallback(result);
       }
@@ -181,6 +192,14 @@ export class MCPAppsClient {
     }
   }
 
+  /**
+   * Returns the server's name for a tool, e.g. 'list_profiles' becomes
+   * 'minder_list_profiles' with the default prefix.
+   */
+  toolName(name: string): string {
+    return this.toolPrefix + name;
+  }
+
   async callTool<T = unknown>(
     name: string,
     args: Record<string, unknown> = {}
@@ -230,7 +249,7 @@ export class MCPAppsClient {
     }
     // API returns array directly, wrap it for dashboard
     const profiles = await this.callTool<Profile[]>(
-      'minder_list_profiles',
+      this.toolName('list_profiles'),
       args
     );
     return { profiles: Array.isArray(profiles) ? profiles : [] };
@@ -253,7 +272,7 @@ export class MCPAppsClient {
     }
     // API returns nested structure, flatten it for dashboard
     const response = await this.callTool<ProfileStatusApiResponse>(
-      'minder_get_profile_status',
+      this.toolName('get_profile_status'),
       args
     );
     return {
@@ -285,7 +304,7 @@ export class MCPAppsClient {
     }
     // API returns { results: [...], has_more, next_cursor }
     const response = await this.callTool<RepositoriesApiResponse>(
-      'minder_list_repositories',
+      this.toolName('list_repositories'),
       args
     );
     return {
@@ -1,8 +1,26 @@
-import { defineConfig } from 'vite';
+import { createHash } from 'node:crypto';
+import { readFileSync, writeFileSync } from 'node:fs';
+import { resolve } from 'node:path';
+import { defineConfig, type Plugin } from 'vite';
 import { viteSingleFile } from 'vite-plugin-singlefile';
 
+// integrityHash writes the SHA-256 of the built index.html next to it. The
+// server embeds both and refuses to start when they do not match.
+function integrityHash(): Plugin {
+  return {
+    name: 'integrity-hash',
+    apply: 'build',
+    writeBundle(options) {
+      const dir = options.dir ?? 'dist';
+      const html = readFileSync(resolve(dir, 'index.html'));
+      const hash = createHash('sha256').update(html).digest('hex');
+      writeFileSync(resolve(dir, 'index.html.sha256'), `${hash}\n`);
+    },
+  };
+}
+
 export default defineConfig({
-  plugins: [viteSingleFile()],
+  plugins: [viteSingleFile(), integrityHash()],
   build: {
     outDir: '../../internal/resources/dist',
     emptyOutDir: true,